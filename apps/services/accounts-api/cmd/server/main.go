@@ -10,6 +10,7 @@ import (
 	"log"
 	"log/slog"
 	"os"
+	"time"
 
 	"connectrpc.com/connect"
 	"connectrpc.com/grpcreflect"
@@ -19,7 +20,9 @@ import (
 
 	connectrpcadapter "apps/services/accounts-api/internal/adapters/connectrpc"
 	"apps/services/accounts-api/internal/adapters/database/repositories"
+	"apps/services/accounts-api/internal/adapters/handlers/messagebroker"
 	"libs/backend/boot"
+	"libs/backend/eventing"
 	"libs/backend/httpauth"
 	"libs/backend/proto-gen/go/accounts/accountsapi/v1/accountsapiv1connect"
 )
@@ -48,14 +51,24 @@ func run() error {
 		return err
 	}
 
-	// Custom interceptors
-	authInterceptor := httpauth.NewAuthInterceptor(logger)
-
-	options := []connect.HandlerOption{
-		connect.WithInterceptors(
-			validationInterceptor,
-			authInterceptor.Incoming(),
-		),
+	// Custom interceptors, revoked tokens are rejected from the cache kept up to date by the auth event stream
+	revocationCache := httpauth.NewRevocationCache()
+	accessTokenValidator, err := httpauth.NewJWTValidator(
+		httpauth.WithTrustedIssuer(httpauth.TrustedIssuer{
+			IssuerURL: httpauth.Auth0IssuerURL(config.Auth0Domain),
+			Audiences: []string{config.Auth0Audience},
+			JWKSFile:  config.AuthJWKSFile,
+		}),
+		httpauth.WithRevocationStore(revocationCache),
+	)
+	if err != nil {
+		logger.Error("Cannot set up access token validator", slog.Any("error", err))
+		return err
+	}
+	policy, err := httpauth.LoadPolicyOrDefault(config.AuthPolicyFile)
+	if err != nil {
+		logger.Error("Cannot load the role policy", slog.Any("error", err))
+		return err
 	}
 
 	// Initialize the gRPC Options
@@ -72,12 +85,67 @@ func run() error {
 			SSLMode:  config.DBSSLMode,
 			TimeZone: config.DBTimeZone,
 		}).
+		SetSchedulerOptions(boot.SchedulerOptions{
+			// Publishes the account events stored in the outbox with the account changes
+			Enabled:      true,
+			PollInterval: time.Second,
+		}).
 		SetAMQPOptions(boot.AMQPOptions{
 			ConnectionURI: config.AMQPUrl,
 			OnConnectionCallback: func(params boot.AMQPCallBackParams) error {
 				params.Logger.Info("AMQP connected successfully")
+
+				// Set up the accounts event exchanges
+				accountsEventRegisterer := eventing.NewAccountsEventSetup(params.Controller.Registerer, params.Logger)
+				accountsEventRegisterer.
+					CreateExchange().
+					CreateDeadletter().
+					CreateStream().
+					Complete()
+
+				// Set up the auth exchange the session revocations are published to and
+				// the queue of the identity events the accounts are kept up to date with
+				authEventRegisterer := eventing.NewAuthEventSetup(params.Controller.Registerer, params.Logger)
+				authEventRegisterer.
+					CreateExchange().
+					CreateDeadletter().
+					CreateQueue(config.IdentityEventsQueueName).
+					BindQueues(eventing.GetIdentityEventsRoutingKeys()).
+					CreateStream().
+					Complete()
+
+				params.Logger.Info("Set up all AMQP queues and exchanges")
+
 				return nil
 			},
+			Handlers: []boot.AMQPHandler{
+				func(hp boot.AMQPHandlerParams) error {
+					// Keep the revocation cache up to date
+					return eventing.FollowSessionRevocations(ctx, hp.AMQPController.Consumer, hp.Logger, revocationCache, httpauth.DefaultMaxTokenLifetime)
+				},
+				func(hp boot.AMQPHandlerParams) error {
+					// Initialize the event dispatcher
+					dispatcher, err := eventing.NewDispatcher(hp.Logger)
+					if err != nil {
+						return err
+					}
+
+					// Record the identity events on the accounts
+					accountRepo := repositories.NewAccountRespository(hp.Logger, hp.DB)
+					revocationRepo := repositories.NewRevocationRepository(hp.Logger, hp.DB)
+					app := app.NewApp(
+						app.WithRegistrationService(services.NewAccountService(hp.Logger, accountRepo)),
+						app.WithRevocationService(services.NewRevocationService(services.RevocationServiceParams{
+							Logger:     hp.Logger,
+							Repository: revocationRepo,
+							Publisher:  hp.AMQPController.ConfirmPublisher,
+						})),
+					)
+					handler := messagebroker.NewLavinMQHandler(hp.Logger, hp.AMQPController.Consumer, dispatcher, app)
+
+					return handler.HandleIdentityEvents(ctx, config.IdentityEventsQueueName)
+				},
+			},
 		}).
 		SetConnectRPCOptions(boot.ConnectRPCOptions{
 			Port: 3000,
@@ -94,27 +162,71 @@ func run() error {
 
 					// Create repositories
 					accountRepo := repositories.NewAccountRespository(params.Logger, params.DB)
+					apiKeyRepo := repositories.NewAPIKeyRepository(params.Logger, params.DB)
+					revocationRepo := repositories.NewRevocationRepository(params.Logger, params.DB)
 
 					// Create services
 					registrationService := services.NewAccountService(params.Logger, accountRepo)
+					apiKeyService := services.NewAPIKeyService(services.APIKeyServiceParams{
+						Logger:     params.Logger,
+						Repository: apiKeyRepo,
+					})
+					revocationService := services.NewRevocationService(services.RevocationServiceParams{
+						Logger:     params.Logger,
+						Repository: revocationRepo,
+						Publisher:  params.AMQPController.ConfirmPublisher,
+					})
 
 					// Create Application
 					app := app.NewApp(
 						app.WithRegistrationService(registrationService),
+						app.WithAPIKeyService(apiKeyService),
+						app.WithRevocationService(revocationService),
+					)
+
+					// Accept the API keys of the accounts alongside the access tokens
+					authInterceptor := httpauth.NewAuthInterceptor(
+						logger,
+						accessTokenValidator,
+						httpauth.WithPolicy(policy),
+						httpauth.WithAPIKeyValidator(httpauth.NewAPIKeyValidator(connectrpcadapter.NewAPIKeyVerifier(app))),
 					)
 
+					options := []connect.HandlerOption{
+						connect.WithInterceptors(
+							validationInterceptor,
+							authInterceptor.Incoming(),
+						),
+					}
+
 					// Register all ConnectRPC handlers
 					registrationHandler := connectrpcadapter.NewRegistrationHandler(params.Logger, app)
+					apiKeyHandler := connectrpcadapter.NewAPIKeyHandler(params.Logger, app)
+					sessionHandler := connectrpcadapter.NewSessionHandler(params.Logger, app)
 
-					// Assign the handler to the HTTP path
+					// Assign the handlers to the HTTP paths
 					path, httpHandler := accountsapiv1connect.NewAccountServiceHandler(
 						registrationHandler,
 						options...,
 					)
+					apiKeyPath, apiKeyHTTPHandler := accountsapiv1connect.NewApiKeyServiceHandler(
+						apiKeyHandler,
+						options...,
+					)
+					sessionPath, sessionHTTPHandler := accountsapiv1connect.NewSessionServiceHandler(
+						sessionHandler,
+						options...,
+					)
 
 					// HTTP Handlers and reflection registered with Mux
 					params.Mux.Handle(path, httpHandler)
-					reflector := grpcreflect.NewStaticReflector(accountsapiv1connect.AccountServiceName)
+					params.Mux.Handle(apiKeyPath, apiKeyHTTPHandler)
+					params.Mux.Handle(sessionPath, sessionHTTPHandler)
+					reflector := grpcreflect.NewStaticReflector(
+						accountsapiv1connect.AccountServiceName,
+						accountsapiv1connect.ApiKeyServiceName,
+						accountsapiv1connect.SessionServiceName,
+					)
 					params.Mux.Handle(grpcreflect.NewHandlerV1(reflector))
 					params.Mux.Handle(grpcreflect.NewHandlerV1Alpha(reflector))
 
@@ -122,10 +234,10 @@ func run() error {
 				},
 			},
 		}).
-		SetBootCallbacks([]boot.BootCallback{
+		SetDBReadyCallbacks([]boot.BootCallback{
 			func(params boot.BootCallbackParams) error {
-				// Run DB migrations
-				if err := params.DB.AutoMigrate(&models.Account{}); err != nil {
+				// Run DB migrations before the identity events are consumed
+				if err := params.DB.AutoMigrate(&models.Account{}, &models.APIKey{}, &models.SessionRevocation{}); err != nil {
 					params.Logger.Error("Failed to run DB migrations", slog.Any("error", err))
 					return err
 				}
@@ -133,6 +245,8 @@ func run() error {
 				params.Logger.Info("Ran DB migrations", slog.String("serviceName", serviceName))
 				return nil
 			},
+		}).
+		SetBootCallbacks([]boot.BootCallback{
 			func(params boot.BootCallbackParams) error {
 				params.Logger.Info("Service booted successfully", slog.String("serviceName", serviceName))
 				return nil
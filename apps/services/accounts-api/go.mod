@@ -7,6 +7,7 @@ require (
 	connectrpc.com/grpcreflect v1.2.0
 	connectrpc.com/validate v0.1.0
 	github.com/google/uuid v1.6.0
+	github.com/stretchr/testify v1.10.0
 	google.golang.org/grpc v1.68.1
 	google.golang.org/protobuf v1.35.2
 	gorm.io/gorm v1.25.12
@@ -27,4 +28,5 @@ require (
 	golang.org/x/text v0.20.0 // indirect
 	google.golang.org/genproto/googleapis/api v0.0.0-20240903143218-8af14fe29dc1 // indirect
 	google.golang.org/genproto/googleapis/rpc v0.0.0-20241118233622-e639e219e697 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
@@ -2,11 +2,15 @@ package connectrpc
 
 import (
 	"apps/services/accounts-api/internal/app"
+	"apps/services/accounts-api/internal/app/ports"
 	"context"
+	"errors"
 	"fmt"
 	"libs/backend/boot"
 	"libs/backend/domain/user/entities"
 	userValueObjects "libs/backend/domain/user/valueobjects"
+	"libs/backend/eventing"
+	"libs/backend/httpauth"
 	accountsapiv1 "libs/backend/proto-gen/go/accounts/accountsapi/v1"
 	"libs/backend/proto-gen/go/accounts/accountsapi/v1/accountsapiv1connect"
 	accountsDomain "libs/backend/proto-gen/go/accounts/domain"
@@ -47,10 +51,20 @@ func (r *AccountServiceHandler) CreateAccount(
 		entities.WithCommonID(commonID),
 		entities.WithEmailAddress(emailAddress),
 		entities.WithUserUsername(req.Msg.Username),
+		entities.WithUserFirstName(req.Msg.FirstName),
+		entities.WithUserLastName(req.Msg.LastName),
+		entities.WithUserNickname(req.Msg.Nickname),
+		entities.WithEmailAddressVerified(req.Msg.EmailAddressVerified),
+		entities.WithPhoneNumber(req.Msg.PhoneNumber),
+		entities.WithPhoneNumberVerified(req.Msg.PhoneNumberVerified),
+		entities.WithStrategy(req.Msg.Strategy),
+		entities.WithMetadata(eventing.MetadataFromStruct(req.Msg.Metadata)),
 	)
 
 	// Create a new user
-	r.App.RegistrationService.RegisterUser(ctx, user)
+	if err := r.App.RegistrationService.RegisterUser(ctx, user); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
 
 	return connect.NewResponse(&accountsapiv1.CreateAcountResponse{IsSuccess: true}), nil
 }
@@ -65,6 +79,10 @@ func (r *AccountServiceHandler) GetAccount(
 	var emailAddress userValueObjects.EmailAddress
 
 	if req.Msg.CommonId != nil {
+		if err := httpauth.AuthorizeOwnerFromContext(ctx, *req.Msg.CommonId); err != nil {
+			return nil, connect.NewError(connect.CodePermissionDenied, err)
+		}
+
 		commonID = userValueObjects.NewCommonIDFromString(*req.Msg.CommonId)
 	}
 
@@ -78,24 +96,55 @@ func (r *AccountServiceHandler) GetAccount(
 		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("user not found: %w", err))
 	}
 
-	// Convert to proto types
-	account := &accountsDomain.Account{
-		CommonId:     user.CommonID.String(),
-		EmailAddress: user.EmailAddress.String(),
-		Username:     user.Username,
-		CreatedAt:    timestamppb.New(user.CreatedAt),
-		UpdatedAt:    timestamppb.New(user.UpdatedAt),
+	// Accounts looked up by email address are authorized once the owner is known
+	if err := httpauth.AuthorizeOwnerFromContext(ctx, user.CommonID.String()); err != nil {
+		return nil, connect.NewError(connect.CodePermissionDenied, err)
 	}
-	resp := &accountsapiv1.GetAccountResponse{Account: account}
+
+	// Convert to proto types
+	resp := &accountsapiv1.GetAccountResponse{Account: convertUserToAccount(user)}
 
 	return connect.NewResponse(resp), nil
 }
 
+// UpdateAccount handles changing the username and/or email address of an account
+func (r *AccountServiceHandler) UpdateAccount(
+	ctx context.Context,
+	req *connect.Request[accountsapiv1.UpdateAccountRequest],
+) (*connect.Response[accountsapiv1.UpdateAccountResponse], error) {
+	if err := httpauth.AuthorizeOwnerFromContext(ctx, req.Msg.CommonId); err != nil {
+		return nil, connect.NewError(connect.CodePermissionDenied, err)
+	}
+
+	opts := []entities.UserOption{
+		entities.WithCommonID(userValueObjects.NewCommonIDFromString(req.Msg.CommonId)),
+	}
+
+	if req.Msg.Username != nil {
+		opts = append(opts, entities.WithUserUsername(*req.Msg.Username))
+	}
+
+	if req.Msg.EmailAddress != nil {
+		opts = append(opts, entities.WithEmailAddress(userValueObjects.NewEmailAddress(*req.Msg.EmailAddress)))
+	}
+
+	user, err := r.App.RegistrationService.UpdateUser(ctx, entities.NewUser(opts...))
+	if err != nil {
+		return nil, convertAccountError(err)
+	}
+
+	return connect.NewResponse(&accountsapiv1.UpdateAccountResponse{Account: convertUserToAccount(user)}), nil
+}
+
 // DeleteAccount handles account deletion
 func (r *AccountServiceHandler) DeleteAccount(
 	ctx context.Context,
 	req *connect.Request[accountsapiv1.DeleteAccountRequest],
 ) (*connect.Response[accountsapiv1.DeleteAccountResponse], error) {
+	if err := httpauth.AuthorizeOwnerFromContext(ctx, req.Msg.CommonId); err != nil {
+		return nil, connect.NewError(connect.CodePermissionDenied, err)
+	}
+
 	// Parse incoming request data
 	parsedCommonID := userValueObjects.NewCommonIDFromString(req.Msg.CommonId)
 	hardDelete := req.Msg.HardDelete
@@ -103,8 +152,60 @@ func (r *AccountServiceHandler) DeleteAccount(
 	// Perform deletion logic
 	deletedAt, err := r.App.RegistrationService.DeleteUser(ctx, parsedCommonID, hardDelete)
 	if err != nil {
-		return nil, connect.NewError(connect.CodeInternal, err)
+		return nil, convertAccountError(err)
 	}
 
 	return connect.NewResponse(&accountsapiv1.DeleteAccountResponse{DeletedAt: timestamppb.New(deletedAt)}), nil
 }
+
+// RestoreAccount handles restoring a soft deleted account
+func (r *AccountServiceHandler) RestoreAccount(
+	ctx context.Context,
+	req *connect.Request[accountsapiv1.RestoreAccountRequest],
+) (*connect.Response[accountsapiv1.RestoreAccountResponse], error) {
+	if err := httpauth.AuthorizeOwnerFromContext(ctx, req.Msg.CommonId); err != nil {
+		return nil, connect.NewError(connect.CodePermissionDenied, err)
+	}
+
+	parsedCommonID := userValueObjects.NewCommonIDFromString(req.Msg.CommonId)
+
+	user, err := r.App.RegistrationService.RestoreUser(ctx, parsedCommonID)
+	if err != nil {
+		return nil, convertAccountError(err)
+	}
+
+	return connect.NewResponse(&accountsapiv1.RestoreAccountResponse{Account: convertUserToAccount(user)}), nil
+}
+
+// convertAccountError maps the account errors to connect codes
+func convertAccountError(err error) error {
+	switch {
+	case errors.Is(err, ports.ErrAccountNotFound):
+		return connect.NewError(connect.CodeNotFound, err)
+	default:
+		return connect.NewError(connect.CodeInternal, err)
+	}
+}
+
+// convertUserToAccount converts the user to the account proto type
+func convertUserToAccount(user entities.User) *accountsDomain.Account {
+	// The metadata is read back from JSON, so it always converts
+	metadata, _ := eventing.NewMetadataStruct(user.Metadata)
+
+	return &accountsDomain.Account{
+		CommonId:     user.CommonID.String(),
+		EmailAddress: user.EmailAddress.String(),
+		Username:     user.Username,
+		CreatedAt:    timestamppb.New(user.CreatedAt),
+		UpdatedAt:    timestamppb.New(user.UpdatedAt),
+
+		FirstName:            user.FirstName,
+		LastName:             user.LastName,
+		Nickname:             user.Nickname,
+		EmailAddressVerified: user.EmailAddressVerified,
+		PhoneNumber:          user.PhoneNumber,
+		PhoneNumberVerified:  user.PhoneNumberVerified,
+		Strategy:             user.Strategy,
+		Metadata:             metadata,
+	}
+}
@@ -0,0 +1,187 @@
+package connectrpc
+
+import (
+	"apps/services/accounts-api/internal/app"
+	"apps/services/accounts-api/internal/app/ports"
+	"apps/services/accounts-api/internal/domain/entities"
+	"context"
+	"errors"
+	"fmt"
+	"libs/backend/boot"
+	userValueObjects "libs/backend/domain/user/valueobjects"
+	"libs/backend/httpauth"
+	accountsapiv1 "libs/backend/proto-gen/go/accounts/accountsapi/v1"
+	"libs/backend/proto-gen/go/accounts/accountsapi/v1/accountsapiv1connect"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// APIKeyServiceHandler handles all gRPC endpoints to manage the API keys of accounts
+type APIKeyServiceHandler struct {
+	accountsapiv1connect.UnimplementedApiKeyServiceHandler
+
+	// Logger is the logger from the boot framework
+	Logger boot.Logger
+
+	// App is the application
+	App app.App
+}
+
+// NewAPIKeyHandler will return a pointer to the API key handler
+func NewAPIKeyHandler(logger boot.Logger, app app.App) *APIKeyServiceHandler {
+	return &APIKeyServiceHandler{
+		Logger: logger,
+		App:    app,
+	}
+}
+
+// NewAPIKeyVerifier verifies the API keys of the ApiKey authorization scheme
+// against the stored keys for the auth interceptor
+func NewAPIKeyVerifier(app app.App) httpauth.APIKeyVerifier {
+	return httpauth.APIKeyVerifierFunc(func(ctx context.Context, key string) (httpauth.APIKeyPrincipal, error) {
+		apiKey, err := app.APIKeyService.VerifyAPIKey(ctx, key)
+		if err != nil {
+			return httpauth.APIKeyPrincipal{}, err
+		}
+
+		return httpauth.APIKeyPrincipal{
+			KeyID:     apiKey.ID.String(),
+			CommonID:  apiKey.CommonID.String(),
+			Scopes:    apiKey.Scopes,
+			ExpiresAt: apiKey.ExpiresAt,
+		}, nil
+	})
+}
+
+// CreateApiKey creates an API key of the account and returns the key once
+func (h *APIKeyServiceHandler) CreateApiKey(
+	ctx context.Context,
+	req *connect.Request[accountsapiv1.CreateApiKeyRequest],
+) (*connect.Response[accountsapiv1.CreateApiKeyResponse], error) {
+	if err := httpauth.AuthorizeOwnerFromContext(ctx, req.Msg.CommonId); err != nil {
+		return nil, connect.NewError(connect.CodePermissionDenied, err)
+	}
+
+	// A key cannot be granted more than its creator was granted
+	claims, err := httpauth.GetClaimsFromContext(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+	for _, scope := range req.Msg.Scopes {
+		if !claims.HasScope(scope) {
+			return nil, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("%w: %s", httpauth.ErrMissingScope, scope))
+		}
+	}
+
+	var expiresAt time.Time
+	if req.Msg.ExpiresAt != nil {
+		expiresAt = req.Msg.ExpiresAt.AsTime()
+	}
+
+	apiKey, key, err := h.App.APIKeyService.CreateAPIKey(
+		ctx,
+		userValueObjects.NewCommonIDFromString(req.Msg.CommonId),
+		req.Msg.Name,
+		req.Msg.Scopes,
+		expiresAt,
+	)
+	if err != nil {
+		return nil, convertAPIKeyError(err)
+	}
+
+	return connect.NewResponse(&accountsapiv1.CreateApiKeyResponse{
+		ApiKey: convertAPIKeyToProto(apiKey),
+		Key:    key,
+	}), nil
+}
+
+// ListApiKeys returns the API keys of the account without their secrets
+func (h *APIKeyServiceHandler) ListApiKeys(
+	ctx context.Context,
+	req *connect.Request[accountsapiv1.ListApiKeysRequest],
+) (*connect.Response[accountsapiv1.ListApiKeysResponse], error) {
+	if err := httpauth.AuthorizeOwnerFromContext(ctx, req.Msg.CommonId); err != nil {
+		return nil, connect.NewError(connect.CodePermissionDenied, err)
+	}
+
+	apiKeys, err := h.App.APIKeyService.ListAPIKeys(ctx, userValueObjects.NewCommonIDFromString(req.Msg.CommonId))
+	if err != nil {
+		return nil, convertAPIKeyError(err)
+	}
+
+	resp := &accountsapiv1.ListApiKeysResponse{
+		ApiKeys: make([]*accountsapiv1.ApiKey, 0, len(apiKeys)),
+	}
+	for _, apiKey := range apiKeys {
+		resp.ApiKeys = append(resp.ApiKeys, convertAPIKeyToProto(apiKey))
+	}
+
+	return connect.NewResponse(resp), nil
+}
+
+// RevokeApiKey revokes an API key of the account
+func (h *APIKeyServiceHandler) RevokeApiKey(
+	ctx context.Context,
+	req *connect.Request[accountsapiv1.RevokeApiKeyRequest],
+) (*connect.Response[accountsapiv1.RevokeApiKeyResponse], error) {
+	if err := httpauth.AuthorizeOwnerFromContext(ctx, req.Msg.CommonId); err != nil {
+		return nil, connect.NewError(connect.CodePermissionDenied, err)
+	}
+
+	id, err := uuid.Parse(req.Msg.Id)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	apiKey, err := h.App.APIKeyService.RevokeAPIKey(
+		ctx,
+		userValueObjects.NewCommonIDFromString(req.Msg.CommonId),
+		id,
+	)
+	if err != nil {
+		return nil, convertAPIKeyError(err)
+	}
+
+	return connect.NewResponse(&accountsapiv1.RevokeApiKeyResponse{ApiKey: convertAPIKeyToProto(apiKey)}), nil
+}
+
+// convertAPIKeyError maps the API key errors to connect codes
+func convertAPIKeyError(err error) error {
+	switch {
+	case errors.Is(err, ports.ErrAPIKeyNotFound):
+		return connect.NewError(connect.CodeNotFound, err)
+	case errors.Is(err, ports.ErrAPIKeyScopeNotAllowed):
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	case errors.Is(err, ports.ErrAPIKeyLimitReached):
+		return connect.NewError(connect.CodeResourceExhausted, err)
+	default:
+		return connect.NewError(connect.CodeInternal, err)
+	}
+}
+
+// convertAPIKeyToProto converts the API key to the proto type without its hash
+func convertAPIKeyToProto(apiKey entities.APIKey) *accountsapiv1.ApiKey {
+	return &accountsapiv1.ApiKey{
+		Id:         apiKey.ID.String(),
+		CommonId:   apiKey.CommonID.String(),
+		Name:       apiKey.Name,
+		Prefix:     apiKey.Prefix,
+		Scopes:     apiKey.Scopes,
+		ExpiresAt:  optionalTimestamp(apiKey.ExpiresAt),
+		LastUsedAt: optionalTimestamp(apiKey.LastUsedAt),
+		RevokedAt:  optionalTimestamp(apiKey.RevokedAt),
+		CreatedAt:  timestamppb.New(apiKey.CreatedAt),
+	}
+}
+
+// optionalTimestamp leaves zero times unset
+func optionalTimestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+
+	return timestamppb.New(t)
+}
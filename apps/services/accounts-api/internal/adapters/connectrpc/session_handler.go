@@ -0,0 +1,113 @@
+package connectrpc
+
+import (
+	"apps/services/accounts-api/internal/app"
+	"apps/services/accounts-api/internal/domain/entities"
+	"context"
+	"libs/backend/boot"
+	userValueObjects "libs/backend/domain/user/valueobjects"
+	"libs/backend/httpauth"
+	accountsapiv1 "libs/backend/proto-gen/go/accounts/accountsapi/v1"
+	"libs/backend/proto-gen/go/accounts/accountsapi/v1/accountsapiv1connect"
+
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// SessionServiceHandler handles all gRPC endpoints for admins to revoke sessions,
+// the role policy restricts them to admins and machines
+type SessionServiceHandler struct {
+	accountsapiv1connect.UnimplementedSessionServiceHandler
+
+	// Logger is the logger from the boot framework
+	Logger boot.Logger
+
+	// App is the application
+	App app.App
+}
+
+// NewSessionHandler will return a pointer to the session handler
+func NewSessionHandler(logger boot.Logger, app app.App) *SessionServiceHandler {
+	return &SessionServiceHandler{
+		Logger: logger,
+		App:    app,
+	}
+}
+
+// RevokeSessions rejects every access token of the account issued until now
+func (h *SessionServiceHandler) RevokeSessions(
+	ctx context.Context,
+	req *connect.Request[accountsapiv1.RevokeSessionsRequest],
+) (*connect.Response[accountsapiv1.RevokeSessionsResponse], error) {
+	revocation, err := h.App.RevocationService.RevokeSessions(
+		ctx,
+		userValueObjects.NewCommonIDFromString(req.Msg.CommonId),
+		req.Msg.Reason,
+		httpauth.GetSubjectFromContext(ctx),
+	)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(&accountsapiv1.RevokeSessionsResponse{Revocation: convertRevocationToProto(revocation)}), nil
+}
+
+// RevokeToken rejects a single access token by its jti
+func (h *SessionServiceHandler) RevokeToken(
+	ctx context.Context,
+	req *connect.Request[accountsapiv1.RevokeTokenRequest],
+) (*connect.Response[accountsapiv1.RevokeTokenResponse], error) {
+	revocation, err := h.App.RevocationService.RevokeToken(
+		ctx,
+		req.Msg.Jti,
+		userValueObjects.NewCommonIDFromString(req.Msg.CommonId),
+		req.Msg.ExpiresAt.AsTime(),
+		req.Msg.Reason,
+		httpauth.GetSubjectFromContext(ctx),
+	)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(&accountsapiv1.RevokeTokenResponse{Revocation: convertRevocationToProto(revocation)}), nil
+}
+
+// ListRevocations lists the session revocations of the account
+func (h *SessionServiceHandler) ListRevocations(
+	ctx context.Context,
+	req *connect.Request[accountsapiv1.ListRevocationsRequest],
+) (*connect.Response[accountsapiv1.ListRevocationsResponse], error) {
+	revocations, err := h.App.RevocationService.ListRevocations(ctx, userValueObjects.NewCommonIDFromString(req.Msg.CommonId))
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	resp := &accountsapiv1.ListRevocationsResponse{
+		Revocations: make([]*accountsapiv1.Revocation, 0, len(revocations)),
+	}
+	for _, revocation := range revocations {
+		resp.Revocations = append(resp.Revocations, convertRevocationToProto(revocation))
+	}
+
+	return connect.NewResponse(resp), nil
+}
+
+// convertRevocationToProto converts the revocation to the proto type
+func convertRevocationToProto(revocation entities.Revocation) *accountsapiv1.Revocation {
+	var commonID string
+	if !revocation.CommonID.IsEmpty() {
+		commonID = revocation.CommonID.String()
+	}
+
+	return &accountsapiv1.Revocation{
+		Id:                 revocation.ID.String(),
+		Jti:                revocation.JTI,
+		CommonId:           commonID,
+		Subject:            revocation.Subject,
+		TokensIssuedBefore: optionalTimestamp(revocation.TokensIssuedBefore),
+		ExpiresAt:          timestamppb.New(revocation.ExpiresAt),
+		Reason:             revocation.Reason,
+		RevokedBy:          revocation.RevokedBy,
+		CreatedAt:          timestamppb.New(revocation.CreatedAt),
+	}
+}
@@ -1,6 +1,7 @@
 package repositories
 
 import (
+	"apps/services/accounts-api/internal/app/ports"
 	"apps/services/accounts-api/internal/models"
 	"context"
 	"errors"
@@ -32,6 +33,14 @@ func NewAccountRespository(logger boot.Logger, db *gorm.DB) AccountRepository {
 	}
 }
 
+// Transaction runs the function with a repository and an outbox bound to one
+// database transaction, the boot scheduler publishes the outbox once it commits
+func (r AccountRepository) Transaction(ctx context.Context, fn func(ports.AccountRepository, ports.EventOutbox) error) error {
+	return r.Database.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(NewAccountRespository(r.Logger, tx), boot.NewTransactionalScheduleStore(tx))
+	})
+}
+
 // CreateAccount creates an account in the database
 func (r AccountRepository) CreateAccount(ctx context.Context, user userEntities.User) error {
 	r.Logger.Info("Creating account", slog.String("commonID", user.CommonID.String()))
@@ -41,6 +50,15 @@ func (r AccountRepository) CreateAccount(ctx context.Context, user userEntities.
 		CommonID:     user.CommonID.Value(),
 		EmailAddress: user.EmailAddress.String(),
 		UserName:     user.Username,
+
+		FirstName:            user.FirstName,
+		LastName:             user.LastName,
+		Nickname:             user.Nickname,
+		EmailAddressVerified: user.EmailAddressVerified,
+		PhoneNumber:          user.PhoneNumber,
+		PhoneNumberVerified:  user.PhoneNumberVerified,
+		Strategy:             user.Strategy,
+		Metadata:             user.Metadata,
 	}
 
 	// Save the account in the database
@@ -57,10 +75,13 @@ func (r AccountRepository) GetAccountByCommonID(ctx context.Context, commonID us
 	r.Logger.Info("Getting account", slog.String("commonID", commonID.String()))
 
 	account := &models.Account{}
-	r.Database.First(account, "common_id = ?", commonID.Value())
+	err := r.Database.First(account, "common_id = ?", commonID.Value()).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return userEntities.User{}, fmt.Errorf("cannot get account: %w", err)
+	}
 
 	if account.ID == uuid.Nil {
-		return userEntities.User{}, errors.New("account not found")
+		return userEntities.User{}, ports.ErrAccountNotFound
 	}
 
 	return r.convertAccountToUser(account), nil
@@ -74,21 +95,53 @@ func (r AccountRepository) GetAccountByEmailAddress(ctx context.Context, emailAd
 	r.Database.First(account, "email_address = ?", emailAdress)
 
 	if account.ID == uuid.Nil {
-		return userEntities.User{}, errors.New("account not found")
+		return userEntities.User{}, ports.ErrAccountNotFound
 	}
 
 	return r.convertAccountToUser(account), nil
 }
 
+// UpdateAccount updates the username and/or email address of the account by commonID
+func (r AccountRepository) UpdateAccount(ctx context.Context, user userEntities.User) (userEntities.User, error) {
+	r.Logger.Info("Updating account", slog.String("commonID", user.CommonID.String()))
+
+	updates := make(map[string]any)
+	if user.Username != "" {
+		updates["user_name"] = user.Username
+	}
+	if !user.EmailAddress.IsEmpty() {
+		updates["email_address"] = user.EmailAddress.String()
+	}
+
+	if len(updates) > 0 {
+		result := r.Database.Model(&models.Account{}).Where("common_id = ?", user.CommonID.Value()).Updates(updates)
+		if result.Error != nil {
+			r.Logger.Error("Cannot update the user by commonID", slog.String("commonID", user.CommonID.String()))
+			return userEntities.User{}, fmt.Errorf("cannot update account: %w", result.Error)
+		}
+
+		if result.RowsAffected == 0 {
+			return userEntities.User{}, ports.ErrAccountNotFound
+		}
+	}
+
+	return r.GetAccountByCommonID(ctx, user.CommonID)
+}
+
 // SoftDeleteAccountByCommonID will mark the user as deleted in the database with a timestamp
 func (r AccountRepository) SoftDeleteAccountByCommonID(ctx context.Context, commonID userValueObjects.CommonID) (time.Time, error) {
 	r.Logger.Info("Handling soft deletion of account by commonID", slog.String("commonID", commonID.String()))
 
 	// Handle soft deletion in the database
 	deletedAccount := &models.Account{}
-	if err := r.Database.Delete(deletedAccount, "common_id = ?", commonID).Error; err != nil {
+	result := r.Database.Delete(deletedAccount, "common_id = ?", commonID)
+	if result.Error != nil {
 		r.Logger.Error("Cannot soft delete the user by commonID", slog.String("commonID", commonID.String()))
-		return time.Time{}, fmt.Errorf("cannot soft delete account: %w", err)
+		return time.Time{}, fmt.Errorf("cannot soft delete account: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return time.Time{}, ports.ErrAccountNotFound
 	}
 
 	return deletedAccount.DeletedAt.Time, nil
@@ -100,14 +153,69 @@ func (r AccountRepository) HardDeleteAccountByCommonID(ctx context.Context, comm
 
 	// Handle soft deletion in the database
 	deletedAccount := &models.Account{}
-	if err := r.Database.Unscoped().Delete(deletedAccount, "common_id = ?", commonID).Error; err != nil {
+	result := r.Database.Unscoped().Delete(deletedAccount, "common_id = ?", commonID)
+	if result.Error != nil {
 		r.Logger.Error("Cannot hard delete the user by commonID", slog.String("commonID", commonID.String()))
-		return time.Time{}, fmt.Errorf("cannot hard delete accoutn: %w", err)
+		return time.Time{}, fmt.Errorf("cannot hard delete accoutn: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return time.Time{}, ports.ErrAccountNotFound
 	}
 
 	return deletedAccount.DeletedAt.Time, nil
 }
 
+// RestoreAccountByCommonID will clear the deletion timestamp of a soft deleted account
+func (r AccountRepository) RestoreAccountByCommonID(ctx context.Context, commonID userValueObjects.CommonID) (userEntities.User, error) {
+	r.Logger.Info("Restoring account by commonID", slog.String("commonID", commonID.String()))
+
+	result := r.Database.Unscoped().
+		Model(&models.Account{}).
+		Where("common_id = ? AND deleted_at IS NOT NULL", commonID.Value()).
+		Update("deleted_at", nil)
+	if result.Error != nil {
+		r.Logger.Error("Cannot restore the user by commonID", slog.String("commonID", commonID.String()))
+		return userEntities.User{}, fmt.Errorf("cannot restore account: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return userEntities.User{}, fmt.Errorf("deleted %w", ports.ErrAccountNotFound)
+	}
+
+	return r.GetAccountByCommonID(ctx, commonID)
+}
+
+// VerifyEmailAddress marks the email address of the account as verified, an
+// account whose email address changed since is left unverified
+func (r AccountRepository) VerifyEmailAddress(ctx context.Context, commonID userValueObjects.CommonID, emailAddress userValueObjects.EmailAddress) (bool, error) {
+	r.Logger.Info("Verifying email address of account", slog.String("commonID", commonID.String()))
+
+	result := r.Database.Model(&models.Account{}).
+		Where("common_id = ? AND email_address = ? AND NOT email_address_verified", commonID.Value(), emailAddress.String()).
+		Update("email_address_verified", true)
+	if result.Error != nil {
+		r.Logger.Error("Cannot verify the email address of the user by commonID", slog.String("commonID", commonID.String()))
+		return false, fmt.Errorf("cannot verify email address: %w", result.Error)
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+// RecordLogin stores the login time of the account, the identity events may
+// arrive out of order so an earlier login does not replace a later one
+func (r AccountRepository) RecordLogin(ctx context.Context, commonID userValueObjects.CommonID, loggedInAt time.Time) error {
+	result := r.Database.Model(&models.Account{}).
+		Where("common_id = ? AND (last_login_at IS NULL OR last_login_at < ?)", commonID.Value(), loggedInAt).
+		UpdateColumn("last_login_at", loggedInAt)
+	if result.Error != nil {
+		r.Logger.Error("Cannot record the login of the user by commonID", slog.String("commonID", commonID.String()))
+		return fmt.Errorf("cannot record login: %w", result.Error)
+	}
+
+	return nil
+}
+
 // convertAccountToUser converts an account to a user
 func (r AccountRepository) convertAccountToUser(account *models.Account) userEntities.User {
 	parsedCommonID := userValueObjects.NewCommonIDFromUUID(account.CommonID)
@@ -117,7 +225,34 @@ func (r AccountRepository) convertAccountToUser(account *models.Account) userEnt
 		userEntities.WithCommonID(parsedCommonID),
 		userEntities.WithEmailAddress(parsedEmailAddress),
 		userEntities.WithUserUsername(account.UserName),
+		userEntities.WithUserFirstName(account.FirstName),
+		userEntities.WithUserLastName(account.LastName),
+		userEntities.WithUserNickname(account.Nickname),
+		userEntities.WithEmailAddressVerified(account.EmailAddressVerified),
+		userEntities.WithPhoneNumber(account.PhoneNumber),
+		userEntities.WithPhoneNumberVerified(account.PhoneNumberVerified),
+		userEntities.WithStrategy(account.Strategy),
+		userEntities.WithMetadata(metadataOrEmpty(account.Metadata)),
+		userEntities.WithLastLoginAt(timeOrZero(account.LastLoginAt)),
 		userEntities.WithCreatedAt(account.CreatedAt),
 		userEntities.WithUpdatedAt(account.UpdatedAt),
 	)
 }
+
+// metadataOrEmpty reads NULL metadata as empty metadata
+func metadataOrEmpty(metadata map[string]any) map[string]any {
+	if metadata == nil {
+		return make(map[string]any)
+	}
+
+	return metadata
+}
+
+// timeOrZero reads a NULL timestamp as the zero time
+func timeOrZero(t *time.Time) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+
+	return *t
+}
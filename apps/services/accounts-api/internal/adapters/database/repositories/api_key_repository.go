@@ -0,0 +1,174 @@
+package repositories
+
+import (
+	"apps/services/accounts-api/internal/app/ports"
+	"apps/services/accounts-api/internal/domain/entities"
+	"apps/services/accounts-api/internal/models"
+	"context"
+	"errors"
+	"fmt"
+	"libs/backend/boot"
+	userValueObjects "libs/backend/domain/user/valueobjects"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Assertion of the proper interface
+var _ ports.APIKeyRepository = APIKeyRepository{}
+
+// APIKeyRepository stores the API keys of the accounts
+type APIKeyRepository struct {
+	// Logger is the logger from the boot framework
+	Logger boot.Logger
+
+	// Database is the database connection
+	Database *gorm.DB
+}
+
+// NewAPIKeyRepository creates a new API key repository
+func NewAPIKeyRepository(logger boot.Logger, db *gorm.DB) APIKeyRepository {
+	return APIKeyRepository{
+		Logger:   logger,
+		Database: db,
+	}
+}
+
+// CreateAPIKey stores a new API key
+func (r APIKeyRepository) CreateAPIKey(ctx context.Context, apiKey entities.APIKey) error {
+	apiKeyModel := r.convertAPIKeyToModel(apiKey)
+	if err := r.Database.WithContext(ctx).Create(&apiKeyModel).Error; err != nil {
+		return fmt.Errorf("cannot create api key: %w", err)
+	}
+
+	return nil
+}
+
+// GetAPIKeyByPrefix gets the API key by its unique prefix
+func (r APIKeyRepository) GetAPIKeyByPrefix(ctx context.Context, prefix string) (entities.APIKey, error) {
+	apiKeyModel := models.APIKey{}
+	err := r.Database.WithContext(ctx).First(&apiKeyModel, "prefix = ?", prefix).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return entities.APIKey{}, ports.ErrAPIKeyNotFound
+	}
+	if err != nil {
+		return entities.APIKey{}, fmt.Errorf("cannot get api key: %w", err)
+	}
+
+	return r.convertModelToAPIKey(apiKeyModel), nil
+}
+
+// ListAPIKeys lists the API keys of the account, newest first
+func (r APIKeyRepository) ListAPIKeys(ctx context.Context, commonID userValueObjects.CommonID) ([]entities.APIKey, error) {
+	apiKeyModels := make([]models.APIKey, 0)
+	err := r.Database.WithContext(ctx).
+		Where("common_id = ?", commonID.Value()).
+		Order("created_at DESC").
+		Find(&apiKeyModels).
+		Error
+	if err != nil {
+		return nil, fmt.Errorf("cannot list api keys: %w", err)
+	}
+
+	apiKeys := make([]entities.APIKey, 0, len(apiKeyModels))
+	for _, apiKeyModel := range apiKeyModels {
+		apiKeys = append(apiKeys, r.convertModelToAPIKey(apiKeyModel))
+	}
+
+	return apiKeys, nil
+}
+
+// RevokeAPIKey revokes the API key of the account, revoking a revoked key
+// keeps its first revocation time
+func (r APIKeyRepository) RevokeAPIKey(ctx context.Context, commonID userValueObjects.CommonID, id uuid.UUID, revokedAt time.Time) (entities.APIKey, error) {
+	apiKeyModel := models.APIKey{}
+	result := r.Database.WithContext(ctx).
+		Model(&apiKeyModel).
+		Clauses(clause.Returning{}).
+		Where("id = ? AND common_id = ?", id, commonID.Value()).
+		Updates(map[string]any{
+			"revoked_at": gorm.Expr("COALESCE(revoked_at, ?)", revokedAt),
+			"updated_at": revokedAt,
+		})
+	if result.Error != nil {
+		return entities.APIKey{}, fmt.Errorf("cannot revoke api key: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return entities.APIKey{}, ports.ErrAPIKeyNotFound
+	}
+
+	return r.convertModelToAPIKey(apiKeyModel), nil
+}
+
+// TouchAPIKey records the last use of the API key
+func (r APIKeyRepository) TouchAPIKey(ctx context.Context, id uuid.UUID, lastUsedAt time.Time) error {
+	err := r.Database.WithContext(ctx).
+		Model(&models.APIKey{}).
+		Where("id = ?", id).
+		Update("last_used_at", lastUsedAt).
+		Error
+	if err != nil {
+		return fmt.Errorf("cannot record api key use: %w", err)
+	}
+
+	return nil
+}
+
+// convertAPIKeyToModel converts an API key to the database model
+func (r APIKeyRepository) convertAPIKeyToModel(apiKey entities.APIKey) models.APIKey {
+	scopes := apiKey.Scopes
+	if scopes == nil {
+		scopes = []string{}
+	}
+
+	return models.APIKey{
+		ID:         apiKey.ID,
+		CommonID:   apiKey.CommonID.Value(),
+		Name:       apiKey.Name,
+		Prefix:     apiKey.Prefix,
+		SecretHash: apiKey.SecretHash,
+		Scopes:     scopes,
+		ExpiresAt:  optionalTime(apiKey.ExpiresAt),
+		LastUsedAt: optionalTime(apiKey.LastUsedAt),
+		RevokedAt:  optionalTime(apiKey.RevokedAt),
+		CreatedAt:  apiKey.CreatedAt,
+		UpdatedAt:  apiKey.CreatedAt,
+	}
+}
+
+// convertModelToAPIKey converts a database model to an API key
+func (r APIKeyRepository) convertModelToAPIKey(apiKeyModel models.APIKey) entities.APIKey {
+	return entities.APIKey{
+		ID:         apiKeyModel.ID,
+		CommonID:   userValueObjects.NewCommonIDFromUUID(apiKeyModel.CommonID),
+		Name:       apiKeyModel.Name,
+		Prefix:     apiKeyModel.Prefix,
+		SecretHash: apiKeyModel.SecretHash,
+		Scopes:     apiKeyModel.Scopes,
+		ExpiresAt:  valueTime(apiKeyModel.ExpiresAt),
+		LastUsedAt: valueTime(apiKeyModel.LastUsedAt),
+		RevokedAt:  valueTime(apiKeyModel.RevokedAt),
+		CreatedAt:  apiKeyModel.CreatedAt,
+	}
+}
+
+// optionalTime stores zero times as NULL
+func optionalTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+
+	return &t
+}
+
+// valueTime reads NULL times as zero times
+func valueTime(t *time.Time) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+
+	return *t
+}
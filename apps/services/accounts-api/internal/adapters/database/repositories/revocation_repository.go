@@ -0,0 +1,119 @@
+package repositories
+
+import (
+	"apps/services/accounts-api/internal/app/ports"
+	"apps/services/accounts-api/internal/domain/entities"
+	"apps/services/accounts-api/internal/models"
+	"context"
+	"fmt"
+	"libs/backend/boot"
+	userValueObjects "libs/backend/domain/user/valueobjects"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Assertion of the proper interface
+var _ ports.RevocationRepository = RevocationRepository{}
+
+// RevocationRepository stores the session revocations
+type RevocationRepository struct {
+	// Logger is the logger from the boot framework
+	Logger boot.Logger
+
+	// Database is the database connection
+	Database *gorm.DB
+}
+
+// NewRevocationRepository creates a new session revocation repository
+func NewRevocationRepository(logger boot.Logger, db *gorm.DB) RevocationRepository {
+	return RevocationRepository{
+		Logger:   logger,
+		Database: db,
+	}
+}
+
+// CreateRevocation stores a new revocation
+func (r RevocationRepository) CreateRevocation(ctx context.Context, revocation entities.Revocation) error {
+	revocationModel := r.convertRevocationToModel(revocation)
+	if err := r.Database.WithContext(ctx).Create(&revocationModel).Error; err != nil {
+		return fmt.Errorf("cannot create session revocation: %w", err)
+	}
+
+	return nil
+}
+
+// ListRevocations lists the revocations of the account, newest first
+func (r RevocationRepository) ListRevocations(ctx context.Context, commonID userValueObjects.CommonID) ([]entities.Revocation, error) {
+	revocationModels := make([]models.SessionRevocation, 0)
+	err := r.Database.WithContext(ctx).
+		Where("common_id = ?", commonID.Value()).
+		Order("created_at DESC").
+		Find(&revocationModels).
+		Error
+	if err != nil {
+		return nil, fmt.Errorf("cannot list session revocations: %w", err)
+	}
+
+	revocations := make([]entities.Revocation, 0, len(revocationModels))
+	for _, revocationModel := range revocationModels {
+		revocations = append(revocations, r.convertModelToRevocation(revocationModel))
+	}
+
+	return revocations, nil
+}
+
+// convertRevocationToModel converts a revocation to the database model
+func (r RevocationRepository) convertRevocationToModel(revocation entities.Revocation) models.SessionRevocation {
+	var jti *string
+	if revocation.JTI != "" {
+		jti = &revocation.JTI
+	}
+
+	var commonID *uuid.UUID
+	if !revocation.CommonID.IsEmpty() {
+		value := revocation.CommonID.Value()
+		commonID = &value
+	}
+
+	var subject *string
+	if revocation.Subject != "" {
+		subject = &revocation.Subject
+	}
+
+	return models.SessionRevocation{
+		ID:                 revocation.ID,
+		JTI:                jti,
+		CommonID:           commonID,
+		Subject:            subject,
+		TokensIssuedBefore: optionalTime(revocation.TokensIssuedBefore),
+		ExpiresAt:          revocation.ExpiresAt,
+		Reason:             revocation.Reason,
+		RevokedBy:          revocation.RevokedBy,
+		CreatedAt:          revocation.CreatedAt,
+	}
+}
+
+// convertModelToRevocation converts a database model to a revocation
+func (r RevocationRepository) convertModelToRevocation(revocationModel models.SessionRevocation) entities.Revocation {
+	revocation := entities.Revocation{
+		ID:                 revocationModel.ID,
+		TokensIssuedBefore: valueTime(revocationModel.TokensIssuedBefore),
+		ExpiresAt:          revocationModel.ExpiresAt,
+		Reason:             revocationModel.Reason,
+		RevokedBy:          revocationModel.RevokedBy,
+		CreatedAt:          revocationModel.CreatedAt,
+	}
+
+	if revocationModel.JTI != nil {
+		revocation.JTI = *revocationModel.JTI
+	}
+	if revocationModel.CommonID != nil {
+		revocation.CommonID = userValueObjects.NewCommonIDFromUUID(*revocationModel.CommonID)
+	}
+	if revocationModel.Subject != nil {
+		revocation.Subject = *revocationModel.Subject
+	}
+
+	return revocation
+}
@@ -0,0 +1,138 @@
+package messagebroker
+
+import (
+	"apps/services/accounts-api/internal/app"
+	"apps/services/accounts-api/internal/app/ports"
+	"context"
+	"errors"
+	"libs/backend/boot"
+	userValueObjects "libs/backend/domain/user/valueobjects"
+	"libs/backend/eventing"
+	accountseventsv1 "libs/backend/proto-gen/go/accounts/accountsevents/v1"
+	"log/slog"
+)
+
+// LavinMQHandler handles all incoming events from LavinMQ
+type LavinMQHandler struct {
+	Logger     boot.Logger
+	Consumer   boot.AMQPConsumer
+	Dispatcher *eventing.Dispatcher
+	App        app.App
+}
+
+// NewLavinMQHandler is the constructor for LavinMQHandler
+func NewLavinMQHandler(logger boot.Logger, consumer boot.AMQPConsumer, dispatcher *eventing.Dispatcher, app app.App) LavinMQHandler {
+	return LavinMQHandler{
+		Logger:     logger,
+		Consumer:   consumer,
+		Dispatcher: dispatcher,
+		App:        app,
+	}
+}
+
+// identityProviderRevoker is recorded as the revoker of the sessions the
+// identity provider ended
+const identityProviderRevoker = "identity-provider"
+
+// HandleIdentityEvents keeps the accounts up to date with the logins, the
+// verified email addresses, the blocked and the deleted users the identity
+// provider logged
+func (h LavinMQHandler) HandleIdentityEvents(ctx context.Context, queueName string) error {
+	eventing.RegisterUserLoggedInHandler(h.Dispatcher, h.onUserLoggedIn)
+	eventing.RegisterUserEmailVerifiedHandler(h.Dispatcher, h.onUserEmailVerified)
+	eventing.RegisterUserBlockedHandler(h.Dispatcher, h.onUserBlocked)
+	eventing.RegisterUserDeletedInIdPHandler(h.Dispatcher, h.onUserDeletedInIdP)
+
+	if err := h.Dispatcher.Run(ctx, h.Consumer, queueName); err != nil {
+		h.Logger.Error("Cannot consume messages", slog.Any("error", err))
+		return err
+	}
+
+	return nil
+}
+
+// onUserLoggedIn records the login on the account of the user
+func (h LavinMQHandler) onUserLoggedIn(ctx context.Context, userLoggedInEvent *accountseventsv1.UserLoggedIn) error {
+	commonID, ok := h.parseCommonID(userLoggedInEvent.CommonId, userLoggedInEvent.UserId)
+	if !ok {
+		return nil
+	}
+
+	if err := h.App.RegistrationService.RecordLogin(ctx, commonID, userLoggedInEvent.LoggedInAt.AsTime()); err != nil {
+		h.Logger.Error("Cannot record login", slog.String("commonID", commonID.String()), slog.Any("error", err))
+		return err
+	}
+
+	return nil
+}
+
+// onUserEmailVerified marks the verified email address on the account of the user
+func (h LavinMQHandler) onUserEmailVerified(ctx context.Context, userEmailVerifiedEvent *accountseventsv1.UserEmailVerified) error {
+	commonID, ok := h.parseCommonID(userEmailVerifiedEvent.CommonId, userEmailVerifiedEvent.UserId)
+	if !ok {
+		return nil
+	}
+
+	emailAddress := userValueObjects.NewEmailAddress(userEmailVerifiedEvent.EmailAddress)
+	if err := h.App.RegistrationService.VerifyEmailAddress(ctx, commonID, emailAddress); err != nil {
+		h.Logger.Error("Cannot verify email address", slog.String("commonID", commonID.String()), slog.Any("error", err))
+		return err
+	}
+
+	return nil
+}
+
+// onUserBlocked revokes the sessions of the account and the Auth0 user, a
+// blocked user cannot log in again but its issued access tokens stay valid
+// until revoked
+func (h LavinMQHandler) onUserBlocked(ctx context.Context, userBlockedEvent *accountseventsv1.UserBlocked) error {
+	commonID, ok := h.parseCommonID(userBlockedEvent.CommonId, userBlockedEvent.UserId)
+	if !ok {
+		return nil
+	}
+
+	if _, err := h.App.RevocationService.RevokeUserSessions(ctx, commonID, userBlockedEvent.UserId, "user blocked in the identity provider", identityProviderRevoker); err != nil {
+		h.Logger.Error("Cannot revoke sessions of blocked user", slog.String("commonID", commonID.String()), slog.Any("error", err))
+		return err
+	}
+
+	return nil
+}
+
+// onUserDeletedInIdP revokes the sessions and soft deletes the account of the
+// user, accounts that are already deleted are left as they are
+func (h LavinMQHandler) onUserDeletedInIdP(ctx context.Context, userDeletedEvent *accountseventsv1.UserDeletedInIdP) error {
+	commonID, ok := h.parseCommonID(userDeletedEvent.CommonId, userDeletedEvent.UserId)
+	if !ok {
+		return nil
+	}
+
+	if _, err := h.App.RevocationService.RevokeUserSessions(ctx, commonID, userDeletedEvent.UserId, "user deleted in the identity provider", identityProviderRevoker); err != nil {
+		h.Logger.Error("Cannot revoke sessions of deleted user", slog.String("commonID", commonID.String()), slog.Any("error", err))
+		return err
+	}
+
+	if _, err := h.App.RegistrationService.DeleteUser(ctx, commonID, false); err != nil {
+		if errors.Is(err, ports.ErrAccountNotFound) {
+			h.Logger.Info("Account of deleted user already deleted", slog.String("commonID", commonID.String()))
+			return nil
+		}
+
+		h.Logger.Error("Cannot delete account of deleted user", slog.String("commonID", commonID.String()), slog.Any("error", err))
+		return err
+	}
+
+	return nil
+}
+
+// parseCommonID parses the common id of an identity event, events of users
+// without an account are skipped since there is no account to update
+func (h LavinMQHandler) parseCommonID(commonID, userID string) (userValueObjects.CommonID, bool) {
+	parsed := userValueObjects.NewCommonIDFromString(commonID)
+	if parsed.IsEmpty() {
+		h.Logger.Warn("Skipping identity event without an account", slog.String("userID", userID))
+		return parsed, false
+	}
+
+	return parsed, true
+}
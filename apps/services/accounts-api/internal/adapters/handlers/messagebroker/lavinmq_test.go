@@ -0,0 +1,202 @@
+package messagebroker_test
+
+import (
+	"apps/services/accounts-api/internal/adapters/handlers/messagebroker"
+	"apps/services/accounts-api/internal/app"
+	"apps/services/accounts-api/internal/app/ports"
+	"apps/services/accounts-api/internal/domain/entities"
+	"context"
+	"errors"
+	"libs/backend/boot"
+	"libs/backend/boot/amqptest"
+	userValueObjects "libs/backend/domain/user/valueobjects"
+	"libs/backend/eventing"
+	accountseventsv1 "libs/backend/proto-gen/go/accounts/accountsevents/v1"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// fakeAccountService records the identity events applied to the accounts
+type fakeAccountService struct {
+	ports.AccountService
+	logins   chan time.Time
+	verified chan userValueObjects.EmailAddress
+	deleted  chan string
+}
+
+func (s fakeAccountService) DeleteUser(_ context.Context, commonID userValueObjects.CommonID, hardDelete bool) (time.Time, error) {
+	if hardDelete {
+		return time.Time{}, errors.New("unexpected hard delete")
+	}
+
+	s.deleted <- commonID.String()
+	return time.Now(), ports.ErrAccountNotFound
+}
+
+// fakeRevocationService records the revoked sessions
+type fakeRevocationService struct {
+	ports.RevocationService
+	revoked chan entities.Revocation
+}
+
+func (s fakeRevocationService) RevokeUserSessions(_ context.Context, commonID userValueObjects.CommonID, subject, reason, _ string) (entities.Revocation, error) {
+	revocation := entities.Revocation{CommonID: commonID, Subject: subject, Reason: reason}
+	s.revoked <- revocation
+	return revocation, nil
+}
+
+func (s fakeAccountService) RecordLogin(_ context.Context, _ userValueObjects.CommonID, loggedInAt time.Time) error {
+	s.logins <- loggedInAt
+	return nil
+}
+
+func (s fakeAccountService) VerifyEmailAddress(_ context.Context, _ userValueObjects.CommonID, emailAddress userValueObjects.EmailAddress) error {
+	s.verified <- emailAddress
+	return nil
+}
+
+func TestLavinMQHandlerIdentityEvents(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	logger := boot.NewSlogger()
+	queueName := eventing.GetQueueName(eventing.AccountsAPIService, eventing.IdentityEventsQueue)
+	const commonID = "5f1d3c6e-52a7-4b43-a8b5-0c0a1f0f6f11"
+
+	broker := amqptest.NewBroker()
+	defer broker.Close()
+
+	setup := eventing.NewAuthEventSetup(broker, logger)
+	setup.
+		CreateExchange().
+		CreateDeadletter().
+		CreateQueue(queueName).
+		BindQueues(eventing.GetIdentityEventsRoutingKeys()).
+		Complete()
+
+	accountService := fakeAccountService{
+		logins:   make(chan time.Time, 1),
+		verified: make(chan userValueObjects.EmailAddress, 1),
+		deleted:  make(chan string, 1),
+	}
+	revocationService := fakeRevocationService{revoked: make(chan entities.Revocation, 1)}
+	dispatcher, err := eventing.NewDispatcher(logger)
+	require.NoError(t, err)
+
+	handler := messagebroker.NewLavinMQHandler(logger, broker, dispatcher, app.NewApp(
+		app.WithRegistrationService(accountService),
+		app.WithRevocationService(revocationService),
+	))
+	go func() {
+		_ = handler.HandleIdentityEvents(ctx, queueName)
+	}()
+
+	publish := func(t *testing.T, eventName eventing.EventName, version eventing.EventVersion, event proto.Message) {
+		t.Helper()
+
+		msg, err := eventing.NewProtoPublishing(eventName, version, event)
+		require.NoError(t, err)
+		require.NoError(t, broker.Publish(eventing.AuthExchange, eventName.String(), true, false, msg))
+	}
+	auth0Log := &accountseventsv1.Auth0Log{LogId: "log-1"}
+	date := time.Date(2024, 12, 3, 15, 15, 0, 0, time.UTC)
+
+	t.Run("records the logins", func(t *testing.T) {
+		publish(t, eventing.EventNameUserLoggedIn, eventing.UserLoggedInVersion, &accountseventsv1.UserLoggedIn{
+			UserId:     "auth0|user-1",
+			LoggedInAt: timestamppb.New(date),
+			Log:        auth0Log,
+			CommonId:   commonID,
+		})
+
+		select {
+		case loggedInAt := <-accountService.logins:
+			assert.Equal(t, date, loggedInAt)
+		case <-time.After(time.Second):
+			t.Fatal("login was not recorded")
+		}
+	})
+
+	t.Run("verifies the email addresses", func(t *testing.T) {
+		publish(t, eventing.EventNameUserEmailVerified, eventing.UserEmailVerifiedVersion, &accountseventsv1.UserEmailVerified{
+			UserId:       "auth0|user-1",
+			EmailAddress: "user@example.com",
+			VerifiedAt:   timestamppb.New(date),
+			Log:          auth0Log,
+			CommonId:     commonID,
+		})
+
+		select {
+		case emailAddress := <-accountService.verified:
+			assert.Equal(t, "user@example.com", emailAddress.String())
+		case <-time.After(time.Second):
+			t.Fatal("email address was not verified")
+		}
+	})
+
+	t.Run("revokes the sessions of blocked users", func(t *testing.T) {
+		publish(t, eventing.EventNameUserBlocked, eventing.UserBlockedVersion, &accountseventsv1.UserBlocked{
+			UserId:    "auth0|user-1",
+			BlockedAt: timestamppb.New(date),
+			Log:       auth0Log,
+			CommonId:  commonID,
+		})
+
+		select {
+		case revocation := <-revocationService.revoked:
+			assert.Equal(t, commonID, revocation.CommonID.String())
+			assert.Equal(t, "auth0|user-1", revocation.Subject)
+			assert.Equal(t, "user blocked in the identity provider", revocation.Reason)
+		case <-time.After(time.Second):
+			t.Fatal("sessions were not revoked")
+		}
+	})
+
+	t.Run("revokes the sessions and deletes the accounts of deleted users", func(t *testing.T) {
+		publish(t, eventing.EventNameUserDeletedInIdP, eventing.UserDeletedInIdPVersion, &accountseventsv1.UserDeletedInIdP{
+			UserId:    "auth0|user-1",
+			DeletedAt: timestamppb.New(date),
+			Log:       auth0Log,
+			CommonId:  commonID,
+		})
+
+		select {
+		case revocation := <-revocationService.revoked:
+			assert.Equal(t, "auth0|user-1", revocation.Subject)
+			assert.Equal(t, "user deleted in the identity provider", revocation.Reason)
+		case <-time.After(time.Second):
+			t.Fatal("sessions were not revoked")
+		}
+		select {
+		case deleted := <-accountService.deleted:
+			assert.Equal(t, commonID, deleted)
+		case <-time.After(time.Second):
+			t.Fatal("account was not deleted")
+		}
+
+		// An account that is already deleted is acknowledged
+		assert.Eventually(t, func() bool {
+			return broker.QueueLength(queueName) == 0 && broker.UnackedCount(queueName) == 0
+		}, time.Second, 10*time.Millisecond)
+		assert.Zero(t, broker.QueueLength(eventing.AuthDeadletterQueue))
+	})
+
+	t.Run("skips the events of users without an account", func(t *testing.T) {
+		publish(t, eventing.EventNameUserLoggedIn, eventing.UserLoggedInVersion, &accountseventsv1.UserLoggedIn{
+			UserId:     "auth0|unlinked",
+			LoggedInAt: timestamppb.New(date),
+			Log:        auth0Log,
+		})
+
+		assert.Eventually(t, func() bool {
+			return broker.QueueLength(queueName) == 0 && broker.UnackedCount(queueName) == 0
+		}, time.Second, 10*time.Millisecond)
+		assert.Empty(t, accountService.logins)
+		assert.Zero(t, broker.QueueLength(eventing.AuthDeadletterQueue))
+	})
+}
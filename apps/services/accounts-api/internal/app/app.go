@@ -6,6 +6,12 @@ import "apps/services/accounts-api/internal/app/ports"
 type App struct {
 	// RegistrationService is the registration service
 	RegistrationService ports.AccountService
+
+	// APIKeyService is the API key service
+	APIKeyService ports.APIKeyService
+
+	// RevocationService is the session revocation service
+	RevocationService ports.RevocationService
 }
 
 // AppOption is the option for the application
@@ -28,3 +34,17 @@ func WithRegistrationService(service ports.AccountService) AppOption {
 		a.RegistrationService = service
 	}
 }
+
+// WithAPIKeyService sets the API key service in the application
+func WithAPIKeyService(service ports.APIKeyService) AppOption {
+	return func(a *App) {
+		a.APIKeyService = service
+	}
+}
+
+// WithRevocationService sets the session revocation service in the application
+func WithRevocationService(service ports.RevocationService) AppOption {
+	return func(a *App) {
+		a.RevocationService = service
+	}
+}
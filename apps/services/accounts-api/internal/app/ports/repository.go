@@ -1,14 +1,37 @@
 package ports
 
 import (
+	"apps/services/accounts-api/internal/domain/entities"
 	"context"
+	"errors"
+	"libs/backend/boot"
 	userEntities "libs/backend/domain/user/entities"
 	userValueObjects "libs/backend/domain/user/valueobjects"
 	"time"
+
+	"github.com/google/uuid"
 )
 
+var (
+	// ErrAccountNotFound is returned when an account does not exist
+	ErrAccountNotFound = errors.New("account not found")
+
+	// ErrAPIKeyNotFound is returned when an API key does not exist
+	ErrAPIKeyNotFound = errors.New("api key not found")
+)
+
+// EventOutbox stores the events of a transaction, they are published once it commits
+type EventOutbox interface {
+	// Save stores the event for publishing
+	Save(context.Context, boot.ScheduledPublishing) error
+}
+
 // AccountRepository is the interface for the account repository
 type AccountRepository interface {
+	// Transaction runs the function with a repository and an event outbox bound
+	// to one database transaction, which is rolled back when it returns an error
+	Transaction(context.Context, func(AccountRepository, EventOutbox) error) error
+
 	// CreateAccount creates an account in the database
 	CreateAccount(context.Context, userEntities.User) error
 
@@ -18,9 +41,49 @@ type AccountRepository interface {
 	// GetAccountByEmailAddress gets an account from the database by email address
 	GetAccountByEmailAddress(context.Context, userValueObjects.EmailAddress) (userEntities.User, error)
 
+	// UpdateAccount updates the username and/or email address of the account and returns the result
+	UpdateAccount(context.Context, userEntities.User) (userEntities.User, error)
+
 	// SoftDeleteAccountByCommonID will soft delete the user
 	SoftDeleteAccountByCommonID(context.Context, userValueObjects.CommonID) (time.Time, error)
 
 	// HardDeleteAccountByCommonID will hard delete the user
 	HardDeleteAccountByCommonID(context.Context, userValueObjects.CommonID) (time.Time, error)
+
+	// RestoreAccountByCommonID will restore a soft deleted user
+	RestoreAccountByCommonID(context.Context, userValueObjects.CommonID) (userEntities.User, error)
+
+	// VerifyEmailAddress marks the email address of the account as verified when
+	// it is still the address of the account, it reports whether the account changed
+	VerifyEmailAddress(context.Context, userValueObjects.CommonID, userValueObjects.EmailAddress) (bool, error)
+
+	// RecordLogin stores the login time of the account unless a later login is stored
+	RecordLogin(context.Context, userValueObjects.CommonID, time.Time) error
+}
+
+// APIKeyRepository is the interface for the API key repository
+type APIKeyRepository interface {
+	// CreateAPIKey stores a new API key
+	CreateAPIKey(context.Context, entities.APIKey) error
+
+	// GetAPIKeyByPrefix gets the API key by its unique prefix
+	GetAPIKeyByPrefix(context.Context, string) (entities.APIKey, error)
+
+	// ListAPIKeys lists the API keys of the account, newest first
+	ListAPIKeys(context.Context, userValueObjects.CommonID) ([]entities.APIKey, error)
+
+	// RevokeAPIKey revokes the API key of the account and returns the result
+	RevokeAPIKey(context.Context, userValueObjects.CommonID, uuid.UUID, time.Time) (entities.APIKey, error)
+
+	// TouchAPIKey records the last use of the API key
+	TouchAPIKey(context.Context, uuid.UUID, time.Time) error
+}
+
+// RevocationRepository is the interface for the session revocation repository
+type RevocationRepository interface {
+	// CreateRevocation stores a new revocation
+	CreateRevocation(context.Context, entities.Revocation) error
+
+	// ListRevocations lists the revocations of the account, newest first
+	ListRevocations(context.Context, userValueObjects.CommonID) ([]entities.Revocation, error)
 }
@@ -1,10 +1,25 @@
 package ports
 
 import (
+	"apps/services/accounts-api/internal/domain/entities"
 	"context"
+	"errors"
 	userEntities "libs/backend/domain/user/entities"
 	userValueObjects "libs/backend/domain/user/valueobjects"
 	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrInvalidAPIKey is returned for unknown, revoked and expired API keys alike
+	ErrInvalidAPIKey = errors.New("api key invalid")
+
+	// ErrAPIKeyScopeNotAllowed is returned when a scope cannot be granted to API keys
+	ErrAPIKeyScopeNotAllowed = errors.New("scope not allowed for api keys")
+
+	// ErrAPIKeyLimitReached is returned when an account has too many active API keys
+	ErrAPIKeyLimitReached = errors.New("api key limit reached")
 )
 
 // AccountService is the interface for the registration service
@@ -15,6 +30,50 @@ type AccountService interface {
 	// GetUser gets a user from the system
 	GetUser(ctx context.Context, commonID userValueObjects.CommonID, emailAddress userValueObjects.EmailAddress) (userEntities.User, error)
 
+	// UpdateUser updates the username and/or email address of the user
+	UpdateUser(ctx context.Context, user userEntities.User) (userEntities.User, error)
+
 	// Delete user will delete the user from the system (hard or soft deletion)
 	DeleteUser(ctx context.Context, commonID userValueObjects.CommonID, hardDelete bool) (time.Time, error)
+
+	// RestoreUser restores a soft deleted user
+	RestoreUser(ctx context.Context, commonID userValueObjects.CommonID) (userEntities.User, error)
+
+	// VerifyEmailAddress marks the email address the identity provider verified as verified
+	VerifyEmailAddress(ctx context.Context, commonID userValueObjects.CommonID, emailAddress userValueObjects.EmailAddress) error
+
+	// RecordLogin records the login the identity provider logged
+	RecordLogin(ctx context.Context, commonID userValueObjects.CommonID, loggedInAt time.Time) error
+}
+
+// APIKeyService is the interface for the API key service
+type APIKeyService interface {
+	// CreateAPIKey creates an API key of the account and returns it with the
+	// key, which is not stored and cannot be retrieved again
+	CreateAPIKey(ctx context.Context, commonID userValueObjects.CommonID, name string, scopes []string, expiresAt time.Time) (entities.APIKey, string, error)
+
+	// ListAPIKeys lists the API keys of the account
+	ListAPIKeys(ctx context.Context, commonID userValueObjects.CommonID) ([]entities.APIKey, error)
+
+	// RevokeAPIKey revokes the API key of the account
+	RevokeAPIKey(ctx context.Context, commonID userValueObjects.CommonID, id uuid.UUID) (entities.APIKey, error)
+
+	// VerifyAPIKey returns the active API key of the key
+	VerifyAPIKey(ctx context.Context, key string) (entities.APIKey, error)
+}
+
+// RevocationService is the interface for the session revocation service
+type RevocationService interface {
+	// RevokeSessions rejects every access token of the account issued until now
+	RevokeSessions(ctx context.Context, commonID userValueObjects.CommonID, reason, revokedBy string) (entities.Revocation, error)
+
+	// RevokeUserSessions rejects every access token of the account and of the
+	// sub of the identity provider user issued until now
+	RevokeUserSessions(ctx context.Context, commonID userValueObjects.CommonID, subject, reason, revokedBy string) (entities.Revocation, error)
+
+	// RevokeToken rejects the access token of the jti until it expires
+	RevokeToken(ctx context.Context, jti string, commonID userValueObjects.CommonID, expiresAt time.Time, reason, revokedBy string) (entities.Revocation, error)
+
+	// ListRevocations lists the revocations of the account
+	ListRevocations(ctx context.Context, commonID userValueObjects.CommonID) ([]entities.Revocation, error)
 }
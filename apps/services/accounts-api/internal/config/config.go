@@ -1,6 +1,7 @@
 package config
 
 import (
+	"libs/backend/eventing"
 	"os"
 )
 
@@ -14,6 +15,19 @@ type Config struct {
 	DBPort     string
 	DBSSLMode  string
 	DBTimeZone string
+
+	Auth0Domain   string
+	Auth0Audience string
+
+	// AuthJWKSFile replaces the key set of the Auth0 tenant, e.g. in local development
+	AuthJWKSFile string
+
+	// AuthPolicyFile replaces the default role policy
+	AuthPolicyFile string
+
+	// IdentityEventsQueueName is the queue of the logins and verified email
+	// addresses the accounts are kept up to date with
+	IdentityEventsQueueName string
 }
 
 // NewConfig constructs the config
@@ -27,6 +41,13 @@ func NewConfig() (Config, error) {
 		DBPort:     os.Getenv("DATABASE_PORT"),
 		DBSSLMode:  os.Getenv("DATABASE_SSL_MODE"),
 		DBTimeZone: os.Getenv("DATABASE_TIMEZONE"),
+
+		Auth0Domain:    os.Getenv("AUTH0_DOMAIN"),
+		Auth0Audience:  os.Getenv("AUTH0_AUDIENCE"),
+		AuthJWKSFile:   os.Getenv("AUTH_JWKS_FILE"),
+		AuthPolicyFile: os.Getenv("AUTH_POLICY_FILE"),
+
+		IdentityEventsQueueName: eventing.GetQueueName(eventing.AccountsAPIService, eventing.IdentityEventsQueue),
 	}
 
 	return config, nil
@@ -0,0 +1,45 @@
+package entities
+
+import (
+	userValueObjects "libs/backend/domain/user/valueobjects"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// APIKey is a key of an account for clients that cannot use OAuth, only the
+// hash of the key is stored
+type APIKey struct {
+	ID       uuid.UUID
+	CommonID userValueObjects.CommonID
+	Name     string
+
+	// Prefix identifies the key, it is the start of the key and shown to users
+	Prefix string
+
+	// SecretHash is the hex SHA-256 hash of the whole key
+	SecretHash string
+
+	Scopes []string
+
+	// ExpiresAt is zero for keys that never expire
+	ExpiresAt  time.Time
+	LastUsedAt time.Time
+	RevokedAt  time.Time
+	CreatedAt  time.Time
+}
+
+// IsRevoked lets the caller know if the key was revoked
+func (k APIKey) IsRevoked() bool {
+	return !k.RevokedAt.IsZero()
+}
+
+// IsExpired lets the caller know if the key is expired at the time
+func (k APIKey) IsExpired(now time.Time) bool {
+	return !k.ExpiresAt.IsZero() && !now.Before(k.ExpiresAt)
+}
+
+// IsActive lets the caller know if the key is accepted at the time
+func (k APIKey) IsActive(now time.Time) bool {
+	return !k.IsRevoked() && !k.IsExpired(now)
+}
@@ -0,0 +1,41 @@
+package entities
+
+import (
+	userValueObjects "libs/backend/domain/user/valueobjects"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Revocation rejects a single access token by its jti, or every access token
+// of an account issued before TokensIssuedBefore
+type Revocation struct {
+	ID uuid.UUID
+
+	// JTI is set for the revocation of a single token
+	JTI string
+
+	// CommonID is the account, optional for the revocation of a single token
+	CommonID userValueObjects.CommonID
+
+	// Subject is the sub claim of the tokens revoked along with the account,
+	// which covers the tokens issued without a common id claim
+	Subject string
+
+	// TokensIssuedBefore is set for the revocation of every token of the account
+	TokensIssuedBefore time.Time
+
+	// ExpiresAt is when every revoked token has expired
+	ExpiresAt time.Time
+
+	Reason string
+
+	// RevokedBy is the subject of the admin who revoked the sessions
+	RevokedBy string
+	CreatedAt time.Time
+}
+
+// IsSessionRevocation lets the caller know if every token of the account was revoked
+func (r Revocation) IsSessionRevocation() bool {
+	return r.JTI == ""
+}
@@ -4,11 +4,18 @@ import (
 	"apps/services/accounts-api/internal/app/ports"
 	"context"
 	"errors"
+	"fmt"
 	"libs/backend/boot"
 	userEntities "libs/backend/domain/user/entities"
 	userValueObjects "libs/backend/domain/user/valueobjects"
+	"libs/backend/eventing"
+	accountseventsv1 "libs/backend/proto-gen/go/accounts/accountsevents/v1"
+	accountsDomain "libs/backend/proto-gen/go/accounts/domain"
 	"log/slog"
 	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // AccountService is the registration service
@@ -16,7 +23,8 @@ type AccountService struct {
 	// Logger is the logger from the boot framework
 	Logger boot.Logger
 
-	// AccountRepository is the account repository
+	// AccountRepository is the account repository, the lifecycle events are
+	// stored in its outbox within the transaction of the account change
 	AccountRepository ports.AccountRepository
 }
 
@@ -28,17 +36,37 @@ func NewAccountService(logger boot.Logger, accountRepository ports.AccountReposi
 	}
 }
 
-// RegisterUser registers a user in the system and the database
+// RegisterUser registers a user in the system and the database. Registering
+// an existing common id succeeds without a change, so callers retry safely.
 func (s AccountService) RegisterUser(ctx context.Context, user userEntities.User) error {
 	s.Logger.Info("Registering user", slog.String("commonID", user.CommonID.String()))
 
-	// Create account
-	err := s.AccountRepository.CreateAccount(ctx, user)
-	if err != nil {
-		return err
-	}
+	return s.AccountRepository.Transaction(ctx, func(accounts ports.AccountRepository, outbox ports.EventOutbox) error {
+		// The account created event was stored with the existing account
+		_, err := accounts.GetAccountByCommonID(ctx, user.CommonID)
+		if err == nil {
+			s.Logger.Info("Account already registered", slog.String("commonID", user.CommonID.String()))
+			return nil
+		}
+		if !errors.Is(err, ports.ErrAccountNotFound) {
+			return err
+		}
 
-	return nil
+		// Create account
+		if err := accounts.CreateAccount(ctx, user); err != nil {
+			return err
+		}
+
+		// Read the account back to publish the stored timestamps
+		created, err := accounts.GetAccountByCommonID(ctx, user.CommonID)
+		if err != nil {
+			created = user
+		}
+
+		return s.saveEvent(ctx, outbox, created.CommonID, eventing.EventNameAccountCreated, eventing.AccountCreatedVersion, &accountseventsv1.AccountCreated{
+			Account: newAccount(created),
+		})
+	})
 }
 
 // GetUser gets a user from the system
@@ -68,23 +96,188 @@ func (s AccountService) GetUser(ctx context.Context, commonID userValueObjects.C
 	return user, nil
 }
 
+// UpdateUser updates the username and/or email address of the user
+func (s AccountService) UpdateUser(ctx context.Context, user userEntities.User) (userEntities.User, error) {
+	s.Logger.Info("Updating user", slog.String("commonID", user.CommonID.String()))
+
+	// Track which fields the caller changed
+	updatedFields := make([]string, 0, 2)
+	if user.Username != "" {
+		updatedFields = append(updatedFields, "username")
+	}
+	if !user.EmailAddress.IsEmpty() {
+		updatedFields = append(updatedFields, "email_address")
+	}
+
+	var updated userEntities.User
+	err := s.AccountRepository.Transaction(ctx, func(accounts ports.AccountRepository, outbox ports.EventOutbox) error {
+		var err error
+		updated, err = accounts.UpdateAccount(ctx, user)
+		if err != nil {
+			return err
+		}
+
+		if len(updatedFields) == 0 {
+			return nil
+		}
+
+		return s.saveEvent(ctx, outbox, user.CommonID, eventing.EventNameAccountUpdated, eventing.AccountUpdatedVersion, &accountseventsv1.AccountUpdated{
+			Account:       newAccount(updated),
+			UpdatedFields: updatedFields,
+		})
+	})
+	if err != nil {
+		return userEntities.NewEmptyUser(), err
+	}
+
+	return updated, nil
+}
+
 // Delete user will delete the user from the system (hard or soft deletion)
 func (s AccountService) DeleteUser(ctx context.Context, commonID userValueObjects.CommonID, hardDelete bool) (time.Time, error) {
 	s.Logger.Info("Deleting user by commonID", slog.String("commonID", commonID.String()), slog.Bool("hardDelete", hardDelete))
 
-	switch {
-	case !hardDelete:
-		deletedAt, err := s.AccountRepository.SoftDeleteAccountByCommonID(ctx, commonID)
+	var deletedAt time.Time
+	err := s.AccountRepository.Transaction(ctx, func(accounts ports.AccountRepository, outbox ports.EventOutbox) error {
+		var err error
+
+		switch {
+		case !hardDelete:
+			deletedAt, err = accounts.SoftDeleteAccountByCommonID(ctx, commonID)
+			if err != nil {
+				return err
+			}
+
+			return s.saveEvent(ctx, outbox, commonID, eventing.EventNameAccountSoftDeleted, eventing.AccountSoftDeletedVersion, &accountseventsv1.AccountSoftDeleted{
+				CommonId:  commonID.String(),
+				DeletedAt: timestamppb.New(occurredAt(deletedAt)),
+			})
+		default:
+			deletedAt, err = accounts.HardDeleteAccountByCommonID(ctx, commonID)
+			if err != nil {
+				return err
+			}
+
+			return s.saveEvent(ctx, outbox, commonID, eventing.EventNameAccountHardDeleted, eventing.AccountHardDeletedVersion, &accountseventsv1.AccountHardDeleted{
+				CommonId:  commonID.String(),
+				DeletedAt: timestamppb.New(occurredAt(deletedAt)),
+			})
+		}
+	})
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return deletedAt, nil
+}
+
+// RestoreUser restores a soft deleted user
+func (s AccountService) RestoreUser(ctx context.Context, commonID userValueObjects.CommonID) (userEntities.User, error) {
+	s.Logger.Info("Restoring user by commonID", slog.String("commonID", commonID.String()))
+
+	var restored userEntities.User
+	err := s.AccountRepository.Transaction(ctx, func(accounts ports.AccountRepository, outbox ports.EventOutbox) error {
+		var err error
+		restored, err = accounts.RestoreAccountByCommonID(ctx, commonID)
 		if err != nil {
-			return time.Time{}, err
+			return err
 		}
-		return deletedAt, nil
-	default:
-		deletedAt, err := s.AccountRepository.HardDeleteAccountByCommonID(ctx, commonID)
+
+		return s.saveEvent(ctx, outbox, commonID, eventing.EventNameAccountRestored, eventing.AccountRestoredVersion, &accountseventsv1.AccountRestored{
+			Account:    newAccount(restored),
+			RestoredAt: timestamppb.Now(),
+		})
+	})
+	if err != nil {
+		return userEntities.NewEmptyUser(), err
+	}
+
+	return restored, nil
+}
+
+// VerifyEmailAddress marks the email address the identity provider verified
+// as verified. The account updated event is only stored when the account
+// changed, so redelivered and outdated verifications do not repeat it.
+func (s AccountService) VerifyEmailAddress(ctx context.Context, commonID userValueObjects.CommonID, emailAddress userValueObjects.EmailAddress) error {
+	s.Logger.Info("Verifying email address", slog.String("commonID", commonID.String()))
+
+	return s.AccountRepository.Transaction(ctx, func(accounts ports.AccountRepository, outbox ports.EventOutbox) error {
+		verified, err := accounts.VerifyEmailAddress(ctx, commonID, emailAddress)
+		if err != nil || !verified {
+			return err
+		}
+
+		updated, err := accounts.GetAccountByCommonID(ctx, commonID)
 		if err != nil {
-			return time.Time{}, err
+			return err
 		}
 
-		return deletedAt, nil
+		return s.saveEvent(ctx, outbox, commonID, eventing.EventNameAccountUpdated, eventing.AccountUpdatedVersion, &accountseventsv1.AccountUpdated{
+			Account:       newAccount(updated),
+			UpdatedFields: []string{"email_address_verified"},
+		})
+	})
+}
+
+// RecordLogin records the login the identity provider logged, logins are not
+// published as account events since the auth exchange carries them already
+func (s AccountService) RecordLogin(ctx context.Context, commonID userValueObjects.CommonID, loggedInAt time.Time) error {
+	return s.AccountRepository.RecordLogin(ctx, commonID, loggedInAt)
+}
+
+// saveEvent stores the lifecycle event in the outbox of the transaction, it is
+// published to the accounts exchange once the transaction commits. The event
+// name doubles as the routing key and the common id keeps the events of the
+// account in order for partitioned consumers.
+func (s AccountService) saveEvent(ctx context.Context, outbox ports.EventOutbox, commonID userValueObjects.CommonID, eventName eventing.EventName, version eventing.EventVersion, event proto.Message) error {
+	msg, err := eventing.NewProtoPublishing(eventName, version, event)
+	if err != nil {
+		s.Logger.Error("Cannot marshal account event", slog.String("eventName", eventName.String()), slog.Any("error", err))
+		return err
+	}
+	msg = eventing.WithCommonID(msg, commonID.String())
+
+	if err := outbox.Save(ctx, boot.ScheduledPublishing{
+		Exchange:   eventing.AccountsExchange,
+		RoutingKey: eventName.String(),
+		DeliverAt:  time.Now(),
+		Publishing: msg,
+	}); err != nil {
+		s.Logger.Error("Cannot store account event", slog.String("eventName", eventName.String()), slog.Any("error", err))
+		return fmt.Errorf("cannot store %s event: %w", eventName, err)
+	}
+
+	return nil
+}
+
+// newAccount converts the user to the account domain message
+func newAccount(user userEntities.User) *accountsDomain.Account {
+	// The metadata is read back from JSON, so it always converts
+	metadata, _ := eventing.NewMetadataStruct(user.Metadata)
+
+	return &accountsDomain.Account{
+		CommonId:     user.CommonID.String(),
+		EmailAddress: user.EmailAddress.String(),
+		Username:     user.Username,
+		CreatedAt:    timestamppb.New(user.CreatedAt),
+		UpdatedAt:    timestamppb.New(user.UpdatedAt),
+
+		FirstName:            user.FirstName,
+		LastName:             user.LastName,
+		Nickname:             user.Nickname,
+		EmailAddressVerified: user.EmailAddressVerified,
+		PhoneNumber:          user.PhoneNumber,
+		PhoneNumberVerified:  user.PhoneNumberVerified,
+		Strategy:             user.Strategy,
+		Metadata:             metadata,
 	}
 }
+
+// occurredAt falls back to the current time when the repository cannot report the timestamp
+func occurredAt(t time.Time) time.Time {
+	if t.IsZero() {
+		return time.Now()
+	}
+
+	return t
+}
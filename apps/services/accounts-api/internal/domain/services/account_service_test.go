@@ -0,0 +1,231 @@
+package services_test
+
+import (
+	"apps/services/accounts-api/internal/app/ports"
+	"apps/services/accounts-api/internal/domain/services"
+	"context"
+	"libs/backend/boot"
+	userEntities "libs/backend/domain/user/entities"
+	userValueObjects "libs/backend/domain/user/valueobjects"
+	"libs/backend/eventing"
+	accountseventsv1 "libs/backend/proto-gen/go/accounts/accountsevents/v1"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+// fakeAccountRepository keeps accounts in memory
+type fakeAccountRepository struct {
+	accounts map[string]userEntities.User
+	deleted  map[string]userEntities.User
+
+	// events are the outbox events of the committed transactions
+	events []boot.ScheduledPublishing
+}
+
+func newFakeAccountRepository() *fakeAccountRepository {
+	return &fakeAccountRepository{
+		accounts: make(map[string]userEntities.User),
+		deleted:  make(map[string]userEntities.User),
+		events:   make([]boot.ScheduledPublishing, 0),
+	}
+}
+
+// fakeOutbox collects the events of a transaction
+type fakeOutbox struct {
+	events []boot.ScheduledPublishing
+}
+
+func (o *fakeOutbox) Save(_ context.Context, scheduled boot.ScheduledPublishing) error {
+	o.events = append(o.events, scheduled)
+	return nil
+}
+
+func (r *fakeAccountRepository) Transaction(_ context.Context, fn func(ports.AccountRepository, ports.EventOutbox) error) error {
+	outbox := &fakeOutbox{}
+	if err := fn(r, outbox); err != nil {
+		return err
+	}
+
+	r.events = append(r.events, outbox.events...)
+	return nil
+}
+
+func (r *fakeAccountRepository) CreateAccount(_ context.Context, user userEntities.User) error {
+	user.CreatedAt = time.Now()
+	user.UpdatedAt = user.CreatedAt
+	r.accounts[user.CommonID.String()] = user
+	return nil
+}
+
+func (r *fakeAccountRepository) GetAccountByCommonID(_ context.Context, commonID userValueObjects.CommonID) (userEntities.User, error) {
+	account, ok := r.accounts[commonID.String()]
+	if !ok {
+		return userEntities.User{}, ports.ErrAccountNotFound
+	}
+	return account, nil
+}
+
+func (r *fakeAccountRepository) GetAccountByEmailAddress(context.Context, userValueObjects.EmailAddress) (userEntities.User, error) {
+	return userEntities.User{}, nil
+}
+
+func (r *fakeAccountRepository) UpdateAccount(_ context.Context, user userEntities.User) (userEntities.User, error) {
+	account := r.accounts[user.CommonID.String()]
+	if user.Username != "" {
+		account.Username = user.Username
+	}
+	account.UpdatedAt = time.Now()
+	r.accounts[user.CommonID.String()] = account
+	return account, nil
+}
+
+func (r *fakeAccountRepository) SoftDeleteAccountByCommonID(_ context.Context, commonID userValueObjects.CommonID) (time.Time, error) {
+	r.deleted[commonID.String()] = r.accounts[commonID.String()]
+	delete(r.accounts, commonID.String())
+	return time.Now(), nil
+}
+
+func (r *fakeAccountRepository) HardDeleteAccountByCommonID(_ context.Context, commonID userValueObjects.CommonID) (time.Time, error) {
+	delete(r.accounts, commonID.String())
+	delete(r.deleted, commonID.String())
+	return time.Time{}, nil
+}
+
+func (r *fakeAccountRepository) RestoreAccountByCommonID(_ context.Context, commonID userValueObjects.CommonID) (userEntities.User, error) {
+	account, ok := r.deleted[commonID.String()]
+	if !ok {
+		return userEntities.User{}, ports.ErrAccountNotFound
+	}
+	delete(r.deleted, commonID.String())
+	r.accounts[commonID.String()] = account
+	return account, nil
+}
+
+func (r *fakeAccountRepository) VerifyEmailAddress(_ context.Context, commonID userValueObjects.CommonID, emailAddress userValueObjects.EmailAddress) (bool, error) {
+	account, ok := r.accounts[commonID.String()]
+	if !ok || account.EmailAddressVerified || account.EmailAddress.String() != emailAddress.String() {
+		return false, nil
+	}
+	account.EmailAddressVerified = true
+	r.accounts[commonID.String()] = account
+	return true, nil
+}
+
+func (r *fakeAccountRepository) RecordLogin(_ context.Context, commonID userValueObjects.CommonID, loggedInAt time.Time) error {
+	account, ok := r.accounts[commonID.String()]
+	if ok && account.LastLoginAt.Before(loggedInAt) {
+		account.LastLoginAt = loggedInAt
+		r.accounts[commonID.String()] = account
+	}
+	return nil
+}
+
+func TestAccountServiceLifecycleEvents(t *testing.T) {
+	ctx := context.Background()
+	logger := boot.NewSlogger()
+
+	repository := newFakeAccountRepository()
+	service := services.NewAccountService(logger, repository)
+
+	commonID := userValueObjects.NewCommonID()
+	user := userEntities.NewUser(
+		userEntities.WithCommonID(commonID),
+		userEntities.WithEmailAddress(userValueObjects.NewEmailAddress("user@example.com")),
+		userEntities.WithUserUsername("user"),
+	)
+
+	require.NoError(t, service.RegisterUser(ctx, user))
+
+	// Retried registrations succeed without another event
+	require.NoError(t, service.RegisterUser(ctx, user))
+
+	_, err := service.UpdateUser(ctx, userEntities.NewUser(
+		userEntities.WithCommonID(commonID),
+		userEntities.WithUserUsername("renamed"),
+	))
+	require.NoError(t, err)
+	_, err = service.DeleteUser(ctx, commonID, false)
+	require.NoError(t, err)
+	_, err = service.RestoreUser(ctx, commonID)
+	require.NoError(t, err)
+	_, err = service.DeleteUser(ctx, commonID, true)
+	require.NoError(t, err)
+
+	// A failed change rolls back its event
+	_, err = service.RestoreUser(ctx, commonID)
+	require.ErrorIs(t, err, ports.ErrAccountNotFound)
+
+	events := repository.events
+	require.Len(t, events, 5)
+
+	eventNames := make([]string, 0, len(events))
+	for _, event := range events {
+		eventNames = append(eventNames, event.Publishing.Type)
+		assert.Equal(t, eventing.AccountsExchange, event.Exchange)
+		assert.Equal(t, event.Publishing.Type, event.RoutingKey)
+		assert.False(t, event.DeliverAt.IsZero())
+		assert.EqualValues(t, 1, event.Publishing.Headers[eventing.EventVersionHeader])
+		assert.Equal(t, commonID.String(), event.Publishing.Headers[eventing.CommonIDHeader])
+	}
+	assert.Equal(t, []string{
+		eventing.EventNameAccountCreated.String(),
+		eventing.EventNameAccountUpdated.String(),
+		eventing.EventNameAccountSoftDeleted.String(),
+		eventing.EventNameAccountRestored.String(),
+		eventing.EventNameAccountHardDeleted.String(),
+	}, eventNames)
+
+	var updated accountseventsv1.AccountUpdated
+	require.NoError(t, proto.Unmarshal(events[1].Publishing.Body, &updated))
+	assert.Equal(t, "renamed", updated.Account.Username)
+	assert.Equal(t, []string{"username"}, updated.UpdatedFields)
+
+	var hardDeleted accountseventsv1.AccountHardDeleted
+	require.NoError(t, proto.Unmarshal(events[4].Publishing.Body, &hardDeleted))
+	assert.Equal(t, commonID.String(), hardDeleted.CommonId)
+	assert.False(t, hardDeleted.DeletedAt.AsTime().IsZero())
+}
+
+func TestAccountServiceIdentityEvents(t *testing.T) {
+	ctx := context.Background()
+
+	repository := newFakeAccountRepository()
+	service := services.NewAccountService(boot.NewSlogger(), repository)
+
+	commonID := userValueObjects.NewCommonID()
+	emailAddress := userValueObjects.NewEmailAddress("user@example.com")
+	require.NoError(t, service.RegisterUser(ctx, userEntities.NewUser(
+		userEntities.WithCommonID(commonID),
+		userEntities.WithEmailAddress(emailAddress),
+		userEntities.WithUserUsername("user"),
+	)))
+	repository.events = repository.events[:0]
+
+	t.Run("verifies the email address once", func(t *testing.T) {
+		require.NoError(t, service.VerifyEmailAddress(ctx, commonID, userValueObjects.NewEmailAddress("previous@example.com")))
+		assert.Empty(t, repository.events)
+
+		require.NoError(t, service.VerifyEmailAddress(ctx, commonID, emailAddress))
+		require.NoError(t, service.VerifyEmailAddress(ctx, commonID, emailAddress))
+		require.Len(t, repository.events, 1)
+
+		var updated accountseventsv1.AccountUpdated
+		require.NoError(t, proto.Unmarshal(repository.events[0].Publishing.Body, &updated))
+		assert.True(t, updated.Account.EmailAddressVerified)
+		assert.Equal(t, []string{"email_address_verified"}, updated.UpdatedFields)
+	})
+
+	t.Run("keeps the latest login", func(t *testing.T) {
+		loggedInAt := time.Date(2024, 12, 3, 15, 15, 0, 0, time.UTC)
+		require.NoError(t, service.RecordLogin(ctx, commonID, loggedInAt))
+		require.NoError(t, service.RecordLogin(ctx, commonID, loggedInAt.Add(-time.Hour)))
+
+		account, err := repository.GetAccountByCommonID(ctx, commonID)
+		require.NoError(t, err)
+		assert.Equal(t, loggedInAt, account.LastLoginAt)
+	})
+}
@@ -0,0 +1,74 @@
+package services
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	// apiKeyPrefix marks the keys handed to users
+	apiKeyPrefix = "cc_"
+
+	// apiKeyIDBytes is the length of the random identifier in the prefix
+	apiKeyIDBytes = 6
+
+	// apiKeySecretBytes is the length of the random secret
+	apiKeySecretBytes = 32
+
+	// apiKeySeparator separates the prefix from the secret
+	apiKeySeparator = "_"
+)
+
+// ErrMalformedAPIKey is returned when a key does not have the format of the keys
+var ErrMalformedAPIKey = errors.New("malformed api key")
+
+// GenerateAPIKey returns a new key of the form cc_<id>_<secret> with its
+// prefix cc_<id> and the hash of the key
+func GenerateAPIKey() (key, prefix, secretHash string, err error) {
+	id := make([]byte, apiKeyIDBytes)
+	if _, err := rand.Read(id); err != nil {
+		return "", "", "", fmt.Errorf("cannot generate api key id: %w", err)
+	}
+
+	secret := make([]byte, apiKeySecretBytes)
+	if _, err := rand.Read(secret); err != nil {
+		return "", "", "", fmt.Errorf("cannot generate api key secret: %w", err)
+	}
+
+	prefix = apiKeyPrefix + hex.EncodeToString(id)
+	key = prefix + apiKeySeparator + base64.RawURLEncoding.EncodeToString(secret)
+
+	return key, prefix, HashAPIKey(key), nil
+}
+
+// ParseAPIKeyPrefix returns the prefix of the key
+func ParseAPIKeyPrefix(key string) (string, error) {
+	if !strings.HasPrefix(key, apiKeyPrefix) {
+		return "", ErrMalformedAPIKey
+	}
+
+	prefixLength := len(apiKeyPrefix) + hex.EncodedLen(apiKeyIDBytes)
+	if len(key) <= prefixLength+len(apiKeySeparator) || key[prefixLength:prefixLength+len(apiKeySeparator)] != apiKeySeparator {
+		return "", ErrMalformedAPIKey
+	}
+
+	return key[:prefixLength], nil
+}
+
+// HashAPIKey returns the hex SHA-256 hash of the key, the keys are random so
+// a slow password hash is not needed
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// MatchesAPIKeyHash compares the hash of the key to the stored hash in constant time
+func MatchesAPIKeyHash(key, secretHash string) bool {
+	return subtle.ConstantTimeCompare([]byte(HashAPIKey(key)), []byte(secretHash)) == 1
+}
@@ -0,0 +1,163 @@
+package services
+
+import (
+	"apps/services/accounts-api/internal/app/ports"
+	"apps/services/accounts-api/internal/domain/entities"
+	"context"
+	"errors"
+	"fmt"
+	"libs/backend/boot"
+	userValueObjects "libs/backend/domain/user/valueobjects"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Assertion of the proper interface
+var _ ports.APIKeyService = (*APIKeyService)(nil)
+
+const (
+	// MaxActiveAPIKeys is how many unrevoked, unexpired keys an account may have
+	MaxActiveAPIKeys = 10
+
+	// apiKeyTouchInterval throttles the writes recording the last use of a key
+	apiKeyTouchInterval = time.Minute
+)
+
+// AllowedAPIKeyScopes are the scopes API keys may be granted, keys cannot
+// manage keys or reach the procedures of machines. Keys are only verified by
+// the accounts API, so they are limited to the scopes of its procedures.
+var AllowedAPIKeyScopes = []string{
+	"read:accounts",
+	"update:accounts",
+}
+
+// APIKeyService manages the API keys of accounts
+type APIKeyService struct {
+	logger     boot.Logger
+	repository ports.APIKeyRepository
+	now        func() time.Time
+}
+
+// APIKeyServiceParams is a struct to hold the parameters for the APIKeyService
+type APIKeyServiceParams struct {
+	Logger     boot.Logger
+	Repository ports.APIKeyRepository
+
+	// Now returns the current time and defaults to time.Now
+	Now func() time.Time
+}
+
+// NewAPIKeyService constructs the API key service
+func NewAPIKeyService(params APIKeyServiceParams) *APIKeyService {
+	if params.Now == nil {
+		params.Now = time.Now
+	}
+
+	return &APIKeyService{
+		logger:     params.Logger,
+		repository: params.Repository,
+		now:        params.Now,
+	}
+}
+
+// CreateAPIKey creates an API key of the account and returns it with the key
+func (s *APIKeyService) CreateAPIKey(ctx context.Context, commonID userValueObjects.CommonID, name string, scopes []string, expiresAt time.Time) (entities.APIKey, string, error) {
+	for _, scope := range scopes {
+		if !slices.Contains(AllowedAPIKeyScopes, scope) {
+			return entities.APIKey{}, "", fmt.Errorf("%w: %s", ports.ErrAPIKeyScopeNotAllowed, scope)
+		}
+	}
+
+	now := s.now()
+	existing, err := s.repository.ListAPIKeys(ctx, commonID)
+	if err != nil {
+		return entities.APIKey{}, "", err
+	}
+
+	active := 0
+	for _, apiKey := range existing {
+		if apiKey.IsActive(now) {
+			active++
+		}
+	}
+	if active >= MaxActiveAPIKeys {
+		return entities.APIKey{}, "", ports.ErrAPIKeyLimitReached
+	}
+
+	key, prefix, secretHash, err := GenerateAPIKey()
+	if err != nil {
+		return entities.APIKey{}, "", err
+	}
+
+	apiKey := entities.APIKey{
+		ID:         uuid.New(),
+		CommonID:   commonID,
+		Name:       name,
+		Prefix:     prefix,
+		SecretHash: secretHash,
+		Scopes:     scopes,
+		ExpiresAt:  expiresAt,
+		CreatedAt:  now,
+	}
+
+	if err := s.repository.CreateAPIKey(ctx, apiKey); err != nil {
+		return entities.APIKey{}, "", err
+	}
+
+	s.logger.Info("Created api key", slog.String("apiKeyID", apiKey.ID.String()), slog.String("commonID", commonID.String()))
+
+	return apiKey, key, nil
+}
+
+// ListAPIKeys lists the API keys of the account
+func (s *APIKeyService) ListAPIKeys(ctx context.Context, commonID userValueObjects.CommonID) ([]entities.APIKey, error) {
+	return s.repository.ListAPIKeys(ctx, commonID)
+}
+
+// RevokeAPIKey revokes the API key of the account
+func (s *APIKeyService) RevokeAPIKey(ctx context.Context, commonID userValueObjects.CommonID, id uuid.UUID) (entities.APIKey, error) {
+	apiKey, err := s.repository.RevokeAPIKey(ctx, commonID, id, s.now())
+	if err != nil {
+		return entities.APIKey{}, err
+	}
+
+	s.logger.Info("Revoked api key", slog.String("apiKeyID", id.String()), slog.String("commonID", commonID.String()))
+
+	return apiKey, nil
+}
+
+// VerifyAPIKey returns the active API key of the key. Unknown, revoked and
+// expired keys fail with the same error so callers cannot probe for keys.
+func (s *APIKeyService) VerifyAPIKey(ctx context.Context, key string) (entities.APIKey, error) {
+	prefix, err := ParseAPIKeyPrefix(key)
+	if err != nil {
+		return entities.APIKey{}, ports.ErrInvalidAPIKey
+	}
+
+	apiKey, err := s.repository.GetAPIKeyByPrefix(ctx, prefix)
+	if errors.Is(err, ports.ErrAPIKeyNotFound) {
+		return entities.APIKey{}, ports.ErrInvalidAPIKey
+	}
+	if err != nil {
+		return entities.APIKey{}, err
+	}
+
+	now := s.now()
+	if !MatchesAPIKeyHash(key, apiKey.SecretHash) || !apiKey.IsActive(now) {
+		return entities.APIKey{}, ports.ErrInvalidAPIKey
+	}
+
+	// Recording the use is best effort and must not reject a valid key
+	if now.Sub(apiKey.LastUsedAt) >= apiKeyTouchInterval {
+		if err := s.repository.TouchAPIKey(ctx, apiKey.ID, now); err != nil {
+			s.logger.Warn("Cannot record the use of the api key", slog.String("apiKeyID", apiKey.ID.String()), slog.Any("error", err))
+		} else {
+			apiKey.LastUsedAt = now
+		}
+	}
+
+	return apiKey, nil
+}
@@ -0,0 +1,192 @@
+package services_test
+
+import (
+	"apps/services/accounts-api/internal/app/ports"
+	"apps/services/accounts-api/internal/domain/entities"
+	"apps/services/accounts-api/internal/domain/services"
+	"context"
+	"libs/backend/boot"
+	userValueObjects "libs/backend/domain/user/valueobjects"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeAPIKeyRepository keeps API keys in memory
+type fakeAPIKeyRepository struct {
+	mu      sync.Mutex
+	apiKeys map[uuid.UUID]entities.APIKey
+}
+
+func newFakeAPIKeyRepository() *fakeAPIKeyRepository {
+	return &fakeAPIKeyRepository{apiKeys: make(map[uuid.UUID]entities.APIKey)}
+}
+
+func (r *fakeAPIKeyRepository) CreateAPIKey(_ context.Context, apiKey entities.APIKey) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.apiKeys[apiKey.ID] = apiKey
+	return nil
+}
+
+func (r *fakeAPIKeyRepository) GetAPIKeyByPrefix(_ context.Context, prefix string) (entities.APIKey, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, apiKey := range r.apiKeys {
+		if apiKey.Prefix == prefix {
+			return apiKey, nil
+		}
+	}
+
+	return entities.APIKey{}, ports.ErrAPIKeyNotFound
+}
+
+func (r *fakeAPIKeyRepository) ListAPIKeys(_ context.Context, commonID userValueObjects.CommonID) ([]entities.APIKey, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	apiKeys := make([]entities.APIKey, 0)
+	for _, apiKey := range r.apiKeys {
+		if apiKey.CommonID.String() == commonID.String() {
+			apiKeys = append(apiKeys, apiKey)
+		}
+	}
+	sort.Slice(apiKeys, func(i, j int) bool { return apiKeys[i].CreatedAt.After(apiKeys[j].CreatedAt) })
+
+	return apiKeys, nil
+}
+
+func (r *fakeAPIKeyRepository) RevokeAPIKey(_ context.Context, commonID userValueObjects.CommonID, id uuid.UUID, revokedAt time.Time) (entities.APIKey, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	apiKey, ok := r.apiKeys[id]
+	if !ok || apiKey.CommonID.String() != commonID.String() {
+		return entities.APIKey{}, ports.ErrAPIKeyNotFound
+	}
+
+	if apiKey.RevokedAt.IsZero() {
+		apiKey.RevokedAt = revokedAt
+	}
+	r.apiKeys[id] = apiKey
+
+	return apiKey, nil
+}
+
+func (r *fakeAPIKeyRepository) TouchAPIKey(_ context.Context, id uuid.UUID, lastUsedAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	apiKey := r.apiKeys[id]
+	apiKey.LastUsedAt = lastUsedAt
+	r.apiKeys[id] = apiKey
+	return nil
+}
+
+func TestAPIKeyService(t *testing.T) {
+	ctx := context.Background()
+	commonID := userValueObjects.NewCommonIDFromString("0b0d2f5e-7f55-4a4c-9f55-1bde1f4d1c11")
+	otherCommonID := userValueObjects.NewCommonIDFromString("5e3c1b6a-1f3d-4c43-8f0e-9e6f7a1d2b3c")
+
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	newService := func() (*services.APIKeyService, *fakeAPIKeyRepository) {
+		repository := newFakeAPIKeyRepository()
+		return services.NewAPIKeyService(services.APIKeyServiceParams{
+			Logger:     boot.NewSlogger(),
+			Repository: repository,
+			Now:        func() time.Time { return now },
+		}), repository
+	}
+
+	t.Run("creates a key verified by its secret", func(t *testing.T) {
+		service, repository := newService()
+
+		apiKey, key, err := service.CreateAPIKey(ctx, commonID, "cron", []string{"read:accounts"}, time.Time{})
+		require.NoError(t, err)
+		assert.Equal(t, commonID.String(), apiKey.CommonID.String())
+		assert.NotContains(t, apiKey.SecretHash, key)
+
+		verified, err := service.VerifyAPIKey(ctx, key)
+		require.NoError(t, err)
+		assert.Equal(t, apiKey.ID, verified.ID)
+		assert.Equal(t, []string{"read:accounts"}, verified.Scopes)
+		assert.Equal(t, now, repository.apiKeys[apiKey.ID].LastUsedAt)
+	})
+
+	t.Run("rejects scopes keys cannot be granted", func(t *testing.T) {
+		service, _ := newService()
+
+		_, _, err := service.CreateAPIKey(ctx, commonID, "admin", []string{"write:api_keys"}, time.Time{})
+		assert.ErrorIs(t, err, ports.ErrAPIKeyScopeNotAllowed)
+
+		// Services that do not verify keys cannot be granted either
+		_, _, err = service.CreateAPIKey(ctx, commonID, "hooks", []string{"write:webhooks"}, time.Time{})
+		assert.ErrorIs(t, err, ports.ErrAPIKeyScopeNotAllowed)
+	})
+
+	t.Run("limits the active keys of an account", func(t *testing.T) {
+		service, _ := newService()
+
+		for range services.MaxActiveAPIKeys {
+			_, _, err := service.CreateAPIKey(ctx, commonID, "key", []string{"read:accounts"}, time.Time{})
+			require.NoError(t, err)
+		}
+
+		_, _, err := service.CreateAPIKey(ctx, commonID, "key", []string{"read:accounts"}, time.Time{})
+		assert.ErrorIs(t, err, ports.ErrAPIKeyLimitReached)
+
+		_, _, err = service.CreateAPIKey(ctx, otherCommonID, "key", []string{"read:accounts"}, time.Time{})
+		assert.NoError(t, err)
+	})
+
+	t.Run("rejects revoked, expired, unknown and malformed keys alike", func(t *testing.T) {
+		service, _ := newService()
+
+		revoked, revokedKey, err := service.CreateAPIKey(ctx, commonID, "revoked", []string{"read:accounts"}, time.Time{})
+		require.NoError(t, err)
+		_, err = service.RevokeAPIKey(ctx, commonID, revoked.ID)
+		require.NoError(t, err)
+
+		_, expiredKey, err := service.CreateAPIKey(ctx, commonID, "expired", []string{"read:accounts"}, now)
+		require.NoError(t, err)
+
+		_, validKey, err := service.CreateAPIKey(ctx, commonID, "valid", []string{"read:accounts"}, now.Add(time.Hour))
+		require.NoError(t, err)
+		prefix, err := services.ParseAPIKeyPrefix(validKey)
+		require.NoError(t, err)
+
+		for _, key := range []string{revokedKey, expiredKey, prefix + "_wrong", "cc_unknown", "not-a-key"} {
+			_, err := service.VerifyAPIKey(ctx, key)
+			assert.ErrorIs(t, err, ports.ErrInvalidAPIKey, key)
+		}
+
+		_, err = service.VerifyAPIKey(ctx, validKey)
+		assert.NoError(t, err)
+	})
+
+	t.Run("revokes only the keys of the account", func(t *testing.T) {
+		service, _ := newService()
+
+		apiKey, _, err := service.CreateAPIKey(ctx, commonID, "key", []string{"read:accounts"}, time.Time{})
+		require.NoError(t, err)
+
+		_, err = service.RevokeAPIKey(ctx, otherCommonID, apiKey.ID)
+		assert.ErrorIs(t, err, ports.ErrAPIKeyNotFound)
+
+		revoked, err := service.RevokeAPIKey(ctx, commonID, apiKey.ID)
+		require.NoError(t, err)
+		assert.True(t, revoked.IsRevoked())
+
+		apiKeys, err := service.ListAPIKeys(ctx, commonID)
+		require.NoError(t, err)
+		require.Len(t, apiKeys, 1)
+		assert.False(t, apiKeys[0].IsActive(now))
+	})
+}
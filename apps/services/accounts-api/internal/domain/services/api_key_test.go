@@ -0,0 +1,36 @@
+package services_test
+
+import (
+	"apps/services/accounts-api/internal/domain/services"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateAPIKey(t *testing.T) {
+	key, prefix, secretHash, err := services.GenerateAPIKey()
+	require.NoError(t, err)
+
+	assert.True(t, strings.HasPrefix(key, prefix+"_"))
+	assert.True(t, strings.HasPrefix(prefix, "cc_"))
+	assert.NotContains(t, secretHash, key)
+	assert.True(t, services.MatchesAPIKeyHash(key, secretHash))
+	assert.False(t, services.MatchesAPIKeyHash(key+"x", secretHash))
+
+	parsed, err := services.ParseAPIKeyPrefix(key)
+	require.NoError(t, err)
+	assert.Equal(t, prefix, parsed)
+
+	other, _, _, err := services.GenerateAPIKey()
+	require.NoError(t, err)
+	assert.NotEqual(t, key, other)
+}
+
+func TestParseAPIKeyPrefix(t *testing.T) {
+	for _, key := range []string{"", "cc_", "sk_0123456789ab_secret", "cc_0123456789ab", "cc_0123456789ab_", "cc_0123456789abXsecret"} {
+		_, err := services.ParseAPIKeyPrefix(key)
+		assert.ErrorIs(t, err, services.ErrMalformedAPIKey, key)
+	}
+}
@@ -0,0 +1,155 @@
+package services
+
+import (
+	"apps/services/accounts-api/internal/app/ports"
+	"apps/services/accounts-api/internal/domain/entities"
+	"context"
+	"fmt"
+	"libs/backend/boot"
+	userValueObjects "libs/backend/domain/user/valueobjects"
+	"libs/backend/eventing"
+	"libs/backend/httpauth"
+	accountseventsv1 "libs/backend/proto-gen/go/accounts/accountsevents/v1"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Assertion of the proper interface
+var _ ports.RevocationService = (*RevocationService)(nil)
+
+// RevocationService revokes sessions and propagates the revocations to every
+// service through the sessions revoked event
+type RevocationService struct {
+	logger           boot.Logger
+	repository       ports.RevocationRepository
+	publisher        boot.AMQPConfirmPublisher
+	maxTokenLifetime time.Duration
+	now              func() time.Time
+}
+
+// RevocationServiceParams is a struct to hold the parameters for the RevocationService
+type RevocationServiceParams struct {
+	Logger     boot.Logger
+	Repository ports.RevocationRepository
+	Publisher  boot.AMQPConfirmPublisher
+
+	// MaxTokenLifetime bounds how long revocations are kept and defaults to
+	// httpauth.DefaultMaxTokenLifetime
+	MaxTokenLifetime time.Duration
+
+	// Now returns the current time and defaults to time.Now
+	Now func() time.Time
+}
+
+// NewRevocationService constructs the session revocation service
+func NewRevocationService(params RevocationServiceParams) *RevocationService {
+	if params.MaxTokenLifetime == 0 {
+		params.MaxTokenLifetime = httpauth.DefaultMaxTokenLifetime
+	}
+	if params.Now == nil {
+		params.Now = time.Now
+	}
+
+	return &RevocationService{
+		logger:           params.Logger,
+		repository:       params.Repository,
+		publisher:        params.Publisher,
+		maxTokenLifetime: params.MaxTokenLifetime,
+		now:              params.Now,
+	}
+}
+
+// RevokeSessions rejects every access token of the account issued until now
+func (s *RevocationService) RevokeSessions(ctx context.Context, commonID userValueObjects.CommonID, reason, revokedBy string) (entities.Revocation, error) {
+	return s.RevokeUserSessions(ctx, commonID, "", reason, revokedBy)
+}
+
+// RevokeUserSessions rejects every access token of the account and of the sub
+// of the identity provider user issued until now, the sub covers the tokens
+// issued without a common id claim
+func (s *RevocationService) RevokeUserSessions(ctx context.Context, commonID userValueObjects.CommonID, subject, reason, revokedBy string) (entities.Revocation, error) {
+	now := s.now()
+
+	return s.revoke(ctx, entities.Revocation{
+		ID:                 uuid.New(),
+		CommonID:           commonID,
+		Subject:            subject,
+		TokensIssuedBefore: now,
+		ExpiresAt:          now.Add(s.maxTokenLifetime),
+		Reason:             reason,
+		RevokedBy:          revokedBy,
+		CreatedAt:          now,
+	})
+}
+
+// RevokeToken rejects the access token of the jti until it expires, expiry
+// beyond the lifetime of the tokens is capped since no such token is valid
+func (s *RevocationService) RevokeToken(ctx context.Context, jti string, commonID userValueObjects.CommonID, expiresAt time.Time, reason, revokedBy string) (entities.Revocation, error) {
+	now := s.now()
+	if latest := now.Add(s.maxTokenLifetime); expiresAt.After(latest) {
+		expiresAt = latest
+	}
+
+	return s.revoke(ctx, entities.Revocation{
+		ID:        uuid.New(),
+		JTI:       jti,
+		CommonID:  commonID,
+		ExpiresAt: expiresAt,
+		Reason:    reason,
+		RevokedBy: revokedBy,
+		CreatedAt: now,
+	})
+}
+
+// ListRevocations lists the revocations of the account
+func (s *RevocationService) ListRevocations(ctx context.Context, commonID userValueObjects.CommonID) ([]entities.Revocation, error) {
+	return s.repository.ListRevocations(ctx, commonID)
+}
+
+// revoke stores the revocation and publishes it to the auth exchange, where
+// every service follows the revocations through the auth event stream
+func (s *RevocationService) revoke(ctx context.Context, revocation entities.Revocation) (entities.Revocation, error) {
+	if err := s.repository.CreateRevocation(ctx, revocation); err != nil {
+		return entities.Revocation{}, err
+	}
+
+	event := &accountseventsv1.SessionsRevoked{
+		Jti:       revocation.JTI,
+		Subject:   revocation.Subject,
+		ExpiresAt: timestamppb.New(revocation.ExpiresAt),
+		Reason:    revocation.Reason,
+		RevokedBy: revocation.RevokedBy,
+		RevokedAt: timestamppb.New(revocation.CreatedAt),
+	}
+	if !revocation.TokensIssuedBefore.IsZero() {
+		event.TokensIssuedBefore = timestamppb.New(revocation.TokensIssuedBefore)
+	}
+	if !revocation.CommonID.IsEmpty() {
+		event.CommonId = revocation.CommonID.String()
+	}
+
+	msg, err := eventing.NewProtoPublishing(eventing.EventNameSessionsRevoked, eventing.SessionsRevokedVersion, event)
+	if err != nil {
+		return entities.Revocation{}, err
+	}
+	if event.CommonId != "" {
+		msg = eventing.WithCommonID(msg, event.CommonId)
+	}
+
+	if err := s.publisher.PublishWithConfirm(ctx, eventing.AuthExchange, eventing.GetSessionsRevokedRoutingKey(), false, msg); err != nil {
+		s.logger.Error("Cannot publish sessions revoked event", slog.String("revocationID", revocation.ID.String()), slog.Any("error", err))
+		return entities.Revocation{}, fmt.Errorf("cannot publish %s event: %w", eventing.EventNameSessionsRevoked, err)
+	}
+
+	s.logger.Info(
+		"Revoked sessions",
+		slog.String("revocationID", revocation.ID.String()),
+		slog.String("commonID", event.CommonId),
+		slog.String("revokedBy", revocation.RevokedBy),
+	)
+
+	return revocation, nil
+}
@@ -0,0 +1,181 @@
+package services_test
+
+import (
+	"apps/services/accounts-api/internal/domain/entities"
+	"apps/services/accounts-api/internal/domain/services"
+	"context"
+	"errors"
+	"libs/backend/boot"
+	"libs/backend/boot/amqptest"
+	userValueObjects "libs/backend/domain/user/valueobjects"
+	"libs/backend/eventing"
+	"libs/backend/httpauth"
+	accountseventsv1 "libs/backend/proto-gen/go/accounts/accountsevents/v1"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+// fakeRevocationRepository keeps revocations in memory
+type fakeRevocationRepository struct {
+	mu          sync.Mutex
+	revocations []entities.Revocation
+}
+
+func (r *fakeRevocationRepository) CreateRevocation(_ context.Context, revocation entities.Revocation) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.revocations = append([]entities.Revocation{revocation}, r.revocations...)
+	return nil
+}
+
+func (r *fakeRevocationRepository) ListRevocations(_ context.Context, commonID userValueObjects.CommonID) ([]entities.Revocation, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	revocations := make([]entities.Revocation, 0)
+	for _, revocation := range r.revocations {
+		if revocation.CommonID.Equals(commonID) {
+			revocations = append(revocations, revocation)
+		}
+	}
+
+	return revocations, nil
+}
+
+func newRevocationBroker(t *testing.T) *amqptest.Broker {
+	t.Helper()
+
+	broker := amqptest.NewBroker()
+	t.Cleanup(func() { _ = broker.Close() })
+
+	setup := eventing.NewAuthEventSetup(broker, boot.NewSlogger())
+	setup.
+		CreateExchange().
+		CreateStream().
+		Complete()
+
+	return broker
+}
+
+func TestRevocationService(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().Truncate(time.Second)
+	commonID := userValueObjects.NewCommonID()
+
+	t.Run("revokes the sessions of the account", func(t *testing.T) {
+		broker := newRevocationBroker(t)
+		repository := &fakeRevocationRepository{}
+		service := services.NewRevocationService(services.RevocationServiceParams{
+			Logger:     boot.NewSlogger(),
+			Repository: repository,
+			Publisher:  broker,
+			Now:        func() time.Time { return now },
+		})
+
+		revocation, err := service.RevokeSessions(ctx, commonID, "compromised", "auth0|admin")
+		require.NoError(t, err)
+		assert.True(t, revocation.IsSessionRevocation())
+		assert.Equal(t, now, revocation.TokensIssuedBefore)
+		assert.Equal(t, now.Add(httpauth.DefaultMaxTokenLifetime), revocation.ExpiresAt)
+
+		revocations, err := service.ListRevocations(ctx, commonID)
+		require.NoError(t, err)
+		assert.Equal(t, []entities.Revocation{revocation}, revocations)
+
+		messages := broker.Messages(eventing.AuthEventStream)
+		require.Len(t, messages, 1)
+		assert.Equal(t, eventing.EventNameSessionsRevoked.String(), messages[0].Type)
+		assert.Equal(t, commonID.String(), messages[0].Headers[eventing.CommonIDHeader])
+
+		var event accountseventsv1.SessionsRevoked
+		require.NoError(t, proto.Unmarshal(messages[0].Body, &event))
+		assert.Equal(t, commonID.String(), event.CommonId)
+		assert.Equal(t, now, event.TokensIssuedBefore.AsTime().Local())
+		assert.Equal(t, "auth0|admin", event.RevokedBy)
+	})
+
+	t.Run("revokes the sessions of the identity provider user", func(t *testing.T) {
+		broker := newRevocationBroker(t)
+		service := services.NewRevocationService(services.RevocationServiceParams{
+			Logger:     boot.NewSlogger(),
+			Repository: &fakeRevocationRepository{},
+			Publisher:  broker,
+			Now:        func() time.Time { return now },
+		})
+
+		revocation, err := service.RevokeUserSessions(ctx, commonID, "auth0|user", "blocked", "identity-provider")
+		require.NoError(t, err)
+		assert.Equal(t, "auth0|user", revocation.Subject)
+
+		messages := broker.Messages(eventing.AuthEventStream)
+		require.Len(t, messages, 1)
+
+		var event accountseventsv1.SessionsRevoked
+		require.NoError(t, proto.Unmarshal(messages[0].Body, &event))
+		assert.Equal(t, commonID.String(), event.CommonId)
+		assert.Equal(t, "auth0|user", event.Subject)
+	})
+
+	t.Run("caps the expiry of revoked tokens at the token lifetime", func(t *testing.T) {
+		broker := newRevocationBroker(t)
+		service := services.NewRevocationService(services.RevocationServiceParams{
+			Logger:           boot.NewSlogger(),
+			Repository:       &fakeRevocationRepository{},
+			Publisher:        broker,
+			MaxTokenLifetime: time.Hour,
+			Now:              func() time.Time { return now },
+		})
+
+		revocation, err := service.RevokeToken(ctx, "token-1", userValueObjects.CommonID{}, now.Add(48*time.Hour), "", "auth0|admin")
+		require.NoError(t, err)
+		assert.False(t, revocation.IsSessionRevocation())
+		assert.Equal(t, now.Add(time.Hour), revocation.ExpiresAt)
+
+		messages := broker.Messages(eventing.AuthEventStream)
+		require.Len(t, messages, 1)
+		assert.NotContains(t, messages[0].Headers, eventing.CommonIDHeader)
+	})
+
+	t.Run("fails when the revocation cannot be propagated", func(t *testing.T) {
+		broker := newRevocationBroker(t)
+		broker.SetPublishError(errors.New("broker unavailable"))
+		service := services.NewRevocationService(services.RevocationServiceParams{
+			Logger:     boot.NewSlogger(),
+			Repository: &fakeRevocationRepository{},
+			Publisher:  broker,
+		})
+
+		_, err := service.RevokeSessions(ctx, commonID, "", "auth0|admin")
+		assert.Error(t, err)
+	})
+
+	t.Run("followers reject the revoked tokens", func(t *testing.T) {
+		broker := newRevocationBroker(t)
+		service := services.NewRevocationService(services.RevocationServiceParams{
+			Logger:     boot.NewSlogger(),
+			Repository: &fakeRevocationRepository{},
+			Publisher:  broker,
+		})
+
+		cache := httpauth.NewRevocationCache()
+		followCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		go func() {
+			_ = eventing.FollowSessionRevocations(followCtx, broker, boot.NewSlogger(), cache, httpauth.DefaultMaxTokenLifetime)
+		}()
+
+		_, err := service.RevokeToken(ctx, "token-1", commonID, time.Now().Add(time.Hour), "", "auth0|admin")
+		require.NoError(t, err)
+
+		assert.Eventually(t, func() bool {
+			revoked, err := cache.IsTokenRevoked(ctx, "token-1")
+			return err == nil && revoked
+		}, time.Second, 10*time.Millisecond)
+	})
+}
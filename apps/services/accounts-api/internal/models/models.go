@@ -1,6 +1,8 @@
 package models
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
@@ -12,4 +14,42 @@ type Account struct {
 	CommonID     uuid.UUID `gorm:"type:uuid;index:idx_common_id;not null;"`
 	UserName     string    `gorm:"unique;index:idx_user_name;index:idx_user_name_email;not null;"`
 	EmailAddress string    `gorm:"unique;index:idx_email;index:idx_user_name_email;not null;"`
+
+	FirstName            string
+	LastName             string
+	Nickname             string
+	EmailAddressVerified bool
+	PhoneNumber          string
+	PhoneNumberVerified  bool
+	Strategy             string
+	Metadata             map[string]any `gorm:"serializer:json;"`
+	LastLoginAt          *time.Time
+}
+
+// APIKey is our model, which corresponds to the "api_keys" table
+type APIKey struct {
+	ID         uuid.UUID `gorm:"primaryKey;type:uuid"`
+	CommonID   uuid.UUID `gorm:"type:uuid;index:idx_api_key_common_id;not null;"`
+	Name       string    `gorm:"not null;"`
+	Prefix     string    `gorm:"uniqueIndex:idx_api_key_prefix;not null;"`
+	SecretHash string    `gorm:"not null;"`
+	Scopes     []string  `gorm:"serializer:json;not null;"`
+	ExpiresAt  *time.Time
+	LastUsedAt *time.Time
+	RevokedAt  *time.Time
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// SessionRevocation is our model, which corresponds to the "session_revocations" table
+type SessionRevocation struct {
+	ID                 uuid.UUID  `gorm:"primaryKey;type:uuid"`
+	JTI                *string    `gorm:"index:idx_session_revocation_jti;"`
+	CommonID           *uuid.UUID `gorm:"type:uuid;index:idx_session_revocation_common_id;"`
+	Subject            *string
+	TokensIssuedBefore *time.Time
+	ExpiresAt          time.Time `gorm:"index:idx_session_revocation_expires_at;not null;"`
+	Reason             string    `gorm:"not null;"`
+	RevokedBy          string    `gorm:"not null;"`
+	CreatedAt          time.Time
 }
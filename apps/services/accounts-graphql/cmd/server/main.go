@@ -2,12 +2,14 @@ package main
 
 import (
 	"apps/services/accounts-graphql/internal/config"
+	"apps/services/accounts-graphql/internal/graph/directives"
 	"apps/services/accounts-graphql/internal/graph/generated"
 	"apps/services/accounts-graphql/internal/graph/resolvers"
 	"context"
 	"errors"
 	"libs/backend/boot"
 	"libs/backend/cache"
+	"libs/backend/eventing"
 	auth "libs/backend/httpauth"
 	"log"
 	"log/slog"
@@ -51,6 +53,28 @@ func run() error {
 		connect.WithInterceptors(validationInterceptor),
 	}
 
+	// The claims of the caller authorize the resolvers, revoked tokens are
+	// rejected from the cache kept up to date by the auth event stream
+	revocationCache := auth.NewRevocationCache()
+	accessTokenValidator, err := auth.NewJWTValidator(
+		auth.WithTrustedIssuer(auth.TrustedIssuer{
+			IssuerURL: auth.Auth0IssuerURL(config.Auth0Domain),
+			Audiences: []string{config.Auth0Audience},
+			JWKSFile:  config.AuthJWKSFile,
+		}),
+		auth.WithRevocationStore(revocationCache),
+	)
+	if err != nil {
+		logger.Error("Cannot set up access token validator", slog.Any("error", err))
+		return err
+	}
+
+	policy, err := auth.LoadPolicyOrDefault(config.AuthPolicyFile)
+	if err != nil {
+		logger.Error("Cannot load the role policy", slog.Any("error", err))
+		return err
+	}
+
 	// Initialize the gRPC Options
 	bootService := boot.
 		NewBuildServiceBuilder().
@@ -61,12 +85,24 @@ func run() error {
 			OnConnectionCallback: func(params boot.AMQPCallBackParams) error {
 				params.Logger.Info("AMQP connected successfully")
 
-				// Set up all AMQP queues and exchanges
+				// Set up the auth event stream the session revocations are followed from
+				authEventRegisterer := eventing.NewAuthEventSetup(params.Controller.Registerer, params.Logger)
+				authEventRegisterer.
+					CreateExchange().
+					CreateDeadletter().
+					CreateStream().
+					Complete()
 
 				params.Logger.Info("Set up all AMQP queues and exchanges")
 
 				return nil
 			},
+			Handlers: []boot.AMQPHandler{
+				func(hp boot.AMQPHandlerParams) error {
+					// Keep the revocation cache up to date
+					return eventing.FollowSessionRevocations(ctx, hp.AMQPController.Consumer, hp.Logger, revocationCache, auth.DefaultMaxTokenLifetime)
+				},
+			},
 		}).
 		SetConnectRPCOptions(boot.ConnectRPCOptions{
 			Port: 3000,
@@ -83,8 +119,10 @@ func run() error {
 
 					// Set up all ConnectRPC Handlers
 					srv := handler.New(generated.NewExecutableSchema(generated.Config{
-						Resolvers: resolvers.NewResolver(params.Logger, config),
+						Resolvers:  resolvers.NewResolver(params.Logger, config, policy),
+						Directives: directives.NewDirectiveRoot(),
 					}))
+					srv.SetErrorPresenter(directives.ErrorPresenter)
 					srv.AddTransport(transport.Options{})
 					srv.AddTransport(transport.GET{})
 					srv.AddTransport(transport.POST{})
@@ -110,7 +148,7 @@ func run() error {
 					})
 
 					params.Mux.Handle("/", playground.Handler("GraphQL playground", "/graphql"))
-					params.Mux.Handle("/graphql", auth.AuthMiddleware(srv))
+					params.Mux.Handle("/graphql", auth.AuthMiddleware(auth.ClaimsMiddleware(accessTokenValidator, srv)))
 
 					return nil
 				},
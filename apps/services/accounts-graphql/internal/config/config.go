@@ -8,6 +8,15 @@ import (
 type Config struct {
 	AMQPUrl        string
 	AccountsAPIURI string
+
+	Auth0Domain   string
+	Auth0Audience string
+
+	// AuthJWKSFile replaces the key set of the Auth0 tenant, e.g. in local development
+	AuthJWKSFile string
+
+	// AuthPolicyFile replaces the default role policy
+	AuthPolicyFile string
 }
 
 // NewConfig constructs the config
@@ -15,6 +24,11 @@ func NewConfig() (Config, error) {
 	config := Config{
 		AMQPUrl:        os.Getenv("AMQP_CONNECTION_URI"),
 		AccountsAPIURI: os.Getenv("ACCOUNTS_API_URI"),
+
+		Auth0Domain:    os.Getenv("AUTH0_DOMAIN"),
+		Auth0Audience:  os.Getenv("AUTH0_AUDIENCE"),
+		AuthJWKSFile:   os.Getenv("AUTH_JWKS_FILE"),
+		AuthPolicyFile: os.Getenv("AUTH_POLICY_FILE"),
 	}
 
 	return config, nil
@@ -0,0 +1,106 @@
+package directives
+
+import (
+	"apps/services/accounts-graphql/internal/graph/generated"
+	"context"
+	"errors"
+	"fmt"
+	"libs/backend/httpauth"
+
+	"connectrpc.com/connect"
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// Error codes of the authorization errors, the codes Apollo clients and the
+// router recognize
+const (
+	CodeUnauthenticated = "UNAUTHENTICATED"
+	CodeForbidden       = "FORBIDDEN"
+)
+
+// NewDirectiveRoot returns the implementations of the schema directives
+func NewDirectiveRoot() generated.DirectiveRoot {
+	return generated.DirectiveRoot{
+		Auth:   Auth,
+		Public: Public,
+	}
+}
+
+// Auth resolves the field only for callers with validated claims granted
+// every required scope, before the resolver calls any downstream service
+func Auth(ctx context.Context, _ any, next graphql.Resolver, requires []string) (any, error) {
+	claims, err := httpauth.GetClaimsFromContext(ctx)
+	if errors.Is(err, httpauth.ErrTokenInvalid) {
+		return nil, newError(ctx, CodeUnauthenticated, httpauth.ErrTokenInvalid.Error())
+	}
+	if err != nil {
+		return nil, newError(ctx, CodeUnauthenticated, "authentication required")
+	}
+
+	for _, scope := range requires {
+		if !claims.HasScope(scope) {
+			return nil, newError(ctx, CodeForbidden, fmt.Sprintf("%s: %s", httpauth.ErrMissingScope, scope))
+		}
+	}
+
+	return next(ctx)
+}
+
+// Public resolves the field for every caller, it marks fields that are
+// deliberately left without the auth directive
+func Public(ctx context.Context, _ any, next graphql.Resolver) (any, error) {
+	return next(ctx)
+}
+
+// ErrorPresenter adds the error code to the authorization errors returned by
+// the resolvers and by the downstream services
+func ErrorPresenter(ctx context.Context, err error) *gqlerror.Error {
+	gqlErr := graphql.DefaultErrorPresenter(ctx, err)
+	if _, ok := gqlErr.Extensions["code"]; ok {
+		return gqlErr
+	}
+
+	if code := errorCode(err); code != "" {
+		if gqlErr.Extensions == nil {
+			gqlErr.Extensions = make(map[string]any)
+		}
+		gqlErr.Extensions["code"] = code
+	}
+
+	return gqlErr
+}
+
+// errorCode maps the authorization errors to their error codes
+func errorCode(err error) string {
+	switch {
+	case errors.Is(err, httpauth.ErrCustomClaimsNotValid),
+		errors.Is(err, httpauth.ErrTokenInvalid):
+		return CodeUnauthenticated
+	case errors.Is(err, httpauth.ErrNotOwner),
+		errors.Is(err, httpauth.ErrPolicyDenied),
+		errors.Is(err, httpauth.ErrMissingScope):
+		return CodeForbidden
+	}
+
+	var connectErr *connect.Error
+	if errors.As(err, &connectErr) {
+		switch connectErr.Code() {
+		case connect.CodeUnauthenticated:
+			return CodeUnauthenticated
+		case connect.CodePermissionDenied:
+			return CodeForbidden
+		}
+	}
+
+	return ""
+}
+
+// newError returns the error of the field with the error code
+func newError(ctx context.Context, code, message string) *gqlerror.Error {
+	return &gqlerror.Error{
+		Message:    message,
+		Path:       graphql.GetPath(ctx),
+		Extensions: map[string]any{"code": code},
+	}
+}
@@ -40,6 +40,8 @@ type ResolverRoot interface {
 }
 
 type DirectiveRoot struct {
+	Auth   func(ctx context.Context, obj any, next graphql.Resolver, requires []string) (res any, err error)
+	Public func(ctx context.Context, obj any, next graphql.Resolver) (res any, err error)
 }
 
 type ComplexityRoot struct {
@@ -440,14 +442,14 @@ extend type Query {
   """
   Obtains the account by commonID or email address
   """
-  account(input: RetrieveAccountInput!): Account
+  account(input: RetrieveAccountInput!): Account @auth(requires: ["read:accounts"])
 }
 
 extend type Mutation {
   """
   Delete account by commonID
   """
-  deleteAccount(commonID: UUID!): Time!
+  deleteAccount(commonID: UUID!): Time! @auth(requires: ["delete:accounts"])
 }
 `, BuiltIn: false},
 	{Name: "../schemas/schema.graphql", Input: `# GraphQL schema example
@@ -476,6 +478,17 @@ directive @goTag(
   value: String
 ) on INPUT_FIELD_DEFINITION | FIELD_DEFINITION
 
+"""
+auth requires an authenticated caller granted every listed scope, the field
+fails with UNAUTHENTICATED without a valid token and FORBIDDEN without a scope
+"""
+directive @auth(requires: [String!]) on FIELD_DEFINITION
+
+"""
+public resolves the field without an access token
+"""
+directive @public on FIELD_DEFINITION
+
 """
 Viewer is the root query object for the user
 """
@@ -484,7 +497,7 @@ type Viewer {
 }
 
 type Query {
-  empty: Boolean!
+  empty: Boolean! @public
 
   """
   Viewer is the root query object for the user
@@ -494,11 +507,11 @@ type Query {
     The unique identifier for the viewer
     """
     commonID: UUID!
-  ): Viewer @goField(forceResolver: true)
+  ): Viewer @goField(forceResolver: true) @auth(requires: ["read:accounts"])
 }
 
 type Mutation {
-  empty: Boolean!
+  empty: Boolean! @public
 }
 `, BuiltIn: false},
 	{Name: "../../../federation/directives.graphql", Input: `
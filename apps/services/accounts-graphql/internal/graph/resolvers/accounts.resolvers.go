@@ -8,7 +8,6 @@ import (
 	"apps/services/accounts-graphql/internal/graph/models"
 	"context"
 	"fmt"
-	"libs/backend/httpauth"
 	accountsapiv1 "libs/backend/proto-gen/go/accounts/accountsapi/v1"
 	"log/slog"
 	"time"
@@ -21,15 +20,20 @@ import (
 func (r *mutationResolver) DeleteAccount(ctx context.Context, commonID uuid.UUID) (*time.Time, error) {
 	r.Logger.Info("Deleting account", slog.String("commonID", commonID.String()))
 
+	if err := r.authorize(ctx, "delete", "account"); err != nil {
+		return nil, fmt.Errorf("cannot delete account: %w", err)
+	}
+	if err := r.authorizeOwner(ctx, "delete", "account", commonID.String()); err != nil {
+		return nil, fmt.Errorf("cannot delete account: %w", err)
+	}
+
 	// Call Accounts API
 	const hardDelete = false
 
-	accessToken := httpauth.GetAuthTokenFromContext(ctx)
 	req := connect.NewRequest(&accountsapiv1.DeleteAccountRequest{
 		CommonId:   commonID.String(),
 		HardDelete: hardDelete,
 	})
-	req.Header().Add(httpauth.AuthorizationHeaderKey, accessToken)
 
 	resp, err := r.AccountsAPIClient.DeleteAccount(ctx, req)
 
@@ -45,6 +49,10 @@ func (r *mutationResolver) DeleteAccount(ctx context.Context, commonID uuid.UUID
 func (r *queryResolver) Account(ctx context.Context, input models.RetrieveAccountInput) (*models.Account, error) {
 	loggerValues := make([]any, 0)
 
+	if err := r.authorize(ctx, "read", "account"); err != nil {
+		return nil, fmt.Errorf("cannot get account: %w", err)
+	}
+
 	// Call the Accounts API
 	commonID := input.CommonID
 	emailAddress := input.EmailAddress
@@ -54,6 +62,10 @@ func (r *queryResolver) Account(ctx context.Context, input models.RetrieveAccoun
 	switch {
 	case commonID != nil:
 		commonIDStr := commonID.String()
+		if err := r.authorizeOwner(ctx, "read", "account", commonIDStr); err != nil {
+			return nil, fmt.Errorf("cannot get account: %w", err)
+		}
+
 		req = connect.NewRequest(&accountsapiv1.GetAccountRequest{
 			CommonId: &commonIDStr,
 		})
@@ -68,8 +80,6 @@ func (r *queryResolver) Account(ctx context.Context, input models.RetrieveAccoun
 	r.Logger.Info("Fetching account", loggerValues...)
 
 	// Call the accounts API
-	authHeader := httpauth.GetAuthTokenFromContext(ctx)
-	req.Header().Add(httpauth.AuthorizationHeaderKey, authHeader)
 	resp, err := r.AccountsAPIClient.GetAccount(ctx, req)
 
 	// Check if there was an error or if the account is nil
@@ -83,6 +93,11 @@ func (r *queryResolver) Account(ctx context.Context, input models.RetrieveAccoun
 		return nil, fmt.Errorf("commonID cannot be parsed from account: %w", err)
 	}
 
+	// Accounts looked up by email address are authorized once the owner is known
+	if err := r.authorizeOwner(ctx, "read", "account", parsedCommonID.String()); err != nil {
+		return nil, fmt.Errorf("cannot get account: %w", err)
+	}
+
 	return &models.Account{
 		ID:           parsedCommonID,
 		EmailAddress: resp.Msg.Account.EmailAddress,
@@ -2,9 +2,13 @@ package resolvers
 
 import (
 	"apps/services/accounts-graphql/internal/config"
+	"context"
 	"libs/backend/boot"
+	"libs/backend/httpauth"
 	"libs/backend/proto-gen/go/accounts/accountsapi/v1/accountsapiv1connect"
 	"net/http"
+
+	"connectrpc.com/connect"
 )
 
 //go:generate go run github.com/99designs/gqlgen generate
@@ -16,17 +20,41 @@ import (
 type Resolver struct {
 	Logger            boot.Logger
 	AccountsAPIClient accountsapiv1connect.AccountServiceClient
+	Policy            *httpauth.Policy
 }
 
-func NewResolver(logger boot.Logger, config config.Config) *Resolver {
-	// Set up Accounts API Client
+func NewResolver(logger boot.Logger, config config.Config, policy *httpauth.Policy) *Resolver {
+	// Set up Accounts API Client, forwarding the token of the caller
 	accountsAPIClient := accountsapiv1connect.NewAccountServiceClient(
 		http.DefaultClient,
 		config.AccountsAPIURI,
+		connect.WithInterceptors(httpauth.NewClientAuthInterceptor()),
 	)
 
 	return &Resolver{
 		Logger:            logger,
 		AccountsAPIClient: accountsAPIClient,
+		Policy:            policy,
 	}
 }
+
+// authorize ensures the role policy allows the caller to perform the action on the resource type
+func (r *Resolver) authorize(ctx context.Context, action, resource string) error {
+	claims, err := httpauth.GetClaimsFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	return r.Policy.Authorize(claims, action, resource)
+}
+
+// authorizeOwner ensures the caller owns the account of the common id, or that
+// the role policy allows it to perform the action on the resource type of any account
+func (r *Resolver) authorizeOwner(ctx context.Context, action, resource, commonID string) error {
+	claims, err := httpauth.GetClaimsFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	return r.Policy.AuthorizeOwner(claims, action, resource, commonID)
+}
@@ -9,7 +9,6 @@ import (
 	"apps/services/accounts-graphql/internal/graph/models"
 	"context"
 	"fmt"
-	"libs/backend/httpauth"
 	accountsapiv1 "libs/backend/proto-gen/go/accounts/accountsapi/v1"
 
 	"connectrpc.com/connect"
@@ -30,12 +29,16 @@ func (r *queryResolver) Empty(ctx context.Context) (bool, error) {
 func (r *queryResolver) Viewer(ctx context.Context, commonID uuid.UUID) (*models.Viewer, error) {
 	// Call the Accounts API
 	commonIDStr := commonID.String()
+	if err := r.authorize(ctx, "read", "account"); err != nil {
+		return nil, fmt.Errorf("cannot view account: %w", err)
+	}
+	if err := r.authorizeOwner(ctx, "read", "account", commonIDStr); err != nil {
+		return nil, fmt.Errorf("cannot view account: %w", err)
+	}
 
 	req := connect.NewRequest(&accountsapiv1.GetAccountRequest{
 		CommonId: &commonIDStr,
 	})
-	authHeader := httpauth.GetAuthTokenFromContext(ctx)
-	req.Header().Add(httpauth.AuthorizationHeaderKey, authHeader)
 	resp, err := r.AccountsAPIClient.GetAccount(ctx, req)
 
 	// Check if there was an error or if the account is nil
@@ -0,0 +1,139 @@
+// Command replay feeds the history of an event stream through the worker's
+// event handlers, e.g. to restart the registration sagas of users whose
+// events were dead lettered:
+//
+//	replay -stream authEventStream -from 2024-06-01T00:00:00Z
+package main
+
+import (
+	"apps/services/accounts-worker/internal/adapters/database/repositories"
+	"apps/services/accounts-worker/internal/adapters/handlers/messagebroker"
+	"apps/services/accounts-worker/internal/adapters/identity"
+	"apps/services/accounts-worker/internal/app"
+	"apps/services/accounts-worker/internal/config"
+	"apps/services/accounts-worker/internal/domain/services"
+	"context"
+	"log"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/rabbitmq/amqp091-go"
+
+	"libs/backend/auth/m2m"
+	boot "libs/backend/boot"
+	"libs/backend/eventing"
+)
+
+func run() error {
+	// Stop the replay on interrupt
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// Create logger
+	logger := boot.NewSlogger()
+
+	// Construct config
+	config, err := config.NewConfig()
+	if err != nil {
+		logger.Error("Trouble constructing config")
+		return err
+	}
+
+	stream, opts, err := eventing.ParseReplayFlags(os.Args[0], os.Args[1:])
+	if err != nil {
+		return err
+	}
+
+	db, err := boot.OpenDB(boot.DBOptions{
+		Host:     config.DBHost,
+		Name:     config.DBName,
+		User:     config.DBUser,
+		Password: config.DBPassword,
+		Port:     config.DBPort,
+		SSLMode:  config.DBSSLMode,
+		TimeZone: config.DBTimeZone,
+	})
+	if err != nil {
+		logger.Error("Cannot connect to database", slog.Any("error", err))
+		return err
+	}
+
+	connection, err := amqp091.Dial(config.AMQPUri)
+	if err != nil {
+		logger.Error("Cannot connect to AMQP broker", slog.Any("error", err))
+		return err
+	}
+	defer connection.Close()
+
+	channel, err := connection.Channel()
+	if err != nil {
+		logger.Error("Cannot open AMQP channel", slog.Any("error", err))
+		return err
+	}
+	defer channel.Close()
+
+	// Initialize M2M Token Client
+	m2mClient, err := m2m.NewM2M(
+		config.Auth0Domain,
+		config.Auth0Audience,
+		config.Auth0ClientID,
+		config.Auth0ClientSecret,
+	)
+	if err != nil {
+		return err
+	}
+
+	// Initialize Auth0 Management API client for compensations
+	managementClient, err := identity.NewManagementClient(config.Auth0Domain, m2mClient, config.Auth0ManagementInMemory)
+	if err != nil {
+		return err
+	}
+	identityProvider := identity.NewAuth0IdentityProvider(logger, managementClient)
+
+	// Initialize services
+	accountService := services.NewAccountService(services.AccountServiceParams{
+		Logger:         logger,
+		AccountsAPIURI: config.AccountsAPIUri,
+		M2MClient:      m2mClient,
+	})
+	registrationSagaService := services.NewRegistrationSagaService(services.RegistrationSagaServiceParams{
+		Logger:           logger,
+		Repository:       repositories.NewRegistrationSagaRepository(logger, db),
+		AccountService:   accountService,
+		IdentityProvider: identityProvider,
+	})
+
+	// Initialize the application
+	application := app.NewApp(
+		app.WithAccountService(accountService),
+		app.WithRegistrationSagaService(registrationSagaService),
+	)
+
+	// Initialize the event dispatcher
+	dispatcher, err := eventing.NewDispatcher(logger)
+	if err != nil {
+		return err
+	}
+
+	// Registration sagas are idempotent, so replaying a user registration
+	// only starts the sagas that are missing
+	handler := messagebroker.NewLavinMQHandler(logger, channel, dispatcher, application, m2mClient)
+	handler.RegisterEventHandlers()
+
+	result, err := dispatcher.Replay(ctx, channel, stream, opts)
+	if err != nil {
+		logger.Error("Cannot replay event stream", slog.String("stream", stream), slog.Int64("lastOffset", result.LastOffset), slog.Any("error", err))
+		return err
+	}
+
+	return nil
+}
+
+func main() {
+	if err := run(); err != nil {
+		log.Printf("Cannot replay events: %v", err)
+		os.Exit(1)
+	}
+}
@@ -1,16 +1,21 @@
 package main
 
 import (
+	connectrpcadapter "apps/services/accounts-worker/internal/adapters/connectrpc"
+	"apps/services/accounts-worker/internal/adapters/database/repositories"
 	"apps/services/accounts-worker/internal/adapters/handlers/messagebroker"
+	"apps/services/accounts-worker/internal/adapters/identity"
 	"apps/services/accounts-worker/internal/app"
 	"apps/services/accounts-worker/internal/config"
 	"apps/services/accounts-worker/internal/domain/services"
+	"apps/services/accounts-worker/internal/models"
 	"context"
 	"log"
 	"log/slog"
 	"os"
 
 	"connectrpc.com/connect"
+	"connectrpc.com/grpcreflect"
 	"connectrpc.com/validate"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
@@ -18,6 +23,8 @@ import (
 	"libs/backend/auth/m2m"
 	boot "libs/backend/boot"
 	"libs/backend/eventing"
+	"libs/backend/httpauth"
+	"libs/backend/proto-gen/go/accounts/accountsworker/v1/accountsworkerv1connect"
 )
 
 func run() error {
@@ -40,8 +47,42 @@ func run() error {
 		logger.Error("Cannot set up validation interceptor", slog.Any("error", err))
 		return err
 	}
-	_ = []connect.HandlerOption{
-		connect.WithInterceptors(validationInterceptor),
+	// Custom interceptors, revoked tokens are rejected from the cache kept up to date by the auth event stream
+	revocationCache := httpauth.NewRevocationCache()
+	accessTokenValidator, err := httpauth.NewJWTValidator(
+		httpauth.WithTrustedIssuer(httpauth.TrustedIssuer{
+			IssuerURL: httpauth.Auth0IssuerURL(config.Auth0Domain),
+			Audiences: []string{config.Auth0Audience},
+			JWKSFile:  config.AuthJWKSFile,
+		}),
+		httpauth.WithRevocationStore(revocationCache),
+	)
+	if err != nil {
+		logger.Error("Cannot set up access token validator", slog.Any("error", err))
+		return err
+	}
+	policy, err := httpauth.LoadPolicyOrDefault(config.AuthPolicyFile)
+	if err != nil {
+		logger.Error("Cannot load the role policy", slog.Any("error", err))
+		return err
+	}
+	authInterceptor := httpauth.NewAuthInterceptor(logger, accessTokenValidator, httpauth.WithPolicy(policy))
+
+	options := []connect.HandlerOption{
+		connect.WithInterceptors(
+			validationInterceptor,
+			authInterceptor.Incoming(),
+		),
+	}
+
+	// The account events of a user are handled in order, different users in parallel
+	accountLifecycleQueue := eventing.PartitionedQueue{
+		Name:                 config.AccountLifecycleQueueName,
+		Exchange:             eventing.AccountsExchange,
+		RoutingKeys:          []string{eventing.GetAccountCreatedRoutingKey()},
+		Partitions:           config.AccountLifecyclePartitions,
+		DeadletterExchange:   eventing.AccountsDeadletterExchange,
+		DeadletterRoutingKey: eventing.AccountsDeadletterRoutingKey,
 	}
 
 	// Initialize the gRPC Options
@@ -49,6 +90,15 @@ func run() error {
 		NewBuildServiceBuilder().
 		SetServiceName(config.ServiceName).
 		SetLogger(logger).
+		SetDBOptions(boot.DBOptions{
+			Host:     config.DBHost,
+			Name:     config.DBName,
+			User:     config.DBUser,
+			Password: config.DBPassword,
+			Port:     config.DBPort,
+			SSLMode:  config.DBSSLMode,
+			TimeZone: config.DBTimeZone,
+		}).
 		SetAMQPOptions(boot.AMQPOptions{
 			ConnectionURI: config.AMQPUri,
 			OnConnectionCallback: func(params boot.AMQPCallBackParams) error {
@@ -61,13 +111,31 @@ func run() error {
 					CreateDeadletter().
 					CreateQueue(config.UserRegistrationQueueName).
 					BindQueues([]string{eventing.GetUserRegisteredRoutingKey()}).
+					CreateStream().
+					Complete()
+
+				// Set up the accounts exchanges
+				accountsEventRegisterer := eventing.NewAccountsEventSetup(params.Controller.Registerer, params.Logger)
+				accountsEventRegisterer.
+					CreateExchange().
+					CreateDeadletter().
 					Complete()
 
+				// Partition the account events the registration saga waits on by user
+				if err := accountLifecycleQueue.Declare(params.Controller.Registerer); err != nil {
+					params.Logger.Error("Cannot set up the account lifecycle partitions", slog.Any("error", err))
+					return err
+				}
+
 				params.Logger.Info("Set up all AMQP queues and exchanges")
 
 				return nil
 			},
 			Handlers: []boot.AMQPHandler{
+				func(hp boot.AMQPHandlerParams) error {
+					// Keep the revocation cache up to date
+					return eventing.FollowSessionRevocations(ctx, hp.AMQPController.Consumer, hp.Logger, revocationCache, httpauth.DefaultMaxTokenLifetime)
+				},
 				func(hp boot.AMQPHandlerParams) error {
 
 					// Initialize M2M Token Client
@@ -81,24 +149,57 @@ func run() error {
 						return err
 					}
 
+					// Initialize Auth0 Management API client for compensations
+					managementClient, err := identity.NewManagementClient(config.Auth0Domain, m2mClient, config.Auth0ManagementInMemory)
+					if err != nil {
+						return err
+					}
+					identityProvider := identity.NewAuth0IdentityProvider(logger, managementClient)
+
 					// Initialize services
 					accountService := services.NewAccountService(services.AccountServiceParams{
 						Logger:         logger,
 						AccountsAPIURI: config.AccountsAPIUri,
 						M2MClient:      m2mClient,
 					})
+					registrationSagaService := services.NewRegistrationSagaService(services.RegistrationSagaServiceParams{
+						Logger:           logger,
+						Repository:       repositories.NewRegistrationSagaRepository(logger, hp.DB),
+						AccountService:   accountService,
+						IdentityProvider: identityProvider,
+					})
 
 					// Initialize the application
-					application := app.NewApp(app.WithAccountService(accountService))
+					application := app.NewApp(
+						app.WithAccountService(accountService),
+						app.WithRegistrationSagaService(registrationSagaService),
+					)
+
+					// Initialize the event dispatcher
+					dispatcher, err := eventing.NewDispatcher(logger)
+					if err != nil {
+						return err
+					}
 
 					// Initialize the message broker handler
 					handler := messagebroker.NewLavinMQHandler(
 						logger,
 						hp.AMQPController.Consumer,
+						dispatcher,
 						application,
 						m2mClient,
 					)
 
+					// Retry and compensate registration sagas that timed out
+					go registrationSagaService.Run(ctx, services.DefaultSagaPollInterval)
+
+					// Handle the account events that advance the registration saga
+					go func() {
+						if err := handler.HandleAccountLifecycleEvents(ctx, accountLifecycleQueue); err != nil {
+							hp.Logger.Error("Cannot handle account lifecycle events", slog.Any("error", err))
+						}
+					}()
+
 					// Handle the user registered event
 					if err := handler.HandleUserRegisteredEvent(ctx, config.UserRegistrationQueueName); err != nil {
 						hp.Logger.Error("Cannot handle user registered event", slog.Any("error", err))
@@ -113,7 +214,44 @@ func run() error {
 			TransportCredentials: []credentials.TransportCredentials{
 				insecure.NewCredentials(),
 			},
-			Handlers: []boot.ConnectRPCHandler{},
+			Handlers: []boot.ConnectRPCHandler{
+				func(params boot.ConnectRPCHandlerParams) error {
+					// Create the registration saga query service
+					registrationSagaService := services.NewRegistrationSagaService(services.RegistrationSagaServiceParams{
+						Logger:     params.Logger,
+						Repository: repositories.NewRegistrationSagaRepository(params.Logger, params.DB),
+					})
+
+					// Create Application
+					application := app.NewApp(app.WithRegistrationSagaService(registrationSagaService))
+
+					// Assign the handler to the HTTP path
+					path, httpHandler := accountsworkerv1connect.NewRegistrationSagaServiceHandler(
+						connectrpcadapter.NewRegistrationSagaHandler(params.Logger, application),
+						options...,
+					)
+
+					// HTTP Handlers and reflection registered with Mux
+					params.Mux.Handle(path, httpHandler)
+					reflector := grpcreflect.NewStaticReflector(accountsworkerv1connect.RegistrationSagaServiceName)
+					params.Mux.Handle(grpcreflect.NewHandlerV1(reflector))
+					params.Mux.Handle(grpcreflect.NewHandlerV1Alpha(reflector))
+
+					return nil
+				},
+			},
+		}).
+		SetDBReadyCallbacks([]boot.BootCallback{
+			func(params boot.BootCallbackParams) error {
+				// Run DB migrations before the consumers and the saga poller start
+				if err := params.DB.AutoMigrate(&models.RegistrationSaga{}, &models.RegistrationSagaStep{}); err != nil {
+					params.Logger.Error("Failed to run DB migrations", slog.Any("error", err))
+					return err
+				}
+
+				params.Logger.Info("Ran DB migrations", slog.String("serviceName", config.ServiceName))
+				return nil
+			},
 		}).
 		SetBootCallbacks([]boot.BootCallback{
 			func(params boot.BootCallbackParams) error {
@@ -0,0 +1,146 @@
+package connectrpc
+
+import (
+	"apps/services/accounts-worker/internal/app"
+	"apps/services/accounts-worker/internal/app/ports"
+	"apps/services/accounts-worker/internal/domain/entities"
+	"context"
+	"errors"
+	"libs/backend/boot"
+	userValueObjects "libs/backend/domain/user/valueobjects"
+	accountsworkerv1 "libs/backend/proto-gen/go/accounts/accountsworker/v1"
+	"libs/backend/proto-gen/go/accounts/accountsworker/v1/accountsworkerv1connect"
+	"time"
+
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// defaultPageSize is used when the caller does not provide a page size
+const defaultPageSize = 25
+
+// sagaStates maps the saga states to the proto enum
+var sagaStates = map[entities.RegistrationSagaState]accountsworkerv1.RegistrationSagaState{
+	entities.RegistrationSagaStateRunning:      accountsworkerv1.RegistrationSagaState_REGISTRATION_SAGA_STATE_RUNNING,
+	entities.RegistrationSagaStateCompleted:    accountsworkerv1.RegistrationSagaState_REGISTRATION_SAGA_STATE_COMPLETED,
+	entities.RegistrationSagaStateCompensating: accountsworkerv1.RegistrationSagaState_REGISTRATION_SAGA_STATE_COMPENSATING,
+	entities.RegistrationSagaStateCompensated:  accountsworkerv1.RegistrationSagaState_REGISTRATION_SAGA_STATE_COMPENSATED,
+	entities.RegistrationSagaStateFailed:       accountsworkerv1.RegistrationSagaState_REGISTRATION_SAGA_STATE_FAILED,
+}
+
+// stepStatuses maps the step statuses to the proto enum
+var stepStatuses = map[entities.RegistrationStepStatus]accountsworkerv1.RegistrationSagaStepStatus{
+	entities.RegistrationStepStatusPending:     accountsworkerv1.RegistrationSagaStepStatus_REGISTRATION_SAGA_STEP_STATUS_PENDING,
+	entities.RegistrationStepStatusRunning:     accountsworkerv1.RegistrationSagaStepStatus_REGISTRATION_SAGA_STEP_STATUS_RUNNING,
+	entities.RegistrationStepStatusSucceeded:   accountsworkerv1.RegistrationSagaStepStatus_REGISTRATION_SAGA_STEP_STATUS_SUCCEEDED,
+	entities.RegistrationStepStatusFailed:      accountsworkerv1.RegistrationSagaStepStatus_REGISTRATION_SAGA_STEP_STATUS_FAILED,
+	entities.RegistrationStepStatusCompensated: accountsworkerv1.RegistrationSagaStepStatus_REGISTRATION_SAGA_STEP_STATUS_COMPENSATED,
+}
+
+// RegistrationSagaHandler handles all gRPC endpoints to inspect registration sagas
+type RegistrationSagaHandler struct {
+	accountsworkerv1connect.UnimplementedRegistrationSagaServiceHandler
+
+	// Logger is the logger from the boot framework
+	Logger boot.Logger
+
+	// App is the application
+	App app.App
+}
+
+// NewRegistrationSagaHandler will return a pointer to the registration saga handler
+func NewRegistrationSagaHandler(logger boot.Logger, app app.App) *RegistrationSagaHandler {
+	return &RegistrationSagaHandler{
+		Logger: logger,
+		App:    app,
+	}
+}
+
+// GetRegistrationSaga returns the registration saga of a user
+func (h *RegistrationSagaHandler) GetRegistrationSaga(
+	ctx context.Context,
+	req *connect.Request[accountsworkerv1.GetRegistrationSagaRequest],
+) (*connect.Response[accountsworkerv1.GetRegistrationSagaResponse], error) {
+	commonID := userValueObjects.NewCommonIDFromString(req.Msg.CommonId)
+
+	saga, err := h.App.RegistrationSagaService.GetRegistration(ctx, commonID)
+	if errors.Is(err, ports.ErrSagaNotFound) {
+		return nil, connect.NewError(connect.CodeNotFound, err)
+	}
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(&accountsworkerv1.GetRegistrationSagaResponse{Saga: convertSagaToProto(saga)}), nil
+}
+
+// ListRegistrationSagas returns the most recently updated registration sagas
+func (h *RegistrationSagaHandler) ListRegistrationSagas(
+	ctx context.Context,
+	req *connect.Request[accountsworkerv1.ListRegistrationSagasRequest],
+) (*connect.Response[accountsworkerv1.ListRegistrationSagasResponse], error) {
+	// Convert the state filter, unspecified lists every state
+	var state entities.RegistrationSagaState
+	for s, protoState := range sagaStates {
+		if protoState == req.Msg.State {
+			state = s
+		}
+	}
+
+	pageSize := int(req.Msg.PageSize)
+	if pageSize == 0 {
+		pageSize = defaultPageSize
+	}
+
+	sagas, err := h.App.RegistrationSagaService.ListRegistrations(ctx, state, pageSize)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	resp := &accountsworkerv1.ListRegistrationSagasResponse{
+		Sagas: make([]*accountsworkerv1.RegistrationSaga, 0, len(sagas)),
+	}
+	for _, saga := range sagas {
+		resp.Sagas = append(resp.Sagas, convertSagaToProto(saga))
+	}
+
+	return connect.NewResponse(resp), nil
+}
+
+// convertSagaToProto converts the saga to the proto type
+func convertSagaToProto(saga entities.RegistrationSaga) *accountsworkerv1.RegistrationSaga {
+	steps := make([]*accountsworkerv1.RegistrationSagaStep, 0, len(saga.Steps))
+	for _, step := range saga.Steps {
+		steps = append(steps, &accountsworkerv1.RegistrationSagaStep{
+			Name:        string(step.Name),
+			Status:      stepStatuses[step.Status],
+			Attempts:    int32(step.Attempts),
+			LastError:   step.LastError,
+			StartedAt:   optionalTimestamp(step.StartedAt),
+			CompletedAt: optionalTimestamp(step.CompletedAt),
+		})
+	}
+
+	return &accountsworkerv1.RegistrationSaga{
+		CommonId:     saga.User.CommonID.String(),
+		Username:     saga.User.Username,
+		EmailAddress: saga.User.EmailAddress.String(),
+		State:        sagaStates[saga.State],
+		CurrentStep:  string(saga.CurrentStepName()),
+		Steps:        steps,
+		LastError:    saga.LastError,
+		DeadlineAt:   optionalTimestamp(saga.DeadlineAt),
+		CreatedAt:    timestamppb.New(saga.CreatedAt),
+		UpdatedAt:    timestamppb.New(saga.UpdatedAt),
+		CompletedAt:  optionalTimestamp(saga.CompletedAt),
+	}
+}
+
+// optionalTimestamp leaves zero times unset
+func optionalTimestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+
+	return timestamppb.New(t)
+}
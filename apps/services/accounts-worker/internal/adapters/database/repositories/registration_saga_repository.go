@@ -0,0 +1,304 @@
+package repositories
+
+import (
+	"apps/services/accounts-worker/internal/app/ports"
+	"apps/services/accounts-worker/internal/domain/entities"
+	"apps/services/accounts-worker/internal/models"
+	"context"
+	"errors"
+	"fmt"
+	"libs/backend/boot"
+	userEntities "libs/backend/domain/user/entities"
+	userValueObjects "libs/backend/domain/user/valueobjects"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Assertion of the proper interface
+var _ ports.RegistrationSagaRepository = RegistrationSagaRepository{}
+
+// activeSagaStates are the states the timeout poller drives
+var activeSagaStates = []string{
+	string(entities.RegistrationSagaStateRunning),
+	string(entities.RegistrationSagaStateCompensating),
+}
+
+// RegistrationSagaRepository stores registration sagas and their steps
+type RegistrationSagaRepository struct {
+	// Logger is the logger from the boot framework
+	Logger boot.Logger
+
+	// Database is the database connection
+	Database *gorm.DB
+}
+
+// NewRegistrationSagaRepository creates a new registration saga repository
+func NewRegistrationSagaRepository(logger boot.Logger, db *gorm.DB) RegistrationSagaRepository {
+	return RegistrationSagaRepository{
+		Logger:   logger,
+		Database: db,
+	}
+}
+
+// CreateSaga stores a new saga with its steps
+func (r RegistrationSagaRepository) CreateSaga(ctx context.Context, saga entities.RegistrationSaga) error {
+	r.Logger.Info("Creating registration saga", slog.String("commonID", saga.User.CommonID.String()))
+
+	sagaModel := r.convertSagaToModel(saga)
+	sagaModel.ID = uuid.New()
+
+	return r.Database.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Do nothing when the user already has a saga
+		result := tx.Omit("Steps").Clauses(clause.OnConflict{DoNothing: true}).Create(&sagaModel)
+		if result.Error != nil {
+			return fmt.Errorf("cannot create registration saga: %w", result.Error)
+		}
+
+		if result.RowsAffected == 0 {
+			return ports.ErrSagaAlreadyExists
+		}
+
+		steps := r.convertStepsToModels(sagaModel.ID, saga.Steps)
+		if len(steps) == 0 {
+			return nil
+		}
+
+		if err := tx.Create(&steps).Error; err != nil {
+			return fmt.Errorf("cannot create registration saga steps: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// GetSagaByCommonID gets the saga of the user
+func (r RegistrationSagaRepository) GetSagaByCommonID(ctx context.Context, commonID userValueObjects.CommonID) (entities.RegistrationSaga, error) {
+	sagaModel := models.RegistrationSaga{}
+	err := r.Database.WithContext(ctx).
+		Preload("Steps", func(db *gorm.DB) *gorm.DB { return db.Order("position") }).
+		First(&sagaModel, "common_id = ?", commonID.Value()).
+		Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return entities.RegistrationSaga{}, ports.ErrSagaNotFound
+	}
+	if err != nil {
+		return entities.RegistrationSaga{}, fmt.Errorf("cannot get registration saga: %w", err)
+	}
+
+	return r.convertModelToSaga(sagaModel), nil
+}
+
+// SaveSaga stores the saga and its steps when the version is unchanged and increments the version
+func (r RegistrationSagaRepository) SaveSaga(ctx context.Context, saga *entities.RegistrationSaga) error {
+	return r.Database.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.RegistrationSaga{}).
+			Where("common_id = ? AND version = ?", saga.User.CommonID.Value(), saga.Version).
+			Updates(map[string]any{
+				"state":        string(saga.State),
+				"current_step": saga.CurrentStep,
+				"last_error":   saga.LastError,
+				"deadline_at":  optionalTime(saga.DeadlineAt),
+				"completed_at": optionalTime(saga.CompletedAt),
+				"version":      saga.Version + 1,
+				"updated_at":   saga.UpdatedAt,
+			})
+		if result.Error != nil {
+			return fmt.Errorf("cannot save registration saga: %w", result.Error)
+		}
+
+		if result.RowsAffected == 0 {
+			return ports.ErrSagaConflict
+		}
+
+		sagaID := tx.Model(&models.RegistrationSaga{}).Select("id").Where("common_id = ?", saga.User.CommonID.Value())
+		for position, step := range saga.Steps {
+			err := tx.Model(&models.RegistrationSagaStep{}).
+				Where("saga_id = (?) AND position = ?", sagaID, position).
+				Updates(map[string]any{
+					"status":       string(step.Status),
+					"attempts":     step.Attempts,
+					"last_error":   step.LastError,
+					"started_at":   optionalTime(step.StartedAt),
+					"completed_at": optionalTime(step.CompletedAt),
+					"updated_at":   saga.UpdatedAt,
+					"acknowledged": step.Acknowledged,
+				}).
+				Error
+			if err != nil {
+				return fmt.Errorf("cannot save registration saga step: %w", err)
+			}
+		}
+
+		saga.Version++
+		return nil
+	})
+}
+
+// ListExpiredSagas lists active sagas with a deadline before the time
+func (r RegistrationSagaRepository) ListExpiredSagas(ctx context.Context, now time.Time, limit int) ([]entities.RegistrationSaga, error) {
+	sagaModels := make([]models.RegistrationSaga, 0)
+	err := r.Database.WithContext(ctx).
+		Preload("Steps", func(db *gorm.DB) *gorm.DB { return db.Order("position") }).
+		Where("state IN ? AND deadline_at IS NOT NULL AND deadline_at <= ?", activeSagaStates, now).
+		Order("deadline_at").
+		Limit(limit).
+		Find(&sagaModels).
+		Error
+	if err != nil {
+		return nil, fmt.Errorf("cannot list expired registration sagas: %w", err)
+	}
+
+	return r.convertModelsToSagas(sagaModels), nil
+}
+
+// ListSagas lists the most recently updated sagas, optionally filtered by state
+func (r RegistrationSagaRepository) ListSagas(ctx context.Context, state entities.RegistrationSagaState, limit int) ([]entities.RegistrationSaga, error) {
+	query := r.Database.WithContext(ctx).
+		Preload("Steps", func(db *gorm.DB) *gorm.DB { return db.Order("position") }).
+		Order("updated_at DESC").
+		Limit(limit)
+
+	if state != "" {
+		query = query.Where("state = ?", string(state))
+	}
+
+	sagaModels := make([]models.RegistrationSaga, 0)
+	if err := query.Find(&sagaModels).Error; err != nil {
+		return nil, fmt.Errorf("cannot list registration sagas: %w", err)
+	}
+
+	return r.convertModelsToSagas(sagaModels), nil
+}
+
+// convertSagaToModel converts a saga to the database model without steps
+func (r RegistrationSagaRepository) convertSagaToModel(saga entities.RegistrationSaga) models.RegistrationSaga {
+	return models.RegistrationSaga{
+		CommonID:     saga.User.CommonID.Value(),
+		UserName:     saga.User.Username,
+		EmailAddress: saga.User.EmailAddress.String(),
+		State:        string(saga.State),
+		CurrentStep:  saga.CurrentStep,
+		LastError:    saga.LastError,
+		DeadlineAt:   optionalTime(saga.DeadlineAt),
+		Version:      saga.Version,
+		CompletedAt:  optionalTime(saga.CompletedAt),
+		CreatedAt:    saga.CreatedAt,
+		UpdatedAt:    saga.UpdatedAt,
+
+		FirstName:            saga.User.FirstName,
+		LastName:             saga.User.LastName,
+		Nickname:             saga.User.Nickname,
+		EmailAddressVerified: saga.User.EmailAddressVerified,
+		PhoneNumber:          saga.User.PhoneNumber,
+		PhoneNumberVerified:  saga.User.PhoneNumberVerified,
+		Strategy:             saga.User.Strategy,
+		IdentityUserID:       saga.User.IdentityUserID,
+		Metadata:             saga.User.Metadata,
+	}
+}
+
+// convertStepsToModels converts the saga steps to database models
+func (r RegistrationSagaRepository) convertStepsToModels(sagaID uuid.UUID, steps []entities.RegistrationStep) []models.RegistrationSagaStep {
+	stepModels := make([]models.RegistrationSagaStep, 0, len(steps))
+	for position, step := range steps {
+		stepModels = append(stepModels, models.RegistrationSagaStep{
+			ID:          uuid.New(),
+			SagaID:      sagaID,
+			Position:    position,
+			Name:        string(step.Name),
+			Status:      string(step.Status),
+			Attempts:    step.Attempts,
+			LastError:   step.LastError,
+			StartedAt:   optionalTime(step.StartedAt),
+			CompletedAt: optionalTime(step.CompletedAt),
+
+			Acknowledged: step.Acknowledged,
+		})
+	}
+
+	return stepModels
+}
+
+// convertModelsToSagas converts database models to sagas
+func (r RegistrationSagaRepository) convertModelsToSagas(sagaModels []models.RegistrationSaga) []entities.RegistrationSaga {
+	sagas := make([]entities.RegistrationSaga, 0, len(sagaModels))
+	for _, sagaModel := range sagaModels {
+		sagas = append(sagas, r.convertModelToSaga(sagaModel))
+	}
+
+	return sagas
+}
+
+// convertModelToSaga converts a database model to a saga
+func (r RegistrationSagaRepository) convertModelToSaga(sagaModel models.RegistrationSaga) entities.RegistrationSaga {
+	steps := make([]entities.RegistrationStep, 0, len(sagaModel.Steps))
+	for _, step := range sagaModel.Steps {
+		steps = append(steps, entities.RegistrationStep{
+			Name:        entities.RegistrationStepName(step.Name),
+			Status:      entities.RegistrationStepStatus(step.Status),
+			Attempts:    step.Attempts,
+			LastError:   step.LastError,
+			StartedAt:   valueTime(step.StartedAt),
+			CompletedAt: valueTime(step.CompletedAt),
+
+			Acknowledged: step.Acknowledged,
+		})
+	}
+
+	return entities.RegistrationSaga{
+		User: userEntities.NewUser(
+			userEntities.WithCommonID(userValueObjects.NewCommonIDFromUUID(sagaModel.CommonID)),
+			userEntities.WithEmailAddress(userValueObjects.NewEmailAddress(sagaModel.EmailAddress)),
+			userEntities.WithUserUsername(sagaModel.UserName),
+			userEntities.WithUserFirstName(sagaModel.FirstName),
+			userEntities.WithUserLastName(sagaModel.LastName),
+			userEntities.WithUserNickname(sagaModel.Nickname),
+			userEntities.WithEmailAddressVerified(sagaModel.EmailAddressVerified),
+			userEntities.WithPhoneNumber(sagaModel.PhoneNumber),
+			userEntities.WithPhoneNumberVerified(sagaModel.PhoneNumberVerified),
+			userEntities.WithStrategy(sagaModel.Strategy),
+			userEntities.WithIdentityUserID(sagaModel.IdentityUserID),
+			userEntities.WithMetadata(metadataOrEmpty(sagaModel.Metadata)),
+		),
+		State:       entities.RegistrationSagaState(sagaModel.State),
+		CurrentStep: sagaModel.CurrentStep,
+		Steps:       steps,
+		LastError:   sagaModel.LastError,
+		DeadlineAt:  valueTime(sagaModel.DeadlineAt),
+		Version:     sagaModel.Version,
+		CreatedAt:   sagaModel.CreatedAt,
+		UpdatedAt:   sagaModel.UpdatedAt,
+		CompletedAt: valueTime(sagaModel.CompletedAt),
+	}
+}
+
+// metadataOrEmpty reads NULL metadata as empty metadata
+func metadataOrEmpty(metadata map[string]any) map[string]any {
+	if metadata == nil {
+		return make(map[string]any)
+	}
+
+	return metadata
+}
+
+// optionalTime stores zero times as NULL
+func optionalTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+
+	return &t
+}
+
+// valueTime reads NULL times as zero times
+func valueTime(t *time.Time) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+
+	return *t
+}
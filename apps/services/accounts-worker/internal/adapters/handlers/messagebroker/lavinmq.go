@@ -7,69 +7,101 @@ import (
 	"libs/backend/boot"
 	userEntities "libs/backend/domain/user/entities"
 	userValueObjects "libs/backend/domain/user/valueobjects"
+	"libs/backend/eventing"
 	accountseventsv1 "libs/backend/proto-gen/go/accounts/accountsevents/v1"
 	"log/slog"
-
-	"google.golang.org/protobuf/proto"
 )
 
 // LavinMQHandler handles all incoming events from LavinMQ
 type LavinMQHandler struct {
-	Logger   boot.Logger
-	Consumer boot.AMQPConsumer
-	M2M      m2m.M2MGenerator
-	App      app.App
+	Logger     boot.Logger
+	Consumer   boot.AMQPConsumer
+	Dispatcher *eventing.Dispatcher
+	M2M        m2m.M2MGenerator
+	App        app.App
 }
 
 // LavinMQHandler is the constructor for LavinMQHandler
-func NewLavinMQHandler(logger boot.Logger, consumer boot.AMQPConsumer, app app.App, m2mGenerator m2m.M2MGenerator) LavinMQHandler {
+func NewLavinMQHandler(logger boot.Logger, consumer boot.AMQPConsumer, dispatcher *eventing.Dispatcher, app app.App, m2mGenerator m2m.M2MGenerator) LavinMQHandler {
 	return LavinMQHandler{
-		Logger:   logger,
-		Consumer: consumer,
-		App:      app,
-		M2M:      m2mGenerator,
+		Logger:     logger,
+		Consumer:   consumer,
+		Dispatcher: dispatcher,
+		App:        app,
+		M2M:        m2mGenerator,
 	}
 }
 
 // HandleUserRegisteredEvent handles the user registered event
 func (h LavinMQHandler) HandleUserRegisteredEvent(ctx context.Context, queueName string) error {
-	msgs, err := h.Consumer.Consume(
-		queueName, // queue
-		"",        // consumer
-		true,      // auto-ack
-		false,     // exclusive
-		false,     // no-local
-		false,     // no-wait
-		nil,       // args
-	)
-	if err != nil {
+	eventing.RegisterUserRegisteredHandler(h.Dispatcher, h.onUserRegistered)
+
+	if err := h.Dispatcher.Run(ctx, h.Consumer, queueName); err != nil {
+		h.Logger.Error("Cannot consume messages", slog.Any("error", err))
+		return err
+	}
+
+	return nil
+}
+
+// HandleAccountLifecycleEvents handles the accounts events the registration saga
+// waits on, in publish order per user
+func (h LavinMQHandler) HandleAccountLifecycleEvents(ctx context.Context, queue eventing.PartitionedQueue) error {
+	eventing.RegisterAccountCreatedHandler(h.Dispatcher, h.onAccountCreated)
+
+	if err := h.Dispatcher.RunPartitioned(ctx, h.Consumer, queue); err != nil {
 		h.Logger.Error("Cannot consume messages", slog.Any("error", err))
 		return err
 	}
 
-	// Loop through all messages in queue
-	for msg := range msgs {
-		// Unmarshal the message to userRegisteredEvent
-		var userRegisteredEvent accountseventsv1.UserRegistered
-		proto.Unmarshal(msg.Body, &userRegisteredEvent)
-
-		// Parse CommonID
-		commonID := userValueObjects.NewCommonIDFromString(userRegisteredEvent.CommonId)
-		emailAddress := userValueObjects.NewEmailAddress(userRegisteredEvent.EmailAddress)
-
-		// Create User in Accounts API
-		user := userEntities.NewUser(
-			userEntities.WithCommonID(commonID),
-			userEntities.WithEmailAddress(emailAddress),
-			userEntities.WithUserUsername(userRegisteredEvent.Username),
-		)
-
-		// Create Account in Accounts API
-		go func() {
-			if err := h.App.AccountService.CreateAccount(ctx, user); err != nil {
-				h.Logger.Error("Cannot create account", slog.Any("error", err))
-			}
-		}()
+	return nil
+}
+
+// RegisterEventHandlers registers the handler of every event the worker
+// consumes without consuming a queue, so a replay feeds the event streams
+// through the same handlers
+func (h LavinMQHandler) RegisterEventHandlers() {
+	eventing.RegisterUserRegisteredHandler(h.Dispatcher, h.onUserRegistered)
+	eventing.RegisterAccountCreatedHandler(h.Dispatcher, h.onAccountCreated)
+}
+
+// onUserRegistered starts the registration saga for a newly registered user
+func (h LavinMQHandler) onUserRegistered(ctx context.Context, userRegisteredEvent *accountseventsv1.UserRegistered) error {
+	// Parse CommonID
+	commonID := userValueObjects.NewCommonIDFromString(userRegisteredEvent.CommonId)
+	emailAddress := userValueObjects.NewEmailAddress(userRegisteredEvent.EmailAddress)
+
+	user := userEntities.NewUser(
+		userEntities.WithCommonID(commonID),
+		userEntities.WithEmailAddress(emailAddress),
+		userEntities.WithUserUsername(userRegisteredEvent.Username),
+		userEntities.WithUserFirstName(userRegisteredEvent.FirstName),
+		userEntities.WithUserLastName(userRegisteredEvent.LastName),
+		userEntities.WithUserNickname(userRegisteredEvent.Nickname),
+		userEntities.WithEmailAddressVerified(userRegisteredEvent.EmailAddressVerified),
+		userEntities.WithPhoneNumber(userRegisteredEvent.PhoneNumber),
+		userEntities.WithPhoneNumberVerified(userRegisteredEvent.PhoneNumberVerified),
+		userEntities.WithStrategy(userRegisteredEvent.Strategy),
+		userEntities.WithIdentityUserID(userRegisteredEvent.UserId),
+		userEntities.WithMetadata(eventing.MetadataFromStruct(userRegisteredEvent.Metadata)),
+	)
+
+	// The saga creates the account in the Accounts API and compensates failures
+	if err := h.App.RegistrationSagaService.StartRegistration(ctx, user); err != nil {
+		h.Logger.Error("Cannot start registration saga", slog.Any("error", err))
+		return err
+	}
+
+	return nil
+}
+
+// onAccountCreated advances the registration saga of the account
+func (h LavinMQHandler) onAccountCreated(ctx context.Context, accountCreatedEvent *accountseventsv1.AccountCreated) error {
+	commonID := userValueObjects.NewCommonIDFromString(accountCreatedEvent.Account.GetCommonId())
+
+	if err := h.App.RegistrationSagaService.HandleAccountCreated(ctx, commonID); err != nil {
+		h.Logger.Error("Cannot advance registration saga", slog.Any("error", err))
+		return err
 	}
 
 	return nil
@@ -0,0 +1,148 @@
+package messagebroker_test
+
+import (
+	"apps/services/accounts-worker/internal/adapters/handlers/messagebroker"
+	"apps/services/accounts-worker/internal/app"
+	"apps/services/accounts-worker/internal/app/ports"
+	"context"
+	"libs/backend/boot"
+	"libs/backend/boot/amqptest"
+	userEntities "libs/backend/domain/user/entities"
+	"libs/backend/eventing"
+	accountseventsv1 "libs/backend/proto-gen/go/accounts/accountsevents/v1"
+	"testing"
+	"time"
+
+	"github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+// fakeRegistrationSagaService records started registrations
+type fakeRegistrationSagaService struct {
+	ports.RegistrationSagaService
+	created chan userEntities.User
+}
+
+func (s fakeRegistrationSagaService) StartRegistration(_ context.Context, user userEntities.User) error {
+	s.created <- user
+	return nil
+}
+
+func TestLavinMQHandlerUserRegistered(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	logger := boot.NewSlogger()
+	queueName := "accounts-worker-user-registration"
+
+	broker := amqptest.NewBroker()
+	defer broker.Close()
+
+	setup := eventing.NewAuthEventSetup(broker, logger)
+	setup.
+		CreateExchange().
+		CreateDeadletter().
+		CreateQueue(queueName).
+		BindQueues([]string{eventing.GetUserRegisteredRoutingKey()}).
+		Complete()
+
+	sagaService := fakeRegistrationSagaService{created: make(chan userEntities.User, 1)}
+	dispatcher, err := eventing.NewDispatcher(logger)
+	require.NoError(t, err)
+
+	handler := messagebroker.NewLavinMQHandler(
+		logger,
+		broker,
+		dispatcher,
+		app.NewApp(app.WithRegistrationSagaService(sagaService)),
+		nil,
+	)
+	go func() {
+		_ = handler.HandleUserRegisteredEvent(ctx, queueName)
+	}()
+
+	t.Run("starts the registration saga", func(t *testing.T) {
+		body, err := proto.Marshal(&accountseventsv1.UserRegistered{
+			Username:     "user",
+			EmailAddress: "user@example.com",
+			CommonId:     "5f1d3c6e-52a7-4b43-a8b5-0c0a1f0f6f11",
+		})
+		require.NoError(t, err)
+		require.NoError(t, broker.Publish(eventing.AuthExchange, eventing.GetUserRegisteredRoutingKey(), true, false, amqp091.Publishing{
+			Type: eventing.EventNameUserRegistered.String(),
+			Body: body,
+		}))
+
+		select {
+		case user := <-sagaService.created:
+			assert.Equal(t, "user", user.Username)
+			assert.Equal(t, "user@example.com", user.EmailAddress.String())
+			assert.Equal(t, "5f1d3c6e-52a7-4b43-a8b5-0c0a1f0f6f11", user.CommonID.String())
+		case <-time.After(time.Second):
+			t.Fatal("registration saga was not started")
+		}
+	})
+
+	t.Run("dead letters invalid events", func(t *testing.T) {
+		body, err := proto.Marshal(&accountseventsv1.UserRegistered{EmailAddress: "not-an-email"})
+		require.NoError(t, err)
+		require.NoError(t, broker.Publish(eventing.AuthExchange, eventing.GetUserRegisteredRoutingKey(), true, false, amqp091.Publishing{
+			Type: eventing.EventNameUserRegistered.String(),
+			Body: body,
+		}))
+
+		assert.Eventually(t, func() bool {
+			return broker.QueueLength(eventing.AuthDeadletterQueue) == 1
+		}, time.Second, 10*time.Millisecond)
+		assert.Empty(t, sagaService.created)
+	})
+}
+
+func TestLavinMQHandlerReplay(t *testing.T) {
+	logger := boot.NewSlogger()
+
+	broker := amqptest.NewBroker()
+	defer broker.Close()
+
+	setup := eventing.NewAuthEventSetup(broker, logger)
+	setup.
+		CreateExchange().
+		CreateStream().
+		Complete()
+
+	body, err := proto.Marshal(&accountseventsv1.UserRegistered{
+		Username:     "user",
+		EmailAddress: "user@example.com",
+		CommonId:     "5f1d3c6e-52a7-4b43-a8b5-0c0a1f0f6f11",
+	})
+	require.NoError(t, err)
+	require.NoError(t, broker.Publish(eventing.AuthExchange, eventing.GetUserRegisteredRoutingKey(), true, false, amqp091.Publishing{
+		Type: eventing.EventNameUserRegistered.String(),
+		Body: body,
+	}))
+
+	sagaService := fakeRegistrationSagaService{created: make(chan userEntities.User, 1)}
+	dispatcher, err := eventing.NewDispatcher(logger)
+	require.NoError(t, err)
+
+	handler := messagebroker.NewLavinMQHandler(
+		logger,
+		broker,
+		dispatcher,
+		app.NewApp(app.WithRegistrationSagaService(sagaService)),
+		nil,
+	)
+	handler.RegisterEventHandlers()
+
+	result, err := dispatcher.Replay(context.Background(), broker, eventing.AuthEventStream, eventing.ReplayOptions{
+		Offset:      boot.NewNamedStreamOffset(boot.StreamOffsetFirst),
+		IdleTimeout: 50 * time.Millisecond,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Handled)
+
+	user := <-sagaService.created
+	assert.Equal(t, "5f1d3c6e-52a7-4b43-a8b5-0c0a1f0f6f11", user.CommonID.String())
+}
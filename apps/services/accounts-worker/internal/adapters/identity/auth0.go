@@ -0,0 +1,100 @@
+package identity
+
+import (
+	"apps/services/accounts-worker/internal/app/ports"
+	"context"
+	"errors"
+	"fmt"
+	"libs/backend/auth"
+	"libs/backend/auth/m2m"
+	"libs/backend/boot"
+	userEntities "libs/backend/domain/user/entities"
+	"log/slog"
+	"time"
+)
+
+// Assertion of the proper interface
+var _ ports.IdentityProvider = Auth0IdentityProvider{}
+
+// App metadata keys written on failed registrations
+const (
+	RegistrationFailedKey        = "registration_failed"
+	RegistrationFailedAtKey      = "registration_failed_at"
+	RegistrationFailureReasonKey = "registration_failure_reason"
+)
+
+// Auth0IdentityProvider compensates registrations with the Auth0 Management API
+type Auth0IdentityProvider struct {
+	Logger     boot.Logger
+	Management auth.ManagementClient
+}
+
+// NewAuth0IdentityProvider will return the identity provider of the Management API client
+func NewAuth0IdentityProvider(logger boot.Logger, management auth.ManagementClient) Auth0IdentityProvider {
+	return Auth0IdentityProvider{
+		Logger:     logger,
+		Management: management,
+	}
+}
+
+// NewManagementClient returns the Auth0 Management API client authorized by the
+// token source, or the in-memory stand-in when there is no Auth0 tenant
+func NewManagementClient(domain string, tokenSource *m2m.TokenSource, inMemory bool) (auth.ManagementClient, error) {
+	if inMemory {
+		return auth.NewInMemoryManagement(), nil
+	}
+
+	management, err := auth.NewManagement(auth.ManagementParams{
+		Domain:      domain,
+		TokenSource: tokenSource,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cannot create auth0 Management API client: %w", err)
+	}
+
+	return management, nil
+}
+
+// LinkAccount writes the common id of the created account into the app
+// metadata of the Auth0 user of the registration, where the access tokens and
+// the log stream resolve it from
+func (p Auth0IdentityProvider) LinkAccount(ctx context.Context, user userEntities.User) error {
+	if user.IdentityUserID == "" {
+		p.Logger.Warn("No auth0 user id to link", slog.String("commonID", user.CommonID.String()))
+		return nil
+	}
+
+	if _, err := p.Management.SetCommonID(ctx, user.IdentityUserID, user.CommonID.String()); err != nil {
+		return fmt.Errorf("cannot link auth0 user: %w", err)
+	}
+
+	p.Logger.Info("Linked account to auth0 user", slog.String("commonID", user.CommonID.String()))
+	return nil
+}
+
+// FlagRegistrationFailed marks the Auth0 user of the registration in the app
+// metadata, other identities sharing the email address are left untouched
+func (p Auth0IdentityProvider) FlagRegistrationFailed(ctx context.Context, user userEntities.User, reason string) error {
+	if user.IdentityUserID == "" {
+		p.Logger.Warn("No auth0 user id to flag", slog.String("commonID", user.CommonID.String()))
+		return nil
+	}
+
+	appMetadata := map[string]any{
+		RegistrationFailedKey:        true,
+		RegistrationFailedAtKey:      time.Now().UTC().Format(time.RFC3339),
+		RegistrationFailureReasonKey: reason,
+	}
+
+	if _, err := p.Management.SetAppMetadata(ctx, user.IdentityUserID, appMetadata); err != nil {
+		if errors.Is(err, auth.ErrUserNotFound) {
+			p.Logger.Warn("No auth0 user to flag", slog.String("commonID", user.CommonID.String()))
+			return nil
+		}
+
+		return fmt.Errorf("cannot flag auth0 user: %w", err)
+	}
+
+	p.Logger.Info("Flagged failed registration in auth0", slog.String("commonID", user.CommonID.String()))
+	return nil
+}
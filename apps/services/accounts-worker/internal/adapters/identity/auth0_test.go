@@ -0,0 +1,76 @@
+package identity_test
+
+import (
+	"apps/services/accounts-worker/internal/adapters/identity"
+	"context"
+	"libs/backend/auth"
+	"libs/backend/boot"
+	userEntities "libs/backend/domain/user/entities"
+	userValueObjects "libs/backend/domain/user/valueobjects"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuth0IdentityProviderFlagRegistrationFailed(t *testing.T) {
+	ctx := context.Background()
+
+	management := auth.NewInMemoryManagement(auth.WithUsers(
+		auth.User{ID: "auth0|registered", Email: "user@example.com"},
+		auth.User{ID: "google-oauth2|other", Email: "user@example.com"},
+	))
+	provider := identity.NewAuth0IdentityProvider(boot.NewSlogger(), management)
+
+	t.Run("flags only the registered user", func(t *testing.T) {
+		user := userEntities.NewUser(
+			userEntities.WithCommonID(userValueObjects.NewCommonID()),
+			userEntities.WithEmailAddress(userValueObjects.NewEmailAddress("user@example.com")),
+			userEntities.WithIdentityUserID("auth0|registered"),
+		)
+		require.NoError(t, provider.FlagRegistrationFailed(ctx, user, "account not created"))
+
+		registered, err := management.GetUser(ctx, "auth0|registered")
+		require.NoError(t, err)
+		assert.Equal(t, true, registered.AppMetadata[identity.RegistrationFailedKey])
+		assert.Equal(t, "account not created", registered.AppMetadata[identity.RegistrationFailureReasonKey])
+
+		other, err := management.GetUser(ctx, "google-oauth2|other")
+		require.NoError(t, err)
+		assert.NotContains(t, other.AppMetadata, identity.RegistrationFailedKey)
+	})
+
+	t.Run("skips registrations without a known user", func(t *testing.T) {
+		assert.NoError(t, provider.FlagRegistrationFailed(ctx, userEntities.NewUser(), "account not created"))
+		assert.NoError(t, provider.FlagRegistrationFailed(ctx, userEntities.NewUser(userEntities.WithIdentityUserID("auth0|unknown")), "account not created"))
+	})
+}
+
+func TestAuth0IdentityProviderLinkAccount(t *testing.T) {
+	ctx := context.Background()
+
+	management := auth.NewInMemoryManagement(auth.WithUsers(
+		auth.User{ID: "auth0|registered", Email: "user@example.com"},
+	))
+	provider := identity.NewAuth0IdentityProvider(boot.NewSlogger(), management)
+
+	t.Run("stores the common id on the registered user", func(t *testing.T) {
+		user := userEntities.NewUser(
+			userEntities.WithCommonID(userValueObjects.NewCommonID()),
+			userEntities.WithIdentityUserID("auth0|registered"),
+		)
+		require.NoError(t, provider.LinkAccount(ctx, user))
+
+		registered, err := management.GetUser(ctx, "auth0|registered")
+		require.NoError(t, err)
+		assert.Equal(t, user.CommonID.String(), registered.CommonID())
+	})
+
+	t.Run("fails for an unknown user", func(t *testing.T) {
+		user := userEntities.NewUser(
+			userEntities.WithCommonID(userValueObjects.NewCommonID()),
+			userEntities.WithIdentityUserID("auth0|unknown"),
+		)
+		assert.ErrorIs(t, provider.LinkAccount(ctx, user), auth.ErrUserNotFound)
+	})
+}
@@ -7,8 +7,9 @@ import (
 
 // App is the main application struct
 type App struct {
-	AccountService ports.AccountService
-	M2MService     m2m.M2MGenerator
+	AccountService          ports.AccountService
+	RegistrationSagaService ports.RegistrationSagaService
+	M2MService              m2m.M2MGenerator
 }
 
 // AppOption is a function that modifies the App struct
@@ -32,6 +33,13 @@ func WithAccountService(accountService ports.AccountService) AppOption {
 	}
 }
 
+// WithRegistrationSagaService sets the registration saga service
+func WithRegistrationSagaService(registrationSagaService ports.RegistrationSagaService) AppOption {
+	return func(app *App) {
+		app.RegistrationSagaService = registrationSagaService
+	}
+}
+
 // WithM2MGenerator will allow the application to access m2m tokens from the auth provider
 func WithM2MGenerator(m2mGenerator m2m.M2MGenerator) AppOption {
 	return func(a *App) {
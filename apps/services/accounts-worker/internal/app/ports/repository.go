@@ -0,0 +1,38 @@
+package ports
+
+import (
+	"apps/services/accounts-worker/internal/domain/entities"
+	"context"
+	"errors"
+	userValueObjects "libs/backend/domain/user/valueobjects"
+	"time"
+)
+
+var (
+	// ErrSagaNotFound is returned when a registration saga does not exist
+	ErrSagaNotFound = errors.New("registration saga not found")
+
+	// ErrSagaAlreadyExists is returned when a registration saga was already started
+	ErrSagaAlreadyExists = errors.New("registration saga already exists")
+
+	// ErrSagaConflict is returned when the saga was changed concurrently
+	ErrSagaConflict = errors.New("registration saga was changed concurrently")
+)
+
+// RegistrationSagaRepository is the interface for the registration saga repository
+type RegistrationSagaRepository interface {
+	// CreateSaga stores a new saga with its steps
+	CreateSaga(context.Context, entities.RegistrationSaga) error
+
+	// GetSagaByCommonID gets the saga of the user
+	GetSagaByCommonID(context.Context, userValueObjects.CommonID) (entities.RegistrationSaga, error)
+
+	// SaveSaga stores the saga and its steps when the version is unchanged and increments the version
+	SaveSaga(context.Context, *entities.RegistrationSaga) error
+
+	// ListExpiredSagas lists active sagas with a deadline before the time
+	ListExpiredSagas(context.Context, time.Time, int) ([]entities.RegistrationSaga, error)
+
+	// ListSagas lists the most recently updated sagas, optionally filtered by state
+	ListSagas(context.Context, entities.RegistrationSagaState, int) ([]entities.RegistrationSaga, error)
+}
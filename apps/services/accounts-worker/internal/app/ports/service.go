@@ -1,9 +1,11 @@
 package ports
 
 import (
+	"apps/services/accounts-worker/internal/domain/entities"
 	"context"
 	"errors"
 	userEntities "libs/backend/domain/user/entities"
+	userValueObjects "libs/backend/domain/user/valueobjects"
 )
 
 var (
@@ -15,9 +17,40 @@ var (
 
 	// ErrUserNotCreated is returned when a user is not created
 	ErrUserNotCreated = errors.New("user not created")
+
+	// ErrUserNotDeleted is returned when a user is not deleted
+	ErrUserNotDeleted = errors.New("user not deleted")
 )
 
 // AccountService will be a placeholder
 type AccountService interface {
 	CreateAccount(context.Context, userEntities.User) error
+	DeleteAccount(context.Context, userValueObjects.CommonID) error
+}
+
+// RegistrationSagaService drives and inspects registration sagas
+type RegistrationSagaService interface {
+	// StartRegistration starts the saga for a newly registered user
+	StartRegistration(context.Context, userEntities.User) error
+
+	// HandleAccountCreated advances the saga once the account exists
+	HandleAccountCreated(context.Context, userValueObjects.CommonID) error
+
+	// HandleTimeouts retries or fails every saga with an expired deadline
+	HandleTimeouts(context.Context) error
+
+	// GetRegistration returns the saga of the user
+	GetRegistration(context.Context, userValueObjects.CommonID) (entities.RegistrationSaga, error)
+
+	// ListRegistrations returns the most recently updated sagas, optionally filtered by state
+	ListRegistrations(context.Context, entities.RegistrationSagaState, int) ([]entities.RegistrationSaga, error)
+}
+
+// IdentityProvider is used to link and compensate registrations in the identity provider
+type IdentityProvider interface {
+	// LinkAccount stores the common id of the created account on the user
+	LinkAccount(ctx context.Context, user userEntities.User) error
+
+	// FlagRegistrationFailed marks the user so the failed registration can be resolved
+	FlagRegistrationFailed(ctx context.Context, user userEntities.User, reason string) error
 }
@@ -1,16 +1,13 @@
 package config
 
 import (
-	"fmt"
+	"libs/backend/eventing"
 	"os"
 )
 
 const (
 	// serviceName is the name of the microservice
-	serviceName = "accounts-worker"
-
-	// userRegistrationQueueName is the name of the queue for user registration
-	userRegistrationQueueName = "user-registration"
+	serviceName = eventing.AccountsWorkerService
 )
 
 // Config for the application
@@ -18,26 +15,62 @@ type Config struct {
 	ServiceName               string
 	AMQPUri                   string
 	UserRegistrationQueueName string
-	AccountsAPIUri            string
+	AccountLifecycleQueueName string
+
+	// AccountLifecyclePartitions is the number of partitions the account events are spread over
+	AccountLifecyclePartitions int
+	AccountsAPIUri             string
+
+	DBHost     string
+	DBName     string
+	DBUser     string
+	DBPassword string
+	DBPort     string
+	DBSSLMode  string
+	DBTimeZone string
 
 	Auth0Domain       string
 	Auth0ClientID     string
 	Auth0ClientSecret string
 	Auth0Audience     string
+
+	// Auth0ManagementInMemory replaces the Auth0 Management API with the
+	// in-memory stand-in, e.g. in local development
+	Auth0ManagementInMemory bool
+
+	// AuthJWKSFile replaces the key set of the Auth0 tenant, e.g. in local development
+	AuthJWKSFile string
+
+	// AuthPolicyFile replaces the default role policy
+	AuthPolicyFile string
 }
 
 // NewConfig constructs the config
 func NewConfig() (Config, error) {
 	config := Config{
-		ServiceName:               serviceName,
-		AMQPUri:                   os.Getenv("AMQP_CONNECTION_URI"),
-		UserRegistrationQueueName: fmt.Sprintf("%s-%s", serviceName, userRegistrationQueueName),
-		AccountsAPIUri:            os.Getenv("ACCOUNTS_API_URI"),
+		ServiceName:                serviceName,
+		AMQPUri:                    os.Getenv("AMQP_CONNECTION_URI"),
+		UserRegistrationQueueName:  eventing.GetQueueName(serviceName, eventing.UserRegistrationQueue),
+		AccountLifecycleQueueName:  eventing.GetQueueName(serviceName, eventing.AccountLifecycleQueue),
+		AccountLifecyclePartitions: eventing.DefaultPartitions,
+		AccountsAPIUri:             os.Getenv("ACCOUNTS_API_URI"),
+
+		DBHost:     os.Getenv("DATABASE_HOST"),
+		DBName:     os.Getenv("DATABASE_NAME"),
+		DBUser:     os.Getenv("DATABASE_USER"),
+		DBPassword: os.Getenv("DATABASE_PASSWORD"),
+		DBPort:     os.Getenv("DATABASE_PORT"),
+		DBSSLMode:  os.Getenv("DATABASE_SSL_MODE"),
+		DBTimeZone: os.Getenv("DATABASE_TIMEZONE"),
 
 		Auth0Domain:       os.Getenv("AUTH0_DOMAIN"),
 		Auth0ClientID:     os.Getenv("AUTH0_CLIENT_ID"),
 		Auth0ClientSecret: os.Getenv("AUTH0_CLIENT_SECRET"),
 		Auth0Audience:     os.Getenv("AUTH0_AUDIENCE"),
+		AuthJWKSFile:      os.Getenv("AUTH_JWKS_FILE"),
+		AuthPolicyFile:    os.Getenv("AUTH_POLICY_FILE"),
+
+		Auth0ManagementInMemory: os.Getenv("AUTH0_MANAGEMENT_IN_MEMORY") == "true",
 	}
 
 	return config, nil
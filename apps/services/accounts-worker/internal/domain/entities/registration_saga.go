@@ -0,0 +1,91 @@
+package entities
+
+import (
+	userEntities "libs/backend/domain/user/entities"
+	"time"
+)
+
+// RegistrationSagaState is the overall state of a registration saga
+type RegistrationSagaState string
+
+// Registration Saga States
+const (
+	RegistrationSagaStateRunning      RegistrationSagaState = "running"
+	RegistrationSagaStateCompleted    RegistrationSagaState = "completed"
+	RegistrationSagaStateCompensating RegistrationSagaState = "compensating"
+	RegistrationSagaStateCompensated  RegistrationSagaState = "compensated"
+	RegistrationSagaStateFailed       RegistrationSagaState = "failed"
+)
+
+// IsActive lets the caller know if the saga still drives commands
+func (s RegistrationSagaState) IsActive() bool {
+	return s == RegistrationSagaStateRunning || s == RegistrationSagaStateCompensating
+}
+
+// RegistrationStepName identifies a step of the registration saga
+type RegistrationStepName string
+
+// Registration Steps
+const (
+	RegistrationStepCreateAccount RegistrationStepName = "create_account"
+	RegistrationStepLinkIdentity  RegistrationStepName = "link_identity"
+)
+
+// RegistrationStepStatus is the state of a single saga step
+type RegistrationStepStatus string
+
+// Registration Step Statuses
+const (
+	RegistrationStepStatusPending     RegistrationStepStatus = "pending"
+	RegistrationStepStatusRunning     RegistrationStepStatus = "running"
+	RegistrationStepStatusSucceeded   RegistrationStepStatus = "succeeded"
+	RegistrationStepStatusFailed      RegistrationStepStatus = "failed"
+	RegistrationStepStatusCompensated RegistrationStepStatus = "compensated"
+)
+
+// RegistrationStep tracks the progress of a single step
+type RegistrationStep struct {
+	Name        RegistrationStepName
+	Status      RegistrationStepStatus
+	Attempts    int
+	LastError   string
+	StartedAt   time.Time
+	CompletedAt time.Time
+
+	// Acknowledged is set once a command or the completion event proved the
+	// effect of the step exists, only acknowledged steps are compensated
+	Acknowledged bool
+}
+
+// RegistrationSaga tracks a user registration from the identity provider
+// until every downstream system is provisioned
+type RegistrationSaga struct {
+	User        userEntities.User
+	State       RegistrationSagaState
+	CurrentStep int
+	Steps       []RegistrationStep
+	LastError   string
+	DeadlineAt  time.Time
+	Version     int
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	CompletedAt time.Time
+}
+
+// Current returns the step the saga is waiting on
+func (s *RegistrationSaga) Current() *RegistrationStep {
+	if s.CurrentStep < 0 || s.CurrentStep >= len(s.Steps) {
+		return nil
+	}
+
+	return &s.Steps[s.CurrentStep]
+}
+
+// CurrentStepName returns the name of the current step or an empty name once the saga finished
+func (s RegistrationSaga) CurrentStepName() RegistrationStepName {
+	if step := s.Current(); step != nil {
+		return step.Name
+	}
+
+	return ""
+}
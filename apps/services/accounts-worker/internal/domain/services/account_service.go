@@ -3,10 +3,11 @@ package services
 import (
 	"apps/services/accounts-worker/internal/app/ports"
 	"context"
-	"fmt"
 	"libs/backend/auth/m2m"
 	boot "libs/backend/boot"
 	userEntities "libs/backend/domain/user/entities"
+	userValueObjects "libs/backend/domain/user/valueobjects"
+	"libs/backend/eventing"
 	"libs/backend/httpauth"
 	accountsapiv1 "libs/backend/proto-gen/go/accounts/accountsapi/v1"
 	"libs/backend/proto-gen/go/accounts/accountsapi/v1/accountsapiv1connect"
@@ -34,7 +35,12 @@ type AccountServiceParams struct {
 
 // NewAccountService will construct the auth service
 func NewAccountService(params AccountServiceParams) AccountService {
-	registrationServiceClient := accountsapiv1connect.NewAccountServiceClient(http.DefaultClient, params.AccountsAPIURI)
+	// Every request to the accounts-api carries a machine token
+	registrationServiceClient := accountsapiv1connect.NewAccountServiceClient(
+		http.DefaultClient,
+		params.AccountsAPIURI,
+		connect.WithInterceptors(httpauth.NewClientAuthInterceptor(httpauth.WithTokenSource(params.M2MClient))),
+	)
 
 	return AccountService{
 		Logger:                    params.Logger,
@@ -47,19 +53,26 @@ func NewAccountService(params AccountServiceParams) AccountService {
 // RegisterUser is an application interface method to handle user registration
 // webhooks
 func (s AccountService) CreateAccount(ctx context.Context, user userEntities.User) error {
-	// Obtain machine-to-machine-token
-	m2mToken, err := s.M2MClient.GetToken()
+	metadata, err := eventing.NewMetadataStruct(user.Metadata)
 	if err != nil {
-		return fmt.Errorf("m2m token generation failure")
+		s.Logger.Error("Cannot convert user metadata", slog.Any("error", err))
+		return ports.ErrUserNotCreated
 	}
 
 	// Call the accounts-api to create the account
 	req := connect.NewRequest(&accountsapiv1.CreateAccountRequest{
-		Username:     user.Username,
-		EmailAddress: user.EmailAddress.String(),
-		CommonId:     user.CommonID.String(),
+		Username:             user.Username,
+		EmailAddress:         user.EmailAddress.String(),
+		CommonId:             user.CommonID.String(),
+		FirstName:            user.FirstName,
+		LastName:             user.LastName,
+		Nickname:             user.Nickname,
+		EmailAddressVerified: user.EmailAddressVerified,
+		PhoneNumber:          user.PhoneNumber,
+		PhoneNumberVerified:  user.PhoneNumberVerified,
+		Strategy:             user.Strategy,
+		Metadata:             metadata,
 	})
-	req.Header().Add(httpauth.AuthorizationHeaderKey, m2mToken.GetHeaderValue())
 	account, err := s.RegistrationServiceClient.CreateAccount(ctx, req)
 
 	if err != nil {
@@ -70,3 +83,25 @@ func (s AccountService) CreateAccount(ctx context.Context, user userEntities.Use
 	s.Logger.Info("Account created in Accounts API", slog.Any("isSuccess", account.Msg.GetIsSuccess()))
 	return nil
 }
+
+// DeleteAccount permanently deletes the account in the accounts-api
+func (s AccountService) DeleteAccount(ctx context.Context, commonID userValueObjects.CommonID) error {
+	// Call the accounts-api to delete the account
+	req := connect.NewRequest(&accountsapiv1.DeleteAccountRequest{
+		CommonId:   commonID.String(),
+		HardDelete: true,
+	})
+
+	_, err := s.RegistrationServiceClient.DeleteAccount(ctx, req)
+	if connect.CodeOf(err) == connect.CodeNotFound {
+		s.Logger.Info("Account already deleted in Accounts API", slog.String("commonID", commonID.String()))
+		return nil
+	}
+	if err != nil {
+		s.Logger.Error("Cannot delete account in Accounts API", slog.Any("error", err))
+		return ports.ErrUserNotDeleted
+	}
+
+	s.Logger.Info("Account deleted in Accounts API", slog.String("commonID", commonID.String()))
+	return nil
+}
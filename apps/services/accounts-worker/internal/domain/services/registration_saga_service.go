@@ -0,0 +1,404 @@
+package services
+
+import (
+	"apps/services/accounts-worker/internal/app/ports"
+	"apps/services/accounts-worker/internal/domain/entities"
+	"context"
+	"errors"
+	"fmt"
+	boot "libs/backend/boot"
+	userEntities "libs/backend/domain/user/entities"
+	userValueObjects "libs/backend/domain/user/valueobjects"
+	"libs/backend/eventing"
+	"log/slog"
+	"time"
+)
+
+// Assertion of the proper interface
+var _ ports.RegistrationSagaService = (*RegistrationSagaService)(nil)
+
+const (
+	// DefaultSagaPollInterval is how often expired sagas are checked
+	DefaultSagaPollInterval = 15 * time.Second
+
+	// defaultSagaBatchSize is the number of expired sagas handled per poll
+	defaultSagaBatchSize = 100
+
+	// defaultRetryDelay is the delay before a failed command is retried
+	defaultRetryDelay = 10 * time.Second
+)
+
+// ErrStepTimedOut is recorded when a step did not complete before its deadline
+var ErrStepTimedOut = errors.New("registration step timed out")
+
+// registrationStep defines how a saga step is driven. Steps run in the order of
+// the table, each one is completed once its command is acknowledged, or by its
+// event when the response of the command was lost.
+type registrationStep struct {
+	name        entities.RegistrationStepName
+	completedBy eventing.EventName
+	timeout     time.Duration
+	maxAttempts int
+	execute     func(ctx context.Context, saga entities.RegistrationSaga) error
+	compensate  func(ctx context.Context, saga entities.RegistrationSaga) error
+}
+
+// RegistrationSagaService is the process manager coordinating a user
+// registration across the identity provider and the accounts domain
+type RegistrationSagaService struct {
+	logger           boot.Logger
+	repository       ports.RegistrationSagaRepository
+	identityProvider ports.IdentityProvider
+	steps            []registrationStep
+	retryDelay       time.Duration
+	now              func() time.Time
+}
+
+// RegistrationSagaServiceParams is a struct to hold the parameters for the RegistrationSagaService
+type RegistrationSagaServiceParams struct {
+	Logger           boot.Logger
+	Repository       ports.RegistrationSagaRepository
+	AccountService   ports.AccountService
+	IdentityProvider ports.IdentityProvider
+
+	// StepTimeout bounds how long a step waits for the response of its command
+	StepTimeout time.Duration
+
+	// MaxAttempts is how many times a step command is tried before the saga fails
+	MaxAttempts int
+
+	// RetryDelay is the delay before a failed command is retried
+	RetryDelay time.Duration
+
+	// Now returns the current time and defaults to time.Now
+	Now func() time.Time
+}
+
+// NewRegistrationSagaService constructs the registration process manager and its step table
+func NewRegistrationSagaService(params RegistrationSagaServiceParams) *RegistrationSagaService {
+	if params.StepTimeout <= 0 {
+		params.StepTimeout = 30 * time.Second
+	}
+	if params.MaxAttempts <= 0 {
+		params.MaxAttempts = 3
+	}
+	if params.RetryDelay <= 0 {
+		params.RetryDelay = defaultRetryDelay
+	}
+	if params.Now == nil {
+		params.Now = time.Now
+	}
+
+	accountService := params.AccountService
+
+	return &RegistrationSagaService{
+		logger:           params.Logger,
+		repository:       params.Repository,
+		identityProvider: params.IdentityProvider,
+		retryDelay:       params.RetryDelay,
+		now:              params.Now,
+		steps: []registrationStep{
+			{
+				name:        entities.RegistrationStepCreateAccount,
+				completedBy: eventing.EventNameAccountCreated,
+				timeout:     params.StepTimeout,
+				maxAttempts: params.MaxAttempts,
+				// The accounts-api creates accounts idempotently by common id, so
+				// the command is safely retried after a lost response
+				execute: func(ctx context.Context, saga entities.RegistrationSaga) error {
+					return accountService.CreateAccount(ctx, saga.User)
+				},
+				compensate: func(ctx context.Context, saga entities.RegistrationSaga) error {
+					return accountService.DeleteAccount(ctx, saga.User.CommonID)
+				},
+			},
+			{
+				name:        entities.RegistrationStepLinkIdentity,
+				timeout:     params.StepTimeout,
+				maxAttempts: params.MaxAttempts,
+				// Writing the common id into the app metadata is idempotent, so
+				// there is nothing to undo once the account is deleted
+				execute: func(ctx context.Context, saga entities.RegistrationSaga) error {
+					return params.IdentityProvider.LinkAccount(ctx, saga.User)
+				},
+			},
+		},
+	}
+}
+
+// StartRegistration starts the saga for a newly registered user and drives the
+// first command. Redelivered registrations of a known user are ignored.
+func (s *RegistrationSagaService) StartRegistration(ctx context.Context, user userEntities.User) error {
+	now := s.now()
+
+	steps := make([]entities.RegistrationStep, 0, len(s.steps))
+	for _, step := range s.steps {
+		steps = append(steps, entities.RegistrationStep{
+			Name:   step.name,
+			Status: entities.RegistrationStepStatusPending,
+		})
+	}
+
+	saga := entities.RegistrationSaga{
+		User:      user,
+		State:     entities.RegistrationSagaStateRunning,
+		Steps:     steps,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err := s.repository.CreateSaga(ctx, saga); err != nil {
+		if errors.Is(err, ports.ErrSagaAlreadyExists) {
+			s.logger.Info("Registration saga already started", slog.String("commonID", user.CommonID.String()))
+			return nil
+		}
+
+		return err
+	}
+
+	s.logger.Info("Started registration saga", slog.String("commonID", user.CommonID.String()))
+
+	// Reload to pick up the stored version
+	saga, err := s.repository.GetSagaByCommonID(ctx, user.CommonID)
+	if err != nil {
+		return err
+	}
+
+	s.executeStep(ctx, &saga)
+	return s.repository.SaveSaga(ctx, &saga)
+}
+
+// HandleAccountCreated completes the create account step when the response of
+// its command was lost
+func (s *RegistrationSagaService) HandleAccountCreated(ctx context.Context, commonID userValueObjects.CommonID) error {
+	return s.handleEvent(ctx, commonID, eventing.EventNameAccountCreated)
+}
+
+// HandleTimeouts retries the current step of every expired saga and fails the
+// saga once the step is out of attempts
+func (s *RegistrationSagaService) HandleTimeouts(ctx context.Context) error {
+	sagas, err := s.repository.ListExpiredSagas(ctx, s.now(), defaultSagaBatchSize)
+	if err != nil {
+		return err
+	}
+
+	for _, saga := range sagas {
+		if err := s.handleTimeout(ctx, saga); err != nil {
+			s.logger.Error("Cannot handle registration saga timeout", slog.String("commonID", saga.User.CommonID.String()), slog.Any("error", err))
+		}
+	}
+
+	return nil
+}
+
+// Run handles timeouts on every interval until the context is cancelled
+func (s *RegistrationSagaService) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.HandleTimeouts(ctx); err != nil {
+				s.logger.Error("Cannot list expired registration sagas", slog.Any("error", err))
+			}
+		}
+	}
+}
+
+// GetRegistration returns the saga of the user
+func (s *RegistrationSagaService) GetRegistration(ctx context.Context, commonID userValueObjects.CommonID) (entities.RegistrationSaga, error) {
+	return s.repository.GetSagaByCommonID(ctx, commonID)
+}
+
+// ListRegistrations returns the most recently updated sagas, optionally filtered by state
+func (s *RegistrationSagaService) ListRegistrations(ctx context.Context, state entities.RegistrationSagaState, limit int) ([]entities.RegistrationSaga, error) {
+	return s.repository.ListSagas(ctx, state, limit)
+}
+
+// handleEvent completes the current step when it waits for the event and drives the next step
+func (s *RegistrationSagaService) handleEvent(ctx context.Context, commonID userValueObjects.CommonID, eventName eventing.EventName) error {
+	saga, err := s.repository.GetSagaByCommonID(ctx, commonID)
+	if err != nil {
+		if errors.Is(err, ports.ErrSagaNotFound) {
+			s.logger.Warn("No registration saga for event", slog.String("commonID", commonID.String()), slog.String("eventName", eventName.String()))
+			return nil
+		}
+
+		return err
+	}
+
+	step := saga.Current()
+	if saga.State != entities.RegistrationSagaStateRunning || step == nil || s.steps[saga.CurrentStep].completedBy != eventName {
+		s.logger.Info("Ignoring event for registration saga", slog.String("commonID", commonID.String()), slog.String("eventName", eventName.String()))
+		return nil
+	}
+
+	s.completeStep(ctx, &saga)
+	return s.repository.SaveSaga(ctx, &saga)
+}
+
+// handleTimeout retries or fails a single expired saga
+func (s *RegistrationSagaService) handleTimeout(ctx context.Context, saga entities.RegistrationSaga) error {
+	switch {
+	case saga.State == entities.RegistrationSagaStateCompensating:
+		// Compensation was interrupted, run it again
+		s.compensate(ctx, &saga)
+	case saga.State == entities.RegistrationSagaStateRunning && saga.Current() != nil:
+		step := saga.Current()
+		def := s.steps[saga.CurrentStep]
+
+		if step.Attempts >= def.maxAttempts {
+			if step.LastError == "" {
+				step.LastError = ErrStepTimedOut.Error()
+			}
+			s.fail(ctx, &saga, fmt.Errorf("%w: %s after %d attempts: %s", ErrStepTimedOut, step.Name, step.Attempts, step.LastError))
+		} else {
+			s.executeStep(ctx, &saga)
+		}
+	default:
+		return nil
+	}
+
+	return s.repository.SaveSaga(ctx, &saga)
+}
+
+// executeStep sends the command of the current step, completes the step once
+// the command is acknowledged and sets the deadline of its retry otherwise
+func (s *RegistrationSagaService) executeStep(ctx context.Context, saga *entities.RegistrationSaga) {
+	step := saga.Current()
+	def := s.steps[saga.CurrentStep]
+	now := s.now()
+
+	step.Status = entities.RegistrationStepStatusRunning
+	step.Attempts++
+	if step.StartedAt.IsZero() {
+		step.StartedAt = now
+	}
+	saga.UpdatedAt = now
+
+	s.logger.Info(
+		"Executing registration step",
+		slog.String("commonID", saga.User.CommonID.String()),
+		slog.String("step", string(step.Name)),
+		slog.Int("attempt", step.Attempts),
+	)
+
+	commandCtx, cancel := context.WithTimeout(ctx, def.timeout)
+	err := def.execute(commandCtx, *saga)
+	cancel()
+	if err != nil {
+		s.logger.Error("Registration step failed", slog.String("step", string(step.Name)), slog.Any("error", err))
+		step.LastError = err.Error()
+		saga.LastError = err.Error()
+
+		if step.Attempts >= def.maxAttempts {
+			s.fail(ctx, saga, fmt.Errorf("%s failed after %d attempts: %w", step.Name, step.Attempts, err))
+			return
+		}
+
+		saga.DeadlineAt = now.Add(s.retryDelay * time.Duration(step.Attempts))
+		return
+	}
+
+	// The command was acknowledged, so its effect exists and a late event is ignored
+	s.completeStep(ctx, saga)
+}
+
+// completeStep marks the current step as succeeded and drives the next one
+func (s *RegistrationSagaService) completeStep(ctx context.Context, saga *entities.RegistrationSaga) {
+	now := s.now()
+
+	step := saga.Current()
+	step.Status = entities.RegistrationStepStatusSucceeded
+	step.Acknowledged = true
+	step.LastError = ""
+	step.CompletedAt = now
+	saga.LastError = ""
+	saga.UpdatedAt = now
+	saga.CurrentStep++
+
+	if saga.Current() == nil {
+		saga.State = entities.RegistrationSagaStateCompleted
+		saga.DeadlineAt = time.Time{}
+		saga.CompletedAt = now
+		s.logger.Info("Completed registration saga", slog.String("commonID", saga.User.CommonID.String()))
+		return
+	}
+
+	s.executeStep(ctx, saga)
+}
+
+// fail marks the current step as failed and compensates the saga
+func (s *RegistrationSagaService) fail(ctx context.Context, saga *entities.RegistrationSaga, err error) {
+	s.logger.Error("Registration saga failed", slog.String("commonID", saga.User.CommonID.String()), slog.Any("error", err))
+
+	if step := saga.Current(); step != nil {
+		step.Status = entities.RegistrationStepStatusFailed
+	}
+
+	saga.State = entities.RegistrationSagaStateCompensating
+	saga.LastError = err.Error()
+	s.compensate(ctx, saga)
+}
+
+// compensate undoes every step that ran, newest first, and flags the user in
+// the identity provider. The saga stays compensating with a retry deadline when
+// a compensation fails.
+func (s *RegistrationSagaService) compensate(ctx context.Context, saga *entities.RegistrationSaga) {
+	now := s.now()
+	saga.UpdatedAt = now
+
+	last := saga.CurrentStep
+	if last >= len(saga.Steps) {
+		last = len(saga.Steps) - 1
+	}
+
+	for i := last; i >= 0; i-- {
+		step := &saga.Steps[i]
+		def := s.steps[i]
+
+		// Pending steps never sent a command and compensated steps are done
+		if step.Status == entities.RegistrationStepStatusPending ||
+			step.Status == entities.RegistrationStepStatusCompensated ||
+			def.compensate == nil {
+			continue
+		}
+
+		// Without an acknowledged command the saga cannot prove the step had an
+		// effect, so nothing is undone that may belong to a valid registration
+		if !step.Acknowledged {
+			s.logger.Warn("Skipping compensation of unacknowledged registration step", slog.String("commonID", saga.User.CommonID.String()), slog.String("step", string(step.Name)))
+			step.Status = entities.RegistrationStepStatusCompensated
+			continue
+		}
+
+		if err := def.compensate(ctx, *saga); err != nil {
+			s.logger.Error("Cannot compensate registration step", slog.String("step", string(step.Name)), slog.Any("error", err))
+			step.LastError = fmt.Sprintf("compensation failed: %v", err)
+			s.retryCompensation(saga, now)
+			return
+		}
+
+		step.Status = entities.RegistrationStepStatusCompensated
+	}
+
+	if err := s.identityProvider.FlagRegistrationFailed(ctx, saga.User, saga.LastError); err != nil {
+		s.logger.Error("Cannot flag failed registration in identity provider", slog.Any("error", err))
+		s.retryCompensation(saga, now)
+		return
+	}
+
+	saga.State = entities.RegistrationSagaStateCompensated
+	saga.DeadlineAt = time.Time{}
+	saga.CompletedAt = now
+	s.logger.Info("Compensated registration saga", slog.String("commonID", saga.User.CommonID.String()))
+}
+
+// retryCompensation schedules another compensation attempt
+func (s *RegistrationSagaService) retryCompensation(saga *entities.RegistrationSaga, now time.Time) {
+	saga.State = entities.RegistrationSagaStateCompensating
+	saga.DeadlineAt = now.Add(s.retryDelay)
+}
@@ -0,0 +1,345 @@
+package services_test
+
+import (
+	"apps/services/accounts-worker/internal/app/ports"
+	"apps/services/accounts-worker/internal/domain/entities"
+	"apps/services/accounts-worker/internal/domain/services"
+	"context"
+	"errors"
+	"libs/backend/boot"
+	userEntities "libs/backend/domain/user/entities"
+	userValueObjects "libs/backend/domain/user/valueobjects"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSagaRepository keeps sagas in memory with optimistic versioning
+type fakeSagaRepository struct {
+	mu    sync.Mutex
+	sagas map[string]entities.RegistrationSaga
+}
+
+func newFakeSagaRepository() *fakeSagaRepository {
+	return &fakeSagaRepository{sagas: make(map[string]entities.RegistrationSaga)}
+}
+
+func (r *fakeSagaRepository) CreateSaga(_ context.Context, saga entities.RegistrationSaga) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.sagas[saga.User.CommonID.String()]; ok {
+		return ports.ErrSagaAlreadyExists
+	}
+
+	r.sagas[saga.User.CommonID.String()] = copySaga(saga)
+	return nil
+}
+
+func (r *fakeSagaRepository) GetSagaByCommonID(_ context.Context, commonID userValueObjects.CommonID) (entities.RegistrationSaga, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	saga, ok := r.sagas[commonID.String()]
+	if !ok {
+		return entities.RegistrationSaga{}, ports.ErrSagaNotFound
+	}
+
+	return copySaga(saga), nil
+}
+
+func (r *fakeSagaRepository) SaveSaga(_ context.Context, saga *entities.RegistrationSaga) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored := r.sagas[saga.User.CommonID.String()]
+	if stored.Version != saga.Version {
+		return ports.ErrSagaConflict
+	}
+
+	saga.Version++
+	r.sagas[saga.User.CommonID.String()] = copySaga(*saga)
+	return nil
+}
+
+func (r *fakeSagaRepository) ListExpiredSagas(_ context.Context, now time.Time, limit int) ([]entities.RegistrationSaga, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	sagas := make([]entities.RegistrationSaga, 0)
+	for _, saga := range r.sagas {
+		if saga.State.IsActive() && !saga.DeadlineAt.IsZero() && !saga.DeadlineAt.After(now) {
+			sagas = append(sagas, copySaga(saga))
+		}
+	}
+
+	return sagas, nil
+}
+
+func (r *fakeSagaRepository) ListSagas(_ context.Context, state entities.RegistrationSagaState, limit int) ([]entities.RegistrationSaga, error) {
+	return nil, nil
+}
+
+func copySaga(saga entities.RegistrationSaga) entities.RegistrationSaga {
+	saga.Steps = append([]entities.RegistrationStep(nil), saga.Steps...)
+	return saga
+}
+
+// fakeAccountService fails account creation a configurable number of times,
+// loses the responses of created accounts a configurable number of times and
+// creates accounts idempotently by common id like the accounts-api
+type fakeAccountService struct {
+	createFailures int
+	lostResponses  int
+	commands       int
+	created        int
+	deleted        int
+	accounts       map[string]bool
+}
+
+func (s *fakeAccountService) CreateAccount(_ context.Context, user userEntities.User) error {
+	if s.createFailures > 0 {
+		s.createFailures--
+		return ports.ErrUserNotCreated
+	}
+
+	s.commands++
+	if s.accounts == nil {
+		s.accounts = make(map[string]bool)
+	}
+	if !s.accounts[user.CommonID.String()] {
+		s.accounts[user.CommonID.String()] = true
+		s.created++
+	}
+
+	if s.lostResponses > 0 {
+		s.lostResponses--
+		return context.DeadlineExceeded
+	}
+	return nil
+}
+
+func (s *fakeAccountService) DeleteAccount(context.Context, userValueObjects.CommonID) error {
+	s.deleted++
+	return nil
+}
+
+// fakeIdentityProvider records linked and flagged users and fails linking a
+// configurable number of times
+type fakeIdentityProvider struct {
+	err          error
+	linkFailures int
+	linked       []string
+	flagged      []string
+}
+
+func (p *fakeIdentityProvider) LinkAccount(_ context.Context, user userEntities.User) error {
+	if p.linkFailures > 0 {
+		p.linkFailures--
+		return errors.New("auth0 unavailable")
+	}
+
+	p.linked = append(p.linked, user.IdentityUserID)
+	return nil
+}
+
+func (p *fakeIdentityProvider) FlagRegistrationFailed(_ context.Context, user userEntities.User, reason string) error {
+	if p.err != nil {
+		return p.err
+	}
+
+	p.flagged = append(p.flagged, user.IdentityUserID)
+	return nil
+}
+
+type sagaFixture struct {
+	service          *services.RegistrationSagaService
+	repository       *fakeSagaRepository
+	accountService   *fakeAccountService
+	identityProvider *fakeIdentityProvider
+	user             userEntities.User
+	now              time.Time
+}
+
+func newSagaFixture(createFailures, lostResponses int) *sagaFixture {
+	f := &sagaFixture{
+		repository:       newFakeSagaRepository(),
+		accountService:   &fakeAccountService{createFailures: createFailures, lostResponses: lostResponses},
+		identityProvider: &fakeIdentityProvider{},
+		user: userEntities.NewUser(
+			userEntities.WithCommonID(userValueObjects.NewCommonID()),
+			userEntities.WithEmailAddress(userValueObjects.NewEmailAddress("user@example.com")),
+			userEntities.WithUserUsername("user"),
+			userEntities.WithIdentityUserID("auth0|user"),
+		),
+		now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	f.service = services.NewRegistrationSagaService(services.RegistrationSagaServiceParams{
+		Logger:           boot.NewSlogger(),
+		Repository:       f.repository,
+		AccountService:   f.accountService,
+		IdentityProvider: f.identityProvider,
+		StepTimeout:      time.Minute,
+		MaxAttempts:      2,
+		RetryDelay:       time.Second,
+		Now:              func() time.Time { return f.now },
+	})
+
+	return f
+}
+
+func (f *sagaFixture) saga(t *testing.T) entities.RegistrationSaga {
+	t.Helper()
+
+	saga, err := f.service.GetRegistration(context.Background(), f.user.CommonID)
+	require.NoError(t, err)
+	return saga
+}
+
+func TestRegistrationSagaService(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("completes once the account creation is acknowledged", func(t *testing.T) {
+		f := newSagaFixture(0, 0)
+
+		require.NoError(t, f.service.StartRegistration(ctx, f.user))
+		saga := f.saga(t)
+		assert.Equal(t, entities.RegistrationSagaStateCompleted, saga.State)
+		assert.Equal(t, entities.RegistrationStepStatusSucceeded, saga.Steps[0].Status)
+		assert.True(t, saga.Steps[0].Acknowledged)
+		assert.Equal(t, entities.RegistrationStepStatusSucceeded, saga.Steps[1].Status)
+		assert.Equal(t, []string{"auth0|user"}, f.identityProvider.linked)
+		assert.True(t, saga.DeadlineAt.IsZero())
+		assert.Empty(t, saga.CurrentStepName())
+
+		// Redelivered registrations do not send the command again
+		require.NoError(t, f.service.StartRegistration(ctx, f.user))
+		assert.Equal(t, 1, f.accountService.commands)
+
+		// The late event leaves the saga completed
+		require.NoError(t, f.service.HandleAccountCreated(ctx, f.user.CommonID))
+		assert.Equal(t, entities.RegistrationSagaStateCompleted, f.saga(t).State)
+	})
+
+	t.Run("retries failed commands", func(t *testing.T) {
+		f := newSagaFixture(1, 0)
+
+		require.NoError(t, f.service.StartRegistration(ctx, f.user))
+		saga := f.saga(t)
+		assert.Equal(t, entities.RegistrationSagaStateRunning, saga.State)
+		assert.Equal(t, ports.ErrUserNotCreated.Error(), saga.Steps[0].LastError)
+		assert.Equal(t, f.now.Add(time.Second), saga.DeadlineAt)
+
+		f.now = f.now.Add(time.Second)
+		require.NoError(t, f.service.HandleTimeouts(ctx))
+		saga = f.saga(t)
+		assert.Equal(t, 2, saga.Steps[0].Attempts)
+		assert.Equal(t, entities.RegistrationSagaStateCompleted, saga.State)
+		assert.Equal(t, 1, f.accountService.created)
+	})
+
+	t.Run("compensates when the command keeps failing", func(t *testing.T) {
+		f := newSagaFixture(2, 0)
+
+		require.NoError(t, f.service.StartRegistration(ctx, f.user))
+		f.now = f.now.Add(time.Second)
+		require.NoError(t, f.service.HandleTimeouts(ctx))
+
+		saga := f.saga(t)
+		assert.Equal(t, entities.RegistrationSagaStateCompensated, saga.State)
+		assert.Equal(t, entities.RegistrationStepStatusCompensated, saga.Steps[0].Status)
+		assert.Equal(t, []string{"auth0|user"}, f.identityProvider.flagged)
+
+		// No command was acknowledged, so no account is deleted
+		assert.Equal(t, 0, f.accountService.deleted)
+	})
+
+	t.Run("deletes the created account when the identity cannot be linked", func(t *testing.T) {
+		f := newSagaFixture(0, 0)
+		f.identityProvider.linkFailures = 2
+
+		require.NoError(t, f.service.StartRegistration(ctx, f.user))
+		saga := f.saga(t)
+		assert.Equal(t, entities.RegistrationSagaStateRunning, saga.State)
+		assert.Equal(t, entities.RegistrationStepLinkIdentity, saga.CurrentStepName())
+
+		f.now = f.now.Add(time.Second)
+		require.NoError(t, f.service.HandleTimeouts(ctx))
+
+		saga = f.saga(t)
+		assert.Equal(t, entities.RegistrationSagaStateCompensated, saga.State)
+		assert.Equal(t, entities.RegistrationStepStatusCompensated, saga.Steps[0].Status)
+		assert.Equal(t, entities.RegistrationStepStatusFailed, saga.Steps[1].Status)
+		assert.Equal(t, 1, f.accountService.deleted)
+		assert.Equal(t, []string{"auth0|user"}, f.identityProvider.flagged)
+	})
+
+	t.Run("completes by the event when the response of the command is lost", func(t *testing.T) {
+		f := newSagaFixture(0, 1)
+
+		require.NoError(t, f.service.StartRegistration(ctx, f.user))
+		saga := f.saga(t)
+		assert.Equal(t, entities.RegistrationSagaStateRunning, saga.State)
+		assert.False(t, saga.Steps[0].Acknowledged)
+
+		require.NoError(t, f.service.HandleAccountCreated(ctx, f.user.CommonID))
+		assert.Equal(t, entities.RegistrationSagaStateCompleted, f.saga(t).State)
+		assert.Equal(t, 1, f.accountService.created)
+		assert.Equal(t, 0, f.accountService.deleted)
+		assert.Empty(t, f.identityProvider.flagged)
+	})
+
+	t.Run("completes when the command is retried for an existing account", func(t *testing.T) {
+		f := newSagaFixture(0, 1)
+
+		// The account is created but the response and its event are lost
+		require.NoError(t, f.service.StartRegistration(ctx, f.user))
+		f.now = f.now.Add(time.Second)
+		require.NoError(t, f.service.HandleTimeouts(ctx))
+
+		// The retried command hits the existing account and is acknowledged
+		saga := f.saga(t)
+		assert.Equal(t, entities.RegistrationSagaStateCompleted, saga.State)
+		assert.Equal(t, 2, saga.Steps[0].Attempts)
+		assert.Empty(t, saga.Steps[0].LastError)
+		assert.Equal(t, 2, f.accountService.commands)
+		assert.Equal(t, 1, f.accountService.created)
+		assert.Equal(t, 0, f.accountService.deleted)
+	})
+
+	t.Run("does not delete an account it cannot prove it created", func(t *testing.T) {
+		f := newSagaFixture(0, 2)
+
+		require.NoError(t, f.service.StartRegistration(ctx, f.user))
+		f.now = f.now.Add(time.Second)
+		require.NoError(t, f.service.HandleTimeouts(ctx))
+
+		saga := f.saga(t)
+		assert.Equal(t, entities.RegistrationSagaStateCompensated, saga.State)
+		assert.Equal(t, 0, f.accountService.deleted)
+
+		// Late events do not resurrect the saga
+		require.NoError(t, f.service.HandleAccountCreated(ctx, f.user.CommonID))
+		assert.Equal(t, entities.RegistrationSagaStateCompensated, f.saga(t).State)
+	})
+
+	t.Run("retries failed compensations", func(t *testing.T) {
+		f := newSagaFixture(2, 0)
+		f.identityProvider.err = errors.New("auth0 unavailable")
+
+		require.NoError(t, f.service.StartRegistration(ctx, f.user))
+		f.now = f.now.Add(time.Second)
+		require.NoError(t, f.service.HandleTimeouts(ctx))
+		assert.Equal(t, entities.RegistrationSagaStateCompensating, f.saga(t).State)
+
+		f.identityProvider.err = nil
+		f.now = f.now.Add(time.Second)
+		require.NoError(t, f.service.HandleTimeouts(ctx))
+		assert.Equal(t, entities.RegistrationSagaStateCompensated, f.saga(t).State)
+		assert.Equal(t, []string{"auth0|user"}, f.identityProvider.flagged)
+	})
+}
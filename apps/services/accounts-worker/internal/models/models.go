@@ -0,0 +1,51 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RegistrationSaga is our model, which corresponds to the "registration_sagas" table
+type RegistrationSaga struct {
+	ID           uuid.UUID `gorm:"primaryKey;type:uuid"`
+	CommonID     uuid.UUID `gorm:"type:uuid;uniqueIndex:idx_registration_saga_common_id;not null;"`
+	UserName     string    `gorm:"not null;"`
+	EmailAddress string    `gorm:"not null;"`
+	State        string    `gorm:"index:idx_registration_saga_state_deadline;not null;"`
+	CurrentStep  int       `gorm:"not null;"`
+	LastError    string
+	DeadlineAt   *time.Time `gorm:"index:idx_registration_saga_state_deadline;"`
+	Version      int        `gorm:"not null;default:0;"`
+	CompletedAt  *time.Time
+	CreatedAt    time.Time
+	UpdatedAt    time.Time              `gorm:"index:idx_registration_saga_updated_at;"`
+	Steps        []RegistrationSagaStep `gorm:"foreignKey:SagaID;constraint:OnDelete:CASCADE;"`
+
+	// The profile of the user from the registration, kept for the retries of the saga
+	FirstName            string
+	LastName             string
+	Nickname             string
+	EmailAddressVerified bool `gorm:"not null;default:false;"`
+	PhoneNumber          string
+	PhoneNumberVerified  bool `gorm:"not null;default:false;"`
+	Strategy             string
+	IdentityUserID       string
+	Metadata             map[string]any `gorm:"serializer:json;"`
+}
+
+// RegistrationSagaStep is our model, which corresponds to the "registration_saga_steps" table
+type RegistrationSagaStep struct {
+	ID          uuid.UUID `gorm:"primaryKey;type:uuid"`
+	SagaID      uuid.UUID `gorm:"type:uuid;uniqueIndex:idx_registration_saga_step_position;not null;"`
+	Position    int       `gorm:"uniqueIndex:idx_registration_saga_step_position;not null;"`
+	Name        string    `gorm:"not null;"`
+	Status      string    `gorm:"not null;"`
+	Attempts    int       `gorm:"not null;default:0;"`
+	LastError   string
+	StartedAt   *time.Time
+	CompletedAt *time.Time
+	UpdatedAt   time.Time
+
+	Acknowledged bool `gorm:"not null;default:false;"`
+}
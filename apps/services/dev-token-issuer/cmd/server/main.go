@@ -0,0 +1,88 @@
+package main
+
+import (
+	"apps/services/dev-token-issuer/internal/config"
+	"context"
+	"log"
+	"log/slog"
+	"os"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"gopkg.in/go-jose/go-jose.v2"
+
+	boot "libs/backend/boot"
+	"libs/backend/httpauth/devissuer"
+)
+
+func run() error {
+	// Application Context
+	ctx := context.Background()
+
+	// Create logger
+	logger := boot.NewSlogger()
+
+	// Construct config
+	config, err := config.NewConfig()
+	if err != nil {
+		logger.Error("Trouble constructing config", slog.Any("error", err))
+		os.Exit(1)
+	}
+
+	// Construct the issuer, its keys only live as long as the process
+	issuerOptions := []devissuer.Option{devissuer.WithAudiences(config.Audiences...)}
+	if config.Algorithm != "" {
+		issuerOptions = append(issuerOptions, devissuer.WithAlgorithm(jose.SignatureAlgorithm(config.Algorithm)))
+	}
+	if config.ClientID != "" {
+		issuerOptions = append(issuerOptions, devissuer.WithClient(devissuer.Client{
+			ID:     config.ClientID,
+			Secret: config.ClientSecret,
+			Scopes: config.ClientScopes,
+		}))
+	}
+
+	issuer, err := devissuer.NewIssuer(config.IssuerURL, issuerOptions...)
+	if err != nil {
+		logger.Error("Cannot set up the token issuer", slog.Any("error", err))
+		return err
+	}
+
+	logger.Warn("Minting development tokens, never trust this issuer outside local development", slog.String("issuer", issuer.URL()))
+
+	// Initialize the HTTP Options
+	bootService := boot.
+		NewBuildServiceBuilder().
+		SetServiceName(config.ServiceName).
+		SetLogger(logger).
+		SetConnectRPCOptions(boot.ConnectRPCOptions{
+			Port: 3000,
+			TransportCredentials: []credentials.TransportCredentials{
+				insecure.NewCredentials(),
+			},
+			Handlers: []boot.ConnectRPCHandler{
+				func(params boot.ConnectRPCHandlerParams) error {
+					// The issuer handler serves its own well known and token paths
+					params.Mux.Handle("/", issuer.Handler())
+
+					return nil
+				},
+			},
+		}).
+		SetBootCallbacks([]boot.BootCallback{
+			func(params boot.BootCallbackParams) error {
+				params.Logger.Info("Service booted successfully", slog.String("serviceName", config.ServiceName))
+				return nil
+			},
+		}).
+		Build()
+
+	return bootService.Start(ctx)
+}
+
+func main() {
+	if err := run(); err != nil {
+		log.Printf("Cannot start service")
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,17 @@
+module apps/services/dev-token-issuer
+
+go 1.23
+
+require (
+	google.golang.org/grpc v1.68.1
+	gopkg.in/go-jose/go-jose.v2 v2.6.3
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.2-0.20180830191138-d8f796af33cc // indirect
+	github.com/pmezard/go-difflib v1.0.1-0.20181226105442-5d4384ee4fb2 // indirect
+	github.com/stretchr/testify v1.10.0 // indirect
+	golang.org/x/crypto v0.31.0 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20241118233622-e639e219e697 // indirect
+	google.golang.org/protobuf v1.35.2 // indirect
+)
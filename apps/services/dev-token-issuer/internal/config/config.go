@@ -0,0 +1,57 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"strings"
+)
+
+const (
+	// serviceName is the name of the microservice
+	serviceName = "dev-token-issuer"
+
+	// defaultIssuerURL is the url of the issuer in the docker compose network
+	defaultIssuerURL = "http://dev-token-issuer:3000/"
+)
+
+// Config for the application
+type Config struct {
+	ServiceName string
+
+	// IssuerURL is the iss claim, services trust it as their AUTH0_DOMAIN
+	IssuerURL string
+
+	// Audiences tokens are minted for, the first is the default
+	Audiences []string
+
+	// The machine allowed to use the client credentials grant
+	ClientID     string
+	ClientSecret string
+	ClientScopes []string
+
+	// Algorithm is RS256 or ES256
+	Algorithm string
+}
+
+// NewConfig constructs the config
+func NewConfig() (Config, error) {
+	config := Config{
+		ServiceName:  serviceName,
+		IssuerURL:    os.Getenv("DEV_ISSUER_URL"),
+		Audiences:    strings.Fields(strings.ReplaceAll(os.Getenv("DEV_ISSUER_AUDIENCES"), ",", " ")),
+		ClientID:     os.Getenv("DEV_ISSUER_CLIENT_ID"),
+		ClientSecret: os.Getenv("DEV_ISSUER_CLIENT_SECRET"),
+		ClientScopes: strings.Fields(os.Getenv("DEV_ISSUER_CLIENT_SCOPES")),
+		Algorithm:    os.Getenv("DEV_ISSUER_ALGORITHM"),
+	}
+
+	if config.IssuerURL == "" {
+		config.IssuerURL = defaultIssuerURL
+	}
+
+	if len(config.Audiences) == 0 {
+		return Config{}, errors.New("DEV_ISSUER_AUDIENCES is required")
+	}
+
+	return config, nil
+}
@@ -2,9 +2,14 @@ package main
 
 import (
 	connectrpcAdapters "apps/services/inbound-webhooks-api/internal/adapters/connectrpc"
+	"apps/services/inbound-webhooks-api/internal/adapters/handlers/logstream"
+	"apps/services/inbound-webhooks-api/internal/adapters/identity"
+	"apps/services/inbound-webhooks-api/internal/adapters/verification"
 	"apps/services/inbound-webhooks-api/internal/app"
+	"apps/services/inbound-webhooks-api/internal/app/ports"
 	"apps/services/inbound-webhooks-api/internal/app/usecases"
 	"apps/services/inbound-webhooks-api/internal/config"
+	"apps/services/inbound-webhooks-api/internal/models"
 	"context"
 	"errors"
 	"log"
@@ -17,6 +22,7 @@ import (
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 
+	"libs/backend/auth/m2m"
 	boot "libs/backend/boot"
 	"libs/backend/eventing"
 	inboundwebhooksapiv1connect "libs/backend/proto-gen/go/webhooks/inboundwebhooksapi/v1/inboundwebhooksapiv1connect"
@@ -49,11 +55,47 @@ func run() error {
 		connect.WithInterceptors(validationInterceptor),
 	}
 
+	// Webhook signature verification
+	scheme, err := verification.NewScheme(config.WebhookScheme, config.WebhookSecrets)
+	if err != nil {
+		logger.Error("Cannot set up webhook signature scheme", slog.Any("error", err))
+		return err
+	}
+	// Auth0 Management API client the log stream events are linked to the
+	// accounts with, the in-memory stand-in needs no M2M credentials
+	var m2mClient *m2m.TokenSource
+	if !config.Auth0ManagementInMemory {
+		m2mClient, err = m2m.NewM2M(
+			config.Auth0Domain,
+			config.Auth0Audience,
+			config.Auth0ClientID,
+			config.Auth0ClientSecret,
+		)
+		if err != nil {
+			logger.Error("Cannot set up M2M token client", slog.Any("error", err))
+			return err
+		}
+	}
+	managementClient, err := identity.NewManagementClient(config.Auth0Domain, m2mClient, config.Auth0ManagementInMemory)
+	if err != nil {
+		logger.Error("Cannot set up Auth0 Management API client", slog.Any("error", err))
+		return err
+	}
+
 	// Initialize the gRPC Options
 	bootService := boot.
 		NewBuildServiceBuilder().
 		SetServiceName(serviceName).
 		SetLogger(logger).
+		SetDBOptions(boot.DBOptions{
+			Host:     config.DBHost,
+			Name:     config.DBName,
+			User:     config.DBUser,
+			Password: config.DBPassword,
+			Port:     config.DBPort,
+			SSLMode:  config.DBSSLMode,
+			TimeZone: config.DBTimeZone,
+		}).
 		SetAMQPOptions(boot.AMQPOptions{
 			ConnectionURI: config.AMQPUrl,
 			OnConnectionCallback: func(params boot.AMQPCallBackParams) error {
@@ -64,6 +106,7 @@ func run() error {
 				authEventRegisterer.
 					CreateExchange().
 					CreateDeadletter().
+					CreateStream().
 					Complete()
 
 				params.Logger.Info("Set up all AMQP queues and exchanges")
@@ -84,9 +127,34 @@ func run() error {
 						return errors.New(errMsg)
 					}
 
+					// The replicas share the delivery ids and the linked common ids
+					// through the DB, the memory stores only serve a single replica
+					var replayStore ports.ReplayStore
+					var commonIDStore ports.CommonIDStore
+					if params.DB != nil {
+						replayStore = verification.NewDatabaseReplayStore(params.DB, nil)
+						commonIDStore = identity.NewDatabaseCommonIDStore(params.DB)
+					} else {
+						logger.Warn("No DB, webhook replays are only rejected per replica, run a single replica")
+						replayStore = verification.NewMemoryReplayStore(nil)
+						commonIDStore = identity.NewMemoryCommonIDStore()
+					}
+					verifier, err := verification.NewVerifier(verification.VerifierParams{
+						Logger:      logger,
+						Scheme:      scheme,
+						ReplayStore: replayStore,
+						Tolerance:   config.WebhookTolerance,
+					})
+					if err != nil {
+						logger.Error("Cannot set up webhook verifier", slog.Any("error", err))
+						return err
+					}
+
 					// Construct application
-					authService := usecases.NewAuthService(logger, params.AMQPController.Publisher)
-					application := app.NewApplication(authService)
+					identityProvider := identity.NewAuth0IdentityProvider(logger, managementClient, commonIDStore)
+					authService := usecases.NewAuthService(logger, params.AMQPController.ConfirmPublisher, identityProvider)
+					logStreamService := usecases.NewLogStreamService(logger, params.AMQPController.ConfirmPublisher, identityProvider)
+					application := app.NewApplication(authService, logStreamService)
 					authHandler := connectrpcAdapters.NewAuthHandler(logger, application)
 
 					// Set up auth service routes and handlers
@@ -94,16 +162,43 @@ func run() error {
 						authHandler,
 						options...,
 					)
-					params.Mux.Handle(path, handler)
+					params.Mux.Handle(path, verifier.Middleware(handler))
 					reflector := grpcreflect.NewStaticReflector(
 						inboundwebhooksapiv1connect.InboundWebhooksAuthServiceName,
 					)
 					params.Mux.Handle(grpcreflect.NewHandlerV1(reflector))
 					params.Mux.Handle(grpcreflect.NewHandlerV1Alpha(reflector))
+
+					// Set up the Auth0 Log Stream webhook
+					if len(config.LogStreamTokens) == 0 {
+						logger.Warn("No Auth0 log stream tokens, not serving the log stream webhook")
+						return nil
+					}
+					logStreamVerifier, err := verification.NewVerifier(verification.VerifierParams{
+						Logger: logger,
+						Scheme: verification.BearerScheme{Secrets: config.LogStreamTokens},
+					})
+					if err != nil {
+						return err
+					}
+					params.Mux.Handle(logstream.Path, logStreamVerifier.Middleware(logstream.NewHandler(logger, application)))
+
 					return nil
 				},
 			},
 		}).
+		SetDBReadyCallbacks([]boot.BootCallback{
+			func(params boot.BootCallbackParams) error {
+				// Run DB migrations before the webhooks are served
+				if err := params.DB.AutoMigrate(&models.InboundWebhookDelivery{}, &models.IdentityUser{}); err != nil {
+					params.Logger.Error("Failed to run DB migrations", slog.Any("error", err))
+					return err
+				}
+
+				params.Logger.Info("Ran DB migrations", slog.String("serviceName", serviceName))
+				return nil
+			},
+		}).
 		SetBootCallbacks([]boot.BootCallback{
 			func(params boot.BootCallbackParams) error {
 				params.Logger.Info("Service booted successfully", slog.String("serviceName", serviceName))
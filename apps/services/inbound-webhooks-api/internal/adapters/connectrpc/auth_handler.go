@@ -2,6 +2,7 @@ package connectrpc
 
 import (
 	"context"
+	"errors"
 
 	"connectrpc.com/connect"
 
@@ -9,6 +10,7 @@ import (
 	boot "libs/backend/boot"
 	userEntities "libs/backend/domain/user/entities"
 	userValueObjects "libs/backend/domain/user/valueobjects"
+	"libs/backend/eventing"
 	commonv1 "libs/backend/proto-gen/go/common/v1"
 	inboundwebhooksapiv1 "libs/backend/proto-gen/go/webhooks/inboundwebhooksapi/v1"
 )
@@ -39,13 +41,30 @@ func (h *AuthHandler) UserRegistered(
 	emailAddress := userValueObjects.NewEmailAddress(req.Msg.EmailAddress)
 
 	if err := h.Application.AuthService.RegisterUser(
+		ctx,
 		userEntities.NewUser(
 			userEntities.WithCommonID(commonID),
 			userEntities.WithEmailAddress(emailAddress),
 			userEntities.WithUserUsername(req.Msg.Username),
-			userEntities.WithMetadata(make(map[string]any, 0)),
+			userEntities.WithUserFirstName(req.Msg.FirstName),
+			userEntities.WithUserLastName(req.Msg.LastName),
+			userEntities.WithUserNickname(req.Msg.Nickname),
+			userEntities.WithEmailAddressVerified(req.Msg.EmailAddressVerified),
+			userEntities.WithPhoneNumber(req.Msg.PhoneNumber),
+			userEntities.WithPhoneNumberVerified(req.Msg.PhoneNumberVerified),
+			userEntities.WithStrategy(req.Msg.Strategy),
+			userEntities.WithIdentityUserID(req.Msg.UserId),
+			userEntities.WithMetadata(eventing.MetadataFromStruct(req.Msg.Metadata)),
 		),
 	); err != nil {
+		// Let Auth0 retry the webhook when the broker did not accept the event
+		if errors.Is(err, boot.ErrPublishUnroutable) ||
+			errors.Is(err, boot.ErrPublishNacked) ||
+			errors.Is(err, boot.ErrPublishConfirmTimeout) ||
+			errors.Is(err, boot.ErrPublishChannelClosed) {
+			return nil, connect.NewError(connect.CodeUnavailable, err)
+		}
+
 		return nil, connect.NewError(connect.CodeInternal, err)
 	}
 	return connect.NewResponse(&commonv1.Empty{}), nil
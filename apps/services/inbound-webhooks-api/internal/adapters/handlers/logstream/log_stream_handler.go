@@ -0,0 +1,153 @@
+// Package logstream receives the batches of the Auth0 Log Streams custom
+// webhook, see https://auth0.com/docs/customize/log-streams/custom-log-streams
+package logstream
+
+import (
+	"apps/services/inbound-webhooks-api/internal/app"
+	"apps/services/inbound-webhooks-api/internal/domain/entities"
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	boot "libs/backend/boot"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// Path is the route of the log stream webhook
+const Path = "/auth0/log-streams"
+
+// logEntry is a log of the batch, the JSON array, JSON lines and JSON object
+// content formats of the log stream all send these entries
+type logEntry struct {
+	LogID string  `json:"log_id"`
+	Data  logData `json:"data"`
+}
+
+// logData is the Auth0 log of an entry
+type logData struct {
+	Date        time.Time `json:"date"`
+	Type        string    `json:"type"`
+	Description string    `json:"description"`
+	Connection  string    `json:"connection"`
+	ClientID    string    `json:"client_id"`
+	ClientName  string    `json:"client_name"`
+	IP          string    `json:"ip"`
+	UserAgent   string    `json:"user_agent"`
+	UserID      string    `json:"user_id"`
+	UserName    string    `json:"user_name"`
+	LogID       string    `json:"log_id"`
+}
+
+// Handler ingests the log stream batches
+type Handler struct {
+	Logger      boot.Logger
+	Application app.Application
+}
+
+// NewHandler will return a pointer to the log stream handler
+func NewHandler(logger boot.Logger, application app.Application) *Handler {
+	return &Handler{
+		Logger:      logger,
+		Application: application,
+	}
+}
+
+// ServeHTTP publishes the identity events of the batch, failures are answered
+// with a server error so Auth0 retries the batch
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	logs, err := decodeLogs(r.Body)
+	if err != nil {
+		h.Logger.Warn("Cannot decode Auth0 log stream batch", slog.Any("error", err))
+		http.Error(w, "invalid log stream batch", http.StatusBadRequest)
+		return
+	}
+
+	if _, err := h.Application.LogStreamService.IngestLogs(r.Context(), logs); err != nil {
+		http.Error(w, "cannot ingest log stream batch", http.StatusServiceUnavailable)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// decodeLogs decodes the entries of a JSON array, of JSON lines or of a
+// single JSON object
+func decodeLogs(body io.Reader) ([]entities.Auth0Log, error) {
+	reader := bufio.NewReader(body)
+	decoder := json.NewDecoder(reader)
+
+	entries := make([]logEntry, 0)
+	if first, err := peekNonSpace(reader); err != nil {
+		return nil, err
+	} else if first == '[' {
+		if err := decoder.Decode(&entries); err != nil {
+			return nil, err
+		}
+	} else {
+		for {
+			var entry logEntry
+			if err := decoder.Decode(&entry); errors.Is(err, io.EOF) {
+				break
+			} else if err != nil {
+				return nil, err
+			}
+			entries = append(entries, entry)
+		}
+	}
+
+	logs := make([]entities.Auth0Log, 0, len(entries))
+	for _, entry := range entries {
+		id := entry.LogID
+		if id == "" {
+			id = entry.Data.LogID
+		}
+		if id == "" {
+			return nil, fmt.Errorf("log of type %q has no log id", entry.Data.Type)
+		}
+
+		logs = append(logs, entities.Auth0Log{
+			ID:          id,
+			Type:        entry.Data.Type,
+			Date:        entry.Data.Date,
+			Description: entry.Data.Description,
+			Connection:  entry.Data.Connection,
+			ClientID:    entry.Data.ClientID,
+			ClientName:  entry.Data.ClientName,
+			IP:          entry.Data.IP,
+			UserAgent:   entry.Data.UserAgent,
+			UserID:      entry.Data.UserID,
+			UserName:    entry.Data.UserName,
+		})
+	}
+
+	return logs, nil
+}
+
+// peekNonSpace returns the first byte of the body after the whitespace
+func peekNonSpace(reader *bufio.Reader) (byte, error) {
+	for {
+		b, err := reader.ReadByte()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return 0, errors.New("empty log stream batch")
+			}
+			return 0, err
+		}
+
+		switch b {
+		case ' ', '\t', '\r', '\n':
+			continue
+		}
+
+		return b, reader.UnreadByte()
+	}
+}
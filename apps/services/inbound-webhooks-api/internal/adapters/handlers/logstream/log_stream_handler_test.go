@@ -0,0 +1,95 @@
+package logstream_test
+
+import (
+	"apps/services/inbound-webhooks-api/internal/adapters/handlers/logstream"
+	"apps/services/inbound-webhooks-api/internal/app"
+	"apps/services/inbound-webhooks-api/internal/domain/entities"
+	"context"
+	"errors"
+	boot "libs/backend/boot"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeLogStreamService records the ingested logs
+type fakeLogStreamService struct {
+	logs []entities.Auth0Log
+	err  error
+}
+
+func (s *fakeLogStreamService) IngestLogs(_ context.Context, logs []entities.Auth0Log) (int, error) {
+	if s.err != nil {
+		return 0, s.err
+	}
+	s.logs = append(s.logs, logs...)
+	return len(logs), nil
+}
+
+const loginEntry = `{"log_id":"90020241203151500000000000000000000000000000000001","data":{"date":"2024-12-03T15:15:00.000Z","type":"s","connection":"Username-Password-Authentication","client_id":"client-1","client_name":"Career Cue","ip":"203.0.113.7","user_agent":"Chrome 131.0.0 / Mac OS X 10.15.7","user_id":"auth0|user-1","user_name":"user@example.com","log_id":"90020241203151500000000000000000000000000000000001"}}`
+
+const verificationEntry = `{"log_id":"90020241203151600000000000000000000000000000000002","data":{"date":"2024-12-03T15:16:00.000Z","type":"sv","user_id":"auth0|user-1","user_name":"user@example.com"}}`
+
+func serve(t *testing.T, service *fakeLogStreamService, method, body string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	handler := logstream.NewHandler(boot.NewSlogger(), app.Application{LogStreamService: service})
+	res := httptest.NewRecorder()
+	handler.ServeHTTP(res, httptest.NewRequest(method, logstream.Path, strings.NewReader(body)))
+
+	return res
+}
+
+func TestHandler(t *testing.T) {
+	for name, body := range map[string]string{
+		"json array":   "[" + loginEntry + "," + verificationEntry + "]",
+		"json lines":   loginEntry + "\n" + verificationEntry + "\n",
+		"json objects": " " + loginEntry + verificationEntry,
+	} {
+		t.Run("ingests the logs of "+name, func(t *testing.T) {
+			service := &fakeLogStreamService{}
+
+			res := serve(t, service, http.MethodPost, body)
+			assert.Equal(t, http.StatusOK, res.Code)
+
+			require.Len(t, service.logs, 2)
+			assert.Equal(t, entities.Auth0Log{
+				ID:         "90020241203151500000000000000000000000000000000001",
+				Type:       entities.LogTypeSuccessLogin,
+				Date:       time.Date(2024, 12, 3, 15, 15, 0, 0, time.UTC),
+				Connection: "Username-Password-Authentication",
+				ClientID:   "client-1",
+				ClientName: "Career Cue",
+				IP:         "203.0.113.7",
+				UserAgent:  "Chrome 131.0.0 / Mac OS X 10.15.7",
+				UserID:     "auth0|user-1",
+				UserName:   "user@example.com",
+			}, service.logs[0])
+			assert.Equal(t, entities.LogTypeSuccessVerificationEmail, service.logs[1].Type)
+		})
+	}
+
+	t.Run("rejects malformed batches", func(t *testing.T) {
+		service := &fakeLogStreamService{}
+
+		assert.Equal(t, http.StatusBadRequest, serve(t, service, http.MethodPost, "").Code)
+		assert.Equal(t, http.StatusBadRequest, serve(t, service, http.MethodPost, "[{").Code)
+		assert.Equal(t, http.StatusBadRequest, serve(t, service, http.MethodPost, `[{"data":{"type":"s"}}]`).Code)
+		assert.Empty(t, service.logs)
+	})
+
+	t.Run("asks Auth0 to retry when the logs cannot be ingested", func(t *testing.T) {
+		service := &fakeLogStreamService{err: errors.New("broker unavailable")}
+
+		assert.Equal(t, http.StatusServiceUnavailable, serve(t, service, http.MethodPost, "["+loginEntry+"]").Code)
+	})
+
+	t.Run("only accepts posts", func(t *testing.T) {
+		assert.Equal(t, http.StatusMethodNotAllowed, serve(t, &fakeLogStreamService{}, http.MethodGet, "").Code)
+	})
+}
@@ -0,0 +1,95 @@
+package identity
+
+import (
+	"apps/services/inbound-webhooks-api/internal/app/ports"
+	"context"
+	"errors"
+	"fmt"
+	"libs/backend/auth"
+	"libs/backend/auth/m2m"
+	"libs/backend/boot"
+	"log/slog"
+)
+
+// Assertion of the proper interface
+var _ ports.IdentityProvider = (*Auth0IdentityProvider)(nil)
+
+// Auth0IdentityProvider resolves the common ids from the store the
+// registrations link them in, and from the app metadata of the Auth0 users
+// registered before. A user keeps the common id it was linked to, so the
+// resolved ids are stored as well, which spares the rate limit of the
+// Management API and resolves the users once Auth0 deleted them.
+type Auth0IdentityProvider struct {
+	Logger     boot.Logger
+	Management auth.ManagementClient
+	CommonIDs  ports.CommonIDStore
+}
+
+// NewAuth0IdentityProvider will return the identity provider of the Management API client
+func NewAuth0IdentityProvider(logger boot.Logger, management auth.ManagementClient, commonIDs ports.CommonIDStore) *Auth0IdentityProvider {
+	return &Auth0IdentityProvider{
+		Logger:     logger,
+		Management: management,
+		CommonIDs:  commonIDs,
+	}
+}
+
+// NewManagementClient returns the Auth0 Management API client authorized by the
+// token source, or the in-memory stand-in when there is no Auth0 tenant
+func NewManagementClient(domain string, tokenSource *m2m.TokenSource, inMemory bool) (auth.ManagementClient, error) {
+	if inMemory {
+		return auth.NewInMemoryManagement(), nil
+	}
+
+	management, err := auth.NewManagement(auth.ManagementParams{
+		Domain:      domain,
+		TokenSource: tokenSource,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cannot create auth0 Management API client: %w", err)
+	}
+
+	return management, nil
+}
+
+// LinkCommonID stores the common id the user registered with
+func (p *Auth0IdentityProvider) LinkCommonID(ctx context.Context, userID, commonID string) error {
+	if err := p.CommonIDs.Save(ctx, userID, commonID); err != nil {
+		return fmt.Errorf("cannot link common id: %w", err)
+	}
+
+	return nil
+}
+
+// ResolveCommonID returns the stored common id of the user, or the common id
+// of the app metadata of the user for users registered before the store
+func (p *Auth0IdentityProvider) ResolveCommonID(ctx context.Context, userID string) (string, error) {
+	commonID, err := p.CommonIDs.Get(ctx, userID)
+	if err != nil {
+		return "", fmt.Errorf("cannot get stored common id: %w", err)
+	}
+	if commonID != "" {
+		return commonID, nil
+	}
+
+	user, err := p.Management.GetUser(ctx, userID)
+	if errors.Is(err, auth.ErrUserNotFound) {
+		p.Logger.Warn("Auth0 user not found", slog.String("userID", userID))
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("cannot get auth0 user: %w", err)
+	}
+
+	commonID = user.CommonID()
+	if commonID == "" {
+		p.Logger.Warn("Auth0 user not linked to an account", slog.String("userID", userID))
+		return "", nil
+	}
+
+	if err := p.CommonIDs.Save(ctx, userID, commonID); err != nil {
+		return "", fmt.Errorf("cannot store common id: %w", err)
+	}
+
+	return commonID, nil
+}
@@ -0,0 +1,56 @@
+package identity_test
+
+import (
+	"apps/services/inbound-webhooks-api/internal/adapters/identity"
+	"context"
+	"libs/backend/auth"
+	"libs/backend/boot"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuth0IdentityProviderResolveCommonID(t *testing.T) {
+	ctx := context.Background()
+	const commonID = "0b0d2f5e-7f55-4a4c-9f55-1bde1f4d1c11"
+
+	management := auth.NewInMemoryManagement(auth.WithUsers(
+		auth.User{ID: "auth0|linked", AppMetadata: map[string]any{auth.CommonIDKey: commonID}},
+		auth.User{ID: "auth0|unlinked"},
+	))
+	provider := identity.NewAuth0IdentityProvider(boot.NewSlogger(), management, identity.NewMemoryCommonIDStore())
+
+	t.Run("resolves the common id of the app metadata", func(t *testing.T) {
+		resolved, err := provider.ResolveCommonID(ctx, "auth0|linked")
+		require.NoError(t, err)
+		assert.Equal(t, commonID, resolved)
+	})
+
+	t.Run("keeps the resolved common id of a deleted user", func(t *testing.T) {
+		require.NoError(t, management.DeleteUser(ctx, "auth0|linked"))
+
+		resolved, err := provider.ResolveCommonID(ctx, "auth0|linked")
+		require.NoError(t, err)
+		assert.Equal(t, commonID, resolved)
+	})
+
+	t.Run("resolves the common id linked at the registration of a deleted user", func(t *testing.T) {
+		// Deleted in Auth0 before any of its events was resolved
+		require.NoError(t, provider.LinkCommonID(ctx, "auth0|registered", commonID))
+
+		resolved, err := provider.ResolveCommonID(ctx, "auth0|registered")
+		require.NoError(t, err)
+		assert.Equal(t, commonID, resolved)
+	})
+
+	t.Run("resolves no common id for unlinked and unknown users", func(t *testing.T) {
+		resolved, err := provider.ResolveCommonID(ctx, "auth0|unlinked")
+		require.NoError(t, err)
+		assert.Empty(t, resolved)
+
+		resolved, err = provider.ResolveCommonID(ctx, "auth0|unknown")
+		require.NoError(t, err)
+		assert.Empty(t, resolved)
+	})
+}
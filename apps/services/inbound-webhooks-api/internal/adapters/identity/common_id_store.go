@@ -0,0 +1,95 @@
+package identity
+
+import (
+	"apps/services/inbound-webhooks-api/internal/app/ports"
+	"apps/services/inbound-webhooks-api/internal/models"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Assertion of the proper interface
+var (
+	_ ports.CommonIDStore = (*MemoryCommonIDStore)(nil)
+	_ ports.CommonIDStore = (*DatabaseCommonIDStore)(nil)
+)
+
+// maxMemoryUsers bounds the common ids kept in memory, the store is emptied
+// once it is full
+const maxMemoryUsers = 10000
+
+// MemoryCommonIDStore keeps the common ids in memory of a single replica, the
+// users linked by other replicas or before a restart are resolved from Auth0.
+// Services running several replicas use the DatabaseCommonIDStore.
+type MemoryCommonIDStore struct {
+	mu        sync.Mutex
+	commonIDs map[string]string
+}
+
+// NewMemoryCommonIDStore constructs the empty memory store
+func NewMemoryCommonIDStore() *MemoryCommonIDStore {
+	return &MemoryCommonIDStore{commonIDs: make(map[string]string)}
+}
+
+// Save stores the common id of the user
+func (s *MemoryCommonIDStore) Save(_ context.Context, userID, commonID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.commonIDs) >= maxMemoryUsers {
+		clear(s.commonIDs)
+	}
+	s.commonIDs[userID] = commonID
+
+	return nil
+}
+
+// Get returns the stored common id of the user, it is empty when none is stored
+func (s *MemoryCommonIDStore) Get(_ context.Context, userID string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.commonIDs[userID], nil
+}
+
+// DatabaseCommonIDStore keeps the common ids in the database, which every
+// replica shares and which keeps them after Auth0 deleted the users
+type DatabaseCommonIDStore struct {
+	db *gorm.DB
+}
+
+// NewDatabaseCommonIDStore constructs the database store
+func NewDatabaseCommonIDStore(db *gorm.DB) *DatabaseCommonIDStore {
+	return &DatabaseCommonIDStore{db: db}
+}
+
+// Save stores the common id of the user
+func (s *DatabaseCommonIDStore) Save(ctx context.Context, userID, commonID string) error {
+	err := s.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"common_id", "updated_at"}),
+	}).Create(&models.IdentityUser{UserID: userID, CommonID: commonID}).Error
+	if err != nil {
+		return fmt.Errorf("cannot save identity user: %w", err)
+	}
+
+	return nil
+}
+
+// Get returns the stored common id of the user, it is empty when none is stored
+func (s *DatabaseCommonIDStore) Get(ctx context.Context, userID string) (string, error) {
+	var user models.IdentityUser
+	err := s.db.WithContext(ctx).Where("user_id = ?", userID).Take(&user).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("cannot get identity user: %w", err)
+	}
+
+	return user.CommonID, nil
+}
@@ -0,0 +1,87 @@
+package verification
+
+import (
+	"apps/services/inbound-webhooks-api/internal/app/ports"
+	"apps/services/inbound-webhooks-api/internal/models"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Assertion of the proper interface
+var _ ports.ReplayStore = (*DatabaseReplayStore)(nil)
+
+// DatabaseReplayStore remembers the delivery ids in the database, which
+// protects every replica of the service against replays
+type DatabaseReplayStore struct {
+	db  *gorm.DB
+	now func() time.Time
+
+	mu        sync.Mutex
+	nextPrune time.Time
+}
+
+// NewDatabaseReplayStore constructs the database replay store, now defaults to time.Now
+func NewDatabaseReplayStore(db *gorm.DB, now func() time.Time) *DatabaseReplayStore {
+	if now == nil {
+		now = time.Now
+	}
+
+	return &DatabaseReplayStore{
+		db:  db,
+		now: now,
+	}
+}
+
+// Remember stores the delivery id until it expires, it returns false when
+// the id is already stored. The primary key decides between concurrent
+// deliveries of the id, an expired id is taken over by the new delivery.
+func (s *DatabaseReplayStore) Remember(ctx context.Context, deliveryID string, expiresAt time.Time) (bool, error) {
+	now := s.now()
+	if err := s.prune(ctx, now); err != nil {
+		return false, err
+	}
+
+	result := s.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "id"}},
+		DoUpdates: clause.Assignments(map[string]any{"expires_at": expiresAt}),
+		Where: clause.Where{Exprs: []clause.Expression{
+			clause.Lte{Column: clause.Column{Table: "inbound_webhook_deliveries", Name: "expires_at"}, Value: now},
+		}},
+	}).Create(&models.InboundWebhookDelivery{ID: deliveryID, ExpiresAt: expiresAt})
+	if result.Error != nil {
+		return false, fmt.Errorf("cannot remember webhook delivery: %w", result.Error)
+	}
+
+	return result.RowsAffected == 1, nil
+}
+
+// Forget removes the delivery id
+func (s *DatabaseReplayStore) Forget(ctx context.Context, deliveryID string) error {
+	if err := s.db.WithContext(ctx).Delete(&models.InboundWebhookDelivery{}, "id = ?", deliveryID).Error; err != nil {
+		return fmt.Errorf("cannot forget webhook delivery: %w", err)
+	}
+
+	return nil
+}
+
+// prune deletes the expired delivery ids at most once a minute per replica
+func (s *DatabaseReplayStore) prune(ctx context.Context, now time.Time) error {
+	s.mu.Lock()
+	if now.Before(s.nextPrune) {
+		s.mu.Unlock()
+		return nil
+	}
+	s.nextPrune = now.Add(time.Minute)
+	s.mu.Unlock()
+
+	if err := s.db.WithContext(ctx).Delete(&models.InboundWebhookDelivery{}, "expires_at <= ?", now).Error; err != nil {
+		return fmt.Errorf("cannot prune webhook deliveries: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,73 @@
+package verification
+
+import (
+	"apps/services/inbound-webhooks-api/internal/app/ports"
+	"context"
+	"sync"
+	"time"
+)
+
+// Assertion of the proper interface
+var _ ports.ReplayStore = (*MemoryReplayStore)(nil)
+
+// MemoryReplayStore remembers the delivery ids in memory, which protects a
+// single replica against replays. Services running several replicas use the
+// DatabaseReplayStore.
+type MemoryReplayStore struct {
+	mu         sync.Mutex
+	deliveries map[string]time.Time
+	nextPrune  time.Time
+	now        func() time.Time
+}
+
+// NewMemoryReplayStore constructs the in-memory replay store, now defaults to time.Now
+func NewMemoryReplayStore(now func() time.Time) *MemoryReplayStore {
+	if now == nil {
+		now = time.Now
+	}
+
+	return &MemoryReplayStore{
+		deliveries: make(map[string]time.Time),
+		now:        now,
+	}
+}
+
+// Remember stores the delivery id until it expires, it returns false when
+// the id is already stored
+func (s *MemoryReplayStore) Remember(_ context.Context, deliveryID string, expiresAt time.Time) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.prune(now)
+
+	if storedUntil, ok := s.deliveries[deliveryID]; ok && now.Before(storedUntil) {
+		return false, nil
+	}
+
+	s.deliveries[deliveryID] = expiresAt
+	return true, nil
+}
+
+// Forget removes the delivery id
+func (s *MemoryReplayStore) Forget(_ context.Context, deliveryID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.deliveries, deliveryID)
+	return nil
+}
+
+// prune drops the expired delivery ids at most once a minute
+func (s *MemoryReplayStore) prune(now time.Time) {
+	if now.Before(s.nextPrune) {
+		return
+	}
+
+	for id, expiresAt := range s.deliveries {
+		if !now.Before(expiresAt) {
+			delete(s.deliveries, id)
+		}
+	}
+	s.nextPrune = now.Add(time.Minute)
+}
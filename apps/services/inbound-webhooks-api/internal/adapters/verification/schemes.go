@@ -0,0 +1,233 @@
+package verification
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Names of the signature schemes
+const (
+	SchemeHMAC             = "hmac"
+	SchemeBearer           = "bearer"
+	SchemeStripe           = "stripe"
+	SchemeStandardWebhooks = "standard-webhooks"
+)
+
+// Headers of the signature schemes
+const (
+	SignatureHeader          = "X-Webhook-Signature"
+	StripeSignatureHeader    = "Stripe-Signature"
+	StandardWebhookID        = "Webhook-Id"
+	StandardWebhookTimestamp = "Webhook-Timestamp"
+	StandardWebhookSignature = "Webhook-Signature"
+)
+
+// standardWebhooksSecretPrefix prefixes the base64 encoded Standard Webhooks secrets
+const standardWebhooksSecretPrefix = "whsec_"
+
+// Delivery identifies a verified webhook delivery
+type Delivery struct {
+	// ID identifies the delivery for the replay protection, it is empty when
+	// the scheme carries no id
+	ID string
+
+	// Timestamp is the signed time the delivery was sent, it is zero when the
+	// scheme signs no time
+	Timestamp time.Time
+}
+
+// Scheme verifies the signature of the webhooks of a sender, each scheme
+// accepts any of its active secrets so secrets can be rotated
+type Scheme interface {
+	// Verify returns the delivery of the signed request
+	Verify(header http.Header, body []byte) (Delivery, error)
+}
+
+// NewScheme returns the scheme of the name with the active secrets
+func NewScheme(name string, secrets []string) (Scheme, error) {
+	if len(secrets) == 0 {
+		return nil, fmt.Errorf("scheme %s needs at least one secret", name)
+	}
+
+	switch name {
+	case SchemeHMAC:
+		return HMACScheme{Header: SignatureHeader, Secrets: secrets}, nil
+	case SchemeStripe:
+		return HMACScheme{Header: StripeSignatureHeader, Secrets: secrets}, nil
+	case SchemeBearer:
+		return BearerScheme{Secrets: secrets}, nil
+	case SchemeStandardWebhooks:
+		return NewStandardWebhooksScheme(secrets)
+	}
+
+	return nil, fmt.Errorf("unknown webhook signature scheme %q", name)
+}
+
+// HMACScheme verifies HMAC-SHA256 signatures of the timestamp and the body,
+// sent as `t=<unix seconds>,v1=<hex signature>` with a v1 per active secret of
+// the sender. Stripe signs its webhooks with this scheme. The scheme signs no
+// delivery id, so the signature itself identifies the delivery for the replay
+// protection.
+type HMACScheme struct {
+	Header  string
+	Secrets []string
+}
+
+// Verify returns the delivery of the signed request
+func (s HMACScheme) Verify(header http.Header, body []byte) (Delivery, error) {
+	value := header.Get(s.Header)
+	if value == "" {
+		return Delivery{}, ErrMissingSignature
+	}
+
+	var timestamp string
+	var signatures [][]byte
+	for _, part := range strings.Split(value, ",") {
+		key, val, _ := strings.Cut(strings.TrimSpace(part), "=")
+		switch key {
+		case "t":
+			timestamp = val
+		case "v1":
+			if signature, err := hex.DecodeString(val); err == nil {
+				signatures = append(signatures, signature)
+			}
+		}
+	}
+	if timestamp == "" || len(signatures) == 0 {
+		return Delivery{}, fmt.Errorf("%w: malformed %s header", ErrInvalidSignature, s.Header)
+	}
+
+	sentAt, err := parseUnixTimestamp(timestamp)
+	if err != nil {
+		return Delivery{}, err
+	}
+
+	payload := make([]byte, 0, len(timestamp)+1+len(body))
+	payload = append(append(append(payload, timestamp...), '.'), body...)
+
+	for _, secret := range s.Secrets {
+		expected := sign([]byte(secret), payload)
+		for _, signature := range signatures {
+			if hmac.Equal(expected, signature) {
+				return Delivery{ID: hex.EncodeToString(expected), Timestamp: sentAt}, nil
+			}
+		}
+	}
+
+	return Delivery{}, ErrInvalidSignature
+}
+
+// BearerScheme verifies a shared secret sent as the bearer token. The secret
+// does not sign the body or a time, so the deliveries cannot be protected
+// against replays and the scheme is meant for senders without signatures.
+type BearerScheme struct {
+	Secrets []string
+}
+
+// Verify returns the delivery of the authorized request
+func (s BearerScheme) Verify(header http.Header, _ []byte) (Delivery, error) {
+	token, ok := strings.CutPrefix(header.Get("Authorization"), "Bearer ")
+	if !ok || token == "" {
+		return Delivery{}, ErrMissingSignature
+	}
+
+	for _, secret := range s.Secrets {
+		if subtle.ConstantTimeCompare([]byte(token), []byte(secret)) == 1 {
+			return Delivery{}, nil
+		}
+	}
+
+	return Delivery{}, ErrInvalidSignature
+}
+
+// StandardWebhooksScheme verifies the signatures of the Standard Webhooks
+// specification, the HMAC-SHA256 of the id, the timestamp and the body
+type StandardWebhooksScheme struct {
+	secrets [][]byte
+}
+
+// NewStandardWebhooksScheme decodes the `whsec_` prefixed base64 secrets,
+// secrets without the prefix are used as is
+func NewStandardWebhooksScheme(secrets []string) (StandardWebhooksScheme, error) {
+	scheme := StandardWebhooksScheme{secrets: make([][]byte, 0, len(secrets))}
+	for _, secret := range secrets {
+		encoded, ok := strings.CutPrefix(secret, standardWebhooksSecretPrefix)
+		if !ok {
+			scheme.secrets = append(scheme.secrets, []byte(secret))
+			continue
+		}
+
+		decoded, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return StandardWebhooksScheme{}, fmt.Errorf("cannot decode standard webhooks secret: %w", err)
+		}
+		scheme.secrets = append(scheme.secrets, decoded)
+	}
+
+	return scheme, nil
+}
+
+// Verify returns the delivery of the signed request
+func (s StandardWebhooksScheme) Verify(header http.Header, body []byte) (Delivery, error) {
+	id := header.Get(StandardWebhookID)
+	timestamp := header.Get(StandardWebhookTimestamp)
+	value := header.Get(StandardWebhookSignature)
+	if id == "" || timestamp == "" || value == "" {
+		return Delivery{}, ErrMissingSignature
+	}
+
+	sentAt, err := parseUnixTimestamp(timestamp)
+	if err != nil {
+		return Delivery{}, err
+	}
+
+	var signatures [][]byte
+	for _, versioned := range strings.Fields(value) {
+		version, encoded, _ := strings.Cut(versioned, ",")
+		if version != "v1" {
+			continue
+		}
+		if signature, err := base64.StdEncoding.DecodeString(encoded); err == nil {
+			signatures = append(signatures, signature)
+		}
+	}
+
+	payload := make([]byte, 0, len(id)+len(timestamp)+2+len(body))
+	payload = append(append(append(append(append(payload, id...), '.'), timestamp...), '.'), body...)
+
+	for _, secret := range s.secrets {
+		expected := sign(secret, payload)
+		for _, signature := range signatures {
+			if hmac.Equal(expected, signature) {
+				return Delivery{ID: id, Timestamp: sentAt}, nil
+			}
+		}
+	}
+
+	return Delivery{}, ErrInvalidSignature
+}
+
+// sign returns the HMAC-SHA256 of the payload
+func sign(secret, payload []byte) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
+
+// parseUnixTimestamp parses the signed unix seconds
+func parseUnixTimestamp(timestamp string) (time.Time, error) {
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: malformed timestamp", ErrInvalidSignature)
+	}
+
+	return time.Unix(seconds, 0), nil
+}
@@ -0,0 +1,223 @@
+// Package verification rejects the inbound webhooks that are not signed by a
+// known sender, before the handlers publish any event
+package verification
+
+import (
+	"apps/services/inbound-webhooks-api/internal/app/ports"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	boot "libs/backend/boot"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"connectrpc.com/connect"
+)
+
+// Defaults of the verifier
+const (
+	DefaultTolerance   = 5 * time.Minute
+	DefaultMaxBodySize = 1 << 20
+)
+
+// Errors of the verification
+var (
+	ErrMissingSignature = errors.New("webhook signature missing")
+	ErrInvalidSignature = errors.New("webhook signature invalid")
+	ErrStaleTimestamp   = errors.New("webhook timestamp outside of tolerance")
+	ErrReplayed         = errors.New("webhook delivery replayed")
+)
+
+// Verifier verifies the signature, the timestamp and the freshness of the
+// inbound webhooks
+type Verifier struct {
+	logger      boot.Logger
+	scheme      Scheme
+	replays     ports.ReplayStore
+	tolerance   time.Duration
+	maxBodySize int64
+	now         func() time.Time
+}
+
+// VerifierParams is a struct to hold the parameters for the Verifier
+type VerifierParams struct {
+	Logger boot.Logger
+	Scheme Scheme
+
+	// ReplayStore remembers the delivery ids, deliveries are not checked for
+	// replays without it
+	ReplayStore ports.ReplayStore
+
+	// Tolerance is how far the signed timestamp may be off the current time
+	// and defaults to DefaultTolerance
+	Tolerance time.Duration
+
+	// MaxBodySize defaults to DefaultMaxBodySize
+	MaxBodySize int64
+
+	// Now returns the current time and defaults to time.Now
+	Now func() time.Time
+}
+
+// NewVerifier constructs the verifier of the scheme
+func NewVerifier(params VerifierParams) (*Verifier, error) {
+	if params.Scheme == nil {
+		return nil, errors.New("verifier needs a signature scheme")
+	}
+	if params.Tolerance == 0 {
+		params.Tolerance = DefaultTolerance
+	}
+	if params.MaxBodySize == 0 {
+		params.MaxBodySize = DefaultMaxBodySize
+	}
+	if params.Now == nil {
+		params.Now = time.Now
+	}
+
+	return &Verifier{
+		logger:      params.Logger,
+		scheme:      params.Scheme,
+		replays:     params.ReplayStore,
+		tolerance:   params.Tolerance,
+		maxBodySize: params.MaxBodySize,
+		now:         params.Now,
+	}, nil
+}
+
+// Verify checks the signature of the webhook and that its delivery is neither
+// stale nor replayed
+func (v *Verifier) Verify(ctx context.Context, header http.Header, body []byte) error {
+	_, err := v.verify(ctx, header, body)
+	return err
+}
+
+// verify verifies the webhook like Verify and returns the delivery id it
+// remembered, which is empty when the delivery is not checked for replays
+func (v *Verifier) verify(ctx context.Context, header http.Header, body []byte) (string, error) {
+	delivery, err := v.scheme.Verify(header, body)
+	if err != nil {
+		return "", err
+	}
+
+	now := v.now()
+	expiresAt := now.Add(v.tolerance)
+	if !delivery.Timestamp.IsZero() {
+		if age := now.Sub(delivery.Timestamp); age > v.tolerance || age < -v.tolerance {
+			return "", fmt.Errorf("%w: sent at %s", ErrStaleTimestamp, delivery.Timestamp.UTC().Format(time.RFC3339))
+		}
+		// Later deliveries of the id are rejected as stale
+		expiresAt = delivery.Timestamp.Add(v.tolerance)
+	}
+
+	if v.replays == nil || delivery.ID == "" {
+		return "", nil
+	}
+
+	fresh, err := v.replays.Remember(ctx, delivery.ID, expiresAt)
+	if err != nil {
+		return "", fmt.Errorf("cannot check webhook delivery for replays: %w", err)
+	}
+	if !fresh {
+		return "", fmt.Errorf("%w: %s", ErrReplayed, delivery.ID)
+	}
+
+	return delivery.ID, nil
+}
+
+// Middleware rejects the unverified webhooks with the unauthenticated Connect
+// error, verified webhooks reach the handler with their body intact. The
+// delivery id is forgotten again when the handler fails, so the retry of the
+// sender is not rejected as a replay.
+func (v *Verifier) Middleware(next http.Handler) http.Handler {
+	errorWriter := connect.NewErrorWriter()
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, v.maxBodySize))
+		if err != nil {
+			_ = errorWriter.Write(w, r, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("cannot read webhook body: %w", err)))
+			return
+		}
+
+		deliveryID, err := v.verify(r.Context(), r.Header, body)
+		if err != nil {
+			v.logger.Warn("Rejected unverified webhook", slog.String("path", r.URL.Path), slog.Any("error", err))
+			_ = errorWriter.Write(w, r, connect.NewError(connect.CodeUnauthenticated, err))
+			return
+		}
+
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		if deliveryID == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		recorder := &statusRecorder{ResponseWriter: w}
+		next.ServeHTTP(recorder, r)
+		if recorder.succeeded() {
+			return
+		}
+
+		// The context of the request may be canceled by now
+		if err := v.replays.Forget(context.WithoutCancel(r.Context()), deliveryID); err != nil {
+			v.logger.Error("Cannot forget failed webhook delivery", slog.String("deliveryId", deliveryID), slog.Any("error", err))
+		}
+	})
+}
+
+// statusRecorder records the status code the handler answers with
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+// WriteHeader records the status code
+func (r *statusRecorder) WriteHeader(status int) {
+	if r.status == 0 {
+		r.status = status
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// Write records the implicit 200 status code
+func (r *statusRecorder) Write(b []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+	return r.ResponseWriter.Write(b)
+}
+
+// Flush supports the streaming responses
+func (r *statusRecorder) Flush() {
+	if flusher, ok := r.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Unwrap exposes the response writer to the http.ResponseController
+func (r *statusRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
+// succeeded reports whether the handler answered with a 2xx status, gRPC
+// answers errors with a 200 status and a non-zero grpc-status
+func (r *statusRecorder) succeeded() bool {
+	status := r.status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	if status < 200 || status >= 300 {
+		return false
+	}
+
+	header := r.Header()
+	for _, key := range []string{"Grpc-Status", http.TrailerPrefix + "Grpc-Status"} {
+		if value := header.Get(key); value != "" && value != "0" {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,229 @@
+package verification_test
+
+import (
+	"apps/services/inbound-webhooks-api/internal/adapters/verification"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	boot "libs/backend/boot"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const body = `{"commonId":"0b6c6d3a-7d7b-4a58-9f38-7c9b1e0e9f11","emailAddress":"user@example.com"}`
+
+func hmacHex(secret, payload string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(payload))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func hmacBase64(secret []byte, payload string) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(payload))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// hmacHeader signs the body like the senders of the HMAC scheme
+func hmacHeader(secret string, sentAt time.Time) http.Header {
+	timestamp := strconv.FormatInt(sentAt.Unix(), 10)
+	header := http.Header{}
+	header.Set(verification.SignatureHeader, fmt.Sprintf("t=%s,v1=%s", timestamp, hmacHex(secret, timestamp+"."+body)))
+	return header
+}
+
+func newVerifier(t *testing.T, scheme verification.Scheme, now time.Time) *verification.Verifier {
+	t.Helper()
+
+	clock := func() time.Time { return now }
+	verifier, err := verification.NewVerifier(verification.VerifierParams{
+		Logger:      boot.NewSlogger(),
+		Scheme:      scheme,
+		ReplayStore: verification.NewMemoryReplayStore(clock),
+		Now:         clock,
+	})
+	require.NoError(t, err)
+
+	return verifier
+}
+
+func TestVerifier(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().Truncate(time.Second)
+
+	t.Run("accepts hmac signatures of every active secret", func(t *testing.T) {
+		scheme, err := verification.NewScheme(verification.SchemeHMAC, []string{"new-secret", "old-secret"})
+		require.NoError(t, err)
+		verifier := newVerifier(t, scheme, now)
+
+		assert.NoError(t, verifier.Verify(ctx, hmacHeader("new-secret", now), []byte(body)))
+		assert.NoError(t, verifier.Verify(ctx, hmacHeader("old-secret", now.Add(-time.Second)), []byte(body)))
+		assert.ErrorIs(t, verifier.Verify(ctx, hmacHeader("retired-secret", now), []byte(body)), verification.ErrInvalidSignature)
+	})
+
+	t.Run("rejects tampered bodies and missing signatures", func(t *testing.T) {
+		scheme, err := verification.NewScheme(verification.SchemeHMAC, []string{"secret"})
+		require.NoError(t, err)
+		verifier := newVerifier(t, scheme, now)
+
+		assert.ErrorIs(t, verifier.Verify(ctx, hmacHeader("secret", now), []byte(strings.ToUpper(body))), verification.ErrInvalidSignature)
+		assert.ErrorIs(t, verifier.Verify(ctx, http.Header{}, []byte(body)), verification.ErrMissingSignature)
+	})
+
+	t.Run("rejects timestamps outside of the tolerance", func(t *testing.T) {
+		scheme, err := verification.NewScheme(verification.SchemeHMAC, []string{"secret"})
+		require.NoError(t, err)
+		verifier := newVerifier(t, scheme, now)
+
+		err = verifier.Verify(ctx, hmacHeader("secret", now.Add(-verification.DefaultTolerance-time.Second)), []byte(body))
+		assert.ErrorIs(t, err, verification.ErrStaleTimestamp)
+
+		err = verifier.Verify(ctx, hmacHeader("secret", now.Add(verification.DefaultTolerance+time.Second)), []byte(body))
+		assert.ErrorIs(t, err, verification.ErrStaleTimestamp)
+	})
+
+	t.Run("rejects replayed deliveries", func(t *testing.T) {
+		scheme, err := verification.NewScheme(verification.SchemeStripe, []string{"secret"})
+		require.NoError(t, err)
+		verifier := newVerifier(t, scheme, now)
+
+		header := hmacHeader("secret", now)
+		header.Set(verification.StripeSignatureHeader, header.Get(verification.SignatureHeader))
+
+		require.NoError(t, verifier.Verify(ctx, header, []byte(body)))
+		assert.ErrorIs(t, verifier.Verify(ctx, header, []byte(body)), verification.ErrReplayed)
+	})
+
+	t.Run("rejects replayed deliveries with unsigned ids", func(t *testing.T) {
+		scheme, err := verification.NewScheme(verification.SchemeHMAC, []string{"secret"})
+		require.NoError(t, err)
+		verifier := newVerifier(t, scheme, now)
+
+		header := hmacHeader("secret", now)
+		header.Set("X-Webhook-Id", "delivery-1")
+		require.NoError(t, verifier.Verify(ctx, header, []byte(body)))
+
+		header.Set("X-Webhook-Id", "delivery-2")
+		assert.ErrorIs(t, verifier.Verify(ctx, header, []byte(body)), verification.ErrReplayed)
+	})
+
+	t.Run("accepts the bearer secrets", func(t *testing.T) {
+		scheme, err := verification.NewScheme(verification.SchemeBearer, []string{"new-secret", "old-secret"})
+		require.NoError(t, err)
+		verifier := newVerifier(t, scheme, now)
+
+		header := http.Header{}
+		header.Set("Authorization", "Bearer old-secret")
+		assert.NoError(t, verifier.Verify(ctx, header, []byte(body)))
+
+		header.Set("Authorization", "Bearer guessed-secret")
+		assert.ErrorIs(t, verifier.Verify(ctx, header, []byte(body)), verification.ErrInvalidSignature)
+	})
+
+	t.Run("verifies standard webhooks signatures", func(t *testing.T) {
+		secret := []byte("standard-webhooks-secret")
+		scheme, err := verification.NewScheme(verification.SchemeStandardWebhooks, []string{"whsec_" + base64.StdEncoding.EncodeToString(secret)})
+		require.NoError(t, err)
+		verifier := newVerifier(t, scheme, now)
+
+		timestamp := strconv.FormatInt(now.Unix(), 10)
+		header := http.Header{}
+		header.Set(verification.StandardWebhookID, "msg_1")
+		header.Set(verification.StandardWebhookTimestamp, timestamp)
+		header.Set(verification.StandardWebhookSignature, "v1,invalid v1,"+hmacBase64(secret, "msg_1."+timestamp+"."+body))
+
+		require.NoError(t, verifier.Verify(ctx, header, []byte(body)))
+		assert.ErrorIs(t, verifier.Verify(ctx, header, []byte(body)), verification.ErrReplayed)
+
+		header.Set(verification.StandardWebhookID, "msg_2")
+		assert.ErrorIs(t, verifier.Verify(ctx, header, []byte(body)), verification.ErrInvalidSignature)
+	})
+
+	t.Run("needs a known scheme with secrets", func(t *testing.T) {
+		_, err := verification.NewScheme(verification.SchemeHMAC, nil)
+		assert.Error(t, err)
+
+		_, err = verification.NewScheme("unsigned", []string{"secret"})
+		assert.Error(t, err)
+	})
+}
+
+func TestVerifierMiddleware(t *testing.T) {
+	now := time.Now()
+	scheme, err := verification.NewScheme(verification.SchemeHMAC, []string{"secret"})
+	require.NoError(t, err)
+
+	var received string
+	handler := newVerifier(t, scheme, now).Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		received = string(b)
+	}))
+
+	t.Run("passes verified webhooks with their body", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/webhooks.inboundwebhooksapi.v1.InboundWebhooksAuthService/UserRegistered", strings.NewReader(body))
+		req.Header = hmacHeader("secret", now)
+		req.Header.Set("Content-Type", "application/json")
+		res := httptest.NewRecorder()
+
+		handler.ServeHTTP(res, req)
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.Equal(t, body, received)
+	})
+
+	t.Run("rejects unverified webhooks as unauthenticated", func(t *testing.T) {
+		received = ""
+		req := httptest.NewRequest(http.MethodPost, "/webhooks.inboundwebhooksapi.v1.InboundWebhooksAuthService/UserRegistered", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		res := httptest.NewRecorder()
+
+		handler.ServeHTTP(res, req)
+		assert.Equal(t, http.StatusUnauthorized, res.Code)
+		assert.Contains(t, res.Body.String(), "unauthenticated")
+		assert.Empty(t, received)
+	})
+}
+
+func TestVerifierMiddlewareReplays(t *testing.T) {
+	now := time.Now()
+	scheme, err := verification.NewScheme(verification.SchemeHMAC, []string{"secret"})
+	require.NoError(t, err)
+
+	status := http.StatusServiceUnavailable
+	calls := 0
+	handler := newVerifier(t, scheme, now).Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(status)
+	}))
+	deliver := func() int {
+		req := httptest.NewRequest(http.MethodPost, "/webhooks.inboundwebhooksapi.v1.InboundWebhooksAuthService/UserRegistered", strings.NewReader(body))
+		req.Header = hmacHeader("secret", now)
+		res := httptest.NewRecorder()
+		handler.ServeHTTP(res, req)
+		return res.Code
+	}
+
+	t.Run("lets the retry of a failed delivery through", func(t *testing.T) {
+		assert.Equal(t, http.StatusServiceUnavailable, deliver())
+
+		status = http.StatusOK
+		assert.Equal(t, http.StatusOK, deliver())
+		assert.Equal(t, 2, calls)
+	})
+
+	t.Run("rejects the replay of a processed delivery", func(t *testing.T) {
+		assert.Equal(t, http.StatusUnauthorized, deliver())
+		assert.Equal(t, 2, calls)
+	})
+}
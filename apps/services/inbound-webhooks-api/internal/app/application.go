@@ -4,12 +4,14 @@ import "apps/services/inbound-webhooks-api/internal/app/ports"
 
 // Application is the main application struct
 type Application struct {
-	AuthService ports.AuthService
+	AuthService      ports.AuthService
+	LogStreamService ports.LogStreamService
 }
 
 // NewApplication creates a new application
-func NewApplication(authService ports.AuthService) Application {
+func NewApplication(authService ports.AuthService, logStreamService ports.LogStreamService) Application {
 	return Application{
-		AuthService: authService,
+		AuthService:      authService,
+		LogStreamService: logStreamService,
 	}
 }
@@ -1,11 +1,39 @@
 package ports
 
 import (
+	"apps/services/inbound-webhooks-api/internal/domain/entities"
+	"context"
 	userEntities "libs/backend/domain/user/entities"
 )
 
 // AuthService will handle auth webhook
 // interactions
 type AuthService interface {
-	RegisterUser(user userEntities.User) error
+	RegisterUser(ctx context.Context, user userEntities.User) error
+}
+
+// LogStreamService will handle the Auth0 Log Stream
+// batches
+type LogStreamService interface {
+	IngestLogs(ctx context.Context, logs []entities.Auth0Log) (int, error)
+}
+
+// IdentityProvider resolves the accounts of the identity provider users
+type IdentityProvider interface {
+	// LinkCommonID remembers the common id the user registered with, so the
+	// events of the user resolve it even after the user was deleted
+	LinkCommonID(ctx context.Context, userID, commonID string) error
+
+	// ResolveCommonID returns the common id of the account linked to the user,
+	// it is empty when the user is unknown or not linked to an account
+	ResolveCommonID(ctx context.Context, userID string) (string, error)
+}
+
+// CommonIDStore keeps the common ids of the identity provider users
+type CommonIDStore interface {
+	// Save stores the common id of the user
+	Save(ctx context.Context, userID, commonID string) error
+
+	// Get returns the stored common id of the user, it is empty when none is stored
+	Get(ctx context.Context, userID string) (string, error)
 }
@@ -0,0 +1,18 @@
+package ports
+
+import (
+	"context"
+	"time"
+)
+
+// ReplayStore remembers the verified webhook deliveries so a captured
+// delivery cannot be sent again
+type ReplayStore interface {
+	// Remember stores the delivery id until it expires, it returns false when
+	// the id is already stored
+	Remember(ctx context.Context, deliveryID string, expiresAt time.Time) (bool, error)
+
+	// Forget removes the delivery id, so the sender can retry a delivery the
+	// handler failed to process
+	Forget(ctx context.Context, deliveryID string) error
+}
@@ -1,50 +1,70 @@
 package usecases
 
 import (
+	"apps/services/inbound-webhooks-api/internal/app/ports"
+	"context"
+	"fmt"
 	boot "libs/backend/boot"
 	userEntities "libs/backend/domain/user/entities"
 	"libs/backend/eventing"
 	accountseventsv1 "libs/backend/proto-gen/go/accounts/accountsevents/v1"
+	"log/slog"
 
 	"github.com/rabbitmq/amqp091-go"
 	"google.golang.org/protobuf/proto"
-	"google.golang.org/protobuf/types/known/anypb"
-	"google.golang.org/protobuf/types/known/structpb"
 )
 
 // AuthService handles all application auth interactions
 type AuthService struct {
 	Logger             boot.Logger
-	AuthEventPublisher boot.AMQPPublisher
+	AuthEventPublisher boot.AMQPConfirmPublisher
+	IdentityProvider   ports.IdentityProvider
 }
 
 // NewAuthService will construct the auth service
-func NewAuthService(logger boot.Logger, amqpPublisher boot.AMQPPublisher) AuthService {
+func NewAuthService(logger boot.Logger, amqpPublisher boot.AMQPConfirmPublisher, identityProvider ports.IdentityProvider) AuthService {
 	return AuthService{
 		Logger:             logger,
 		AuthEventPublisher: amqpPublisher,
+		IdentityProvider:   identityProvider,
 	}
 }
 
 // RegisterUser is an application interface method to handle user registration
-// webhooks
-func (s AuthService) RegisterUser(user userEntities.User) error {
+// webhooks. The event is published as mandatory and confirmed by the broker so
+// the caller can ask Auth0 to retry when it was not delivered to any queue.
+// The common id is linked to the user first, so the log stream events of the
+// user resolve it even once the user is deleted in Auth0.
+func (s AuthService) RegisterUser(ctx context.Context, user userEntities.User) error {
+	if user.IdentityUserID != "" {
+		if err := s.IdentityProvider.LinkCommonID(ctx, user.IdentityUserID, user.CommonID.String()); err != nil {
+			s.Logger.Error("Cannot link the common id to the user", slog.Any("error", err))
+			return fmt.Errorf("cannot link common id: %w", err)
+		}
+	}
+
 	s.Logger.Info("Publishing userRegistered Event")
 
-	metadata := make(map[string]*anypb.Any)
-	for key, val := range user.Metadata {
-		if convertedVal, err := anypb.New(structpb.NewStringValue(val.(string))); err != nil {
-			s.Logger.Debug("Cannnot convert value in struct")
-		} else {
-			metadata[key] = convertedVal
-		}
+	metadata, err := eventing.NewMetadataStruct(user.Metadata)
+	if err != nil {
+		s.Logger.Error("Cannot convert user metadata", slog.Any("error", err))
+		return err
 	}
 
 	// Create and send event
 	userRegisteredEvent := &accountseventsv1.UserRegistered{
-		Username:     user.Username,
-		EmailAddress: user.EmailAddress.String(),
-		CommonId:     user.CommonID.String(),
+		Username:             user.Username,
+		EmailAddress:         user.EmailAddress.String(),
+		CommonId:             user.CommonID.String(),
+		FirstName:            user.FirstName,
+		LastName:             user.LastName,
+		Nickname:             user.Nickname,
+		EmailAddressVerified: user.EmailAddressVerified,
+		PhoneNumber:          user.PhoneNumber,
+		PhoneNumberVerified:  user.PhoneNumberVerified,
+		Strategy:             user.Strategy,
+		Metadata:             metadata,
+		UserId:               user.IdentityUserID,
 	}
 	b, err := proto.Marshal(userRegisteredEvent)
 	if err != nil {
@@ -52,10 +72,18 @@ func (s AuthService) RegisterUser(user userEntities.User) error {
 		return err
 	}
 
-	s.AuthEventPublisher.Publish(eventing.AuthExchange, eventing.GetUserRegisteredRoutingKey(), false, false, amqp091.Publishing{
-		ContentType: "application/x-protobuf",
-		Body:        b,
-	})
+	msg := eventing.WithCommonID(amqp091.Publishing{
+		ContentType:  "application/x-protobuf",
+		Type:         eventing.EventNameUserRegistered.String(),
+		Headers:      eventing.UserRegisteredVersion.Headers(),
+		DeliveryMode: amqp091.Persistent,
+		Body:         b,
+	}, user.CommonID.String())
+
+	if err := s.AuthEventPublisher.PublishWithConfirm(ctx, eventing.AuthExchange, eventing.GetUserRegisteredRoutingKey(), true, msg); err != nil {
+		s.Logger.Error("Cannot publish user registered event", slog.Any("error", err))
+		return fmt.Errorf("cannot publish user registered event: %w", err)
+	}
 
 	return nil
 }
@@ -0,0 +1,153 @@
+package usecases_test
+
+import (
+	"apps/services/inbound-webhooks-api/internal/app/usecases"
+	"context"
+	"errors"
+	boot "libs/backend/boot"
+	"libs/backend/boot/amqptest"
+	userEntities "libs/backend/domain/user/entities"
+	userValueObjects "libs/backend/domain/user/valueobjects"
+	"libs/backend/eventing"
+	accountseventsv1 "libs/backend/proto-gen/go/accounts/accountsevents/v1"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestAuthServiceRegisterUser(t *testing.T) {
+	ctx := context.Background()
+	logger := boot.NewSlogger()
+	queueName := "test-user-registration"
+
+	user := userEntities.NewUser(
+		userEntities.WithCommonID(userValueObjects.NewCommonID()),
+		userEntities.WithEmailAddress(userValueObjects.NewEmailAddress("user@example.com")),
+		userEntities.WithUserUsername("user"),
+	)
+
+	t.Run("publishes the user registered event", func(t *testing.T) {
+		broker := amqptest.NewBroker()
+		defer broker.Close()
+
+		setup := eventing.NewAuthEventSetup(broker, logger)
+		setup.
+			CreateExchange().
+			CreateDeadletter().
+			CreateQueue(queueName).
+			BindQueues([]string{eventing.GetUserRegisteredRoutingKey()}).
+			Complete()
+
+		service := usecases.NewAuthService(logger, broker, fakeIdentityProvider{commonIDs: make(map[string]string)})
+		require.NoError(t, service.RegisterUser(ctx, user))
+
+		messages := broker.Messages(queueName)
+		require.Len(t, messages, 1)
+		assert.Equal(t, eventing.EventNameUserRegistered.String(), messages[0].Type)
+		assert.EqualValues(t, eventing.UserRegisteredVersion, messages[0].Headers[eventing.EventVersionHeader])
+		assert.Equal(t, user.CommonID.String(), messages[0].Headers[eventing.CommonIDHeader])
+
+		var event accountseventsv1.UserRegistered
+		require.NoError(t, proto.Unmarshal(messages[0].Body, &event))
+		assert.Equal(t, user.CommonID.String(), event.CommonId)
+		assert.Equal(t, user.EmailAddress.String(), event.EmailAddress)
+		assert.Equal(t, user.Username, event.Username)
+	})
+
+	t.Run("publishes the profile and the metadata of any type", func(t *testing.T) {
+		broker := amqptest.NewBroker()
+		defer broker.Close()
+
+		setup := eventing.NewAuthEventSetup(broker, logger)
+		setup.
+			CreateExchange().
+			CreateDeadletter().
+			CreateQueue(queueName).
+			BindQueues([]string{eventing.GetUserRegisteredRoutingKey()}).
+			Complete()
+
+		profile := userEntities.NewUser(
+			userEntities.WithCommonID(user.CommonID),
+			userEntities.WithEmailAddress(user.EmailAddress),
+			userEntities.WithUserUsername(user.Username),
+			userEntities.WithUserFirstName("Jane"),
+			userEntities.WithUserLastName("Doe"),
+			userEntities.WithUserNickname("jd"),
+			userEntities.WithEmailAddressVerified(true),
+			userEntities.WithPhoneNumber("+15555550100"),
+			userEntities.WithStrategy("google-oauth2"),
+			userEntities.WithMetadata(map[string]any{
+				"plan":        "pro",
+				"seats":       3,
+				"beta":        true,
+				"interests":   []string{"go", "design"},
+				"preferences": map[string]any{"theme": "dark"},
+			}),
+		)
+
+		service := usecases.NewAuthService(logger, broker, fakeIdentityProvider{commonIDs: make(map[string]string)})
+		require.NoError(t, service.RegisterUser(ctx, profile))
+
+		messages := broker.Messages(queueName)
+		require.Len(t, messages, 1)
+
+		var event accountseventsv1.UserRegistered
+		require.NoError(t, proto.Unmarshal(messages[0].Body, &event))
+		assert.Equal(t, "Jane", event.FirstName)
+		assert.Equal(t, "Doe", event.LastName)
+		assert.Equal(t, "jd", event.Nickname)
+		assert.True(t, event.EmailAddressVerified)
+		assert.Equal(t, "+15555550100", event.PhoneNumber)
+		assert.Equal(t, "google-oauth2", event.Strategy)
+		assert.Equal(t, map[string]any{
+			"plan":        "pro",
+			"seats":       float64(3),
+			"beta":        true,
+			"interests":   []any{"go", "design"},
+			"preferences": map[string]any{"theme": "dark"},
+		}, event.Metadata.AsMap())
+	})
+
+	t.Run("links the common id to the user before publishing", func(t *testing.T) {
+		broker := amqptest.NewBroker()
+		defer broker.Close()
+
+		setup := eventing.NewAuthEventSetup(broker, logger)
+		setup.
+			CreateExchange().
+			CreateDeadletter().
+			CreateQueue(queueName).
+			BindQueues([]string{eventing.GetUserRegisteredRoutingKey()}).
+			Complete()
+
+		registered := userEntities.NewUser(
+			userEntities.WithCommonID(user.CommonID),
+			userEntities.WithEmailAddress(user.EmailAddress),
+			userEntities.WithIdentityUserID("auth0|user"),
+		)
+		identityProvider := fakeIdentityProvider{commonIDs: make(map[string]string)}
+
+		service := usecases.NewAuthService(logger, broker, identityProvider)
+		require.NoError(t, service.RegisterUser(ctx, registered))
+		assert.Equal(t, map[string]string{"auth0|user": user.CommonID.String()}, identityProvider.commonIDs)
+		assert.Len(t, broker.Messages(queueName), 1)
+
+		// Not published when the link fails, so Auth0 retries the registration
+		service = usecases.NewAuthService(logger, broker, fakeIdentityProvider{err: errors.New("db unavailable")})
+		assert.Error(t, service.RegisterUser(ctx, registered))
+		assert.Len(t, broker.Messages(queueName), 1)
+	})
+
+	t.Run("fails when no queue is bound", func(t *testing.T) {
+		broker := amqptest.NewBroker()
+		defer broker.Close()
+
+		setup := eventing.NewAuthEventSetup(broker, logger)
+		setup.CreateExchange().CreateDeadletter().Complete()
+
+		service := usecases.NewAuthService(logger, broker, fakeIdentityProvider{commonIDs: make(map[string]string)})
+		assert.ErrorIs(t, service.RegisterUser(ctx, user), boot.ErrPublishUnroutable)
+	})
+}
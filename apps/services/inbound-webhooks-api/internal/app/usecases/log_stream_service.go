@@ -0,0 +1,145 @@
+package usecases
+
+import (
+	"apps/services/inbound-webhooks-api/internal/app/ports"
+	"apps/services/inbound-webhooks-api/internal/domain/entities"
+	"context"
+	"fmt"
+	boot "libs/backend/boot"
+	"libs/backend/eventing"
+	accountseventsv1 "libs/backend/proto-gen/go/accounts/accountsevents/v1"
+	"log/slog"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// LogStreamService maps the entries of the Auth0 Log Streams to identity events
+type LogStreamService struct {
+	Logger             boot.Logger
+	AuthEventPublisher boot.AMQPConfirmPublisher
+	IdentityProvider   ports.IdentityProvider
+}
+
+// NewLogStreamService will construct the log stream service
+func NewLogStreamService(logger boot.Logger, amqpPublisher boot.AMQPConfirmPublisher, identityProvider ports.IdentityProvider) LogStreamService {
+	return LogStreamService{
+		Logger:             logger,
+		AuthEventPublisher: amqpPublisher,
+		IdentityProvider:   identityProvider,
+	}
+}
+
+// IngestLogs publishes the identity event of every log of a mapped type and
+// returns the number of published events, logs of other types are skipped.
+// The log id is the message id of the event, since Auth0 resends the whole
+// batch when a single event of it cannot be published. The events carry the
+// common id of the account linked to the Auth0 user, so the consumers of the
+// accounts find the account the event is about.
+func (s LogStreamService) IngestLogs(ctx context.Context, logs []entities.Auth0Log) (int, error) {
+	published := 0
+	for _, log := range logs {
+		if !isMapped(log) {
+			continue
+		}
+
+		commonID, err := s.IdentityProvider.ResolveCommonID(ctx, log.UserID)
+		if err != nil {
+			s.Logger.Error("Cannot resolve the account of the Auth0 user", slog.String("logID", log.ID), slog.Any("error", err))
+			return published, fmt.Errorf("cannot resolve common id: %w", err)
+		}
+
+		eventName, version, event, _ := newIdentityEvent(log, commonID)
+
+		msg, err := eventing.NewProtoPublishing(eventName, version, event)
+		if err != nil {
+			return published, err
+		}
+		msg.MessageId = log.ID
+		msg.Timestamp = log.Date
+		if commonID != "" {
+			msg = eventing.WithCommonID(msg, commonID)
+		}
+
+		if err := s.AuthEventPublisher.PublishWithConfirm(ctx, eventing.AuthExchange, eventName.String(), true, msg); err != nil {
+			s.Logger.Error("Cannot publish identity event", slog.String("eventName", eventName.String()), slog.String("logID", log.ID), slog.Any("error", err))
+			return published, fmt.Errorf("cannot publish %s event: %w", eventName, err)
+		}
+		published++
+	}
+
+	s.Logger.Info("Ingested Auth0 logs", slog.Int("logs", len(logs)), slog.Int("published", published))
+
+	return published, nil
+}
+
+// isMapped checks whether the log maps to an identity event
+func isMapped(log entities.Auth0Log) bool {
+	_, _, _, ok := newIdentityEvent(log, "")
+	return ok
+}
+
+// newIdentityEvent maps the log to the identity event of the account, it
+// returns false for the log types without an event or logs without a user
+func newIdentityEvent(log entities.Auth0Log, commonID string) (eventing.EventName, eventing.EventVersion, proto.Message, bool) {
+	if log.UserID == "" {
+		return "", 0, nil, false
+	}
+
+	date := timestamppb.New(log.Date)
+	auth0Log := &accountseventsv1.Auth0Log{
+		LogId:       log.ID,
+		Type:        log.Type,
+		Description: log.Description,
+		ClientId:    log.ClientID,
+		ClientName:  log.ClientName,
+		Connection:  log.Connection,
+		Ip:          log.IP,
+		UserAgent:   log.UserAgent,
+	}
+
+	switch log.Type {
+	case entities.LogTypeSuccessLogin:
+		return eventing.EventNameUserLoggedIn, eventing.UserLoggedInVersion, &accountseventsv1.UserLoggedIn{
+			UserId:       log.UserID,
+			EmailAddress: log.UserName,
+			LoggedInAt:   date,
+			Log:          auth0Log,
+			CommonId:     commonID,
+		}, true
+	case entities.LogTypeSuccessVerificationEmail:
+		return eventing.EventNameUserEmailVerified, eventing.UserEmailVerifiedVersion, &accountseventsv1.UserEmailVerified{
+			UserId:       log.UserID,
+			EmailAddress: log.UserName,
+			VerifiedAt:   date,
+			Log:          auth0Log,
+			CommonId:     commonID,
+		}, true
+	case entities.LogTypeSuccessChangePassword:
+		return eventing.EventNameUserPasswordChanged, eventing.UserPasswordChangedVersion, &accountseventsv1.UserPasswordChanged{
+			UserId:       log.UserID,
+			EmailAddress: log.UserName,
+			ChangedAt:    date,
+			Log:          auth0Log,
+			CommonId:     commonID,
+		}, true
+	case entities.LogTypeBlockedAccount, entities.LogTypeBlockedUserLogins:
+		return eventing.EventNameUserBlocked, eventing.UserBlockedVersion, &accountseventsv1.UserBlocked{
+			UserId:       log.UserID,
+			EmailAddress: log.UserName,
+			BlockedAt:    date,
+			Log:          auth0Log,
+			CommonId:     commonID,
+		}, true
+	case entities.LogTypeSuccessUserDeletion:
+		return eventing.EventNameUserDeletedInIdP, eventing.UserDeletedInIdPVersion, &accountseventsv1.UserDeletedInIdP{
+			UserId:       log.UserID,
+			EmailAddress: log.UserName,
+			DeletedAt:    date,
+			Log:          auth0Log,
+			CommonId:     commonID,
+		}, true
+	}
+
+	return "", 0, nil, false
+}
@@ -0,0 +1,146 @@
+package usecases_test
+
+import (
+	"apps/services/inbound-webhooks-api/internal/app/usecases"
+	"apps/services/inbound-webhooks-api/internal/domain/entities"
+	"context"
+	"errors"
+	boot "libs/backend/boot"
+	"libs/backend/boot/amqptest"
+	"libs/backend/eventing"
+	accountseventsv1 "libs/backend/proto-gen/go/accounts/accountsevents/v1"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+// fakeIdentityProvider links and resolves the common ids of the known users
+type fakeIdentityProvider struct {
+	commonIDs map[string]string
+	err       error
+}
+
+func (p fakeIdentityProvider) LinkCommonID(_ context.Context, userID, commonID string) error {
+	if p.err != nil {
+		return p.err
+	}
+
+	p.commonIDs[userID] = commonID
+	return nil
+}
+
+func (p fakeIdentityProvider) ResolveCommonID(_ context.Context, userID string) (string, error) {
+	return p.commonIDs[userID], p.err
+}
+
+func TestLogStreamServiceIngestLogs(t *testing.T) {
+	ctx := context.Background()
+	logger := boot.NewSlogger()
+	date := time.Date(2024, 12, 3, 15, 15, 0, 0, time.UTC)
+	const commonID = "0b0d2f5e-7f55-4a4c-9f55-1bde1f4d1c11"
+	identityProvider := fakeIdentityProvider{commonIDs: map[string]string{"auth0|user-1": commonID}}
+
+	newBroker := func(t *testing.T) *amqptest.Broker {
+		broker := amqptest.NewBroker()
+		t.Cleanup(func() { _ = broker.Close() })
+
+		setup := eventing.NewAuthEventSetup(broker, logger)
+		setup.CreateExchange().CreateDeadletter().CreateStream().Complete()
+
+		return broker
+	}
+
+	t.Run("publishes the events of the mapped log types", func(t *testing.T) {
+		broker := newBroker(t)
+		service := usecases.NewLogStreamService(logger, broker, identityProvider)
+
+		published, err := service.IngestLogs(ctx, []entities.Auth0Log{
+			{ID: "log-1", Type: entities.LogTypeSuccessLogin, Date: date, UserID: "auth0|user-1", UserName: "user@example.com", IP: "203.0.113.7"},
+			{ID: "log-2", Type: entities.LogTypeSuccessVerificationEmail, Date: date, UserID: "auth0|user-1", UserName: "user@example.com"},
+			{ID: "log-3", Type: "fp", Date: date, UserID: "auth0|user-1"},
+			{ID: "log-4", Type: entities.LogTypeSuccessChangePassword, Date: date, UserID: "auth0|user-1"},
+			{ID: "log-5", Type: entities.LogTypeBlockedAccount, Date: date, UserID: "auth0|user-1"},
+			{ID: "log-6", Type: entities.LogTypeSuccessUserDeletion, Date: date, UserID: "auth0|user-1"},
+			{ID: "log-7", Type: entities.LogTypeSuccessLogin, Date: date},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 5, published)
+
+		messages := broker.Messages(eventing.AuthEventStream)
+		require.Len(t, messages, 5)
+
+		types := make([]string, 0, len(messages))
+		for _, message := range messages {
+			types = append(types, message.Type)
+		}
+		assert.Equal(t, []string{
+			eventing.EventNameUserLoggedIn.String(),
+			eventing.EventNameUserEmailVerified.String(),
+			eventing.EventNameUserPasswordChanged.String(),
+			eventing.EventNameUserBlocked.String(),
+			eventing.EventNameUserDeletedInIdP.String(),
+		}, types)
+
+		assert.Equal(t, "log-1", messages[0].MessageId)
+		var loggedIn accountseventsv1.UserLoggedIn
+		require.NoError(t, proto.Unmarshal(messages[0].Body, &loggedIn))
+		assert.Equal(t, "auth0|user-1", loggedIn.UserId)
+		assert.Equal(t, "user@example.com", loggedIn.EmailAddress)
+		assert.Equal(t, date, loggedIn.LoggedInAt.AsTime())
+		assert.Equal(t, "203.0.113.7", loggedIn.Log.Ip)
+
+		// The events are linked to the account of the user
+		assert.Equal(t, commonID, loggedIn.CommonId)
+		assert.Equal(t, commonID, messages[0].Headers[eventing.CommonIDHeader])
+	})
+
+	t.Run("publishes the events of unlinked users without a common id", func(t *testing.T) {
+		broker := newBroker(t)
+		service := usecases.NewLogStreamService(logger, broker, identityProvider)
+
+		published, err := service.IngestLogs(ctx, []entities.Auth0Log{
+			{ID: "log-1", Type: entities.LogTypeSuccessVerificationEmail, Date: date, UserID: "auth0|unlinked"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 1, published)
+
+		messages := broker.Messages(eventing.AuthEventStream)
+		require.Len(t, messages, 1)
+		assert.NotContains(t, messages[0].Headers, eventing.CommonIDHeader)
+
+		var verified accountseventsv1.UserEmailVerified
+		require.NoError(t, proto.Unmarshal(messages[0].Body, &verified))
+		assert.Empty(t, verified.CommonId)
+	})
+
+	t.Run("fails when the account of a user cannot be resolved", func(t *testing.T) {
+		broker := newBroker(t)
+		errUnavailable := errors.New("management api unavailable")
+		service := usecases.NewLogStreamService(logger, broker, fakeIdentityProvider{err: errUnavailable})
+
+		published, err := service.IngestLogs(ctx, []entities.Auth0Log{
+			{ID: "log-1", Type: "fp", Date: date, UserID: "auth0|user-1"},
+			{ID: "log-2", Type: entities.LogTypeSuccessLogin, Date: date, UserID: "auth0|user-1"},
+		})
+		assert.ErrorIs(t, err, errUnavailable)
+		assert.Zero(t, published)
+		assert.Empty(t, broker.Messages(eventing.AuthEventStream))
+	})
+
+	t.Run("fails when an event cannot be published", func(t *testing.T) {
+		broker := amqptest.NewBroker()
+		defer broker.Close()
+
+		setup := eventing.NewAuthEventSetup(broker, logger)
+		setup.CreateExchange().CreateDeadletter().Complete()
+
+		service := usecases.NewLogStreamService(logger, broker, identityProvider)
+		_, err := service.IngestLogs(ctx, []entities.Auth0Log{
+			{ID: "log-1", Type: entities.LogTypeSuccessLogin, Date: date, UserID: "auth0|user-1"},
+		})
+		assert.ErrorIs(t, err, boot.ErrPublishUnroutable)
+	})
+}
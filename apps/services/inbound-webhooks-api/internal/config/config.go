@@ -1,19 +1,102 @@
 package config
 
 import (
+	"errors"
+	"fmt"
 	"os"
+	"strings"
+	"time"
 )
 
 // Config for the application
 type Config struct {
 	AMQPUrl string
+
+	// Database the delivery ids of the webhooks are shared through by the
+	// replicas, the ids are kept in memory of a single replica without it
+	DBHost     string
+	DBName     string
+	DBUser     string
+	DBPassword string
+	DBPort     string
+	DBSSLMode  string
+	DBTimeZone string
+
+	// WebhookScheme is the signature scheme of the inbound webhooks, one of
+	// hmac, bearer, stripe or standard-webhooks
+	WebhookScheme string
+
+	// WebhookSecrets are the active secrets of the scheme, several secrets
+	// are accepted while a secret is rotated
+	WebhookSecrets []string
+
+	// WebhookTolerance is how far the signed timestamps may be off, it
+	// defaults to the tolerance of the verifier
+	WebhookTolerance time.Duration
+
+	// LogStreamTokens are the active bearer tokens of the Auth0 Log Stream,
+	// the log stream endpoint is only served with a token
+	LogStreamTokens []string
+
+	// Auth0 Management API credentials, the common ids of the users of the
+	// log stream events are resolved from their app metadata
+	Auth0Domain       string
+	Auth0ClientID     string
+	Auth0ClientSecret string
+	Auth0Audience     string
+
+	// Auth0ManagementInMemory replaces the Auth0 Management API with the
+	// in-memory stand-in, e.g. in local development
+	Auth0ManagementInMemory bool
 }
 
 // NewConfig constructs the config
 func NewConfig() (Config, error) {
 	config := Config{
-		AMQPUrl: os.Getenv("AMQP_CONNECTION_URI"),
+		AMQPUrl:        os.Getenv("AMQP_CONNECTION_URI"),
+		DBHost:         os.Getenv("DATABASE_HOST"),
+		DBName:         os.Getenv("DATABASE_NAME"),
+		DBUser:         os.Getenv("DATABASE_USER"),
+		DBPassword:     os.Getenv("DATABASE_PASSWORD"),
+		DBPort:         os.Getenv("DATABASE_PORT"),
+		DBSSLMode:      os.Getenv("DATABASE_SSL_MODE"),
+		DBTimeZone:     os.Getenv("DATABASE_TIMEZONE"),
+		WebhookScheme:  os.Getenv("WEBHOOK_SIGNATURE_SCHEME"),
+		WebhookSecrets: splitSecrets(os.Getenv("WEBHOOK_SECRETS")),
+
+		LogStreamTokens: splitSecrets(os.Getenv("AUTH0_LOG_STREAM_TOKENS")),
+
+		Auth0Domain:       os.Getenv("AUTH0_DOMAIN"),
+		Auth0ClientID:     os.Getenv("AUTH0_CLIENT_ID"),
+		Auth0ClientSecret: os.Getenv("AUTH0_CLIENT_SECRET"),
+		Auth0Audience:     os.Getenv("AUTH0_AUDIENCE"),
+
+		Auth0ManagementInMemory: os.Getenv("AUTH0_MANAGEMENT_IN_MEMORY") == "true",
+	}
+
+	if config.WebhookScheme == "" {
+		return Config{}, errors.New("WEBHOOK_SIGNATURE_SCHEME is required")
+	}
+
+	if tolerance := os.Getenv("WEBHOOK_TOLERANCE"); tolerance != "" {
+		parsed, err := time.ParseDuration(tolerance)
+		if err != nil {
+			return Config{}, fmt.Errorf("cannot parse WEBHOOK_TOLERANCE: %w", err)
+		}
+		config.WebhookTolerance = parsed
 	}
 
 	return config, nil
 }
+
+// splitSecrets splits the comma separated secrets
+func splitSecrets(secrets string) []string {
+	split := make([]string, 0)
+	for _, secret := range strings.Split(secrets, ",") {
+		if secret = strings.TrimSpace(secret); secret != "" {
+			split = append(split, secret)
+		}
+	}
+
+	return split
+}
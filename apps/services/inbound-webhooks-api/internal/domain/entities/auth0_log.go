@@ -0,0 +1,39 @@
+package entities
+
+import "time"
+
+// Auth0 log event types mapped to identity events, see
+// https://auth0.com/docs/deploy-monitor/logs/log-event-type-codes
+const (
+	LogTypeSuccessLogin             = "s"
+	LogTypeSuccessVerificationEmail = "sv"
+	LogTypeSuccessChangePassword    = "scp"
+	LogTypeSuccessUserDeletion      = "sdu"
+
+	// LogTypeBlockedAccount is logged when an IP address reached the maximum
+	// failed logins into an account
+	LogTypeBlockedAccount = "limit_wc"
+
+	// LogTypeBlockedUserLogins is logged when a user reached the maximum
+	// logins per time period from an IP address
+	LogTypeBlockedUserLogins = "limit_sul"
+)
+
+// Auth0Log is an entry of an Auth0 Log Stream
+type Auth0Log struct {
+	ID          string
+	Type        string
+	Date        time.Time
+	Description string
+	Connection  string
+	ClientID    string
+	ClientName  string
+	IP          string
+	UserAgent   string
+
+	// UserID is the Auth0 user id, e.g. auth0|6751...
+	UserID string
+
+	// UserName is the email address of database and social connections
+	UserName string
+}
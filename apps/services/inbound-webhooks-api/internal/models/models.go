@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+// IdentityUser is our model, which corresponds to the "identity_users" table
+type IdentityUser struct {
+	UserID    string `gorm:"primaryKey;"`
+	CommonID  string `gorm:"not null;"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// InboundWebhookDelivery is our model, which corresponds to the "inbound_webhook_deliveries" table
+type InboundWebhookDelivery struct {
+	ID        string    `gorm:"primaryKey;"`
+	ExpiresAt time.Time `gorm:"index:idx_inbound_webhook_delivery_expires_at;not null;"`
+}
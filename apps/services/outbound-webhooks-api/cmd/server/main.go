@@ -0,0 +1,246 @@
+package main
+
+import (
+	connectrpcadapter "apps/services/outbound-webhooks-api/internal/adapters/connectrpc"
+	"apps/services/outbound-webhooks-api/internal/adapters/database/repositories"
+	"apps/services/outbound-webhooks-api/internal/adapters/handlers/messagebroker"
+	"apps/services/outbound-webhooks-api/internal/adapters/webhooks"
+	"apps/services/outbound-webhooks-api/internal/app"
+	"apps/services/outbound-webhooks-api/internal/config"
+	"apps/services/outbound-webhooks-api/internal/domain/services"
+	"apps/services/outbound-webhooks-api/internal/models"
+	"context"
+	"log"
+	"log/slog"
+	"os"
+
+	"connectrpc.com/connect"
+	"connectrpc.com/grpcreflect"
+	"connectrpc.com/validate"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+
+	boot "libs/backend/boot"
+	"libs/backend/eventing"
+	"libs/backend/httpauth"
+	"libs/backend/proto-gen/go/webhooks/outboundwebhooksapi/v1/outboundwebhooksapiv1connect"
+)
+
+func run() error {
+	// Application Context
+	ctx := context.Background()
+
+	// Create logger
+	logger := boot.NewSlogger()
+
+	// Construct config
+	config, err := config.NewConfig()
+	if err != nil {
+		logger.Error("Trouble constructing config")
+		os.Exit(1)
+	}
+
+	// Connect Interceptors
+	validationInterceptor, err := validate.NewInterceptor()
+	if err != nil {
+		logger.Error("Cannot set up validation interceptor", slog.Any("error", err))
+		return err
+	}
+	// Custom interceptors, revoked tokens are rejected from the cache kept up to date by the auth event stream
+	revocationCache := httpauth.NewRevocationCache()
+	accessTokenValidator, err := httpauth.NewJWTValidator(
+		httpauth.WithTrustedIssuer(httpauth.TrustedIssuer{
+			IssuerURL: httpauth.Auth0IssuerURL(config.Auth0Domain),
+			Audiences: []string{config.Auth0Audience},
+			JWKSFile:  config.AuthJWKSFile,
+		}),
+		httpauth.WithRevocationStore(revocationCache),
+	)
+	if err != nil {
+		logger.Error("Cannot set up access token validator", slog.Any("error", err))
+		return err
+	}
+	policy, err := httpauth.LoadPolicyOrDefault(config.AuthPolicyFile)
+	if err != nil {
+		logger.Error("Cannot load the role policy", slog.Any("error", err))
+		return err
+	}
+	authInterceptor := httpauth.NewAuthInterceptor(logger, accessTokenValidator, httpauth.WithPolicy(policy))
+
+	options := []connect.HandlerOption{
+		connect.WithInterceptors(
+			validationInterceptor,
+			authInterceptor.Incoming(),
+		),
+	}
+
+	// The account events of a user are enqueued in order, different users in parallel
+	accountNotificationQueue := eventing.PartitionedQueue{
+		Name:                 config.AccountNotificationQueueName,
+		Exchange:             eventing.AccountsExchange,
+		RoutingKeys:          []string{eventing.GetDomainRoutingKey(eventing.AccountsDomain)},
+		Partitions:           config.AccountNotificationPartitions,
+		DeadletterExchange:   eventing.AccountsDeadletterExchange,
+		DeadletterRoutingKey: eventing.AccountsDeadletterRoutingKey,
+	}
+
+	// Initialize the gRPC Options
+	bootService := boot.
+		NewBuildServiceBuilder().
+		SetServiceName(config.ServiceName).
+		SetLogger(logger).
+		SetDBOptions(boot.DBOptions{
+			Host:     config.DBHost,
+			Name:     config.DBName,
+			User:     config.DBUser,
+			Password: config.DBPassword,
+			Port:     config.DBPort,
+			SSLMode:  config.DBSSLMode,
+			TimeZone: config.DBTimeZone,
+		}).
+		SetAMQPOptions(boot.AMQPOptions{
+			ConnectionURI: config.AMQPUri,
+			OnConnectionCallback: func(params boot.AMQPCallBackParams) error {
+				params.Logger.Info("AMQP connected successfully")
+
+				// Set up the accounts exchanges
+				accountsEventRegisterer := eventing.NewAccountsEventSetup(params.Controller.Registerer, params.Logger)
+				accountsEventRegisterer.
+					CreateExchange().
+					CreateDeadletter().
+					Complete()
+
+				// Partition every accounts event by user, so the deliveries of a
+				// user are enqueued in publish order
+				if err := accountNotificationQueue.Declare(params.Controller.Registerer); err != nil {
+					params.Logger.Error("Cannot set up the account notification partitions", slog.Any("error", err))
+					return err
+				}
+
+				// Set up the auth event stream the session revocations are followed from
+				authEventRegisterer := eventing.NewAuthEventSetup(params.Controller.Registerer, params.Logger)
+				authEventRegisterer.
+					CreateExchange().
+					CreateDeadletter().
+					CreateStream().
+					Complete()
+
+				params.Logger.Info("Set up all AMQP queues and exchanges")
+
+				return nil
+			},
+			Handlers: []boot.AMQPHandler{
+				func(hp boot.AMQPHandlerParams) error {
+					// Keep the revocation cache up to date
+					return eventing.FollowSessionRevocations(ctx, hp.AMQPController.Consumer, hp.Logger, revocationCache, httpauth.DefaultMaxTokenLifetime)
+				},
+				func(hp boot.AMQPHandlerParams) error {
+					// Initialize services
+					endpointRepository := repositories.NewEndpointRepository(logger, hp.DB)
+					deliveryService := services.NewDeliveryService(services.DeliveryServiceParams{
+						Logger:         logger,
+						Endpoints:      endpointRepository,
+						Deliveries:     repositories.NewDeliveryRepository(logger, hp.DB),
+						Sender:         webhooks.NewHTTPSender(webhooks.DefaultSendTimeout),
+						AttemptTimeout: webhooks.DefaultSendTimeout,
+					})
+
+					// Initialize the application
+					application := app.NewApp(app.WithDeliveryService(deliveryService))
+
+					// Initialize the event dispatcher
+					dispatcher, err := eventing.NewDispatcher(logger)
+					if err != nil {
+						return err
+					}
+
+					// Initialize the message broker handler
+					handler := messagebroker.NewLavinMQHandler(
+						logger,
+						hp.AMQPController.Consumer,
+						dispatcher,
+						application,
+					)
+
+					// Deliver and retry the pending deliveries
+					go deliveryService.Run(ctx, services.DefaultDeliveryPollInterval)
+
+					// Enqueue the deliveries of the account events
+					if err := handler.HandleAccountEvents(ctx, accountNotificationQueue); err != nil {
+						hp.Logger.Error("Cannot handle account events", slog.Any("error", err))
+					}
+
+					return nil
+				},
+			},
+		}).
+		SetConnectRPCOptions(boot.ConnectRPCOptions{
+			Port: 3000,
+			TransportCredentials: []credentials.TransportCredentials{
+				insecure.NewCredentials(),
+			},
+			Handlers: []boot.ConnectRPCHandler{
+				func(params boot.ConnectRPCHandlerParams) error {
+					// Create the endpoint and delivery services
+					endpointRepository := repositories.NewEndpointRepository(params.Logger, params.DB)
+					endpointService := services.NewEndpointService(services.EndpointServiceParams{
+						Logger:     params.Logger,
+						Repository: endpointRepository,
+					})
+					deliveryService := services.NewDeliveryService(services.DeliveryServiceParams{
+						Logger:     params.Logger,
+						Endpoints:  endpointRepository,
+						Deliveries: repositories.NewDeliveryRepository(params.Logger, params.DB),
+					})
+
+					// Create Application
+					application := app.NewApp(
+						app.WithEndpointService(endpointService),
+						app.WithDeliveryService(deliveryService),
+					)
+
+					// Assign the handler to the HTTP path
+					path, httpHandler := outboundwebhooksapiv1connect.NewOutboundWebhooksServiceHandler(
+						connectrpcadapter.NewOutboundWebhooksHandler(params.Logger, application),
+						options...,
+					)
+
+					// HTTP Handlers and reflection registered with Mux
+					params.Mux.Handle(path, httpHandler)
+					reflector := grpcreflect.NewStaticReflector(outboundwebhooksapiv1connect.OutboundWebhooksServiceName)
+					params.Mux.Handle(grpcreflect.NewHandlerV1(reflector))
+					params.Mux.Handle(grpcreflect.NewHandlerV1Alpha(reflector))
+
+					return nil
+				},
+			},
+		}).
+		SetDBReadyCallbacks([]boot.BootCallback{
+			func(params boot.BootCallbackParams) error {
+				// Run DB migrations before the consumers and the delivery poller start
+				if err := params.DB.AutoMigrate(&models.WebhookEndpoint{}, &models.WebhookDelivery{}, &models.WebhookDeliveryAttempt{}); err != nil {
+					params.Logger.Error("Failed to run DB migrations", slog.Any("error", err))
+					return err
+				}
+
+				params.Logger.Info("Ran DB migrations", slog.String("serviceName", config.ServiceName))
+				return nil
+			},
+		}).
+		SetBootCallbacks([]boot.BootCallback{
+			func(params boot.BootCallbackParams) error {
+				params.Logger.Info("Service booted successfully", slog.String("serviceName", config.ServiceName))
+				return nil
+			},
+		}).
+		Build()
+
+	return bootService.Start(ctx)
+}
+
+func main() {
+	if err := run(); err != nil {
+		log.Printf("Cannot start service")
+		os.Exit(1)
+	}
+}
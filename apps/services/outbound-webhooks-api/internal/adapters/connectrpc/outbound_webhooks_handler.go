@@ -0,0 +1,388 @@
+package connectrpc
+
+import (
+	"apps/services/outbound-webhooks-api/internal/app"
+	"apps/services/outbound-webhooks-api/internal/app/ports"
+	"apps/services/outbound-webhooks-api/internal/domain/entities"
+	"apps/services/outbound-webhooks-api/internal/domain/services"
+	"context"
+	"errors"
+	"libs/backend/boot"
+	userValueObjects "libs/backend/domain/user/valueobjects"
+	"libs/backend/httpauth"
+	commonv1 "libs/backend/proto-gen/go/common/v1"
+	outboundwebhooksapiv1 "libs/backend/proto-gen/go/webhooks/outboundwebhooksapi/v1"
+	"libs/backend/proto-gen/go/webhooks/outboundwebhooksapi/v1/outboundwebhooksapiv1connect"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// defaultPageSize is used when the caller does not provide a page size
+const defaultPageSize = 25
+
+// endpointStates maps the endpoint states to the proto enum
+var endpointStates = map[entities.EndpointState]outboundwebhooksapiv1.EndpointState{
+	entities.EndpointStateEnabled:  outboundwebhooksapiv1.EndpointState_ENDPOINT_STATE_ENABLED,
+	entities.EndpointStateDisabled: outboundwebhooksapiv1.EndpointState_ENDPOINT_STATE_DISABLED,
+}
+
+// deliveryStatuses maps the delivery statuses to the proto enum
+var deliveryStatuses = map[entities.DeliveryStatus]outboundwebhooksapiv1.DeliveryStatus{
+	entities.DeliveryStatusPending:   outboundwebhooksapiv1.DeliveryStatus_DELIVERY_STATUS_PENDING,
+	entities.DeliveryStatusSucceeded: outboundwebhooksapiv1.DeliveryStatus_DELIVERY_STATUS_SUCCEEDED,
+	entities.DeliveryStatusFailed:    outboundwebhooksapiv1.DeliveryStatus_DELIVERY_STATUS_FAILED,
+}
+
+// OutboundWebhooksHandler handles all gRPC endpoints to manage webhook endpoints and deliveries
+type OutboundWebhooksHandler struct {
+	outboundwebhooksapiv1connect.UnimplementedOutboundWebhooksServiceHandler
+
+	// Logger is the logger from the boot framework
+	Logger boot.Logger
+
+	// App is the application
+	App app.App
+}
+
+// NewOutboundWebhooksHandler will return a pointer to the outbound webhooks handler
+func NewOutboundWebhooksHandler(logger boot.Logger, app app.App) *OutboundWebhooksHandler {
+	return &OutboundWebhooksHandler{
+		Logger: logger,
+		App:    app,
+	}
+}
+
+// CreateEndpoint registers an endpoint and returns its signing secret once
+func (h *OutboundWebhooksHandler) CreateEndpoint(
+	ctx context.Context,
+	req *connect.Request[outboundwebhooksapiv1.CreateEndpointRequest],
+) (*connect.Response[outboundwebhooksapiv1.CreateEndpointResponse], error) {
+	if err := httpauth.AuthorizeOwnerFromContext(ctx, req.Msg.CommonId); err != nil {
+		return nil, connect.NewError(connect.CodePermissionDenied, err)
+	}
+
+	endpoint, err := h.App.EndpointService.CreateEndpoint(
+		ctx,
+		userValueObjects.NewCommonIDFromString(req.Msg.CommonId),
+		req.Msg.Url,
+		req.Msg.Description,
+		req.Msg.EventTypes,
+	)
+	if err != nil {
+		return nil, convertError(err)
+	}
+
+	return connect.NewResponse(&outboundwebhooksapiv1.CreateEndpointResponse{
+		Endpoint:      convertEndpointToProto(endpoint),
+		SigningSecret: endpoint.SigningSecret,
+	}), nil
+}
+
+// GetEndpoint returns an endpoint
+func (h *OutboundWebhooksHandler) GetEndpoint(
+	ctx context.Context,
+	req *connect.Request[outboundwebhooksapiv1.GetEndpointRequest],
+) (*connect.Response[outboundwebhooksapiv1.GetEndpointResponse], error) {
+	id, err := parseID(req.Msg.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint, err := h.authorizeEndpoint(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return connect.NewResponse(&outboundwebhooksapiv1.GetEndpointResponse{Endpoint: convertEndpointToProto(endpoint)}), nil
+}
+
+// ListEndpoints returns the endpoints of a user
+func (h *OutboundWebhooksHandler) ListEndpoints(
+	ctx context.Context,
+	req *connect.Request[outboundwebhooksapiv1.ListEndpointsRequest],
+) (*connect.Response[outboundwebhooksapiv1.ListEndpointsResponse], error) {
+	if err := httpauth.AuthorizeOwnerFromContext(ctx, req.Msg.CommonId); err != nil {
+		return nil, connect.NewError(connect.CodePermissionDenied, err)
+	}
+
+	endpoints, err := h.App.EndpointService.ListEndpoints(ctx, userValueObjects.NewCommonIDFromString(req.Msg.CommonId))
+	if err != nil {
+		return nil, convertError(err)
+	}
+
+	resp := &outboundwebhooksapiv1.ListEndpointsResponse{
+		Endpoints: make([]*outboundwebhooksapiv1.Endpoint, 0, len(endpoints)),
+	}
+	for _, endpoint := range endpoints {
+		resp.Endpoints = append(resp.Endpoints, convertEndpointToProto(endpoint))
+	}
+
+	return connect.NewResponse(resp), nil
+}
+
+// UpdateEndpoint changes the provided fields of an endpoint
+func (h *OutboundWebhooksHandler) UpdateEndpoint(
+	ctx context.Context,
+	req *connect.Request[outboundwebhooksapiv1.UpdateEndpointRequest],
+) (*connect.Response[outboundwebhooksapiv1.UpdateEndpointResponse], error) {
+	update := entities.EndpointUpdate{
+		URL:         req.Msg.Url,
+		Description: req.Msg.Description,
+	}
+	if req.Msg.EventTypes != nil {
+		eventTypes := req.Msg.EventTypes.GetValues()
+		update.EventTypes = &eventTypes
+	}
+
+	id, err := parseID(req.Msg.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := h.authorizeEndpoint(ctx, id); err != nil {
+		return nil, err
+	}
+
+	endpoint, err := h.App.EndpointService.UpdateEndpoint(ctx, id, update)
+	if err != nil {
+		return nil, convertError(err)
+	}
+
+	return connect.NewResponse(&outboundwebhooksapiv1.UpdateEndpointResponse{Endpoint: convertEndpointToProto(endpoint)}), nil
+}
+
+// EnableEndpoint enables an endpoint and resets its failure count
+func (h *OutboundWebhooksHandler) EnableEndpoint(
+	ctx context.Context,
+	req *connect.Request[outboundwebhooksapiv1.EnableEndpointRequest],
+) (*connect.Response[outboundwebhooksapiv1.EnableEndpointResponse], error) {
+	id, err := parseID(req.Msg.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := h.authorizeEndpoint(ctx, id); err != nil {
+		return nil, err
+	}
+
+	endpoint, err := h.App.EndpointService.EnableEndpoint(ctx, id)
+	if err != nil {
+		return nil, convertError(err)
+	}
+
+	return connect.NewResponse(&outboundwebhooksapiv1.EnableEndpointResponse{Endpoint: convertEndpointToProto(endpoint)}), nil
+}
+
+// DisableEndpoint stops deliveries to an endpoint until it is enabled
+func (h *OutboundWebhooksHandler) DisableEndpoint(
+	ctx context.Context,
+	req *connect.Request[outboundwebhooksapiv1.DisableEndpointRequest],
+) (*connect.Response[outboundwebhooksapiv1.DisableEndpointResponse], error) {
+	id, err := parseID(req.Msg.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := h.authorizeEndpoint(ctx, id); err != nil {
+		return nil, err
+	}
+
+	endpoint, err := h.App.EndpointService.DisableEndpoint(ctx, id)
+	if err != nil {
+		return nil, convertError(err)
+	}
+
+	return connect.NewResponse(&outboundwebhooksapiv1.DisableEndpointResponse{Endpoint: convertEndpointToProto(endpoint)}), nil
+}
+
+// DeleteEndpoint deletes an endpoint and its delivery log
+func (h *OutboundWebhooksHandler) DeleteEndpoint(
+	ctx context.Context,
+	req *connect.Request[outboundwebhooksapiv1.DeleteEndpointRequest],
+) (*connect.Response[commonv1.Empty], error) {
+	id, err := parseID(req.Msg.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := h.authorizeEndpoint(ctx, id); err != nil {
+		return nil, err
+	}
+
+	if err := h.App.EndpointService.DeleteEndpoint(ctx, id); err != nil {
+		return nil, convertError(err)
+	}
+
+	return connect.NewResponse(&commonv1.Empty{}), nil
+}
+
+// ListDeliveries returns the most recent deliveries of an endpoint
+func (h *OutboundWebhooksHandler) ListDeliveries(
+	ctx context.Context,
+	req *connect.Request[outboundwebhooksapiv1.ListDeliveriesRequest],
+) (*connect.Response[outboundwebhooksapiv1.ListDeliveriesResponse], error) {
+	endpointID, err := parseID(req.Msg.EndpointId)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := h.authorizeEndpoint(ctx, endpointID); err != nil {
+		return nil, err
+	}
+
+	// Convert the status filter, unspecified lists every status
+	var status entities.DeliveryStatus
+	for s, protoStatus := range deliveryStatuses {
+		if protoStatus == req.Msg.Status {
+			status = s
+		}
+	}
+
+	pageSize := int(req.Msg.PageSize)
+	if pageSize == 0 {
+		pageSize = defaultPageSize
+	}
+
+	deliveries, err := h.App.DeliveryService.ListDeliveries(ctx, endpointID, status, pageSize)
+	if err != nil {
+		return nil, convertError(err)
+	}
+
+	resp := &outboundwebhooksapiv1.ListDeliveriesResponse{
+		Deliveries: make([]*outboundwebhooksapiv1.Delivery, 0, len(deliveries)),
+	}
+	for _, delivery := range deliveries {
+		resp.Deliveries = append(resp.Deliveries, convertDeliveryToProto(delivery))
+	}
+
+	return connect.NewResponse(resp), nil
+}
+
+// RedeliverDelivery delivers the event of a delivery again as a new delivery
+func (h *OutboundWebhooksHandler) RedeliverDelivery(
+	ctx context.Context,
+	req *connect.Request[outboundwebhooksapiv1.RedeliverDeliveryRequest],
+) (*connect.Response[outboundwebhooksapiv1.RedeliverDeliveryResponse], error) {
+	id, err := parseID(req.Msg.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	original, err := h.App.DeliveryService.GetDelivery(ctx, id)
+	if err != nil {
+		return nil, convertError(err)
+	}
+
+	if _, err := h.authorizeEndpoint(ctx, original.EndpointID); err != nil {
+		return nil, err
+	}
+
+	delivery, err := h.App.DeliveryService.Redeliver(ctx, id)
+	if err != nil {
+		return nil, convertError(err)
+	}
+
+	return connect.NewResponse(&outboundwebhooksapiv1.RedeliverDeliveryResponse{Delivery: convertDeliveryToProto(delivery)}), nil
+}
+
+// parseID parses the id of a request, malformed ids are invalid arguments
+func parseID(id string) (uuid.UUID, error) {
+	parsed, err := uuid.Parse(id)
+	if err != nil {
+		return uuid.Nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	return parsed, nil
+}
+
+// authorizeEndpoint loads the endpoint and ensures the caller owns it
+func (h *OutboundWebhooksHandler) authorizeEndpoint(ctx context.Context, id uuid.UUID) (entities.Endpoint, error) {
+	endpoint, err := h.App.EndpointService.GetEndpoint(ctx, id)
+	if err != nil {
+		return entities.Endpoint{}, convertError(err)
+	}
+
+	if err := httpauth.AuthorizeOwnerFromContext(ctx, endpoint.CommonID.String()); err != nil {
+		return entities.Endpoint{}, connect.NewError(connect.CodePermissionDenied, err)
+	}
+
+	return endpoint, nil
+}
+
+// convertError maps the application errors to connect codes
+func convertError(err error) error {
+	switch {
+	case errors.Is(err, ports.ErrEndpointNotFound), errors.Is(err, ports.ErrDeliveryNotFound):
+		return connect.NewError(connect.CodeNotFound, err)
+	case errors.Is(err, ports.ErrUnsupportedEventType), errors.Is(err, ports.ErrInvalidEndpointURL):
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	case errors.Is(err, services.ErrEndpointDisabled):
+		return connect.NewError(connect.CodeFailedPrecondition, err)
+	default:
+		return connect.NewError(connect.CodeInternal, err)
+	}
+}
+
+// convertEndpointToProto converts the endpoint to the proto type without its secret
+func convertEndpointToProto(endpoint entities.Endpoint) *outboundwebhooksapiv1.Endpoint {
+	return &outboundwebhooksapiv1.Endpoint{
+		Id:                  endpoint.ID.String(),
+		CommonId:            endpoint.CommonID.String(),
+		Url:                 endpoint.URL,
+		Description:         endpoint.Description,
+		EventTypes:          endpoint.EventTypes,
+		State:               endpointStates[endpoint.State],
+		ConsecutiveFailures: int32(endpoint.ConsecutiveFailures),
+		DisabledReason:      endpoint.DisabledReason,
+		DisabledAt:          optionalTimestamp(endpoint.DisabledAt),
+		CreatedAt:           timestamppb.New(endpoint.CreatedAt),
+		UpdatedAt:           timestamppb.New(endpoint.UpdatedAt),
+	}
+}
+
+// convertDeliveryToProto converts the delivery and its attempts to the proto type
+func convertDeliveryToProto(delivery entities.Delivery) *outboundwebhooksapiv1.Delivery {
+	attempts := make([]*outboundwebhooksapiv1.DeliveryAttempt, 0, len(delivery.Attempts))
+	for _, attempt := range delivery.Attempts {
+		attempts = append(attempts, &outboundwebhooksapiv1.DeliveryAttempt{
+			Number:      int32(attempt.Number),
+			StatusCode:  int32(attempt.StatusCode),
+			Error:       attempt.Error,
+			DurationMs:  attempt.Duration.Milliseconds(),
+			AttemptedAt: timestamppb.New(attempt.AttemptedAt),
+		})
+	}
+
+	var redeliveryOf string
+	if delivery.RedeliveryOf != uuid.Nil {
+		redeliveryOf = delivery.RedeliveryOf.String()
+	}
+
+	return &outboundwebhooksapiv1.Delivery{
+		Id:            delivery.ID.String(),
+		EndpointId:    delivery.EndpointID.String(),
+		EventId:       delivery.EventID.String(),
+		EventType:     delivery.EventType,
+		Status:        deliveryStatuses[delivery.Status],
+		AttemptCount:  int32(delivery.AttemptCount),
+		Attempts:      attempts,
+		LastError:     delivery.LastError,
+		RedeliveryOf:  redeliveryOf,
+		NextAttemptAt: optionalTimestamp(delivery.NextAttemptAt),
+		DeliveredAt:   optionalTimestamp(delivery.DeliveredAt),
+		CreatedAt:     timestamppb.New(delivery.CreatedAt),
+		UpdatedAt:     timestamppb.New(delivery.UpdatedAt),
+	}
+}
+
+// optionalTimestamp leaves zero times unset
+func optionalTimestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+
+	return timestamppb.New(t)
+}
@@ -0,0 +1,212 @@
+package connectrpc_test
+
+import (
+	"apps/services/outbound-webhooks-api/internal/adapters/connectrpc"
+	"apps/services/outbound-webhooks-api/internal/app"
+	"apps/services/outbound-webhooks-api/internal/app/ports"
+	"apps/services/outbound-webhooks-api/internal/domain/entities"
+	"context"
+	"libs/backend/boot"
+	userValueObjects "libs/backend/domain/user/valueobjects"
+	"libs/backend/eventing"
+	"libs/backend/httpauth"
+	outboundwebhooksapiv1 "libs/backend/proto-gen/go/webhooks/outboundwebhooksapi/v1"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+// fakeEndpointService keeps endpoints in memory and records changes
+type fakeEndpointService struct {
+	endpoints map[uuid.UUID]entities.Endpoint
+	changed   int
+}
+
+func (s *fakeEndpointService) CreateEndpoint(_ context.Context, commonID userValueObjects.CommonID, url, description string, eventTypes []string) (entities.Endpoint, error) {
+	s.changed++
+	return entities.Endpoint{ID: uuid.New(), CommonID: commonID, URL: url}, nil
+}
+
+func (s *fakeEndpointService) GetEndpoint(_ context.Context, id uuid.UUID) (entities.Endpoint, error) {
+	endpoint, ok := s.endpoints[id]
+	if !ok {
+		return entities.Endpoint{}, ports.ErrEndpointNotFound
+	}
+	return endpoint, nil
+}
+
+func (s *fakeEndpointService) ListEndpoints(context.Context, userValueObjects.CommonID) ([]entities.Endpoint, error) {
+	return nil, nil
+}
+
+func (s *fakeEndpointService) UpdateEndpoint(ctx context.Context, id uuid.UUID, _ entities.EndpointUpdate) (entities.Endpoint, error) {
+	s.changed++
+	return s.GetEndpoint(ctx, id)
+}
+
+func (s *fakeEndpointService) EnableEndpoint(ctx context.Context, id uuid.UUID) (entities.Endpoint, error) {
+	s.changed++
+	return s.GetEndpoint(ctx, id)
+}
+
+func (s *fakeEndpointService) DisableEndpoint(ctx context.Context, id uuid.UUID) (entities.Endpoint, error) {
+	s.changed++
+	return s.GetEndpoint(ctx, id)
+}
+
+func (s *fakeEndpointService) DeleteEndpoint(context.Context, uuid.UUID) error {
+	s.changed++
+	return nil
+}
+
+// fakeDeliveryService keeps deliveries in memory and records redeliveries
+type fakeDeliveryService struct {
+	deliveries  map[uuid.UUID]entities.Delivery
+	redelivered int
+}
+
+func (s *fakeDeliveryService) Enqueue(context.Context, uuid.UUID, userValueObjects.CommonID, eventing.EventName, proto.Message) error {
+	return nil
+}
+
+func (s *fakeDeliveryService) DeliverDue(context.Context) error { return nil }
+
+func (s *fakeDeliveryService) GetDelivery(_ context.Context, id uuid.UUID) (entities.Delivery, error) {
+	delivery, ok := s.deliveries[id]
+	if !ok {
+		return entities.Delivery{}, ports.ErrDeliveryNotFound
+	}
+	return delivery, nil
+}
+
+func (s *fakeDeliveryService) ListDeliveries(context.Context, uuid.UUID, entities.DeliveryStatus, int) ([]entities.Delivery, error) {
+	return nil, nil
+}
+
+func (s *fakeDeliveryService) Redeliver(ctx context.Context, id uuid.UUID) (entities.Delivery, error) {
+	s.redelivered++
+	return s.GetDelivery(ctx, id)
+}
+
+func TestOutboundWebhooksHandlerOwnership(t *testing.T) {
+	owner := userValueObjects.NewCommonID()
+	other := userValueObjects.NewCommonID()
+
+	endpoint := entities.Endpoint{ID: uuid.New(), CommonID: owner, URL: "https://example.com/webhooks"}
+	delivery := entities.Delivery{ID: uuid.New(), EndpointID: endpoint.ID}
+
+	newHandler := func() (*connectrpc.OutboundWebhooksHandler, *fakeEndpointService, *fakeDeliveryService) {
+		endpoints := &fakeEndpointService{endpoints: map[uuid.UUID]entities.Endpoint{endpoint.ID: endpoint}}
+		deliveries := &fakeDeliveryService{deliveries: map[uuid.UUID]entities.Delivery{delivery.ID: delivery}}
+
+		return connectrpc.NewOutboundWebhooksHandler(boot.NewSlogger(), app.NewApp(
+			app.WithEndpointService(endpoints),
+			app.WithDeliveryService(deliveries),
+		)), endpoints, deliveries
+	}
+
+	callerContext := func(commonID userValueObjects.CommonID) context.Context {
+		return context.WithValue(context.Background(), httpauth.ClaimsKey, &httpauth.CustomClaims{CommonID: commonID.String()})
+	}
+
+	t.Run("denies cross-account calls", func(t *testing.T) {
+		handler, endpoints, deliveries := newHandler()
+		ctx := callerContext(other)
+		url := "https://attacker.example.com"
+
+		calls := map[string]func() error{
+			"CreateEndpoint": func() error {
+				_, err := handler.CreateEndpoint(ctx, connect.NewRequest(&outboundwebhooksapiv1.CreateEndpointRequest{CommonId: owner.String(), Url: url}))
+				return err
+			},
+			"ListEndpoints": func() error {
+				_, err := handler.ListEndpoints(ctx, connect.NewRequest(&outboundwebhooksapiv1.ListEndpointsRequest{CommonId: owner.String()}))
+				return err
+			},
+			"GetEndpoint": func() error {
+				_, err := handler.GetEndpoint(ctx, connect.NewRequest(&outboundwebhooksapiv1.GetEndpointRequest{Id: endpoint.ID.String()}))
+				return err
+			},
+			"UpdateEndpoint": func() error {
+				_, err := handler.UpdateEndpoint(ctx, connect.NewRequest(&outboundwebhooksapiv1.UpdateEndpointRequest{Id: endpoint.ID.String(), Url: &url}))
+				return err
+			},
+			"EnableEndpoint": func() error {
+				_, err := handler.EnableEndpoint(ctx, connect.NewRequest(&outboundwebhooksapiv1.EnableEndpointRequest{Id: endpoint.ID.String()}))
+				return err
+			},
+			"DisableEndpoint": func() error {
+				_, err := handler.DisableEndpoint(ctx, connect.NewRequest(&outboundwebhooksapiv1.DisableEndpointRequest{Id: endpoint.ID.String()}))
+				return err
+			},
+			"DeleteEndpoint": func() error {
+				_, err := handler.DeleteEndpoint(ctx, connect.NewRequest(&outboundwebhooksapiv1.DeleteEndpointRequest{Id: endpoint.ID.String()}))
+				return err
+			},
+			"ListDeliveries": func() error {
+				_, err := handler.ListDeliveries(ctx, connect.NewRequest(&outboundwebhooksapiv1.ListDeliveriesRequest{EndpointId: endpoint.ID.String()}))
+				return err
+			},
+			"RedeliverDelivery": func() error {
+				_, err := handler.RedeliverDelivery(ctx, connect.NewRequest(&outboundwebhooksapiv1.RedeliverDeliveryRequest{Id: delivery.ID.String()}))
+				return err
+			},
+		}
+
+		for name, call := range calls {
+			err := call()
+			require.Error(t, err, name)
+			assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err), name)
+		}
+
+		assert.Zero(t, endpoints.changed)
+		assert.Zero(t, deliveries.redelivered)
+	})
+
+	t.Run("allows the owner", func(t *testing.T) {
+		handler, endpoints, deliveries := newHandler()
+		ctx := callerContext(owner)
+
+		_, err := handler.GetEndpoint(ctx, connect.NewRequest(&outboundwebhooksapiv1.GetEndpointRequest{Id: endpoint.ID.String()}))
+		require.NoError(t, err)
+
+		_, err = handler.DisableEndpoint(ctx, connect.NewRequest(&outboundwebhooksapiv1.DisableEndpointRequest{Id: endpoint.ID.String()}))
+		require.NoError(t, err)
+		assert.Equal(t, 1, endpoints.changed)
+
+		_, err = handler.RedeliverDelivery(ctx, connect.NewRequest(&outboundwebhooksapiv1.RedeliverDeliveryRequest{Id: delivery.ID.String()}))
+		require.NoError(t, err)
+		assert.Equal(t, 1, deliveries.redelivered)
+	})
+
+	t.Run("rejects malformed ids as invalid arguments", func(t *testing.T) {
+		handler, endpoints, deliveries := newHandler()
+		ctx := callerContext(owner)
+
+		_, err := handler.GetEndpoint(ctx, connect.NewRequest(&outboundwebhooksapiv1.GetEndpointRequest{Id: "not-a-uuid"}))
+		assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+
+		_, err = handler.DeleteEndpoint(ctx, connect.NewRequest(&outboundwebhooksapiv1.DeleteEndpointRequest{}))
+		assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+
+		_, err = handler.ListDeliveries(ctx, connect.NewRequest(&outboundwebhooksapiv1.ListDeliveriesRequest{EndpointId: "not-a-uuid"}))
+		assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+
+		_, err = handler.RedeliverDelivery(ctx, connect.NewRequest(&outboundwebhooksapiv1.RedeliverDeliveryRequest{Id: "not-a-uuid"}))
+		assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+
+		assert.Zero(t, endpoints.changed)
+		assert.Zero(t, deliveries.redelivered)
+	})
+
+	t.Run("reports unknown endpoints as not found", func(t *testing.T) {
+		handler, _, _ := newHandler()
+
+		_, err := handler.GetEndpoint(callerContext(owner), connect.NewRequest(&outboundwebhooksapiv1.GetEndpointRequest{Id: uuid.NewString()}))
+		assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+	})
+}
@@ -0,0 +1,247 @@
+package repositories
+
+import (
+	"apps/services/outbound-webhooks-api/internal/app/ports"
+	"apps/services/outbound-webhooks-api/internal/domain/entities"
+	"apps/services/outbound-webhooks-api/internal/models"
+	"context"
+	"errors"
+	"fmt"
+	"libs/backend/boot"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Assertion of the proper interface
+var _ ports.DeliveryRepository = DeliveryRepository{}
+
+// DeliveryRepository stores the webhook deliveries and their attempts
+type DeliveryRepository struct {
+	// Logger is the logger from the boot framework
+	Logger boot.Logger
+
+	// Database is the database connection
+	Database *gorm.DB
+}
+
+// NewDeliveryRepository creates a new delivery repository
+func NewDeliveryRepository(logger boot.Logger, db *gorm.DB) DeliveryRepository {
+	return DeliveryRepository{
+		Logger:   logger,
+		Database: db,
+	}
+}
+
+// CreateDeliveries stores new pending deliveries, the unique event index
+// skips the events that are enqueued again
+func (r DeliveryRepository) CreateDeliveries(ctx context.Context, deliveries []entities.Delivery) error {
+	deliveryModels := make([]models.WebhookDelivery, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		deliveryModels = append(deliveryModels, r.convertDeliveryToModel(delivery))
+	}
+
+	if err := r.Database.WithContext(ctx).Omit("Attempts").Clauses(clause.OnConflict{DoNothing: true}).Create(&deliveryModels).Error; err != nil {
+		return fmt.Errorf("cannot create webhook deliveries: %w", err)
+	}
+
+	return nil
+}
+
+// GetDelivery gets the delivery and its attempts by id
+func (r DeliveryRepository) GetDelivery(ctx context.Context, id uuid.UUID) (entities.Delivery, error) {
+	deliveryModel := models.WebhookDelivery{}
+	err := r.Database.WithContext(ctx).
+		Preload("Attempts", func(db *gorm.DB) *gorm.DB { return db.Order("number") }).
+		First(&deliveryModel, "id = ?", id).
+		Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return entities.Delivery{}, ports.ErrDeliveryNotFound
+	}
+	if err != nil {
+		return entities.Delivery{}, fmt.Errorf("cannot get webhook delivery: %w", err)
+	}
+
+	return r.convertModelToDelivery(deliveryModel), nil
+}
+
+// ListDeliveries lists the most recent deliveries of the endpoint, optionally filtered by status
+func (r DeliveryRepository) ListDeliveries(ctx context.Context, endpointID uuid.UUID, status entities.DeliveryStatus, limit int) ([]entities.Delivery, error) {
+	query := r.Database.WithContext(ctx).
+		Preload("Attempts", func(db *gorm.DB) *gorm.DB { return db.Order("number") }).
+		Where("endpoint_id = ?", endpointID).
+		Order("created_at DESC").
+		Limit(limit)
+
+	if status != "" {
+		query = query.Where("status = ?", string(status))
+	}
+
+	deliveryModels := make([]models.WebhookDelivery, 0)
+	if err := query.Find(&deliveryModels).Error; err != nil {
+		return nil, fmt.Errorf("cannot list webhook deliveries: %w", err)
+	}
+
+	return r.convertModelsToDeliveries(deliveryModels), nil
+}
+
+// ClaimDueDeliveries leases up to limit due pending deliveries of enabled
+// endpoints by moving their next attempt past the lease
+func (r DeliveryRepository) ClaimDueDeliveries(ctx context.Context, now time.Time, limit int, lease time.Duration) ([]entities.Delivery, error) {
+	due := make([]models.WebhookDelivery, 0)
+
+	err := r.Database.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		enabledEndpoints := tx.Model(&models.WebhookEndpoint{}).
+			Select("id").
+			Where("state = ?", string(entities.EndpointStateEnabled))
+
+		err := tx.
+			Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND next_attempt_at <= ?", string(entities.DeliveryStatusPending), now).
+			Where("endpoint_id IN (?)", enabledEndpoints).
+			Order("next_attempt_at").
+			Limit(limit).
+			Find(&due).
+			Error
+		if err != nil || len(due) == 0 {
+			return err
+		}
+
+		ids := make([]uuid.UUID, 0, len(due))
+		for _, delivery := range due {
+			ids = append(ids, delivery.ID)
+		}
+
+		return tx.Model(&models.WebhookDelivery{}).Where("id IN ?", ids).Update("next_attempt_at", now.Add(lease)).Error
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cannot claim due webhook deliveries: %w", err)
+	}
+
+	return r.convertModelsToDeliveries(due), nil
+}
+
+// SaveAttempt stores the outcome of the delivery and appends the attempt to its log
+func (r DeliveryRepository) SaveAttempt(ctx context.Context, delivery entities.Delivery, attempt entities.DeliveryAttempt) error {
+	return r.Database.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&models.WebhookDelivery{}).
+			Where("id = ?", delivery.ID).
+			Updates(map[string]any{
+				"status":          string(delivery.Status),
+				"attempt_count":   delivery.AttemptCount,
+				"last_error":      delivery.LastError,
+				"next_attempt_at": optionalTime(delivery.NextAttemptAt),
+				"delivered_at":    optionalTime(delivery.DeliveredAt),
+				"updated_at":      delivery.UpdatedAt,
+			}).
+			Error
+		if err != nil {
+			return fmt.Errorf("cannot save webhook delivery: %w", err)
+		}
+
+		attemptModel := models.WebhookDeliveryAttempt{
+			ID:          uuid.New(),
+			DeliveryID:  delivery.ID,
+			Number:      attempt.Number,
+			StatusCode:  attempt.StatusCode,
+			Error:       attempt.Error,
+			DurationMS:  attempt.Duration.Milliseconds(),
+			AttemptedAt: attempt.AttemptedAt,
+		}
+		if err := tx.Create(&attemptModel).Error; err != nil {
+			return fmt.Errorf("cannot create webhook delivery attempt: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// convertDeliveryToModel converts a delivery to the database model without attempts
+func (r DeliveryRepository) convertDeliveryToModel(delivery entities.Delivery) models.WebhookDelivery {
+	var redeliveryOf *uuid.UUID
+	if delivery.RedeliveryOf != uuid.Nil {
+		redeliveryOf = &delivery.RedeliveryOf
+	}
+
+	return models.WebhookDelivery{
+		ID:            delivery.ID,
+		EndpointID:    delivery.EndpointID,
+		EventID:       delivery.EventID,
+		EventType:     delivery.EventType,
+		Payload:       delivery.Payload,
+		Status:        string(delivery.Status),
+		AttemptCount:  delivery.AttemptCount,
+		LastError:     delivery.LastError,
+		RedeliveryOf:  redeliveryOf,
+		NextAttemptAt: optionalTime(delivery.NextAttemptAt),
+		DeliveredAt:   optionalTime(delivery.DeliveredAt),
+		CreatedAt:     delivery.CreatedAt,
+		UpdatedAt:     delivery.UpdatedAt,
+	}
+}
+
+// convertModelsToDeliveries converts database models to deliveries
+func (r DeliveryRepository) convertModelsToDeliveries(deliveryModels []models.WebhookDelivery) []entities.Delivery {
+	deliveries := make([]entities.Delivery, 0, len(deliveryModels))
+	for _, deliveryModel := range deliveryModels {
+		deliveries = append(deliveries, r.convertModelToDelivery(deliveryModel))
+	}
+
+	return deliveries
+}
+
+// convertModelToDelivery converts a database model to a delivery
+func (r DeliveryRepository) convertModelToDelivery(deliveryModel models.WebhookDelivery) entities.Delivery {
+	attempts := make([]entities.DeliveryAttempt, 0, len(deliveryModel.Attempts))
+	for _, attempt := range deliveryModel.Attempts {
+		attempts = append(attempts, entities.DeliveryAttempt{
+			Number:      attempt.Number,
+			StatusCode:  attempt.StatusCode,
+			Error:       attempt.Error,
+			Duration:    time.Duration(attempt.DurationMS) * time.Millisecond,
+			AttemptedAt: attempt.AttemptedAt,
+		})
+	}
+
+	var redeliveryOf uuid.UUID
+	if deliveryModel.RedeliveryOf != nil {
+		redeliveryOf = *deliveryModel.RedeliveryOf
+	}
+
+	return entities.Delivery{
+		ID:            deliveryModel.ID,
+		EndpointID:    deliveryModel.EndpointID,
+		EventID:       deliveryModel.EventID,
+		EventType:     deliveryModel.EventType,
+		Payload:       deliveryModel.Payload,
+		Status:        entities.DeliveryStatus(deliveryModel.Status),
+		AttemptCount:  deliveryModel.AttemptCount,
+		Attempts:      attempts,
+		LastError:     deliveryModel.LastError,
+		RedeliveryOf:  redeliveryOf,
+		NextAttemptAt: valueTime(deliveryModel.NextAttemptAt),
+		DeliveredAt:   valueTime(deliveryModel.DeliveredAt),
+		CreatedAt:     deliveryModel.CreatedAt,
+		UpdatedAt:     deliveryModel.UpdatedAt,
+	}
+}
+
+// optionalTime stores zero times as NULL
+func optionalTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+
+	return &t
+}
+
+// valueTime reads NULL times as zero times
+func valueTime(t *time.Time) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+
+	return *t
+}
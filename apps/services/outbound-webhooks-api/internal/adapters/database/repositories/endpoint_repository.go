@@ -0,0 +1,205 @@
+package repositories
+
+import (
+	"apps/services/outbound-webhooks-api/internal/app/ports"
+	"apps/services/outbound-webhooks-api/internal/domain/entities"
+	"apps/services/outbound-webhooks-api/internal/models"
+	"context"
+	"errors"
+	"fmt"
+	"libs/backend/boot"
+	userValueObjects "libs/backend/domain/user/valueobjects"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Assertion of the proper interface
+var _ ports.EndpointRepository = EndpointRepository{}
+
+// EndpointRepository stores the webhook endpoints
+type EndpointRepository struct {
+	// Logger is the logger from the boot framework
+	Logger boot.Logger
+
+	// Database is the database connection
+	Database *gorm.DB
+}
+
+// NewEndpointRepository creates a new endpoint repository
+func NewEndpointRepository(logger boot.Logger, db *gorm.DB) EndpointRepository {
+	return EndpointRepository{
+		Logger:   logger,
+		Database: db,
+	}
+}
+
+// CreateEndpoint stores a new endpoint
+func (r EndpointRepository) CreateEndpoint(ctx context.Context, endpoint entities.Endpoint) error {
+	endpointModel := r.convertEndpointToModel(endpoint)
+	if err := r.Database.WithContext(ctx).Create(&endpointModel).Error; err != nil {
+		return fmt.Errorf("cannot create webhook endpoint: %w", err)
+	}
+
+	return nil
+}
+
+// GetEndpoint gets the endpoint by id
+func (r EndpointRepository) GetEndpoint(ctx context.Context, id uuid.UUID) (entities.Endpoint, error) {
+	endpointModel := models.WebhookEndpoint{}
+	err := r.Database.WithContext(ctx).First(&endpointModel, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return entities.Endpoint{}, ports.ErrEndpointNotFound
+	}
+	if err != nil {
+		return entities.Endpoint{}, fmt.Errorf("cannot get webhook endpoint: %w", err)
+	}
+
+	return r.convertModelToEndpoint(endpointModel), nil
+}
+
+// ListEndpoints lists the endpoints of the user, oldest first
+func (r EndpointRepository) ListEndpoints(ctx context.Context, commonID userValueObjects.CommonID) ([]entities.Endpoint, error) {
+	endpointModels := make([]models.WebhookEndpoint, 0)
+	err := r.Database.WithContext(ctx).
+		Where("common_id = ?", commonID.Value()).
+		Order("created_at").
+		Find(&endpointModels).
+		Error
+	if err != nil {
+		return nil, fmt.Errorf("cannot list webhook endpoints: %w", err)
+	}
+
+	endpoints := make([]entities.Endpoint, 0, len(endpointModels))
+	for _, endpointModel := range endpointModels {
+		endpoints = append(endpoints, r.convertModelToEndpoint(endpointModel))
+	}
+
+	return endpoints, nil
+}
+
+// SaveEndpoint stores the changed endpoint
+func (r EndpointRepository) SaveEndpoint(ctx context.Context, endpoint entities.Endpoint) error {
+	endpointModel := r.convertEndpointToModel(endpoint)
+
+	// Selecting the columns updates zero values and applies the event types serializer
+	result := r.Database.WithContext(ctx).
+		Model(&models.WebhookEndpoint{ID: endpoint.ID}).
+		Select(
+			"url",
+			"description",
+			"event_types",
+			"state",
+			"consecutive_failures",
+			"disabled_reason",
+			"disabled_at",
+			"updated_at",
+		).
+		Updates(&endpointModel)
+	if result.Error != nil {
+		return fmt.Errorf("cannot save webhook endpoint: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return ports.ErrEndpointNotFound
+	}
+
+	return nil
+}
+
+// DeleteEndpoint deletes the endpoint, its deliveries are deleted by the foreign key
+func (r EndpointRepository) DeleteEndpoint(ctx context.Context, id uuid.UUID) error {
+	result := r.Database.WithContext(ctx).Delete(&models.WebhookEndpoint{}, "id = ?", id)
+	if result.Error != nil {
+		return fmt.Errorf("cannot delete webhook endpoint: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return ports.ErrEndpointNotFound
+	}
+
+	return nil
+}
+
+// IncrementFailures counts a failed attempt and returns the consecutive failures.
+// The counter is incremented in the database since pollers deliver concurrently.
+func (r EndpointRepository) IncrementFailures(ctx context.Context, id uuid.UUID, now time.Time) (int, error) {
+	endpointModel := models.WebhookEndpoint{}
+	result := r.Database.WithContext(ctx).
+		Model(&endpointModel).
+		Clauses(clause.Returning{Columns: []clause.Column{{Name: "consecutive_failures"}}}).
+		Where("id = ?", id).
+		Updates(map[string]any{
+			"consecutive_failures": gorm.Expr("consecutive_failures + 1"),
+			"updated_at":           now,
+		})
+	if result.Error != nil {
+		return 0, fmt.Errorf("cannot count webhook endpoint failure: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return 0, ports.ErrEndpointNotFound
+	}
+
+	return endpointModel.ConsecutiveFailures, nil
+}
+
+// ResetFailures clears the consecutive failures after a successful attempt
+func (r EndpointRepository) ResetFailures(ctx context.Context, id uuid.UUID, now time.Time) error {
+	err := r.Database.WithContext(ctx).
+		Model(&models.WebhookEndpoint{}).
+		Where("id = ?", id).
+		Updates(map[string]any{
+			"consecutive_failures": 0,
+			"updated_at":           now,
+		}).
+		Error
+	if err != nil {
+		return fmt.Errorf("cannot reset webhook endpoint failures: %w", err)
+	}
+
+	return nil
+}
+
+// convertEndpointToModel converts an endpoint to the database model
+func (r EndpointRepository) convertEndpointToModel(endpoint entities.Endpoint) models.WebhookEndpoint {
+	eventTypes := endpoint.EventTypes
+	if eventTypes == nil {
+		eventTypes = []string{}
+	}
+
+	return models.WebhookEndpoint{
+		ID:                  endpoint.ID,
+		CommonID:            endpoint.CommonID.Value(),
+		URL:                 endpoint.URL,
+		Description:         endpoint.Description,
+		EventTypes:          eventTypes,
+		SigningSecret:       endpoint.SigningSecret,
+		State:               string(endpoint.State),
+		ConsecutiveFailures: endpoint.ConsecutiveFailures,
+		DisabledReason:      endpoint.DisabledReason,
+		DisabledAt:          optionalTime(endpoint.DisabledAt),
+		CreatedAt:           endpoint.CreatedAt,
+		UpdatedAt:           endpoint.UpdatedAt,
+	}
+}
+
+// convertModelToEndpoint converts a database model to an endpoint
+func (r EndpointRepository) convertModelToEndpoint(endpointModel models.WebhookEndpoint) entities.Endpoint {
+	return entities.Endpoint{
+		ID:                  endpointModel.ID,
+		CommonID:            userValueObjects.NewCommonIDFromUUID(endpointModel.CommonID),
+		URL:                 endpointModel.URL,
+		Description:         endpointModel.Description,
+		EventTypes:          endpointModel.EventTypes,
+		SigningSecret:       endpointModel.SigningSecret,
+		State:               entities.EndpointState(endpointModel.State),
+		ConsecutiveFailures: endpointModel.ConsecutiveFailures,
+		DisabledReason:      endpointModel.DisabledReason,
+		DisabledAt:          valueTime(endpointModel.DisabledAt),
+		CreatedAt:           endpointModel.CreatedAt,
+		UpdatedAt:           endpointModel.UpdatedAt,
+	}
+}
@@ -0,0 +1,98 @@
+package messagebroker
+
+import (
+	"apps/services/outbound-webhooks-api/internal/app"
+	"context"
+	"fmt"
+	"libs/backend/boot"
+	userValueObjects "libs/backend/domain/user/valueobjects"
+	"libs/backend/eventing"
+	accountseventsv1 "libs/backend/proto-gen/go/accounts/accountsevents/v1"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/proto"
+)
+
+// eventIDNamespace is the namespace of the event ids derived from message ids and contents
+var eventIDNamespace = uuid.MustParse("0d6f5a4e-8c1b-4f3a-9e2d-7b5c3a1f9e80")
+
+// LavinMQHandler handles all incoming events from LavinMQ
+type LavinMQHandler struct {
+	Logger     boot.Logger
+	Consumer   boot.AMQPConsumer
+	Dispatcher *eventing.Dispatcher
+	App        app.App
+}
+
+// NewLavinMQHandler is the constructor for LavinMQHandler
+func NewLavinMQHandler(logger boot.Logger, consumer boot.AMQPConsumer, dispatcher *eventing.Dispatcher, app app.App) LavinMQHandler {
+	return LavinMQHandler{
+		Logger:     logger,
+		Consumer:   consumer,
+		Dispatcher: dispatcher,
+		App:        app,
+	}
+}
+
+// HandleAccountEvents enqueues the webhook deliveries of every accounts event,
+// in publish order per user
+func (h LavinMQHandler) HandleAccountEvents(ctx context.Context, queue eventing.PartitionedQueue) error {
+	eventing.RegisterAccountCreatedHandler(h.Dispatcher, func(ctx context.Context, event *accountseventsv1.AccountCreated) error {
+		return h.enqueue(ctx, event.GetAccount().GetCommonId(), eventing.EventNameAccountCreated, event)
+	})
+	eventing.RegisterAccountUpdatedHandler(h.Dispatcher, func(ctx context.Context, event *accountseventsv1.AccountUpdated) error {
+		return h.enqueue(ctx, event.GetAccount().GetCommonId(), eventing.EventNameAccountUpdated, event)
+	})
+	eventing.RegisterAccountSoftDeletedHandler(h.Dispatcher, func(ctx context.Context, event *accountseventsv1.AccountSoftDeleted) error {
+		return h.enqueue(ctx, event.GetCommonId(), eventing.EventNameAccountSoftDeleted, event)
+	})
+	eventing.RegisterAccountHardDeletedHandler(h.Dispatcher, func(ctx context.Context, event *accountseventsv1.AccountHardDeleted) error {
+		return h.enqueue(ctx, event.GetCommonId(), eventing.EventNameAccountHardDeleted, event)
+	})
+	eventing.RegisterAccountRestoredHandler(h.Dispatcher, func(ctx context.Context, event *accountseventsv1.AccountRestored) error {
+		return h.enqueue(ctx, event.GetAccount().GetCommonId(), eventing.EventNameAccountRestored, event)
+	})
+
+	if err := h.Dispatcher.RunPartitioned(ctx, h.Consumer, queue); err != nil {
+		h.Logger.Error("Cannot consume messages", slog.Any("error", err))
+		return err
+	}
+
+	return nil
+}
+
+// enqueue creates the deliveries of the event for the endpoints of the user
+func (h LavinMQHandler) enqueue(ctx context.Context, commonID string, eventName eventing.EventName, event proto.Message) error {
+	eventID, err := deliveryEventID(ctx, eventName, event)
+	if err != nil {
+		h.Logger.Error("Cannot derive the webhook event id", slog.String("eventName", eventName.String()), slog.Any("error", err))
+		return err
+	}
+
+	if err := h.App.DeliveryService.Enqueue(ctx, eventID, userValueObjects.NewCommonIDFromString(commonID), eventName, event); err != nil {
+		h.Logger.Error("Cannot enqueue webhook deliveries", slog.String("eventName", eventName.String()), slog.Any("error", err))
+		return err
+	}
+
+	return nil
+}
+
+// deliveryEventID derives the webhook event id from the AMQP message id, so a
+// redelivered message keeps its event id. Messages without an id are
+// identified by their content instead.
+func deliveryEventID(ctx context.Context, eventName eventing.EventName, event proto.Message) (uuid.UUID, error) {
+	if messageID, ok := eventing.MessageIDFromContext(ctx); ok {
+		if id, err := uuid.Parse(messageID); err == nil {
+			return id, nil
+		}
+		return uuid.NewSHA1(eventIDNamespace, []byte(messageID)), nil
+	}
+
+	body, err := proto.MarshalOptions{Deterministic: true}.Marshal(event)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("cannot marshal %s event: %w", eventName, err)
+	}
+
+	return uuid.NewSHA1(eventIDNamespace, append([]byte(eventName.String()), body...)), nil
+}
@@ -0,0 +1,99 @@
+package messagebroker_test
+
+import (
+	"apps/services/outbound-webhooks-api/internal/adapters/handlers/messagebroker"
+	"apps/services/outbound-webhooks-api/internal/app"
+	"apps/services/outbound-webhooks-api/internal/app/ports"
+	"context"
+	"libs/backend/boot"
+	"libs/backend/boot/amqptest"
+	userValueObjects "libs/backend/domain/user/valueobjects"
+	"libs/backend/eventing"
+	accountseventsv1 "libs/backend/proto-gen/go/accounts/accountsevents/v1"
+	accountsDomain "libs/backend/proto-gen/go/accounts/domain"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+// enqueuedEvent is an event passed to the delivery service
+type enqueuedEvent struct {
+	eventID   uuid.UUID
+	commonID  string
+	eventName eventing.EventName
+}
+
+// fakeDeliveryService records the enqueued events
+type fakeDeliveryService struct {
+	ports.DeliveryService
+	enqueued chan enqueuedEvent
+}
+
+func (s fakeDeliveryService) Enqueue(_ context.Context, eventID uuid.UUID, commonID userValueObjects.CommonID, eventName eventing.EventName, _ proto.Message) error {
+	s.enqueued <- enqueuedEvent{eventID: eventID, commonID: commonID.String(), eventName: eventName}
+	return nil
+}
+
+func TestLavinMQHandlerAccountEvents(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	logger := boot.NewSlogger()
+	queue := eventing.PartitionedQueue{
+		Name:                 eventing.GetQueueName(eventing.OutboundWebhooksAPIService, eventing.AccountNotificationQueue),
+		Exchange:             eventing.AccountsExchange,
+		RoutingKeys:          []string{eventing.GetDomainRoutingKey(eventing.AccountsDomain)},
+		DeadletterExchange:   eventing.AccountsDeadletterExchange,
+		DeadletterRoutingKey: eventing.AccountsDeadletterRoutingKey,
+	}
+
+	broker := amqptest.NewBroker()
+	defer broker.Close()
+
+	setup := eventing.NewAccountsEventSetup(broker, logger)
+	setup.
+		CreateExchange().
+		CreateDeadletter().
+		Complete()
+	require.NoError(t, queue.Declare(broker))
+
+	deliveryService := fakeDeliveryService{enqueued: make(chan enqueuedEvent, 2)}
+	dispatcher, err := eventing.NewDispatcher(logger)
+	require.NoError(t, err)
+
+	handler := messagebroker.NewLavinMQHandler(logger, broker, dispatcher, app.NewApp(app.WithDeliveryService(deliveryService)))
+	go func() {
+		_ = handler.HandleAccountEvents(ctx, queue)
+	}()
+
+	commonID := "5f1d3c6e-52a7-4b43-a8b5-0c0a1f0f6f11"
+	publish := func(eventName eventing.EventName, event proto.Message) uuid.UUID {
+		msg, err := eventing.NewProtoPublishing(eventName, eventing.InitialEventVersion, event)
+		require.NoError(t, err)
+		require.NoError(t, broker.Publish(eventing.AccountsExchange, eventName.String(), true, false, eventing.WithCommonID(msg, commonID)))
+		return uuid.MustParse(msg.MessageId)
+	}
+
+	updatedID := publish(eventing.EventNameAccountUpdated, &accountseventsv1.AccountUpdated{Account: &accountsDomain.Account{CommonId: commonID}})
+	deletedID := publish(eventing.EventNameAccountHardDeleted, &accountseventsv1.AccountHardDeleted{CommonId: commonID})
+
+	// The events of the user share a partition and are enqueued in order
+	enqueued := make([]enqueuedEvent, 0, 2)
+	for len(enqueued) < 2 {
+		select {
+		case event := <-deliveryService.enqueued:
+			enqueued = append(enqueued, event)
+		case <-time.After(time.Second):
+			t.Fatal("account events were not enqueued")
+		}
+	}
+
+	assert.Equal(t, []enqueuedEvent{
+		{eventID: updatedID, commonID: commonID, eventName: eventing.EventNameAccountUpdated},
+		{eventID: deletedID, commonID: commonID, eventName: eventing.EventNameAccountHardDeleted},
+	}, enqueued)
+}
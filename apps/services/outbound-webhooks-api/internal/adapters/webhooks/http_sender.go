@@ -0,0 +1,128 @@
+package webhooks
+
+import (
+	"apps/services/outbound-webhooks-api/internal/app/ports"
+	"apps/services/outbound-webhooks-api/internal/domain/entities"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"syscall"
+	"time"
+)
+
+// Assertion of the proper interface
+var _ ports.WebhookSender = HTTPSender{}
+
+const (
+	// DefaultSendTimeout bounds a single delivery attempt
+	DefaultSendTimeout = 10 * time.Second
+
+	// maxResponseBytes is how much of a response is read before the connection is reused
+	maxResponseBytes = 64 << 10
+
+	// userAgent identifies the deliveries to the endpoints
+	userAgent = "CareerCue-Webhooks/1.0"
+)
+
+// ErrNonPublicAddress is returned when an endpoint resolves to an address
+// that deliveries must not reach
+var ErrNonPublicAddress = errors.New("webhook endpoint resolves to a non public address")
+
+// HTTPSender posts deliveries to the endpoints
+type HTTPSender struct {
+	client *http.Client
+}
+
+// HTTPSenderOption is a function that modifies the sender options
+type HTTPSenderOption func(*httpSenderOptions)
+
+type httpSenderOptions struct {
+	allowPrivateNetworks bool
+}
+
+// WithPrivateNetworks lets deliveries reach non public addresses, e.g. the
+// test servers on the loopback interface
+func WithPrivateNetworks() HTTPSenderOption {
+	return func(o *httpSenderOptions) {
+		o.allowPrivateNetworks = true
+	}
+}
+
+// NewHTTPSender constructs the sender. Redirects are not followed, so a
+// delivery is only ever signed for the registered URL, and every resolved
+// address is checked right before the connection is made, so an endpoint
+// cannot reach internal services by pointing its name at them.
+func NewHTTPSender(timeout time.Duration, opts ...HTTPSenderOption) HTTPSender {
+	if timeout <= 0 {
+		timeout = DefaultSendTimeout
+	}
+
+	var options httpSenderOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	dialer := &net.Dialer{Timeout: timeout}
+	if !options.allowPrivateNetworks {
+		dialer.Control = rejectNonPublicAddress
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return HTTPSender{
+		client: &http.Client{
+			Timeout:   timeout,
+			Transport: transport,
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+// Send posts the body and returns the response status code, non 2xx responses are errors
+func (s HTTPSender) Send(ctx context.Context, url string, header http.Header, body []byte) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("cannot create webhook request: %w", err)
+	}
+
+	req.Header = header.Clone()
+	req.Header.Set("User-Agent", userAgent)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("cannot send webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxResponseBytes))
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return resp.StatusCode, fmt.Errorf("endpoint responded with %d", resp.StatusCode)
+	}
+
+	return resp.StatusCode, nil
+}
+
+// rejectNonPublicAddress is the dialer control, it runs after the name is
+// resolved so it sees the address that is actually connected to
+func rejectNonPublicAddress(_, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrNonPublicAddress, err)
+	}
+
+	if !entities.IsPublicAddress(addrPort.Addr()) {
+		return fmt.Errorf("%w: %s", ErrNonPublicAddress, addrPort.Addr())
+	}
+
+	return nil
+}
@@ -0,0 +1,68 @@
+package webhooks_test
+
+import (
+	"apps/services/outbound-webhooks-api/internal/adapters/webhooks"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPSender(t *testing.T) {
+	sender := webhooks.NewHTTPSender(webhooks.DefaultSendTimeout, webhooks.WithPrivateNetworks())
+
+	t.Run("posts the body with the headers", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, "t=1,v1=abc", r.Header.Get("Webhook-Signature"))
+			assert.Equal(t, `{"id":"1"}`, string(body))
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+
+		header := http.Header{}
+		header.Set("Webhook-Signature", "t=1,v1=abc")
+
+		status, err := sender.Send(context.Background(), server.URL, header, []byte(`{"id":"1"}`))
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusNoContent, status)
+	})
+
+	t.Run("fails on error responses", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+
+		status, err := sender.Send(context.Background(), server.URL, http.Header{}, nil)
+		assert.Error(t, err)
+		assert.Equal(t, http.StatusServiceUnavailable, status)
+	})
+
+	t.Run("does not follow redirects", func(t *testing.T) {
+		server := httptest.NewServer(http.RedirectHandler("https://example.com", http.StatusFound))
+		defer server.Close()
+
+		status, err := sender.Send(context.Background(), server.URL, http.Header{}, nil)
+		assert.Error(t, err)
+		assert.Equal(t, http.StatusFound, status)
+	})
+
+	t.Run("does not connect to non public addresses", func(t *testing.T) {
+		var called bool
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			called = true
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+
+		_, err := webhooks.NewHTTPSender(webhooks.DefaultSendTimeout).Send(context.Background(), server.URL, http.Header{}, nil)
+		assert.ErrorIs(t, err, webhooks.ErrNonPublicAddress)
+		assert.False(t, called)
+	})
+}
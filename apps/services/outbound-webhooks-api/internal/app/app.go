@@ -0,0 +1,39 @@
+package app
+
+import (
+	"apps/services/outbound-webhooks-api/internal/app/ports"
+)
+
+// App is the main application struct
+type App struct {
+	EndpointService ports.EndpointService
+	DeliveryService ports.DeliveryService
+}
+
+// AppOption is a function that modifies the App struct
+type AppOption func(*App)
+
+// NewApp creates a new App struct
+func NewApp(opts ...AppOption) App {
+	app := App{}
+
+	for _, opt := range opts {
+		opt(&app)
+	}
+
+	return app
+}
+
+// WithEndpointService sets the endpoint service
+func WithEndpointService(endpointService ports.EndpointService) AppOption {
+	return func(app *App) {
+		app.EndpointService = endpointService
+	}
+}
+
+// WithDeliveryService sets the delivery service
+func WithDeliveryService(deliveryService ports.DeliveryService) AppOption {
+	return func(app *App) {
+		app.DeliveryService = deliveryService
+	}
+}
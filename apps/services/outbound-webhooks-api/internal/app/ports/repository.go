@@ -0,0 +1,63 @@
+package ports
+
+import (
+	"apps/services/outbound-webhooks-api/internal/domain/entities"
+	"context"
+	"errors"
+	userValueObjects "libs/backend/domain/user/valueobjects"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrEndpointNotFound is returned when an endpoint does not exist
+	ErrEndpointNotFound = errors.New("webhook endpoint not found")
+
+	// ErrDeliveryNotFound is returned when a delivery does not exist
+	ErrDeliveryNotFound = errors.New("webhook delivery not found")
+)
+
+// EndpointRepository is the interface for the webhook endpoint repository
+type EndpointRepository interface {
+	// CreateEndpoint stores a new endpoint
+	CreateEndpoint(context.Context, entities.Endpoint) error
+
+	// GetEndpoint gets the endpoint by id
+	GetEndpoint(context.Context, uuid.UUID) (entities.Endpoint, error)
+
+	// ListEndpoints lists the endpoints of the user
+	ListEndpoints(context.Context, userValueObjects.CommonID) ([]entities.Endpoint, error)
+
+	// SaveEndpoint stores the changed endpoint
+	SaveEndpoint(context.Context, entities.Endpoint) error
+
+	// DeleteEndpoint deletes the endpoint and its deliveries
+	DeleteEndpoint(context.Context, uuid.UUID) error
+
+	// IncrementFailures counts a failed attempt and returns the consecutive failures
+	IncrementFailures(context.Context, uuid.UUID, time.Time) (int, error)
+
+	// ResetFailures clears the consecutive failures after a successful attempt
+	ResetFailures(context.Context, uuid.UUID, time.Time) error
+}
+
+// DeliveryRepository is the interface for the webhook delivery repository
+type DeliveryRepository interface {
+	// CreateDeliveries stores new pending deliveries, skipping the events that
+	// already have a delivery to the endpoint that is not a redelivery
+	CreateDeliveries(context.Context, []entities.Delivery) error
+
+	// GetDelivery gets the delivery and its attempts by id
+	GetDelivery(context.Context, uuid.UUID) (entities.Delivery, error)
+
+	// ListDeliveries lists the most recent deliveries of the endpoint, optionally filtered by status
+	ListDeliveries(context.Context, uuid.UUID, entities.DeliveryStatus, int) ([]entities.Delivery, error)
+
+	// ClaimDueDeliveries leases up to limit due pending deliveries of enabled
+	// endpoints so no other poller delivers them concurrently
+	ClaimDueDeliveries(context.Context, time.Time, int, time.Duration) ([]entities.Delivery, error)
+
+	// SaveAttempt stores the outcome of the delivery and appends the attempt to its log
+	SaveAttempt(context.Context, entities.Delivery, entities.DeliveryAttempt) error
+}
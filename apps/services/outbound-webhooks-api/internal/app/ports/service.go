@@ -0,0 +1,68 @@
+package ports
+
+import (
+	"apps/services/outbound-webhooks-api/internal/domain/entities"
+	"context"
+	"errors"
+	userValueObjects "libs/backend/domain/user/valueobjects"
+	"libs/backend/eventing"
+	"net/http"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/proto"
+)
+
+// ErrUnsupportedEventType is returned when an endpoint subscribes to an event that is not delivered
+var ErrUnsupportedEventType = errors.New("unsupported webhook event type")
+
+// ErrInvalidEndpointURL is returned when an endpoint URL is not a public HTTPS URL
+var ErrInvalidEndpointURL = errors.New("invalid webhook endpoint url")
+
+// EndpointService manages the webhook endpoints of users
+type EndpointService interface {
+	// CreateEndpoint registers the endpoint with a new signing secret
+	CreateEndpoint(ctx context.Context, commonID userValueObjects.CommonID, url, description string, eventTypes []string) (entities.Endpoint, error)
+
+	// GetEndpoint returns the endpoint
+	GetEndpoint(context.Context, uuid.UUID) (entities.Endpoint, error)
+
+	// ListEndpoints returns the endpoints of the user
+	ListEndpoints(context.Context, userValueObjects.CommonID) ([]entities.Endpoint, error)
+
+	// UpdateEndpoint changes the provided fields of the endpoint
+	UpdateEndpoint(context.Context, uuid.UUID, entities.EndpointUpdate) (entities.Endpoint, error)
+
+	// EnableEndpoint enables the endpoint and resets its failures
+	EnableEndpoint(context.Context, uuid.UUID) (entities.Endpoint, error)
+
+	// DisableEndpoint stops the deliveries to the endpoint
+	DisableEndpoint(context.Context, uuid.UUID) (entities.Endpoint, error)
+
+	// DeleteEndpoint deletes the endpoint and its deliveries
+	DeleteEndpoint(context.Context, uuid.UUID) error
+}
+
+// DeliveryService delivers events to the webhook endpoints
+type DeliveryService interface {
+	// Enqueue creates a pending delivery of the event for every subscribed endpoint of the user,
+	// enqueueing the same event id again does not create further deliveries
+	Enqueue(ctx context.Context, eventID uuid.UUID, commonID userValueObjects.CommonID, eventName eventing.EventName, event proto.Message) error
+
+	// DeliverDue attempts every due pending delivery
+	DeliverDue(context.Context) error
+
+	// GetDelivery returns the delivery
+	GetDelivery(context.Context, uuid.UUID) (entities.Delivery, error)
+
+	// ListDeliveries returns the most recent deliveries of the endpoint, optionally filtered by status
+	ListDeliveries(context.Context, uuid.UUID, entities.DeliveryStatus, int) ([]entities.Delivery, error)
+
+	// Redeliver delivers the event of the delivery again as a new delivery
+	Redeliver(context.Context, uuid.UUID) (entities.Delivery, error)
+}
+
+// WebhookSender sends a signed delivery to an endpoint
+type WebhookSender interface {
+	// Send posts the body and returns the response status code, non 2xx responses are errors
+	Send(ctx context.Context, url string, header http.Header, body []byte) (int, error)
+}
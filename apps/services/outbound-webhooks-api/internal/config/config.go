@@ -0,0 +1,63 @@
+package config
+
+import (
+	"libs/backend/eventing"
+	"os"
+)
+
+const (
+	// serviceName is the name of the microservice
+	serviceName = eventing.OutboundWebhooksAPIService
+)
+
+// Config for the application
+type Config struct {
+	ServiceName                  string
+	AMQPUri                      string
+	AccountNotificationQueueName string
+
+	// AccountNotificationPartitions is the number of partitions the account events are spread over
+	AccountNotificationPartitions int
+
+	DBHost     string
+	DBName     string
+	DBUser     string
+	DBPassword string
+	DBPort     string
+	DBSSLMode  string
+	DBTimeZone string
+
+	Auth0Domain   string
+	Auth0Audience string
+
+	// AuthJWKSFile replaces the key set of the Auth0 tenant, e.g. in local development
+	AuthJWKSFile string
+
+	// AuthPolicyFile replaces the default role policy
+	AuthPolicyFile string
+}
+
+// NewConfig constructs the config
+func NewConfig() (Config, error) {
+	config := Config{
+		ServiceName:                  serviceName,
+		AMQPUri:                      os.Getenv("AMQP_CONNECTION_URI"),
+		AccountNotificationQueueName:  eventing.GetQueueName(serviceName, eventing.AccountNotificationQueue),
+		AccountNotificationPartitions: eventing.DefaultPartitions,
+
+		DBHost:     os.Getenv("DATABASE_HOST"),
+		DBName:     os.Getenv("DATABASE_NAME"),
+		DBUser:     os.Getenv("DATABASE_USER"),
+		DBPassword: os.Getenv("DATABASE_PASSWORD"),
+		DBPort:     os.Getenv("DATABASE_PORT"),
+		DBSSLMode:  os.Getenv("DATABASE_SSL_MODE"),
+		DBTimeZone: os.Getenv("DATABASE_TIMEZONE"),
+
+		Auth0Domain:    os.Getenv("AUTH0_DOMAIN"),
+		Auth0Audience:  os.Getenv("AUTH0_AUDIENCE"),
+		AuthJWKSFile:   os.Getenv("AUTH_JWKS_FILE"),
+		AuthPolicyFile: os.Getenv("AUTH_POLICY_FILE"),
+	}
+
+	return config, nil
+}
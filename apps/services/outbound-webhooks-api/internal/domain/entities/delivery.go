@@ -0,0 +1,52 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DeliveryStatus is the state of a single delivery
+type DeliveryStatus string
+
+// Delivery Statuses
+const (
+	DeliveryStatusPending   DeliveryStatus = "pending"
+	DeliveryStatusSucceeded DeliveryStatus = "succeeded"
+	DeliveryStatusFailed    DeliveryStatus = "failed"
+)
+
+// DeliveryAttempt records a single request to the endpoint
+type DeliveryAttempt struct {
+	Number      int
+	StatusCode  int
+	Error       string
+	Duration    time.Duration
+	AttemptedAt time.Time
+}
+
+// Delivery is the delivery of one event to one endpoint. Every endpoint
+// subscribed to an event gets its own delivery with the same event id, so
+// receivers can deduplicate retries and redeliveries.
+type Delivery struct {
+	ID         uuid.UUID
+	EndpointID uuid.UUID
+	EventID    uuid.UUID
+	EventType  string
+
+	// Payload is the signed JSON body sent to the endpoint
+	Payload []byte
+
+	Status       DeliveryStatus
+	AttemptCount int
+	Attempts     []DeliveryAttempt
+	LastError    string
+
+	// RedeliveryOf is the delivery this one was manually redelivered from
+	RedeliveryOf uuid.UUID
+
+	NextAttemptAt time.Time
+	DeliveredAt   time.Time
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
@@ -0,0 +1,114 @@
+package entities
+
+import (
+	userValueObjects "libs/backend/domain/user/valueobjects"
+	"net/netip"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EndpointState is the state of the delivery circuit of an endpoint
+type EndpointState string
+
+// Endpoint States
+const (
+	EndpointStateEnabled  EndpointState = "enabled"
+	EndpointStateDisabled EndpointState = "disabled"
+)
+
+// Endpoint is an HTTPS endpoint of a user receiving signed event deliveries
+type Endpoint struct {
+	ID          uuid.UUID
+	CommonID    userValueObjects.CommonID
+	URL         string
+	Description string
+
+	// EventTypes filters the delivered events, empty delivers every event
+	EventTypes []string
+
+	// SigningSecret is the HMAC key of the delivery signatures
+	SigningSecret string
+
+	State EndpointState
+
+	// ConsecutiveFailures counts the failed attempts since the last success,
+	// the endpoint is disabled once it reaches the configured limit
+	ConsecutiveFailures int
+	DisabledReason      string
+	DisabledAt          time.Time
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+}
+
+// IsEnabled lets the caller know if events are delivered to the endpoint
+func (e Endpoint) IsEnabled() bool {
+	return e.State == EndpointStateEnabled
+}
+
+// Subscribes lets the caller know if the endpoint receives the event type
+func (e Endpoint) Subscribes(eventType string) bool {
+	return len(e.EventTypes) == 0 || slices.Contains(e.EventTypes, eventType)
+}
+
+// Enable enables the endpoint and closes the circuit
+func (e *Endpoint) Enable(now time.Time) {
+	e.State = EndpointStateEnabled
+	e.ConsecutiveFailures = 0
+	e.DisabledReason = ""
+	e.DisabledAt = time.Time{}
+	e.UpdatedAt = now
+}
+
+// Disable stops the deliveries to the endpoint
+func (e *Endpoint) Disable(reason string, now time.Time) {
+	e.State = EndpointStateDisabled
+	e.DisabledReason = reason
+	e.DisabledAt = now
+	e.UpdatedAt = now
+}
+
+// EndpointUpdate holds the changed fields of an endpoint, nil fields are unchanged
+type EndpointUpdate struct {
+	URL         *string
+	Description *string
+	EventTypes  *[]string
+}
+
+// nonPublicPrefixes are the ranges that are not covered by the netip
+// predicates but must not be reachable by a delivery either
+var nonPublicPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("240.0.0.0/4"),
+	netip.MustParsePrefix("64:ff9b::/96"),
+}
+
+// IsPublicAddress lets the caller know if a delivery may be sent to the
+// address, loopback, private, link-local (e.g. cloud metadata) and other
+// special purpose addresses are rejected
+func IsPublicAddress(addr netip.Addr) bool {
+	addr = addr.Unmap()
+
+	if !addr.IsValid() ||
+		addr.IsUnspecified() ||
+		addr.IsLoopback() ||
+		addr.IsPrivate() ||
+		addr.IsLinkLocalUnicast() ||
+		addr.IsLinkLocalMulticast() ||
+		addr.IsInterfaceLocalMulticast() ||
+		addr.IsMulticast() {
+		return false
+	}
+
+	for _, prefix := range nonPublicPrefixes {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,440 @@
+package services
+
+import (
+	"apps/services/outbound-webhooks-api/internal/app/ports"
+	"apps/services/outbound-webhooks-api/internal/domain/entities"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	boot "libs/backend/boot"
+	userValueObjects "libs/backend/domain/user/valueobjects"
+	"libs/backend/eventing"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// Assertion of the proper interface
+var _ ports.DeliveryService = (*DeliveryService)(nil)
+
+const (
+	// DefaultDeliveryPollInterval is how often due deliveries are attempted
+	DefaultDeliveryPollInterval = 5 * time.Second
+
+	// defaultDeliveryBatchSize is the number of deliveries attempted per poll
+	defaultDeliveryBatchSize = 100
+
+	// defaultDeliveryLease keeps a claimed delivery from other pollers while it is attempted
+	defaultDeliveryLease = time.Minute
+
+	// defaultAttemptTimeout bounds a single delivery attempt
+	defaultAttemptTimeout = 10 * time.Second
+
+	// defaultMaxAttempts is how often a delivery is attempted before it fails
+	defaultMaxAttempts = 8
+
+	// defaultRetryBackoff is the delay before the first retry, doubled for every further retry
+	defaultRetryBackoff = 30 * time.Second
+
+	// defaultMaxRetryBackoff caps the delay between two attempts
+	defaultMaxRetryBackoff = 6 * time.Hour
+
+	// defaultDisableAfterFailures is how many consecutive failed attempts disable an endpoint
+	defaultDisableAfterFailures = 20
+)
+
+// ErrEndpointDisabled is returned when a delivery is redelivered to a disabled endpoint
+var ErrEndpointDisabled = errors.New("webhook endpoint is disabled")
+
+// Payload is the JSON body of a delivery
+type Payload struct {
+	ID        string          `json:"id"`
+	Type      string          `json:"type"`
+	CreatedAt time.Time       `json:"createdAt"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// DeliveryService delivers events to the webhook endpoints of users with
+// retries, and disables endpoints that keep failing
+type DeliveryService struct {
+	logger               boot.Logger
+	endpoints            ports.EndpointRepository
+	deliveries           ports.DeliveryRepository
+	sender               ports.WebhookSender
+	attemptTimeout       time.Duration
+	maxAttempts          int
+	retryBackoff         time.Duration
+	maxRetryBackoff      time.Duration
+	disableAfterFailures int
+	now                  func() time.Time
+}
+
+// DeliveryServiceParams is a struct to hold the parameters for the DeliveryService
+type DeliveryServiceParams struct {
+	Logger     boot.Logger
+	Endpoints  ports.EndpointRepository
+	Deliveries ports.DeliveryRepository
+	Sender     ports.WebhookSender
+
+	// AttemptTimeout bounds a single delivery attempt, a batch stops before
+	// an attempt could outlast the lease of the claimed deliveries
+	AttemptTimeout time.Duration
+
+	// MaxAttempts is how often a delivery is attempted before it fails
+	MaxAttempts int
+
+	// RetryBackoff is the delay before the first retry, doubled for every further retry
+	RetryBackoff time.Duration
+
+	// MaxRetryBackoff caps the delay between two attempts
+	MaxRetryBackoff time.Duration
+
+	// DisableAfterFailures is how many consecutive failed attempts disable an endpoint
+	DisableAfterFailures int
+
+	// Now returns the current time and defaults to time.Now
+	Now func() time.Time
+}
+
+// NewDeliveryService constructs the delivery service
+func NewDeliveryService(params DeliveryServiceParams) *DeliveryService {
+	if params.AttemptTimeout <= 0 || params.AttemptTimeout >= defaultDeliveryLease {
+		params.AttemptTimeout = defaultAttemptTimeout
+	}
+	if params.MaxAttempts <= 0 {
+		params.MaxAttempts = defaultMaxAttempts
+	}
+	if params.RetryBackoff <= 0 {
+		params.RetryBackoff = defaultRetryBackoff
+	}
+	if params.MaxRetryBackoff <= 0 {
+		params.MaxRetryBackoff = defaultMaxRetryBackoff
+	}
+	if params.DisableAfterFailures <= 0 {
+		params.DisableAfterFailures = defaultDisableAfterFailures
+	}
+	if params.Now == nil {
+		params.Now = time.Now
+	}
+
+	return &DeliveryService{
+		logger:               params.Logger,
+		endpoints:            params.Endpoints,
+		deliveries:           params.Deliveries,
+		sender:               params.Sender,
+		attemptTimeout:       params.AttemptTimeout,
+		maxAttempts:          params.MaxAttempts,
+		retryBackoff:         params.RetryBackoff,
+		maxRetryBackoff:      params.MaxRetryBackoff,
+		disableAfterFailures: params.DisableAfterFailures,
+		now:                  params.Now,
+	}
+}
+
+// Enqueue creates a pending delivery of the event for every enabled endpoint
+// of the user subscribed to it. The deliveries are attempted by the next poll.
+// The event id is the id of the payload and deduplicates redelivered events.
+func (s *DeliveryService) Enqueue(ctx context.Context, eventID uuid.UUID, commonID userValueObjects.CommonID, eventName eventing.EventName, event proto.Message) error {
+	endpoints, err := s.endpoints.ListEndpoints(ctx, commonID)
+	if err != nil {
+		return err
+	}
+
+	now := s.now()
+
+	var payload []byte
+	deliveries := make([]entities.Delivery, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		if !endpoint.IsEnabled() || !endpoint.Subscribes(eventName.String()) {
+			continue
+		}
+
+		if payload == nil {
+			payload, err = encodePayload(eventID, eventName, event, now)
+			if err != nil {
+				return err
+			}
+		}
+
+		deliveries = append(deliveries, entities.Delivery{
+			ID:            uuid.New(),
+			EndpointID:    endpoint.ID,
+			EventID:       eventID,
+			EventType:     eventName.String(),
+			Payload:       payload,
+			Status:        entities.DeliveryStatusPending,
+			NextAttemptAt: now,
+			CreatedAt:     now,
+			UpdatedAt:     now,
+		})
+	}
+
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	if err := s.deliveries.CreateDeliveries(ctx, deliveries); err != nil {
+		return err
+	}
+
+	s.logger.Info(
+		"Enqueued webhook deliveries",
+		slog.String("commonID", commonID.String()),
+		slog.String("eventName", eventName.String()),
+		slog.Int("deliveries", len(deliveries)),
+	)
+
+	return nil
+}
+
+// DeliverDue attempts the due pending deliveries of the enabled endpoints.
+// Attempts only start while they can finish within the lease, the remaining
+// deliveries of the batch are claimed again once their lease expired.
+func (s *DeliveryService) DeliverDue(ctx context.Context) error {
+	claimedAt := s.now()
+	deliveries, err := s.deliveries.ClaimDueDeliveries(ctx, claimedAt, defaultDeliveryBatchSize, defaultDeliveryLease)
+	if err != nil {
+		return err
+	}
+
+	// The last moment an attempt can start and still finish within the lease
+	deadline := claimedAt.Add(defaultDeliveryLease - s.attemptTimeout)
+
+	// The endpoints of the batch, read once
+	endpoints := make(map[uuid.UUID]entities.Endpoint)
+
+	for i, delivery := range deliveries {
+		if s.now().After(deadline) {
+			s.logger.Warn("Webhook delivery lease is running out", slog.Int("remainingDeliveries", len(deliveries)-i))
+			break
+		}
+
+		endpoint, ok := endpoints[delivery.EndpointID]
+		if !ok {
+			endpoint, err = s.endpoints.GetEndpoint(ctx, delivery.EndpointID)
+			if err != nil {
+				s.logger.Error("Cannot get webhook endpoint", slog.String("endpointID", delivery.EndpointID.String()), slog.Any("error", err))
+				continue
+			}
+		}
+
+		// An earlier delivery of the batch may have disabled the endpoint
+		if endpoint.IsEnabled() {
+			endpoint = s.attempt(ctx, endpoint, delivery)
+		}
+		endpoints[endpoint.ID] = endpoint
+	}
+
+	return nil
+}
+
+// Run delivers the due deliveries on every interval until the context is cancelled
+func (s *DeliveryService) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.DeliverDue(ctx); err != nil {
+				s.logger.Error("Cannot claim due webhook deliveries", slog.Any("error", err))
+			}
+		}
+	}
+}
+
+// GetDelivery returns the delivery
+func (s *DeliveryService) GetDelivery(ctx context.Context, id uuid.UUID) (entities.Delivery, error) {
+	return s.deliveries.GetDelivery(ctx, id)
+}
+
+// ListDeliveries returns the most recent deliveries of the endpoint, optionally filtered by status
+func (s *DeliveryService) ListDeliveries(ctx context.Context, endpointID uuid.UUID, status entities.DeliveryStatus, limit int) ([]entities.Delivery, error) {
+	return s.deliveries.ListDeliveries(ctx, endpointID, status, limit)
+}
+
+// Redeliver delivers the event of the delivery again as a new pending
+// delivery, keeping the log of the original delivery intact
+func (s *DeliveryService) Redeliver(ctx context.Context, id uuid.UUID) (entities.Delivery, error) {
+	original, err := s.deliveries.GetDelivery(ctx, id)
+	if err != nil {
+		return entities.Delivery{}, err
+	}
+
+	endpoint, err := s.endpoints.GetEndpoint(ctx, original.EndpointID)
+	if err != nil {
+		return entities.Delivery{}, err
+	}
+	if !endpoint.IsEnabled() {
+		return entities.Delivery{}, ErrEndpointDisabled
+	}
+
+	now := s.now()
+	delivery := entities.Delivery{
+		ID:            uuid.New(),
+		EndpointID:    original.EndpointID,
+		EventID:       original.EventID,
+		EventType:     original.EventType,
+		Payload:       original.Payload,
+		Status:        entities.DeliveryStatusPending,
+		RedeliveryOf:  original.ID,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+
+	if err := s.deliveries.CreateDeliveries(ctx, []entities.Delivery{delivery}); err != nil {
+		return entities.Delivery{}, err
+	}
+
+	s.logger.Info("Redelivering webhook delivery", slog.String("deliveryID", original.ID.String()), slog.String("redeliveryID", delivery.ID.String()))
+
+	return delivery, nil
+}
+
+// attempt sends the delivery once, records the attempt and schedules the
+// retry. It returns the endpoint with its updated circuit state.
+func (s *DeliveryService) attempt(ctx context.Context, endpoint entities.Endpoint, delivery entities.Delivery) entities.Endpoint {
+	start := s.now()
+	header := deliveryHeader(endpoint, delivery, start)
+
+	sendCtx, cancel := context.WithTimeout(ctx, s.attemptTimeout)
+	statusCode, sendErr := s.sender.Send(sendCtx, endpoint.URL, header, delivery.Payload)
+	cancel()
+
+	now := s.now()
+	delivery.AttemptCount++
+	attempt := entities.DeliveryAttempt{
+		Number:      delivery.AttemptCount,
+		StatusCode:  statusCode,
+		Duration:    now.Sub(start),
+		AttemptedAt: start,
+	}
+	delivery.UpdatedAt = now
+
+	logAttrs := []any{
+		slog.String("endpointID", endpoint.ID.String()),
+		slog.String("deliveryID", delivery.ID.String()),
+		slog.Int("attempt", delivery.AttemptCount),
+		slog.Int("statusCode", statusCode),
+	}
+
+	if sendErr == nil {
+		delivery.Status = entities.DeliveryStatusSucceeded
+		delivery.LastError = ""
+		delivery.DeliveredAt = now
+		delivery.NextAttemptAt = time.Time{}
+		s.save(ctx, delivery, attempt)
+
+		if endpoint.ConsecutiveFailures > 0 {
+			if err := s.endpoints.ResetFailures(ctx, endpoint.ID, now); err != nil {
+				s.logger.Error("Cannot reset webhook endpoint failures", slog.Any("error", err))
+			}
+			endpoint.ConsecutiveFailures = 0
+		}
+
+		s.logger.Info("Delivered webhook", logAttrs...)
+		return endpoint
+	}
+
+	attempt.Error = sendErr.Error()
+	delivery.LastError = sendErr.Error()
+	if delivery.AttemptCount >= s.maxAttempts {
+		delivery.Status = entities.DeliveryStatusFailed
+		delivery.NextAttemptAt = time.Time{}
+	} else {
+		delivery.NextAttemptAt = now.Add(s.backoff(delivery.AttemptCount))
+	}
+	s.save(ctx, delivery, attempt)
+
+	s.logger.Warn("Webhook delivery failed", append(logAttrs, slog.Any("error", sendErr))...)
+
+	return s.recordFailure(ctx, endpoint, now)
+}
+
+// recordFailure counts the failed attempt and opens the circuit of the endpoint at the limit
+func (s *DeliveryService) recordFailure(ctx context.Context, endpoint entities.Endpoint, now time.Time) entities.Endpoint {
+	failures, err := s.endpoints.IncrementFailures(ctx, endpoint.ID, now)
+	if err != nil {
+		s.logger.Error("Cannot count webhook endpoint failure", slog.Any("error", err))
+		return endpoint
+	}
+	endpoint.ConsecutiveFailures = failures
+
+	if failures < s.disableAfterFailures {
+		return endpoint
+	}
+
+	// Read the endpoint again, the user may have changed it meanwhile
+	stored, err := s.endpoints.GetEndpoint(ctx, endpoint.ID)
+	if err != nil {
+		s.logger.Error("Cannot get webhook endpoint", slog.Any("error", err))
+		return endpoint
+	}
+
+	stored.Disable(fmt.Sprintf("disabled after %d consecutive failed deliveries", failures), now)
+	if err := s.endpoints.SaveEndpoint(ctx, stored); err != nil {
+		s.logger.Error("Cannot disable webhook endpoint", slog.Any("error", err))
+		return endpoint
+	}
+
+	s.logger.Warn("Disabled failing webhook endpoint", slog.String("endpointID", endpoint.ID.String()), slog.Int("failures", failures))
+
+	return stored
+}
+
+// save stores the outcome of the attempt, the lease retries the delivery when it cannot be stored
+func (s *DeliveryService) save(ctx context.Context, delivery entities.Delivery, attempt entities.DeliveryAttempt) {
+	if err := s.deliveries.SaveAttempt(ctx, delivery, attempt); err != nil {
+		s.logger.Error("Cannot save webhook delivery attempt", slog.String("deliveryID", delivery.ID.String()), slog.Any("error", err))
+	}
+}
+
+// backoff doubles the retry delay for every attempt up to the maximum
+func (s *DeliveryService) backoff(attempt int) time.Duration {
+	delay := s.retryBackoff
+	for i := 1; i < attempt && delay < s.maxRetryBackoff; i++ {
+		delay *= 2
+	}
+
+	return min(delay, s.maxRetryBackoff)
+}
+
+// deliveryHeader returns the headers of a signed delivery
+func deliveryHeader(endpoint entities.Endpoint, delivery entities.Delivery, now time.Time) http.Header {
+	header := make(http.Header)
+	header.Set("Content-Type", "application/json")
+	header.Set(WebhookIDHeader, delivery.EventID.String())
+	header.Set(WebhookEventHeader, delivery.EventType)
+	header.Set(WebhookTimestampHeader, fmt.Sprintf("%d", now.Unix()))
+	header.Set(WebhookSignatureHeader, SignPayload(endpoint.SigningSecret, now, delivery.Payload))
+
+	return header
+}
+
+// encodePayload wraps the JSON form of the event in the delivery envelope
+func encodePayload(eventID uuid.UUID, eventName eventing.EventName, event proto.Message, now time.Time) ([]byte, error) {
+	data, err := protojson.Marshal(event)
+	if err != nil {
+		return nil, fmt.Errorf("cannot encode webhook event: %w", err)
+	}
+
+	payload, err := json.Marshal(Payload{
+		ID:        eventID.String(),
+		Type:      eventName.String(),
+		CreatedAt: now.UTC(),
+		Data:      data,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cannot encode webhook payload: %w", err)
+	}
+
+	return payload, nil
+}
@@ -0,0 +1,224 @@
+package services
+
+import (
+	"apps/services/outbound-webhooks-api/internal/app/ports"
+	"apps/services/outbound-webhooks-api/internal/domain/entities"
+	"context"
+	"fmt"
+	boot "libs/backend/boot"
+	userValueObjects "libs/backend/domain/user/valueobjects"
+	"libs/backend/eventing"
+	"log/slog"
+	"net/netip"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Assertion of the proper interface
+var _ ports.EndpointService = (*EndpointService)(nil)
+
+// SupportedEventTypes are the events delivered to webhook endpoints
+var SupportedEventTypes = []eventing.EventName{
+	eventing.EventNameAccountCreated,
+	eventing.EventNameAccountUpdated,
+	eventing.EventNameAccountSoftDeleted,
+	eventing.EventNameAccountHardDeleted,
+	eventing.EventNameAccountRestored,
+}
+
+// internalHostSuffixes are the names that never resolve to a public host
+var internalHostSuffixes = []string{".localhost", ".local", ".internal", ".home.arpa"}
+
+// EndpointService manages the webhook endpoints of users
+type EndpointService struct {
+	logger     boot.Logger
+	repository ports.EndpointRepository
+	now        func() time.Time
+}
+
+// EndpointServiceParams is a struct to hold the parameters for the EndpointService
+type EndpointServiceParams struct {
+	Logger     boot.Logger
+	Repository ports.EndpointRepository
+
+	// Now returns the current time and defaults to time.Now
+	Now func() time.Time
+}
+
+// NewEndpointService constructs the endpoint service
+func NewEndpointService(params EndpointServiceParams) *EndpointService {
+	if params.Now == nil {
+		params.Now = time.Now
+	}
+
+	return &EndpointService{
+		logger:     params.Logger,
+		repository: params.Repository,
+		now:        params.Now,
+	}
+}
+
+// CreateEndpoint registers the endpoint with a new signing secret
+func (s *EndpointService) CreateEndpoint(ctx context.Context, commonID userValueObjects.CommonID, url, description string, eventTypes []string) (entities.Endpoint, error) {
+	if err := validateEndpointURL(url); err != nil {
+		return entities.Endpoint{}, err
+	}
+	if err := validateEventTypes(eventTypes); err != nil {
+		return entities.Endpoint{}, err
+	}
+
+	secret, err := GenerateSigningSecret()
+	if err != nil {
+		return entities.Endpoint{}, err
+	}
+
+	now := s.now()
+	endpoint := entities.Endpoint{
+		ID:            uuid.New(),
+		CommonID:      commonID,
+		URL:           url,
+		Description:   description,
+		EventTypes:    eventTypes,
+		SigningSecret: secret,
+		State:         entities.EndpointStateEnabled,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+
+	if err := s.repository.CreateEndpoint(ctx, endpoint); err != nil {
+		return entities.Endpoint{}, err
+	}
+
+	s.logger.Info("Created webhook endpoint", slog.String("endpointID", endpoint.ID.String()), slog.String("commonID", commonID.String()))
+
+	return endpoint, nil
+}
+
+// GetEndpoint returns the endpoint
+func (s *EndpointService) GetEndpoint(ctx context.Context, id uuid.UUID) (entities.Endpoint, error) {
+	return s.repository.GetEndpoint(ctx, id)
+}
+
+// ListEndpoints returns the endpoints of the user
+func (s *EndpointService) ListEndpoints(ctx context.Context, commonID userValueObjects.CommonID) ([]entities.Endpoint, error) {
+	return s.repository.ListEndpoints(ctx, commonID)
+}
+
+// UpdateEndpoint changes the provided fields of the endpoint
+func (s *EndpointService) UpdateEndpoint(ctx context.Context, id uuid.UUID, update entities.EndpointUpdate) (entities.Endpoint, error) {
+	endpoint, err := s.repository.GetEndpoint(ctx, id)
+	if err != nil {
+		return entities.Endpoint{}, err
+	}
+
+	if update.URL != nil {
+		if err := validateEndpointURL(*update.URL); err != nil {
+			return entities.Endpoint{}, err
+		}
+		endpoint.URL = *update.URL
+	}
+	if update.Description != nil {
+		endpoint.Description = *update.Description
+	}
+	if update.EventTypes != nil {
+		if err := validateEventTypes(*update.EventTypes); err != nil {
+			return entities.Endpoint{}, err
+		}
+		endpoint.EventTypes = *update.EventTypes
+	}
+	endpoint.UpdatedAt = s.now()
+
+	if err := s.repository.SaveEndpoint(ctx, endpoint); err != nil {
+		return entities.Endpoint{}, err
+	}
+
+	return endpoint, nil
+}
+
+// EnableEndpoint enables the endpoint and resets its failures, pending
+// deliveries are attempted again by the next poll
+func (s *EndpointService) EnableEndpoint(ctx context.Context, id uuid.UUID) (entities.Endpoint, error) {
+	endpoint, err := s.repository.GetEndpoint(ctx, id)
+	if err != nil {
+		return entities.Endpoint{}, err
+	}
+
+	endpoint.Enable(s.now())
+	if err := s.repository.SaveEndpoint(ctx, endpoint); err != nil {
+		return entities.Endpoint{}, err
+	}
+
+	s.logger.Info("Enabled webhook endpoint", slog.String("endpointID", id.String()))
+
+	return endpoint, nil
+}
+
+// DisableEndpoint stops the deliveries to the endpoint
+func (s *EndpointService) DisableEndpoint(ctx context.Context, id uuid.UUID) (entities.Endpoint, error) {
+	endpoint, err := s.repository.GetEndpoint(ctx, id)
+	if err != nil {
+		return entities.Endpoint{}, err
+	}
+
+	endpoint.Disable("disabled by the user", s.now())
+	if err := s.repository.SaveEndpoint(ctx, endpoint); err != nil {
+		return entities.Endpoint{}, err
+	}
+
+	s.logger.Info("Disabled webhook endpoint", slog.String("endpointID", id.String()))
+
+	return endpoint, nil
+}
+
+// DeleteEndpoint deletes the endpoint and its deliveries
+func (s *EndpointService) DeleteEndpoint(ctx context.Context, id uuid.UUID) error {
+	return s.repository.DeleteEndpoint(ctx, id)
+}
+
+// validateEndpointURL ensures the URL is an HTTPS URL of a host that may be
+// public, names are resolved again by the sender on every delivery
+func validateEndpointURL(rawURL string) error {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ports.ErrInvalidEndpointURL, err)
+	}
+
+	if parsed.Scheme != "https" || parsed.User != nil {
+		return fmt.Errorf("%w: only https URLs without credentials are supported", ports.ErrInvalidEndpointURL)
+	}
+
+	host := strings.TrimSuffix(strings.ToLower(parsed.Hostname()), ".")
+	if host == "" {
+		return fmt.Errorf("%w: missing host", ports.ErrInvalidEndpointURL)
+	}
+
+	if addr, err := netip.ParseAddr(host); err == nil {
+		if !entities.IsPublicAddress(addr) {
+			return fmt.Errorf("%w: %s is not a public address", ports.ErrInvalidEndpointURL, host)
+		}
+		return nil
+	}
+
+	if !strings.Contains(host, ".") || slices.ContainsFunc(internalHostSuffixes, func(suffix string) bool {
+		return host == strings.TrimPrefix(suffix, ".") || strings.HasSuffix(host, suffix)
+	}) {
+		return fmt.Errorf("%w: %s is not a public host", ports.ErrInvalidEndpointURL, host)
+	}
+
+	return nil
+}
+
+// validateEventTypes ensures every event type is delivered to endpoints
+func validateEventTypes(eventTypes []string) error {
+	for _, eventType := range eventTypes {
+		if !slices.Contains(SupportedEventTypes, eventing.EventName(eventType)) {
+			return fmt.Errorf("%w: %s", ports.ErrUnsupportedEventType, eventType)
+		}
+	}
+
+	return nil
+}
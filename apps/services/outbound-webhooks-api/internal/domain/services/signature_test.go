@@ -0,0 +1,47 @@
+package services_test
+
+import (
+	"apps/services/outbound-webhooks-api/internal/domain/services"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignature(t *testing.T) {
+	secret, err := services.GenerateSigningSecret()
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(secret, "whsec_"))
+
+	body := []byte(`{"id":"1","type":"career-cue.accounts.accountCreated"}`)
+	signedAt := time.Unix(1700000000, 0)
+	header := services.SignPayload(secret, signedAt, body)
+
+	assert.True(t, strings.HasPrefix(header, "t=1700000000,v1="))
+
+	t.Run("verifies the signed payload", func(t *testing.T) {
+		assert.NoError(t, services.VerifySignature(secret, header, body, services.DefaultSignatureTolerance, signedAt.Add(time.Minute)))
+	})
+
+	t.Run("rejects a changed payload", func(t *testing.T) {
+		err := services.VerifySignature(secret, header, []byte(`{}`), services.DefaultSignatureTolerance, signedAt)
+		assert.ErrorIs(t, err, services.ErrInvalidSignature)
+	})
+
+	t.Run("rejects another secret", func(t *testing.T) {
+		err := services.VerifySignature("whsec_other", header, body, services.DefaultSignatureTolerance, signedAt)
+		assert.ErrorIs(t, err, services.ErrInvalidSignature)
+	})
+
+	t.Run("rejects old signatures", func(t *testing.T) {
+		err := services.VerifySignature(secret, header, body, services.DefaultSignatureTolerance, signedAt.Add(time.Hour))
+		assert.ErrorIs(t, err, services.ErrSignatureExpired)
+	})
+
+	t.Run("rejects malformed headers", func(t *testing.T) {
+		assert.ErrorIs(t, services.VerifySignature(secret, "v1=abc", body, services.DefaultSignatureTolerance, signedAt), services.ErrInvalidSignature)
+		assert.ErrorIs(t, services.VerifySignature(secret, "t=abc,v1=abc", body, services.DefaultSignatureTolerance, signedAt), services.ErrInvalidSignature)
+	})
+}
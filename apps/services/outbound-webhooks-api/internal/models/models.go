@@ -0,0 +1,53 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WebhookEndpoint is our model, which corresponds to the "webhook_endpoints" table
+type WebhookEndpoint struct {
+	ID                  uuid.UUID `gorm:"primaryKey;type:uuid"`
+	CommonID            uuid.UUID `gorm:"type:uuid;index:idx_webhook_endpoint_common_id;not null;"`
+	URL                 string    `gorm:"not null;"`
+	Description         string
+	EventTypes          []string `gorm:"serializer:json;not null;"`
+	SigningSecret       string   `gorm:"not null;"`
+	State               string   `gorm:"not null;"`
+	ConsecutiveFailures int      `gorm:"not null;default:0;"`
+	DisabledReason      string
+	DisabledAt          *time.Time
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+	Deliveries          []WebhookDelivery `gorm:"foreignKey:EndpointID;constraint:OnDelete:CASCADE;"`
+}
+
+// WebhookDelivery is our model, which corresponds to the "webhook_deliveries" table
+type WebhookDelivery struct {
+	ID            uuid.UUID `gorm:"primaryKey;type:uuid"`
+	EndpointID    uuid.UUID `gorm:"type:uuid;index:idx_webhook_delivery_endpoint_created_at;uniqueIndex:idx_webhook_delivery_endpoint_event,where:redelivery_of IS NULL;not null;"`
+	EventID       uuid.UUID `gorm:"type:uuid;uniqueIndex:idx_webhook_delivery_endpoint_event;not null;"`
+	EventType     string    `gorm:"not null;"`
+	Payload       []byte    `gorm:"not null;"`
+	Status        string    `gorm:"index:idx_webhook_delivery_status_next_attempt;not null;"`
+	AttemptCount  int       `gorm:"not null;default:0;"`
+	LastError     string
+	RedeliveryOf  *uuid.UUID `gorm:"type:uuid;"`
+	NextAttemptAt *time.Time `gorm:"index:idx_webhook_delivery_status_next_attempt;"`
+	DeliveredAt   *time.Time
+	CreatedAt     time.Time `gorm:"index:idx_webhook_delivery_endpoint_created_at;"`
+	UpdatedAt     time.Time
+	Attempts      []WebhookDeliveryAttempt `gorm:"foreignKey:DeliveryID;constraint:OnDelete:CASCADE;"`
+}
+
+// WebhookDeliveryAttempt is our model, which corresponds to the "webhook_delivery_attempts" table
+type WebhookDeliveryAttempt struct {
+	ID          uuid.UUID `gorm:"primaryKey;type:uuid"`
+	DeliveryID  uuid.UUID `gorm:"type:uuid;uniqueIndex:idx_webhook_delivery_attempt_number;not null;"`
+	Number      int       `gorm:"uniqueIndex:idx_webhook_delivery_attempt_number;not null;"`
+	StatusCode  int
+	Error       string
+	DurationMS  int64
+	AttemptedAt time.Time
+}
@@ -1,6 +1,96 @@
+// Package auth manages the users of the Auth0 tenant through the Auth0
+// Management API, with an in-memory stand-in for tests and local development
 package auth
 
-func Auth(name string) string {
-	result := "Auth " + name
-	return result
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// CommonIDKey is the app metadata key of the account of the user, which the
+// Auth0 Action adds to the tokens
+const CommonIDKey = "common_id"
+
+// Errors of the Management API
+var (
+	ErrManagementRequest = errors.New("management api request failed")
+	ErrUserNotFound      = errors.New("auth0 user not found")
+	ErrRateLimited       = errors.New("management api rate limit exceeded")
+)
+
+// ManagementClient manages the users of the Auth0 tenant
+type ManagementClient interface {
+	// GetUser returns the user of the Auth0 user id
+	GetUser(ctx context.Context, userID string) (User, error)
+
+	// ListUsersByEmail returns the users of the email address, one per connection
+	ListUsersByEmail(ctx context.Context, email string) ([]User, error)
+
+	// UpdateUser updates the set fields of the user
+	UpdateUser(ctx context.Context, userID string, update UserUpdate) (User, error)
+
+	// DeleteUser deletes the user, deleting an unknown user is not an error
+	DeleteUser(ctx context.Context, userID string) error
+
+	// SetAppMetadata merges the keys into the app metadata of the user, a nil
+	// value removes the key
+	SetAppMetadata(ctx context.Context, userID string, metadata map[string]any) (User, error)
+
+	// SetCommonID links the user to the account
+	SetCommonID(ctx context.Context, userID, commonID string) (User, error)
+
+	// BlockUser prevents the user from logging in
+	BlockUser(ctx context.Context, userID string) error
+
+	// UnblockUser allows the user to log in again
+	UnblockUser(ctx context.Context, userID string) error
+
+	// ResendVerificationEmail sends the email verification to the user again
+	ResendVerificationEmail(ctx context.Context, userID string) (Job, error)
+}
+
+// User is an Auth0 user
+type User struct {
+	ID            string         `json:"user_id"`
+	Email         string         `json:"email,omitempty"`
+	EmailVerified bool           `json:"email_verified"`
+	Name          string         `json:"name,omitempty"`
+	GivenName     string         `json:"given_name,omitempty"`
+	FamilyName    string         `json:"family_name,omitempty"`
+	Nickname      string         `json:"nickname,omitempty"`
+	Picture       string         `json:"picture,omitempty"`
+	Blocked       bool           `json:"blocked"`
+	AppMetadata   map[string]any `json:"app_metadata,omitempty"`
+	UserMetadata  map[string]any `json:"user_metadata,omitempty"`
+	CreatedAt     time.Time      `json:"created_at"`
+	UpdatedAt     time.Time      `json:"updated_at"`
+}
+
+// CommonID returns the account of the user from the app metadata
+func (u User) CommonID() string {
+	commonID, _ := u.AppMetadata[CommonIDKey].(string)
+	return commonID
+}
+
+// UserUpdate holds the fields of a user to update, nil fields are kept
+type UserUpdate struct {
+	Email         *string        `json:"email,omitempty"`
+	EmailVerified *bool          `json:"email_verified,omitempty"`
+	Name          *string        `json:"name,omitempty"`
+	GivenName     *string        `json:"given_name,omitempty"`
+	FamilyName    *string        `json:"family_name,omitempty"`
+	Nickname      *string        `json:"nickname,omitempty"`
+	Picture       *string        `json:"picture,omitempty"`
+	Blocked       *bool          `json:"blocked,omitempty"`
+	AppMetadata   map[string]any `json:"app_metadata,omitempty"`
+	UserMetadata  map[string]any `json:"user_metadata,omitempty"`
+}
+
+// Job is a job of the Management API, such as sending an email
+type Job struct {
+	ID        string    `json:"id"`
+	Type      string    `json:"type"`
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"created_at"`
 }
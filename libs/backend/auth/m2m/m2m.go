@@ -1,3 +1,5 @@
+// Package m2m fetches and caches OAuth2 client credentials tokens, e.g. of
+// Auth0 machine to machine applications
 package m2m
 
 import (
@@ -5,134 +7,321 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math/rand/v2"
 	"net/http"
+	"net/url"
+	"strings"
 	"sync"
 	"time"
 
-	"github.com/auth0/go-auth0/authentication"
+	"golang.org/x/sync/singleflight"
 )
 
 // Assertion of the proper interface
-var _ M2MGenerator = (*M2M)(nil)
+var _ M2MGenerator = (*TokenSource)(nil)
+
+// Defaults of the token source
+const (
+	DefaultRefreshAhead  = 5 * time.Minute
+	DefaultRefreshJitter = time.Minute
+	DefaultHTTPTimeout   = 15 * time.Second
+)
 
 const (
 	clientCredentials string = "client_credentials"
+	bearerTokenType   string = "Bearer"
+)
+
+// Errors of the token requests
+var (
+	ErrTokenRequest     = errors.New("token request failed")
+	ErrInvalidTokenBody = errors.New("token response is invalid")
 )
 
+// M2MGenerator provides the access tokens of a machine
 type M2MGenerator interface {
-	GetToken() (Token, error)
-}
+	// GetToken returns a valid token of the default audience
+	GetToken(ctx context.Context) (Token, error)
 
-type M2M struct {
-	domain       string
-	audience     string
-	clientID     string
-	clientSecret string
-	auth         *authentication.Authentication
-	mu           *sync.RWMutex
-	token        Token
-}
+	// GetTokenForAudience returns a valid token of the audience
+	GetTokenForAudience(ctx context.Context, audience string) (Token, error)
 
-type tokenRequestBody struct {
-	ClientID     string `json:"client_id"`
-	ClientSecret string `json:"client_secret"`
-	Audience     string `json:"audience"`
-	GrantType    string `json:"grant_type"`
+	// AuthorizationHeader satisfies httpauth.TokenSource for client interceptors
+	AuthorizationHeader(ctx context.Context) (string, error)
 }
 
+// tokenResponseBody is the OAuth2 token response
 type tokenResponseBody struct {
 	AccessToken string `json:"access_token"`
 	ExpiresIn   int64  `json:"expires_in"`
 	TokenType   string `json:"token_type"`
 }
 
+// errorResponseBody is the OAuth2 error response
+type errorResponseBody struct {
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// Token is an access token with its absolute expiry
 type Token struct {
-	accessToken string
-	expiresIn   time.Time
-	tokenType   string
+	AccessToken string
+	TokenType   string
+	Expiry      time.Time
+
+	// refreshAt is the time from which the token is refreshed ahead of its expiry
+	refreshAt time.Time
 }
 
+// IsExpired checks whether the token is expired now
 func (t Token) IsExpired() bool {
-	now := time.Now().UTC()
-	return t.expiresIn.Before(now)
+	return t.expiredAt(time.Now())
 }
 
+// IsZero checks whether the token was never fetched
 func (t Token) IsZero() bool {
-	return t.expiresIn.IsZero()
+	return t.AccessToken == ""
 }
 
+// GetHeaderValue returns the value of the Authorization header
 func (t Token) GetHeaderValue() string {
-	return fmt.Sprintf("%s %s", t.tokenType, t.accessToken)
+	tokenType := t.TokenType
+	if tokenType == "" || strings.EqualFold(tokenType, bearerTokenType) {
+		tokenType = bearerTokenType
+	}
+
+	return fmt.Sprintf("%s %s", tokenType, t.AccessToken)
 }
 
-func NewM2M(auth0Domain, auth0Audience, auth0ClientID, auth0ClientSecret string) (*M2M, error) {
-	ctx := context.Background()
-	a, err := authentication.New(ctx, auth0Domain, authentication.WithClientID(auth0ClientID), authentication.WithClientSecret(auth0ClientSecret))
-	if err != nil {
-		return &M2M{}, fmt.Errorf("cannot connect to auth0 Management API: %w", err)
+// expiredAt checks whether the token is expired at the time
+func (t Token) expiredAt(now time.Time) bool {
+	return !now.Before(t.Expiry)
+}
+
+// TokenSource fetches tokens with the client credentials grant and caches
+// them per audience. Tokens are refreshed ahead of their expiry with jitter,
+// and concurrent refreshes of an audience share a single token request.
+type TokenSource struct {
+	tokenURL     string
+	clientID     string
+	clientSecret string
+	audience     string
+	client       *http.Client
+	refreshAhead time.Duration
+	jitter       time.Duration
+	now          func() time.Time
+
+	mu     sync.RWMutex
+	tokens map[string]Token
+	group  singleflight.Group
+}
+
+// TokenSourceParams is a struct to hold the parameters for the TokenSource
+type TokenSourceParams struct {
+	TokenURL     string
+	ClientID     string
+	ClientSecret string
+
+	// Audience is the default audience of the tokens
+	Audience string
+
+	// HTTPClient defaults to a client with the DefaultHTTPTimeout
+	HTTPClient *http.Client
+
+	// RefreshAhead defaults to DefaultRefreshAhead, and is at most half the
+	// lifetime of a token
+	RefreshAhead time.Duration
+
+	// Jitter defaults to DefaultRefreshJitter and spreads the refreshes of
+	// replicas sharing a client
+	Jitter time.Duration
+
+	// Now returns the current time and defaults to time.Now
+	Now func() time.Time
+}
+
+// NewTokenSource constructs the token source of the client
+func NewTokenSource(params TokenSourceParams) (*TokenSource, error) {
+	if params.TokenURL == "" {
+		return nil, errors.New("token source needs a token url")
+	}
+	if params.ClientID == "" {
+		return nil, errors.New("token source needs a client id")
+	}
+	if params.HTTPClient == nil {
+		params.HTTPClient = &http.Client{Timeout: DefaultHTTPTimeout}
+	}
+	if params.RefreshAhead == 0 {
+		params.RefreshAhead = DefaultRefreshAhead
+	}
+	if params.Jitter == 0 {
+		params.Jitter = DefaultRefreshJitter
+	}
+	if params.Now == nil {
+		params.Now = time.Now
 	}
 
-	return &M2M{
-		domain:       auth0Domain,
-		audience:     auth0Audience,
-		clientID:     auth0ClientID,
-		clientSecret: auth0ClientSecret,
-		auth:         a,
-		mu:           new(sync.RWMutex),
-		token:        Token{},
+	return &TokenSource{
+		tokenURL:     params.TokenURL,
+		clientID:     params.ClientID,
+		clientSecret: params.ClientSecret,
+		audience:     params.Audience,
+		client:       params.HTTPClient,
+		refreshAhead: params.RefreshAhead,
+		jitter:       params.Jitter,
+		now:          params.Now,
+		tokens:       make(map[string]Token),
 	}, nil
 }
 
-func (g *M2M) GetToken() (Token, error) {
-	if g.token.IsExpired() {
-		token, err := g.fetchToken()
-		if err != nil {
-			return Token{}, errors.New("trouble fetching new token from auth0")
-		}
+// NewM2M constructs the token source of an Auth0 machine to machine
+// application, a domain with a scheme such as a local issuer is kept as is
+func NewM2M(auth0Domain, auth0Audience, auth0ClientID, auth0ClientSecret string) (*TokenSource, error) {
+	return NewTokenSource(TokenSourceParams{
+		TokenURL:     Auth0TokenURL(auth0Domain),
+		ClientID:     auth0ClientID,
+		ClientSecret: auth0ClientSecret,
+		Audience:     auth0Audience,
+	})
+}
 
-		g.mu.Lock()
-		g.token = token
-		g.mu.Unlock()
+// Auth0TokenURL returns the token endpoint of the Auth0 tenant domain
+func Auth0TokenURL(domain string) string {
+	if !strings.Contains(domain, "://") {
+		domain = "https://" + domain
 	}
 
-	// Read token from the m2m struct
-	var foundToken Token
-	g.mu.RLock()
-	foundToken = g.token
-	g.mu.RUnlock()
+	return strings.TrimSuffix(domain, "/") + "/oauth/token"
+}
 
-	return foundToken, nil
+// GetToken returns a valid token of the default audience
+func (s *TokenSource) GetToken(ctx context.Context) (Token, error) {
+	return s.GetTokenForAudience(ctx, s.audience)
 }
 
-func (g *M2M) fetchToken() (Token, error) {
-	reqBody := tokenRequestBody{
-		ClientID:     g.clientID,
-		ClientSecret: g.clientSecret,
-		Audience:     g.audience,
-		GrantType:    clientCredentials,
+// GetTokenForAudience returns the cached token of the audience. A token due
+// for refresh is still returned while it is refreshed in the background, an
+// expired or missing token is waited for.
+func (s *TokenSource) GetTokenForAudience(ctx context.Context, audience string) (Token, error) {
+	now := s.now()
+
+	s.mu.RLock()
+	token, ok := s.tokens[audience]
+	s.mu.RUnlock()
+
+	if ok && now.Before(token.refreshAt) {
+		return token, nil
+	}
+
+	refreshed := s.group.DoChan(audience, func() (interface{}, error) {
+		return s.refresh(audience)
+	})
+
+	if ok && !token.expiredAt(now) {
+		return token, nil
 	}
 
-	req, err := g.auth.NewRequest(context.Background(), http.MethodPost, fmt.Sprintf("%s/oauth/token", g.domain), reqBody)
+	select {
+	case <-ctx.Done():
+		return Token{}, ctx.Err()
+	case result := <-refreshed:
+		if result.Err != nil {
+			return Token{}, result.Err
+		}
+		return result.Val.(Token), nil
+	}
+}
+
+// AuthorizationHeader returns the header value of a valid token of the default audience
+func (s *TokenSource) AuthorizationHeader(ctx context.Context) (string, error) {
+	token, err := s.GetToken(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return token.GetHeaderValue(), nil
+}
+
+// refresh fetches and caches a token of the audience, it is shared by the
+// concurrent callers so it does not use the context of any of them
+func (s *TokenSource) refresh(audience string) (Token, error) {
+	// A refresh that completed in the meantime already cached a fresh token
+	s.mu.RLock()
+	cached, ok := s.tokens[audience]
+	s.mu.RUnlock()
+	if ok && s.now().Before(cached.refreshAt) {
+		return cached, nil
+	}
+
+	token, err := s.fetchToken(context.Background(), audience)
 	if err != nil {
 		return Token{}, err
 	}
 
-	res, err := g.auth.Do(req)
+	s.mu.Lock()
+	s.tokens[audience] = token
+	s.mu.Unlock()
+
+	return token, nil
+}
+
+// fetchToken requests a token of the audience with the client credentials grant
+func (s *TokenSource) fetchToken(ctx context.Context, audience string) (Token, error) {
+	form := url.Values{
+		"grant_type":    {clientCredentials},
+		"client_id":     {s.clientID},
+		"client_secret": {s.clientSecret},
+	}
+	if audience != "" {
+		form.Set("audience", audience)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.tokenURL, strings.NewReader(form.Encode()))
 	if err != nil {
 		return Token{}, err
 	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	issuedAt := s.now()
+	res, err := s.client.Do(req)
+	if err != nil {
+		return Token{}, fmt.Errorf("%w: %w", ErrTokenRequest, err)
+	}
 	defer res.Body.Close()
 
+	if res.StatusCode != http.StatusOK {
+		var errorResp errorResponseBody
+		_ = json.NewDecoder(res.Body).Decode(&errorResp)
+		return Token{}, fmt.Errorf("%w: status %d %s %s", ErrTokenRequest, res.StatusCode, errorResp.Error, errorResp.ErrorDescription)
+	}
+
 	var tokenResp tokenResponseBody
 	if err := json.NewDecoder(res.Body).Decode(&tokenResp); err != nil {
-		return Token{}, err
+		return Token{}, fmt.Errorf("%w: %w", ErrInvalidTokenBody, err)
 	}
+	if tokenResp.AccessToken == "" || tokenResp.ExpiresIn <= 0 {
+		return Token{}, fmt.Errorf("%w: missing access token or expiry", ErrInvalidTokenBody)
+	}
+
+	// expires_in is relative to the time of the request
+	lifetime := time.Duration(tokenResp.ExpiresIn) * time.Second
 
-	token := Token{
-		accessToken: tokenResp.AccessToken,
-		tokenType:   tokenResp.TokenType,
-		expiresIn:   time.Unix(tokenResp.ExpiresIn, 0),
+	return Token{
+		AccessToken: tokenResp.AccessToken,
+		TokenType:   tokenResp.TokenType,
+		Expiry:      issuedAt.Add(lifetime),
+		refreshAt:   issuedAt.Add(lifetime - s.refreshLead(lifetime)),
+	}, nil
+}
+
+// refreshLead is how long before its expiry a token of the lifetime is
+// refreshed, with a random jitter and at most half the lifetime
+func (s *TokenSource) refreshLead(lifetime time.Duration) time.Duration {
+	lead := s.refreshAhead
+	if s.jitter > 0 {
+		lead += rand.N(s.jitter)
 	}
 
-	return token, nil
+	return min(lead, lifetime/2)
 }
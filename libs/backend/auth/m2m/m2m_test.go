@@ -0,0 +1,254 @@
+package m2m_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"libs/backend/auth/m2m"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const audience = "https://api.career-cue.test"
+
+// clock is a settable time source
+type clock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *clock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// tokenServer is an OAuth2 token endpoint counting its requests
+type tokenServer struct {
+	*httptest.Server
+	requests atomic.Int64
+
+	// release blocks the responses until closed, when set
+	release chan struct{}
+}
+
+func newTokenServer(t *testing.T, release chan struct{}) *tokenServer {
+	ts := &tokenServer{release: release}
+	ts.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		count := ts.requests.Add(1)
+		if ts.release != nil {
+			<-ts.release
+		}
+
+		require.NoError(t, r.ParseForm())
+		if r.PostForm.Get("grant_type") != "client_credentials" || r.PostForm.Get("client_secret") != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"error":"access_denied","error_description":"Unauthorized"}`)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"access_token":"token-%s-%d","token_type":"Bearer","expires_in":3600}`, r.PostForm.Get("audience"), count)
+	}))
+	t.Cleanup(ts.Close)
+
+	return ts
+}
+
+func newTokenSource(t *testing.T, server *tokenServer, c *clock, secret string) *m2m.TokenSource {
+	source, err := m2m.NewTokenSource(m2m.TokenSourceParams{
+		TokenURL:     server.URL + "/oauth/token",
+		ClientID:     "accounts-worker",
+		ClientSecret: secret,
+		Audience:     audience,
+		RefreshAhead: 5 * time.Minute,
+		Jitter:       time.Nanosecond,
+		Now:          c.Now,
+	})
+	require.NoError(t, err)
+
+	return source
+}
+
+func TestTokenSource(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("computes the expiry from expires_in", func(t *testing.T) {
+		c := &clock{now: time.Now()}
+		server := newTokenServer(t, nil)
+		source := newTokenSource(t, server, c, "secret")
+
+		token, err := source.GetToken(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "token-"+audience+"-1", token.AccessToken)
+		assert.Equal(t, c.Now().Add(time.Hour), token.Expiry)
+		assert.False(t, token.IsExpired())
+
+		header, err := source.AuthorizationHeader(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "Bearer "+token.AccessToken, header)
+		assert.EqualValues(t, 1, server.requests.Load())
+	})
+
+	t.Run("caches tokens per audience", func(t *testing.T) {
+		c := &clock{now: time.Now()}
+		server := newTokenServer(t, nil)
+		source := newTokenSource(t, server, c, "secret")
+
+		for range 3 {
+			token, err := source.GetToken(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, "token-"+audience+"-1", token.AccessToken)
+
+			other, err := source.GetTokenForAudience(ctx, "https://other.test")
+			require.NoError(t, err)
+			assert.Equal(t, "token-https://other.test-2", other.AccessToken)
+		}
+		assert.EqualValues(t, 2, server.requests.Load())
+	})
+
+	t.Run("coalesces concurrent refreshes", func(t *testing.T) {
+		release := make(chan struct{})
+		c := &clock{now: time.Now()}
+		server := newTokenServer(t, release)
+		source := newTokenSource(t, server, c, "secret")
+
+		var wg sync.WaitGroup
+		tokens := make([]m2m.Token, 20)
+		for i := range tokens {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				token, err := source.GetToken(ctx)
+				assert.NoError(t, err)
+				tokens[i] = token
+			}()
+		}
+
+		assert.Eventually(t, func() bool { return server.requests.Load() == 1 }, time.Second, time.Millisecond)
+		close(release)
+		wg.Wait()
+
+		assert.EqualValues(t, 1, server.requests.Load())
+		for _, token := range tokens {
+			assert.Equal(t, tokens[0].AccessToken, token.AccessToken)
+		}
+	})
+
+	t.Run("refreshes ahead of the expiry", func(t *testing.T) {
+		c := &clock{now: time.Now()}
+		server := newTokenServer(t, nil)
+		source := newTokenSource(t, server, c, "secret")
+
+		first, err := source.GetToken(ctx)
+		require.NoError(t, err)
+
+		// Within the refresh window the valid token is served while it is refreshed
+		c.Advance(56 * time.Minute)
+		token, err := source.GetToken(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, first.AccessToken, token.AccessToken)
+		assert.Eventually(t, func() bool {
+			token, err := source.GetToken(ctx)
+			return err == nil && token.AccessToken != first.AccessToken
+		}, time.Second, time.Millisecond)
+		assert.EqualValues(t, 2, server.requests.Load())
+	})
+
+	t.Run("waits for the refresh of an expired token", func(t *testing.T) {
+		c := &clock{now: time.Now()}
+		server := newTokenServer(t, nil)
+		source := newTokenSource(t, server, c, "secret")
+
+		first, err := source.GetToken(ctx)
+		require.NoError(t, err)
+
+		c.Advance(2 * time.Hour)
+		token, err := source.GetToken(ctx)
+		require.NoError(t, err)
+		assert.NotEqual(t, first.AccessToken, token.AccessToken)
+		assert.Equal(t, c.Now().Add(time.Hour), token.Expiry)
+	})
+
+	t.Run("fails on a rejected request", func(t *testing.T) {
+		c := &clock{now: time.Now()}
+		server := newTokenServer(t, nil)
+		source := newTokenSource(t, server, c, "wrong")
+
+		_, err := source.GetToken(ctx)
+		assert.ErrorIs(t, err, m2m.ErrTokenRequest)
+		assert.ErrorContains(t, err, "access_denied")
+	})
+
+	t.Run("stops waiting on a canceled context", func(t *testing.T) {
+		release := make(chan struct{})
+		defer close(release)
+
+		c := &clock{now: time.Now()}
+		server := newTokenServer(t, release)
+		source := newTokenSource(t, server, c, "secret")
+
+		canceled, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+		defer cancel()
+
+		_, err := source.GetToken(canceled)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
+
+func TestTransport(t *testing.T) {
+	c := &clock{now: time.Now()}
+	server := newTokenServer(t, nil)
+	source := newTokenSource(t, server, c, "secret")
+
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, r.Header.Get("Authorization"))
+	}))
+	defer api.Close()
+
+	client := &http.Client{Transport: source.RoundTripper(nil)}
+
+	read := func(t *testing.T, req *http.Request) string {
+		res, err := client.Do(req)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		var body [256]byte
+		n, _ := res.Body.Read(body[:])
+		return string(body[:n])
+	}
+
+	t.Run("authorizes the requests", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodGet, api.URL, nil)
+		require.NoError(t, err)
+
+		assert.Equal(t, "Bearer token-"+audience+"-1", read(t, req))
+		assert.Empty(t, req.Header.Get("Authorization"))
+	})
+
+	t.Run("keeps an explicit authorization", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodGet, api.URL, nil)
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer user")
+
+		assert.Equal(t, "Bearer user", read(t, req))
+	})
+}
+
+func TestAuth0TokenURL(t *testing.T) {
+	assert.Equal(t, "https://tenant.us.auth0.com/oauth/token", m2m.Auth0TokenURL("tenant.us.auth0.com"))
+	assert.Equal(t, "http://dev-token-issuer:3000/oauth/token", m2m.Auth0TokenURL("http://dev-token-issuer:3000/"))
+}
@@ -0,0 +1,56 @@
+package m2m
+
+import (
+	"net/http"
+)
+
+// Assertion of the proper interface
+var _ http.RoundTripper = (*Transport)(nil)
+
+// Transport is an http.RoundTripper authorizing the requests with the
+// tokens of the source, requests with an Authorization header are kept as is
+type Transport struct {
+	Source *TokenSource
+
+	// Audience defaults to the default audience of the source
+	Audience string
+
+	// Base defaults to http.DefaultTransport
+	Base http.RoundTripper
+}
+
+// RoundTripper returns the transport of the default audience over the base
+func (s *TokenSource) RoundTripper(base http.RoundTripper) http.RoundTripper {
+	return &Transport{Source: s, Audience: s.audience, Base: base}
+}
+
+// RoundTrip sets the Authorization header on a clone of the request
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	if req.Header.Get("Authorization") != "" {
+		return base.RoundTrip(req)
+	}
+
+	audience := t.Audience
+	if audience == "" {
+		audience = t.Source.audience
+	}
+
+	token, err := t.Source.GetTokenForAudience(req.Context(), audience)
+	if err != nil {
+		// The transport must close the body, even on errors
+		if req.Body != nil {
+			req.Body.Close()
+		}
+		return nil, err
+	}
+
+	authorized := req.Clone(req.Context())
+	authorized.Header.Set("Authorization", token.GetHeaderValue())
+
+	return base.RoundTrip(authorized)
+}
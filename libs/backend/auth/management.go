@@ -0,0 +1,208 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"libs/backend/auth/m2m"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Assertion of the proper interface
+var _ ManagementClient = (*Management)(nil)
+
+// Management is the client of the Auth0 Management API, authorized with the
+// client credentials tokens of the Management API audience
+type Management struct {
+	baseURL string
+	client  *http.Client
+}
+
+// ManagementParams is a struct to hold the parameters for the Management client
+type ManagementParams struct {
+	// Domain is the Auth0 tenant domain, a domain with a scheme is kept as is
+	Domain string
+
+	// TokenSource provides the tokens of the machine to machine application,
+	// which needs to be authorized for the Management API
+	TokenSource *m2m.TokenSource
+
+	// Audience defaults to the Management API audience of the domain
+	Audience string
+
+	// HTTPClient defaults to a client with the m2m.DefaultHTTPTimeout, its
+	// transport is wrapped to authorize the requests
+	HTTPClient *http.Client
+}
+
+// errorResponseBody is the error response of the Management API
+type errorResponseBody struct {
+	StatusCode int    `json:"statusCode"`
+	Error      string `json:"error"`
+	Message    string `json:"message"`
+	ErrorCode  string `json:"errorCode"`
+}
+
+// NewManagement constructs the Management API client of the tenant
+func NewManagement(params ManagementParams) (*Management, error) {
+	if params.Domain == "" {
+		return nil, errors.New("management client needs a domain")
+	}
+	if params.TokenSource == nil {
+		return nil, errors.New("management client needs a token source")
+	}
+
+	baseURL := ManagementURL(params.Domain)
+	if params.Audience == "" {
+		params.Audience = baseURL + "/"
+	}
+
+	client := &http.Client{Timeout: m2m.DefaultHTTPTimeout}
+	if params.HTTPClient != nil {
+		copied := *params.HTTPClient
+		client = &copied
+	}
+	client.Transport = &m2m.Transport{
+		Source:   params.TokenSource,
+		Audience: params.Audience,
+		Base:     client.Transport,
+	}
+
+	return &Management{
+		baseURL: baseURL,
+		client:  client,
+	}, nil
+}
+
+// ManagementURL returns the Management API endpoint of the Auth0 tenant domain
+func ManagementURL(domain string) string {
+	if !strings.Contains(domain, "://") {
+		domain = "https://" + domain
+	}
+
+	return strings.TrimSuffix(domain, "/") + "/api/v2"
+}
+
+// GetUser returns the user of the Auth0 user id
+func (m *Management) GetUser(ctx context.Context, userID string) (User, error) {
+	var user User
+	err := m.do(ctx, http.MethodGet, userPath(userID), nil, &user)
+	return user, err
+}
+
+// ListUsersByEmail returns the users of the email address, one per connection
+func (m *Management) ListUsersByEmail(ctx context.Context, email string) ([]User, error) {
+	users := make([]User, 0)
+	err := m.do(ctx, http.MethodGet, "/users-by-email?"+url.Values{"email": {email}}.Encode(), nil, &users)
+	return users, err
+}
+
+// UpdateUser updates the set fields of the user
+func (m *Management) UpdateUser(ctx context.Context, userID string, update UserUpdate) (User, error) {
+	var user User
+	err := m.do(ctx, http.MethodPatch, userPath(userID), update, &user)
+	return user, err
+}
+
+// DeleteUser deletes the user, deleting an unknown user is not an error
+func (m *Management) DeleteUser(ctx context.Context, userID string) error {
+	err := m.do(ctx, http.MethodDelete, userPath(userID), nil, nil)
+	if errors.Is(err, ErrUserNotFound) {
+		return nil
+	}
+
+	return err
+}
+
+// SetAppMetadata merges the keys into the app metadata of the user, the
+// Management API merges the top level keys and removes the null ones
+func (m *Management) SetAppMetadata(ctx context.Context, userID string, metadata map[string]any) (User, error) {
+	return m.UpdateUser(ctx, userID, UserUpdate{AppMetadata: metadata})
+}
+
+// SetCommonID links the user to the account
+func (m *Management) SetCommonID(ctx context.Context, userID, commonID string) (User, error) {
+	return m.SetAppMetadata(ctx, userID, map[string]any{CommonIDKey: commonID})
+}
+
+// BlockUser prevents the user from logging in
+func (m *Management) BlockUser(ctx context.Context, userID string) error {
+	blocked := true
+	_, err := m.UpdateUser(ctx, userID, UserUpdate{Blocked: &blocked})
+	return err
+}
+
+// UnblockUser allows the user to log in again
+func (m *Management) UnblockUser(ctx context.Context, userID string) error {
+	blocked := false
+	_, err := m.UpdateUser(ctx, userID, UserUpdate{Blocked: &blocked})
+	return err
+}
+
+// ResendVerificationEmail sends the email verification to the user again
+func (m *Management) ResendVerificationEmail(ctx context.Context, userID string) (Job, error) {
+	var job Job
+	err := m.do(ctx, http.MethodPost, "/jobs/verification-email", map[string]string{"user_id": userID}, &job)
+	return job, err
+}
+
+// do sends the request with the JSON body and decodes the JSON response into out
+func (m *Management) do(ctx context.Context, method, path string, body, out any) error {
+	var reqBody io.Reader
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reqBody = bytes.NewReader(encoded)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, m.baseURL+path, reqBody)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	res, err := m.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrManagementRequest, err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= http.StatusBadRequest {
+		var errorResp errorResponseBody
+		_ = json.NewDecoder(res.Body).Decode(&errorResp)
+
+		switch res.StatusCode {
+		case http.StatusNotFound:
+			return fmt.Errorf("%w: %s", ErrUserNotFound, errorResp.Message)
+		case http.StatusTooManyRequests:
+			return fmt.Errorf("%w: %s", ErrRateLimited, errorResp.Message)
+		}
+
+		return fmt.Errorf("%w: %s %s status %d %s %s", ErrManagementRequest, method, path, res.StatusCode, errorResp.ErrorCode, errorResp.Message)
+	}
+
+	if out == nil || res.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	if err := json.NewDecoder(res.Body).Decode(out); err != nil {
+		return fmt.Errorf("%w: invalid response: %w", ErrManagementRequest, err)
+	}
+
+	return nil
+}
+
+// userPath returns the path of the user, Auth0 user ids contain the connection
+// separated by a pipe
+func userPath(userID string) string {
+	return "/users/" + url.PathEscape(userID)
+}
@@ -0,0 +1,256 @@
+package auth_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"libs/backend/auth"
+	"libs/backend/auth/m2m"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const managementToken = "management-token"
+
+// newManagementServer serves the Management API backed by the in-memory
+// stand-in, so the client and the stand-in are held to the same behaviour
+func newManagementServer(t *testing.T, backend *auth.InMemoryManagement) *httptest.Server {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	var server *httptest.Server
+
+	mux.HandleFunc("POST /oauth/token", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		if r.PostForm.Get("audience") != server.URL+"/api/v2/" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		fmt.Fprintf(w, `{"access_token":%q,"token_type":"Bearer","expires_in":86400}`, managementToken)
+	})
+
+	api := http.NewServeMux()
+	api.HandleFunc("GET /api/v2/users/{id}", func(w http.ResponseWriter, r *http.Request) {
+		user, err := backend.GetUser(r.Context(), r.PathValue("id"))
+		writeResponse(w, http.StatusOK, user, err)
+	})
+	api.HandleFunc("GET /api/v2/users-by-email", func(w http.ResponseWriter, r *http.Request) {
+		users, err := backend.ListUsersByEmail(r.Context(), r.URL.Query().Get("email"))
+		writeResponse(w, http.StatusOK, users, err)
+	})
+	api.HandleFunc("PATCH /api/v2/users/{id}", func(w http.ResponseWriter, r *http.Request) {
+		var update auth.UserUpdate
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&update))
+		user, err := backend.UpdateUser(r.Context(), r.PathValue("id"), update)
+		writeResponse(w, http.StatusOK, user, err)
+	})
+	api.HandleFunc("DELETE /api/v2/users/{id}", func(w http.ResponseWriter, r *http.Request) {
+		if _, err := backend.GetUser(r.Context(), r.PathValue("id")); err != nil {
+			writeResponse(w, http.StatusNoContent, nil, err)
+			return
+		}
+		writeResponse(w, http.StatusNoContent, nil, backend.DeleteUser(r.Context(), r.PathValue("id")))
+	})
+	api.HandleFunc("POST /api/v2/jobs/verification-email", func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			UserID string `json:"user_id"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		job, err := backend.ResendVerificationEmail(r.Context(), body.UserID)
+		writeResponse(w, http.StatusCreated, job, err)
+	})
+	mux.Handle("/api/v2/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+managementToken {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"statusCode":401,"error":"Unauthorized","message":"Invalid token"}`)
+			return
+		}
+		api.ServeHTTP(w, r)
+	}))
+
+	server = httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+// writeResponse writes the JSON body or the Management API error
+func writeResponse(w http.ResponseWriter, status int, body any, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	if errors.Is(err, auth.ErrUserNotFound) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"statusCode":404,"error":"Not Found","message":"The user does not exist.","errorCode":"inexistent_user"}`)
+		return
+	}
+
+	w.WriteHeader(status)
+	if body != nil {
+		_ = json.NewEncoder(w).Encode(body)
+	}
+}
+
+func newManagement(t *testing.T, server *httptest.Server) *auth.Management {
+	t.Helper()
+
+	source, err := m2m.NewM2M(server.URL, "https://api.career-cue.test", "accounts-worker", "secret")
+	require.NoError(t, err)
+
+	management, err := auth.NewManagement(auth.ManagementParams{
+		Domain:      server.URL,
+		TokenSource: source,
+	})
+	require.NoError(t, err)
+
+	return management
+}
+
+func TestManagementClients(t *testing.T) {
+	clients := map[string]func(t *testing.T, users ...auth.User) auth.ManagementClient{
+		"in memory": func(_ *testing.T, users ...auth.User) auth.ManagementClient {
+			return auth.NewInMemoryManagement(auth.WithUsers(users...))
+		},
+		"management api": func(t *testing.T, users ...auth.User) auth.ManagementClient {
+			return newManagement(t, newManagementServer(t, auth.NewInMemoryManagement(auth.WithUsers(users...))))
+		},
+	}
+
+	user := auth.User{
+		ID:          "auth0|user-1",
+		Email:       "jane@career-cue.test",
+		Name:        "Jane Doe",
+		AppMetadata: map[string]any{"plan": "free"},
+	}
+	ctx := context.Background()
+
+	for name, newClient := range clients {
+		t.Run(name, func(t *testing.T) {
+			t.Run("gets the user", func(t *testing.T) {
+				client := newClient(t, user)
+
+				got, err := client.GetUser(ctx, user.ID)
+				require.NoError(t, err)
+				assert.Equal(t, user.Email, got.Email)
+				assert.Equal(t, "free", got.AppMetadata["plan"])
+
+				_, err = client.GetUser(ctx, "auth0|unknown")
+				assert.ErrorIs(t, err, auth.ErrUserNotFound)
+			})
+
+			t.Run("lists the users of an email address", func(t *testing.T) {
+				client := newClient(t, user, auth.User{ID: "google-oauth2|user-1", Email: user.Email})
+
+				users, err := client.ListUsersByEmail(ctx, user.Email)
+				require.NoError(t, err)
+				assert.Len(t, users, 2)
+
+				users, err = client.ListUsersByEmail(ctx, "unknown@career-cue.test")
+				require.NoError(t, err)
+				assert.Empty(t, users)
+			})
+
+			t.Run("updates the set fields", func(t *testing.T) {
+				client := newClient(t, user)
+				name := "Jane Roe"
+				verified := true
+
+				got, err := client.UpdateUser(ctx, user.ID, auth.UserUpdate{Name: &name, EmailVerified: &verified})
+				require.NoError(t, err)
+				assert.Equal(t, name, got.Name)
+				assert.True(t, got.EmailVerified)
+				assert.Equal(t, user.Email, got.Email)
+			})
+
+			t.Run("merges the app metadata and links the account", func(t *testing.T) {
+				client := newClient(t, user)
+
+				got, err := client.SetCommonID(ctx, user.ID, "0b6c6d3a-7d7b-4a58-9f38-7c9b1e0e9f11")
+				require.NoError(t, err)
+				assert.Equal(t, "0b6c6d3a-7d7b-4a58-9f38-7c9b1e0e9f11", got.CommonID())
+				assert.Equal(t, "free", got.AppMetadata["plan"])
+
+				got, err = client.SetAppMetadata(ctx, user.ID, map[string]any{"plan": nil})
+				require.NoError(t, err)
+				assert.NotContains(t, got.AppMetadata, "plan")
+				assert.Equal(t, "0b6c6d3a-7d7b-4a58-9f38-7c9b1e0e9f11", got.CommonID())
+			})
+
+			t.Run("blocks and unblocks the user", func(t *testing.T) {
+				client := newClient(t, user)
+
+				require.NoError(t, client.BlockUser(ctx, user.ID))
+				got, err := client.GetUser(ctx, user.ID)
+				require.NoError(t, err)
+				assert.True(t, got.Blocked)
+
+				require.NoError(t, client.UnblockUser(ctx, user.ID))
+				got, err = client.GetUser(ctx, user.ID)
+				require.NoError(t, err)
+				assert.False(t, got.Blocked)
+			})
+
+			t.Run("resends the verification email", func(t *testing.T) {
+				client := newClient(t, user)
+
+				job, err := client.ResendVerificationEmail(ctx, user.ID)
+				require.NoError(t, err)
+				assert.Equal(t, "verification_email", job.Type)
+				assert.NotEmpty(t, job.ID)
+
+				_, err = client.ResendVerificationEmail(ctx, "auth0|unknown")
+				assert.ErrorIs(t, err, auth.ErrUserNotFound)
+			})
+
+			t.Run("deletes the user", func(t *testing.T) {
+				client := newClient(t, user)
+
+				require.NoError(t, client.DeleteUser(ctx, user.ID))
+				_, err := client.GetUser(ctx, user.ID)
+				assert.ErrorIs(t, err, auth.ErrUserNotFound)
+
+				assert.NoError(t, client.DeleteUser(ctx, user.ID))
+			})
+		})
+	}
+}
+
+func TestManagement(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("requests the tokens of the management api audience", func(t *testing.T) {
+		server := newManagementServer(t, auth.NewInMemoryManagement())
+		source, err := m2m.NewM2M(server.URL, "https://api.career-cue.test", "accounts-worker", "secret")
+		require.NoError(t, err)
+
+		management, err := auth.NewManagement(auth.ManagementParams{
+			Domain:      server.URL,
+			TokenSource: source,
+			Audience:    "https://api.career-cue.test",
+		})
+		require.NoError(t, err)
+
+		_, err = management.GetUser(ctx, "auth0|user-1")
+		assert.ErrorIs(t, err, m2m.ErrTokenRequest)
+		assert.Equal(t, "https://tenant.auth0.test/api/v2", auth.ManagementURL("tenant.auth0.test"))
+	})
+
+	t.Run("reports the rate limit", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if strings.HasPrefix(r.URL.Path, "/oauth/token") {
+				fmt.Fprint(w, `{"access_token":"token","token_type":"Bearer","expires_in":86400}`)
+				return
+			}
+			w.WriteHeader(http.StatusTooManyRequests)
+			fmt.Fprint(w, `{"statusCode":429,"error":"Too Many Requests","message":"Global limit has been reached"}`)
+		}))
+		t.Cleanup(server.Close)
+
+		_, err := newManagement(t, server).GetUser(ctx, "auth0|user-1")
+		assert.ErrorIs(t, err, auth.ErrRateLimited)
+	})
+}
@@ -0,0 +1,240 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Assertion of the proper interface
+var _ ManagementClient = (*InMemoryManagement)(nil)
+
+// InMemoryManagement stands in for the Management API in tests and local
+// development, where no Auth0 tenant is available
+type InMemoryManagement struct {
+	mu    sync.RWMutex
+	users map[string]User
+	jobs  []verificationJob
+	now   func() time.Time
+}
+
+// verificationJob is a verification email sent to a user
+type verificationJob struct {
+	Job
+	userID string
+}
+
+// InMemoryManagementOption configures the in-memory Management API
+type InMemoryManagementOption func(*InMemoryManagement)
+
+// WithUsers seeds the users of the in-memory Management API
+func WithUsers(users ...User) InMemoryManagementOption {
+	return func(m *InMemoryManagement) {
+		for _, user := range users {
+			m.users[user.ID] = cloneUser(user)
+		}
+	}
+}
+
+// WithManagementClock sets the time source of the in-memory Management API
+func WithManagementClock(now func() time.Time) InMemoryManagementOption {
+	return func(m *InMemoryManagement) {
+		m.now = now
+	}
+}
+
+// NewInMemoryManagement constructs an empty in-memory Management API
+func NewInMemoryManagement(opts ...InMemoryManagementOption) *InMemoryManagement {
+	m := &InMemoryManagement{
+		users: make(map[string]User),
+		now:   time.Now,
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	return m
+}
+
+// CreateUser adds the user, a user without an id gets an auth0 database id
+func (m *InMemoryManagement) CreateUser(user User) User {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if user.ID == "" {
+		user.ID = "auth0|" + strings.ReplaceAll(uuid.NewString(), "-", "")
+	}
+	now := m.now().UTC()
+	if user.CreatedAt.IsZero() {
+		user.CreatedAt = now
+	}
+	user.UpdatedAt = now
+
+	m.users[user.ID] = cloneUser(user)
+	return cloneUser(user)
+}
+
+// VerificationEmails returns the number of verification emails sent to the user
+func (m *InMemoryManagement) VerificationEmails(userID string) int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	count := 0
+	for _, job := range m.jobs {
+		if job.userID == userID {
+			count++
+		}
+	}
+
+	return count
+}
+
+// GetUser returns the user of the Auth0 user id
+func (m *InMemoryManagement) GetUser(_ context.Context, userID string) (User, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	user, ok := m.users[userID]
+	if !ok {
+		return User{}, fmt.Errorf("%w: %s", ErrUserNotFound, userID)
+	}
+
+	return cloneUser(user), nil
+}
+
+// ListUsersByEmail returns the users of the email address, one per connection
+func (m *InMemoryManagement) ListUsersByEmail(_ context.Context, email string) ([]User, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	users := make([]User, 0)
+	for _, id := range slices.Sorted(maps.Keys(m.users)) {
+		if strings.EqualFold(m.users[id].Email, email) {
+			users = append(users, cloneUser(m.users[id]))
+		}
+	}
+
+	return users, nil
+}
+
+// UpdateUser updates the set fields of the user
+func (m *InMemoryManagement) UpdateUser(_ context.Context, userID string, update UserUpdate) (User, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	user, ok := m.users[userID]
+	if !ok {
+		return User{}, fmt.Errorf("%w: %s", ErrUserNotFound, userID)
+	}
+
+	setIfPresent(&user.Email, update.Email)
+	setIfPresent(&user.EmailVerified, update.EmailVerified)
+	setIfPresent(&user.Name, update.Name)
+	setIfPresent(&user.GivenName, update.GivenName)
+	setIfPresent(&user.FamilyName, update.FamilyName)
+	setIfPresent(&user.Nickname, update.Nickname)
+	setIfPresent(&user.Picture, update.Picture)
+	setIfPresent(&user.Blocked, update.Blocked)
+	user.AppMetadata = mergeMetadata(user.AppMetadata, update.AppMetadata)
+	user.UserMetadata = mergeMetadata(user.UserMetadata, update.UserMetadata)
+	user.UpdatedAt = m.now().UTC()
+
+	m.users[userID] = user
+	return cloneUser(user), nil
+}
+
+// DeleteUser deletes the user, deleting an unknown user is not an error
+func (m *InMemoryManagement) DeleteUser(_ context.Context, userID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.users, userID)
+	return nil
+}
+
+// SetAppMetadata merges the keys into the app metadata of the user, a nil
+// value removes the key
+func (m *InMemoryManagement) SetAppMetadata(ctx context.Context, userID string, metadata map[string]any) (User, error) {
+	return m.UpdateUser(ctx, userID, UserUpdate{AppMetadata: metadata})
+}
+
+// SetCommonID links the user to the account
+func (m *InMemoryManagement) SetCommonID(ctx context.Context, userID, commonID string) (User, error) {
+	return m.SetAppMetadata(ctx, userID, map[string]any{CommonIDKey: commonID})
+}
+
+// BlockUser prevents the user from logging in
+func (m *InMemoryManagement) BlockUser(ctx context.Context, userID string) error {
+	blocked := true
+	_, err := m.UpdateUser(ctx, userID, UserUpdate{Blocked: &blocked})
+	return err
+}
+
+// UnblockUser allows the user to log in again
+func (m *InMemoryManagement) UnblockUser(ctx context.Context, userID string) error {
+	blocked := false
+	_, err := m.UpdateUser(ctx, userID, UserUpdate{Blocked: &blocked})
+	return err
+}
+
+// ResendVerificationEmail records a verification email sent to the user
+func (m *InMemoryManagement) ResendVerificationEmail(_ context.Context, userID string) (Job, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.users[userID]; !ok {
+		return Job{}, fmt.Errorf("%w: %s", ErrUserNotFound, userID)
+	}
+
+	job := Job{
+		ID:        "job_" + strings.ReplaceAll(uuid.NewString(), "-", "")[:16],
+		Type:      "verification_email",
+		Status:    "completed",
+		CreatedAt: m.now().UTC(),
+	}
+	m.jobs = append(m.jobs, verificationJob{Job: job, userID: userID})
+
+	return job, nil
+}
+
+// setIfPresent sets the field to the value of a set update
+func setIfPresent[T any](field *T, value *T) {
+	if value != nil {
+		*field = *value
+	}
+}
+
+// mergeMetadata merges the top level keys like the Management API, nil values
+// remove the key
+func mergeMetadata(metadata, update map[string]any) map[string]any {
+	if len(update) == 0 {
+		return metadata
+	}
+
+	merged := maps.Clone(metadata)
+	if merged == nil {
+		merged = make(map[string]any, len(update))
+	}
+	for key, value := range update {
+		if value == nil {
+			delete(merged, key)
+			continue
+		}
+		merged[key] = value
+	}
+
+	return merged
+}
+
+// cloneUser copies the metadata so callers cannot change the stored user
+func cloneUser(user User) User {
+	user.AppMetadata = maps.Clone(user.AppMetadata)
+	user.UserMetadata = maps.Clone(user.UserMetadata)
+	return user
+}
@@ -3,11 +3,13 @@ package boot
 import (
 	"context"
 	"errors"
+	"io"
 	"log/slog"
 	"strings"
 	"time"
 
 	amqp "github.com/rabbitmq/amqp091-go"
+	"gorm.io/gorm"
 )
 
 // AMQPCallBackParams returns the logger and callback and channel to the caller
@@ -16,10 +18,11 @@ type AMQPCallBackParams struct {
 	Controller AMQPController
 }
 
-// AMQPHandlerParams returns the logger and AMQP controller to the handler
+// AMQPHandlerParams returns the logger, AMQP controller and database to the handler
 type AMQPHandlerParams struct {
 	Logger         Logger
 	AMQPController AMQPController
+	DB             *gorm.DB
 }
 
 // AMQPHandler is a type of callback used specifically for starting the AMQP handlers
@@ -28,9 +31,10 @@ type AMQPHandler func(AMQPHandlerParams) error
 // AMQPOptions configuration to start
 // the LavinMQ connections to queues and exchanges
 type AMQPOptions struct {
-	ConnectionURI        string
-	OnConnectionCallback func(AMQPCallBackParams) error
-	Handlers             []AMQPHandler
+	ConnectionURI         string
+	OnConnectionCallback  func(AMQPCallBackParams) error
+	Handlers              []AMQPHandler
+	PublishConfirmTimeout time.Duration
 }
 
 // IsZero will let the caller know if the AMQPOptions is empty
@@ -71,8 +75,32 @@ func (s *BootService) EstablishAMQPConnection(opts AMQPOptions) error {
 		return err
 	}
 
+	// Create a dedicated channel in confirm mode for reliable publishing
+	confirmCh, err := conn.Channel()
+	if err != nil {
+		s.logger.Error("Cannot create AMQP confirm channel")
+		return err
+	}
+
+	confirmPublisher, err := NewConfirmPublisher(s.logger, confirmCh, opts.PublishConfirmTimeout)
+	if err != nil {
+		s.logger.Error("Cannot create AMQP confirm publisher", slog.Any("error", err))
+		return err
+	}
+
 	// AMQP controller wrapper
 	controller := NewController(s.logger, conn, ch)
+	controller.ConfirmPublisher = confirmPublisher
+
+	// Scheduled publishing needs the database for its schedule table
+	if err := s.initializeScheduler(confirmPublisher); err != nil {
+		s.logger.Error("Cannot create AMQP scheduler", slog.Any("error", err))
+		return err
+	}
+	if s.scheduler != nil {
+		controller.Scheduler = s.scheduler
+	}
+
 	s.amqpController = controller
 
 	// Start/Stop the connection on close
@@ -93,13 +121,13 @@ func (s *BootService) EstablishAMQPConnection(opts AMQPOptions) error {
 
 	s.logger.Info("Registering AMQP handlers", slog.Int("count", len(opts.Handlers)))
 
-	// Register all the handlers and run indefinitely
-	forever := make(chan struct{})
+	// Run every handler in the background, the boot service keeps the process alive
 	for _, handler := range opts.Handlers {
 		handler := handler
 		handlerParams := AMQPHandlerParams{
 			Logger:         s.logger,
 			AMQPController: controller,
+			DB:             s.db,
 		}
 		go func() {
 			if err := handler(handlerParams); err != nil {
@@ -107,7 +135,7 @@ func (s *BootService) EstablishAMQPConnection(opts AMQPOptions) error {
 			}
 		}()
 	}
-	<-forever
+
 	return nil
 }
 
@@ -119,10 +147,24 @@ type AMQPPublisher interface {
 
 // AMQPConsumer defines the AMQP consume methods
 type AMQPConsumer interface {
+	Qos(prefetchCount, prefetchSize int, global bool) error
 	Consume(queue, consumer string, autoAck, exclusive, noLocal, noWait bool, args amqp.Table) (<-chan amqp.Delivery, error)
 	ConsumeWithContext(ctx context.Context, queue, consumer string, autoAck, exclusive, noLocal, noWait bool, args amqp.Table) (<-chan amqp.Delivery, error)
 }
 
+// AMQPConsumerChannel is a consumer on a channel of its own, its prefetch
+// does not affect the consumers of other channels
+type AMQPConsumerChannel interface {
+	AMQPConsumer
+	Close() error
+}
+
+// AMQPChannelOpener is implemented by consumers that can open dedicated
+// channels on their connection
+type AMQPChannelOpener interface {
+	OpenChannel() (AMQPConsumerChannel, error)
+}
+
 // AMQPRegisterer defines the AMQP register methods for queues and exchanges
 type AMQPRegisterer interface {
 	ExchangeDeclare(name, kind string, durable, autoDelete, internal, noWait bool, args amqp.Table) error
@@ -133,12 +175,14 @@ type AMQPRegisterer interface {
 
 // AMQPController returns an interface for publishing, consuming and registering
 type AMQPController struct {
-	logger     Logger
-	connection *amqp.Connection
-	channel    *amqp.Channel
-	Publisher  AMQPPublisher
-	Consumer   AMQPConsumer
-	Registerer AMQPRegisterer
+	logger           Logger
+	connection       *amqp.Connection
+	channel          *amqp.Channel
+	Publisher        AMQPPublisher
+	ConfirmPublisher AMQPConfirmPublisher
+	Scheduler        AMQPScheduler
+	Consumer         AMQPConsumer
+	Registerer       AMQPRegisterer
 }
 
 // NewController constructs the returns object for controlling AMQP
@@ -148,11 +192,26 @@ func NewController(logger Logger, connection *amqp.Connection, channel *amqp.Cha
 		connection: connection,
 		channel:    channel,
 		Publisher:  channel,
-		Consumer:   channel,
+		Consumer:   connectionConsumer{Channel: channel, connection: connection},
 		Registerer: channel,
 	}
 }
 
+// connectionConsumer consumes on the shared channel and opens dedicated
+// channels on the connection for consumers that set their own prefetch
+type connectionConsumer struct {
+	*amqp.Channel
+	connection *amqp.Connection
+}
+
+// Assertion of the proper interface
+var _ AMQPChannelOpener = connectionConsumer{}
+
+// OpenChannel opens a new channel on the connection
+func (c connectionConsumer) OpenChannel() (AMQPConsumerChannel, error) {
+	return c.connection.Channel()
+}
+
 // IsConnected will let the caller know if the controller has established an AMQP broker connection
 func (c AMQPController) IsConnected() bool {
 	return c.connection != nil && !c.connection.IsClosed()
@@ -165,6 +224,13 @@ func (c AMQPController) Close() error {
 		return nil
 	}
 
+	// Close the confirm channel when it was created
+	if closer, ok := c.ConfirmPublisher.(io.Closer); ok {
+		if err := closer.Close(); err != nil {
+			c.logger.Error("Trouble closing the AMQP confirm channel", slog.Any("error", err))
+		}
+	}
+
 	c.logger.Info("Closing the AMQP connection")
 	return c.channel.Close()
 }
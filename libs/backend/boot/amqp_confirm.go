@@ -0,0 +1,181 @@
+package boot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// DefaultPublishConfirmTimeout is used when no confirm timeout is configured
+const DefaultPublishConfirmTimeout = 5 * time.Second
+
+// PublishSequenceHeader carries the delivery tag of the publish, the broker
+// echoes it in basic.return so returns are matched to their publish
+const PublishSequenceHeader = "x-publish-seq"
+
+// notifyBufferSize is the buffer for broker confirmations and returns so the
+// channel dispatcher is never blocked by a slow publisher
+const notifyBufferSize = 64
+
+// Publish Confirm Errors
+var (
+	ErrPublishNacked         = errors.New("publish was nacked by the broker")
+	ErrPublishUnroutable     = errors.New("publish was returned by the broker as unroutable")
+	ErrPublishConfirmTimeout = errors.New("timed out waiting for broker publish confirmation")
+	ErrPublishChannelClosed  = errors.New("publish confirm channel is closed")
+)
+
+// AMQPConfirmPublisher defines publishing that waits until the broker
+// has acknowledged the message
+type AMQPConfirmPublisher interface {
+	PublishWithConfirm(ctx context.Context, exchange, key string, mandatory bool, msg amqp.Publishing) error
+}
+
+// AMQPConfirmChannel is the subset of the AMQP channel used by the confirm publisher
+type AMQPConfirmChannel interface {
+	Confirm(noWait bool) error
+	NotifyPublish(confirm chan amqp.Confirmation) chan amqp.Confirmation
+	NotifyReturn(c chan amqp.Return) chan amqp.Return
+	GetNextPublishSeqNo() uint64
+	PublishWithContext(ctx context.Context, exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error
+	Close() error
+}
+
+// ConfirmPublisher publishes on a dedicated channel in confirm mode and reports
+// nacks, unroutable returns and timeouts back to the caller
+type ConfirmPublisher struct {
+	logger   Logger
+	channel  AMQPConfirmChannel
+	timeout  time.Duration
+	mu       *sync.Mutex
+	confirms chan amqp.Confirmation
+	returns  chan amqp.Return
+}
+
+// Assertion of the proper interface
+var _ AMQPConfirmPublisher = (*ConfirmPublisher)(nil)
+
+// NewConfirmPublisher puts the channel into confirm mode and constructs the publisher
+func NewConfirmPublisher(logger Logger, channel AMQPConfirmChannel, timeout time.Duration) (*ConfirmPublisher, error) {
+	if timeout <= 0 {
+		timeout = DefaultPublishConfirmTimeout
+	}
+
+	if err := channel.Confirm(false); err != nil {
+		return nil, fmt.Errorf("cannot put AMQP channel in confirm mode: %w", err)
+	}
+
+	return &ConfirmPublisher{
+		logger:   logger,
+		channel:  channel,
+		timeout:  timeout,
+		mu:       new(sync.Mutex),
+		confirms: channel.NotifyPublish(make(chan amqp.Confirmation, notifyBufferSize)),
+		returns:  channel.NotifyReturn(make(chan amqp.Return, notifyBufferSize)),
+	}, nil
+}
+
+// PublishWithConfirm publishes the message and blocks until the broker confirms it.
+// Publishes are serialized, confirmations are matched by delivery tag and returns
+// by the sequence header, so late answers for timed out publishes are discarded.
+func (p *ConfirmPublisher) PublishWithConfirm(ctx context.Context, exchange, key string, mandatory bool, msg amqp.Publishing) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	deliveryTag := p.channel.GetNextPublishSeqNo()
+	msg.Headers = withPublishSequence(msg.Headers, deliveryTag)
+	if err := p.channel.PublishWithContext(ctx, exchange, key, mandatory, false, msg); err != nil {
+		return fmt.Errorf("cannot publish message: %w", err)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			p.logger.Error(
+				"Timed out waiting for publish confirmation",
+				slog.String("exchange", exchange),
+				slog.String("routingKey", key),
+			)
+			return ErrPublishConfirmTimeout
+		case confirmation, ok := <-p.confirms:
+			if !ok {
+				return ErrPublishChannelClosed
+			}
+
+			// Skip late confirmations for earlier publishes
+			if confirmation.DeliveryTag < deliveryTag {
+				continue
+			}
+
+			if !confirmation.Ack {
+				return ErrPublishNacked
+			}
+
+			// The broker sends basic.return before basic.ack for unroutable messages
+			if returned, ok := p.takeReturn(deliveryTag); ok {
+				p.logger.Warn(
+					"Message returned by the broker",
+					slog.String("exchange", returned.Exchange),
+					slog.String("routingKey", returned.RoutingKey),
+					slog.String("replyText", returned.ReplyText),
+				)
+				return fmt.Errorf("%w: %s", ErrPublishUnroutable, returned.ReplyText)
+			}
+
+			return nil
+		}
+	}
+}
+
+// Close closes the underlying confirm channel
+func (p *ConfirmPublisher) Close() error {
+	return p.channel.Close()
+}
+
+// takeReturn reads the pending returns without blocking and reports the one of
+// the publish, returns of earlier publishes are discarded
+func (p *ConfirmPublisher) takeReturn(deliveryTag uint64) (amqp.Return, bool) {
+	for {
+		select {
+		case returned, ok := <-p.returns:
+			if !ok {
+				return amqp.Return{}, false
+			}
+			if publishSequence(returned.Headers) == deliveryTag {
+				return returned, true
+			}
+			p.logger.Debug("Discarding return of an earlier publish", slog.String("messageID", returned.MessageId))
+		default:
+			return amqp.Return{}, false
+		}
+	}
+}
+
+// withPublishSequence copies the headers so the caller's table is not mutated
+// and adds the delivery tag
+func withPublishSequence(headers amqp.Table, deliveryTag uint64) amqp.Table {
+	sequenced := make(amqp.Table, len(headers)+1)
+	for key, val := range headers {
+		sequenced[key] = val
+	}
+	sequenced[PublishSequenceHeader] = int64(deliveryTag)
+
+	return sequenced
+}
+
+// publishSequence reads the delivery tag of a returned message, zero when absent
+func publishSequence(headers amqp.Table) uint64 {
+	if seq, ok := headers[PublishSequenceHeader].(int64); ok && seq > 0 {
+		return uint64(seq)
+	}
+
+	return 0
+}
@@ -0,0 +1,118 @@
+package boot_test
+
+import (
+	"context"
+	boot "libs/backend/boot"
+	"testing"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeConfirmChannel answers every publish with the configured confirmation
+type fakeConfirmChannel struct {
+	seq      uint64
+	ack      bool
+	unrouted bool
+	silent   bool
+	confirms chan amqp.Confirmation
+	returns  chan amqp.Return
+
+	// onPublish runs before the publish is answered
+	onPublish func()
+}
+
+func (f *fakeConfirmChannel) Confirm(noWait bool) error { return nil }
+
+func (f *fakeConfirmChannel) NotifyPublish(c chan amqp.Confirmation) chan amqp.Confirmation {
+	f.confirms = c
+	return c
+}
+
+func (f *fakeConfirmChannel) NotifyReturn(c chan amqp.Return) chan amqp.Return {
+	f.returns = c
+	return c
+}
+
+func (f *fakeConfirmChannel) GetNextPublishSeqNo() uint64 { return f.seq + 1 }
+
+func (f *fakeConfirmChannel) PublishWithContext(_ context.Context, exchange, key string, mandatory, _ bool, msg amqp.Publishing) error {
+	f.seq++
+	if f.onPublish != nil {
+		f.onPublish()
+	}
+	if f.silent {
+		return nil
+	}
+	if mandatory && f.unrouted {
+		f.returns <- amqp.Return{Exchange: exchange, RoutingKey: key, ReplyText: "NO_ROUTE", Headers: msg.Headers}
+	}
+	f.confirms <- amqp.Confirmation{DeliveryTag: f.seq, Ack: f.ack}
+	return nil
+}
+
+func (f *fakeConfirmChannel) Close() error { return nil }
+
+func TestConfirmPublisher(t *testing.T) {
+	ctx := context.Background()
+	logger := boot.NewSlogger()
+
+	t.Run("acked publish succeeds", func(t *testing.T) {
+		publisher, err := boot.NewConfirmPublisher(logger, &fakeConfirmChannel{ack: true}, time.Second)
+		require.NoError(t, err)
+
+		assert.NoError(t, publisher.PublishWithConfirm(ctx, "exchange", "key", true, amqp.Publishing{}))
+	})
+
+	t.Run("nacked publish fails", func(t *testing.T) {
+		publisher, err := boot.NewConfirmPublisher(logger, &fakeConfirmChannel{ack: false}, time.Second)
+		require.NoError(t, err)
+
+		assert.ErrorIs(t, publisher.PublishWithConfirm(ctx, "exchange", "key", true, amqp.Publishing{}), boot.ErrPublishNacked)
+	})
+
+	t.Run("unroutable mandatory publish fails", func(t *testing.T) {
+		publisher, err := boot.NewConfirmPublisher(logger, &fakeConfirmChannel{ack: true, unrouted: true}, time.Second)
+		require.NoError(t, err)
+
+		assert.ErrorIs(t, publisher.PublishWithConfirm(ctx, "exchange", "key", true, amqp.Publishing{}), boot.ErrPublishUnroutable)
+	})
+
+	t.Run("missing confirmation times out", func(t *testing.T) {
+		channel := &fakeConfirmChannel{ack: true, silent: true}
+		publisher, err := boot.NewConfirmPublisher(logger, channel, 10*time.Millisecond)
+		require.NoError(t, err)
+
+		assert.ErrorIs(t, publisher.PublishWithConfirm(ctx, "exchange", "key", true, amqp.Publishing{}), boot.ErrPublishConfirmTimeout)
+
+		// A late confirmation for the timed out publish must not satisfy the next one
+		channel.confirms <- amqp.Confirmation{DeliveryTag: 1, Ack: true}
+		channel.silent = false
+		assert.NoError(t, publisher.PublishWithConfirm(ctx, "exchange", "key", true, amqp.Publishing{}))
+	})
+	t.Run("late return of a timed out publish is not blamed on the next one", func(t *testing.T) {
+		channel := &fakeConfirmChannel{ack: true, silent: true}
+		publisher, err := boot.NewConfirmPublisher(logger, channel, 10*time.Millisecond)
+		require.NoError(t, err)
+
+		assert.ErrorIs(t, publisher.PublishWithConfirm(ctx, "exchange", "key", true, amqp.Publishing{}), boot.ErrPublishConfirmTimeout)
+
+		// The broker returns the first publish while the second one is in flight
+		channel.silent = false
+		channel.onPublish = func() {
+			channel.returns <- amqp.Return{ReplyText: "NO_ROUTE", Headers: amqp.Table{boot.PublishSequenceHeader: int64(1)}}
+		}
+		assert.NoError(t, publisher.PublishWithConfirm(ctx, "exchange", "key", true, amqp.Publishing{}))
+	})
+
+	t.Run("publish does not mutate the caller's headers", func(t *testing.T) {
+		publisher, err := boot.NewConfirmPublisher(logger, &fakeConfirmChannel{ack: true}, time.Second)
+		require.NoError(t, err)
+
+		headers := amqp.Table{"common-id": "user-1"}
+		require.NoError(t, publisher.PublishWithConfirm(ctx, "exchange", "key", true, amqp.Publishing{Headers: headers}))
+		assert.Equal(t, amqp.Table{"common-id": "user-1"}, headers)
+	})
+}
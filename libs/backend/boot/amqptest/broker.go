@@ -0,0 +1,580 @@
+// Package amqptest provides an in-memory AMQP broker that implements the boot
+// AMQP interfaces so publishers, consumers and topology setup can be tested
+// without a running LavinMQ.
+package amqptest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	boot "libs/backend/boot"
+	"strconv"
+	"strings"
+	"sync"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// Assertion of the proper interfaces
+var (
+	_ boot.AMQPPublisher        = (*Broker)(nil)
+	_ boot.AMQPConfirmPublisher = (*Broker)(nil)
+	_ boot.AMQPConsumer         = (*Broker)(nil)
+	_ boot.AMQPChannelOpener    = (*Broker)(nil)
+	_ boot.AMQPRegisterer       = (*Broker)(nil)
+)
+
+// Exchange kinds supported by the broker
+const (
+	ExchangeDirect = "direct"
+	ExchangeTopic  = "topic"
+	ExchangeFanout = "fanout"
+
+	// ExchangeConsistentHash routes each message to one bound queue by hashing
+	// the routing key or the header named by the hash-header argument
+	ExchangeConsistentHash = "x-consistent-hash"
+)
+
+// Exchange arguments understood by the broker, LavinMQ reads x-hash-on and
+// the RabbitMQ plugin reads hash-header
+var hashHeaderArgs = []string{"x-hash-on", "hash-header"}
+
+// Queue arguments understood by the broker
+const (
+	argDeadLetterExchange   = "x-dead-letter-exchange"
+	argDeadLetterRoutingKey = "x-dead-letter-routing-key"
+	argMaxLength            = "x-max-length"
+)
+
+// Broker Errors
+var (
+	ErrBrokerClosed     = errors.New("in-memory broker is closed")
+	ErrExchangeNotFound = errors.New("exchange not found")
+	ErrQueueNotFound    = errors.New("queue not found")
+	ErrPrecondition     = errors.New("precondition failed")
+	ErrUnknownDelivery  = errors.New("unknown delivery tag")
+)
+
+// PublishedMessage is a record of a single publish made through the broker
+type PublishedMessage struct {
+	Exchange   string
+	RoutingKey string
+	Mandatory  bool
+	Publishing amqp.Publishing
+	Queues     []string
+}
+
+// binding connects an exchange to a queue or another exchange
+type binding struct {
+	destination string
+	key         string
+	toExchange  bool
+}
+
+// exchange is a declared exchange and its bindings
+type exchange struct {
+	name       string
+	kind       string
+	hashHeader string
+	bindings   []binding
+}
+
+// Broker is an in-memory AMQP broker
+type Broker struct {
+	mu           *sync.Mutex
+	closed       bool
+	done         chan struct{}
+	exchanges    map[string]*exchange
+	queues       map[string]*queue
+	published    []PublishedMessage
+	returned     []amqp.Return
+	publishErr   error
+	prefetch     int
+	openChannels int
+	deliveryTag  uint64
+	generatedIDs int
+}
+
+// NewBroker constructs an empty broker with the default exchange
+func NewBroker() *Broker {
+	return &Broker{
+		mu:        new(sync.Mutex),
+		done:      make(chan struct{}),
+		exchanges: map[string]*exchange{"": {name: "", kind: ExchangeDirect}},
+		queues:    make(map[string]*queue),
+		published: make([]PublishedMessage, 0),
+		returned:  make([]amqp.Return, 0),
+	}
+}
+
+// Controller returns an AMQP controller backed by the broker
+func (b *Broker) Controller() boot.AMQPController {
+	return boot.AMQPController{
+		Publisher:        b,
+		ConfirmPublisher: b,
+		Consumer:         b,
+		Registerer:       b,
+	}
+}
+
+// Close stops all consumers and rejects further operations
+func (b *Broker) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return nil
+	}
+
+	b.closed = true
+	close(b.done)
+	return nil
+}
+
+// SetPublishError makes every publish fail with err until it is reset with nil
+func (b *Broker) SetPublishError(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.publishErr = err
+}
+
+// ExchangeDeclare declares an exchange, failing when it exists with another kind
+func (b *Broker) ExchangeDeclare(name, kind string, durable, autoDelete, internal, noWait bool, args amqp.Table) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return ErrBrokerClosed
+	}
+
+	switch kind {
+	case ExchangeDirect, ExchangeTopic, ExchangeFanout, ExchangeConsistentHash:
+	default:
+		return fmt.Errorf("%w: unsupported exchange kind %q", ErrPrecondition, kind)
+	}
+
+	if existing, ok := b.exchanges[name]; ok {
+		if existing.kind != kind {
+			return fmt.Errorf("%w: exchange %q already declared as %s", ErrPrecondition, name, existing.kind)
+		}
+		return nil
+	}
+
+	ex := &exchange{name: name, kind: kind}
+	for _, arg := range hashHeaderArgs {
+		if header, ok := args[arg].(string); ok {
+			ex.hashHeader = header
+		}
+	}
+
+	b.exchanges[name] = ex
+	return nil
+}
+
+// ExchangeBind binds the destination exchange to the source exchange
+func (b *Broker) ExchangeBind(destination, key, source string, noWait bool, args amqp.Table) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return ErrBrokerClosed
+	}
+
+	src, ok := b.exchanges[source]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrExchangeNotFound, source)
+	}
+	if _, ok := b.exchanges[destination]; !ok {
+		return fmt.Errorf("%w: %s", ErrExchangeNotFound, destination)
+	}
+
+	src.bindings = appendBinding(src.bindings, binding{destination: destination, key: key, toExchange: true})
+	return nil
+}
+
+// QueueDeclare declares a queue, generating a name when none is given
+func (b *Broker) QueueDeclare(name string, durable, autoDelete, exclusive, noWait bool, args amqp.Table) (amqp.Queue, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return amqp.Queue{}, ErrBrokerClosed
+	}
+
+	if name == "" {
+		b.generatedIDs++
+		name = fmt.Sprintf("amq.gen-%d", b.generatedIDs)
+	}
+
+	q, ok := b.queues[name]
+	if !ok {
+		q = newQueue(name, args)
+		b.queues[name] = q
+	}
+
+	return amqp.Queue{Name: q.name, Messages: len(q.ready), Consumers: q.consumers}, nil
+}
+
+// QueueBind binds the queue to the exchange with the routing key
+func (b *Broker) QueueBind(name, key, exchangeName string, noWait bool, args amqp.Table) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return ErrBrokerClosed
+	}
+
+	ex, ok := b.exchanges[exchangeName]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrExchangeNotFound, exchangeName)
+	}
+	if _, ok := b.queues[name]; !ok {
+		return fmt.Errorf("%w: %s", ErrQueueNotFound, name)
+	}
+
+	ex.bindings = appendBinding(ex.bindings, binding{destination: name, key: key})
+	return nil
+}
+
+// Publish routes the message to all bound queues. Unroutable mandatory
+// messages are recorded as returns.
+func (b *Broker) Publish(exchangeName, key string, mandatory, immediate bool, msg amqp.Publishing) error {
+	_, err := b.publish(exchangeName, key, mandatory, msg)
+	return err
+}
+
+// PublishWithContext routes the message to all bound queues
+func (b *Broker) PublishWithContext(_ context.Context, exchangeName, key string, mandatory, immediate bool, msg amqp.Publishing) error {
+	return b.Publish(exchangeName, key, mandatory, immediate, msg)
+}
+
+// PublishWithConfirm routes the message and reports unroutable mandatory messages
+// the same way the confirm publisher does
+func (b *Broker) PublishWithConfirm(ctx context.Context, exchangeName, key string, mandatory bool, msg amqp.Publishing) error {
+	if err := ctx.Err(); err != nil {
+		return boot.ErrPublishConfirmTimeout
+	}
+
+	routed, err := b.publish(exchangeName, key, mandatory, msg)
+	if err != nil {
+		return err
+	}
+
+	if !routed && mandatory {
+		return fmt.Errorf("%w: NO_ROUTE", boot.ErrPublishUnroutable)
+	}
+
+	return nil
+}
+
+// publish records and routes the message, returning whether any queue received it
+func (b *Broker) publish(exchangeName, key string, mandatory bool, msg amqp.Publishing) (bool, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return false, ErrBrokerClosed
+	}
+
+	if b.publishErr != nil {
+		return false, b.publishErr
+	}
+
+	if _, ok := b.exchanges[exchangeName]; !ok {
+		return false, fmt.Errorf("%w: %s", ErrExchangeNotFound, exchangeName)
+	}
+
+	queueNames := b.route(exchangeName, key, msg.Headers)
+	b.published = append(b.published, PublishedMessage{
+		Exchange:   exchangeName,
+		RoutingKey: key,
+		Mandatory:  mandatory,
+		Publishing: msg,
+		Queues:     queueNames,
+	})
+
+	if len(queueNames) == 0 {
+		if mandatory {
+			b.returned = append(b.returned, newReturn(exchangeName, key, msg))
+		}
+		return false, nil
+	}
+
+	for _, queueName := range queueNames {
+		b.enqueue(b.queues[queueName], newMessage(exchangeName, key, msg))
+	}
+
+	return true, nil
+}
+
+// route resolves the queues the exchange delivers the routing key to
+func (b *Broker) route(exchangeName, key string, headers amqp.Table) []string {
+	// The default exchange routes straight to the queue with the same name
+	if exchangeName == "" {
+		if _, ok := b.queues[key]; ok {
+			return []string{key}
+		}
+		return nil
+	}
+
+	seen := make(map[string]bool)
+	visited := make(map[string]bool)
+	queueNames := make([]string, 0)
+
+	var walk func(name string)
+	walk = func(name string) {
+		if visited[name] {
+			return
+		}
+		visited[name] = true
+
+		ex, ok := b.exchanges[name]
+		if !ok {
+			return
+		}
+
+		bindings := ex.bindings
+		if ex.kind == ExchangeConsistentHash {
+			bindings = ex.hashBinding(key, headers)
+		}
+
+		for _, bind := range bindings {
+			if ex.kind != ExchangeConsistentHash && !matches(ex.kind, bind.key, key) {
+				continue
+			}
+			if bind.toExchange {
+				walk(bind.destination)
+				continue
+			}
+			if !seen[bind.destination] {
+				seen[bind.destination] = true
+				queueNames = append(queueNames, bind.destination)
+			}
+		}
+	}
+	walk(exchangeName)
+
+	return queueNames
+}
+
+// Published returns every publish made through the broker
+func (b *Broker) Published() []PublishedMessage {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return append([]PublishedMessage(nil), b.published...)
+}
+
+// Returned returns all mandatory messages that could not be routed
+func (b *Broker) Returned() []amqp.Return {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return append([]amqp.Return(nil), b.returned...)
+}
+
+// HasExchange reports whether the exchange was declared with the kind
+func (b *Broker) HasExchange(name, kind string) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	ex, ok := b.exchanges[name]
+	return ok && ex.kind == kind
+}
+
+// HasQueue reports whether the queue was declared
+func (b *Broker) HasQueue(name string) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	_, ok := b.queues[name]
+	return ok
+}
+
+// QueueArgs returns the arguments the queue was declared with
+func (b *Broker) QueueArgs(name string) amqp.Table {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	q, ok := b.queues[name]
+	if !ok {
+		return nil
+	}
+
+	return q.args
+}
+
+// IsBound reports whether the queue is bound to the exchange with the key
+func (b *Broker) IsBound(queueName, key, exchangeName string) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	ex, ok := b.exchanges[exchangeName]
+	if !ok {
+		return false
+	}
+
+	for _, bind := range ex.bindings {
+		if !bind.toExchange && bind.destination == queueName && bind.key == key {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Messages returns the messages waiting in the queue, or every message of a stream
+func (b *Broker) Messages(queueName string) []amqp.Delivery {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	q, ok := b.queues[queueName]
+	if !ok {
+		return nil
+	}
+
+	stored := q.ready
+	if q.stream {
+		stored = q.log
+	}
+
+	deliveries := make([]amqp.Delivery, 0, len(stored))
+	for _, m := range stored {
+		deliveries = append(deliveries, m.delivery)
+	}
+
+	return deliveries
+}
+
+// QueueLength returns the number of messages waiting in the queue, or stored in a stream
+func (b *Broker) QueueLength(queueName string) int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	q, ok := b.queues[queueName]
+	if !ok {
+		return 0
+	}
+
+	if q.stream {
+		return len(q.log)
+	}
+
+	return len(q.ready)
+}
+
+// UnackedCount returns the number of delivered messages awaiting acknowledgement
+func (b *Broker) UnackedCount(queueName string) int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	q, ok := b.queues[queueName]
+	if !ok {
+		return 0
+	}
+
+	return len(q.unacked)
+}
+
+// hashBinding picks the binding of the hashed routing key or header, where
+// the binding key is the weight of the destination. Messages with the same
+// hash value always go to the same destination while the bindings are unchanged.
+func (ex *exchange) hashBinding(key string, headers amqp.Table) []binding {
+	value := key
+	if ex.hashHeader != "" {
+		header, ok := headers[ex.hashHeader]
+		if !ok {
+			return nil
+		}
+		value = fmt.Sprint(header)
+	}
+
+	weighted := make([]binding, 0, len(ex.bindings))
+	for _, bind := range ex.bindings {
+		weight, err := strconv.Atoi(bind.key)
+		if err != nil || weight < 1 {
+			weight = 1
+		}
+		for i := 0; i < weight; i++ {
+			weighted = append(weighted, bind)
+		}
+	}
+
+	if len(weighted) == 0 {
+		return nil
+	}
+
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(value))
+	return []binding{weighted[h.Sum32()%uint32(len(weighted))]}
+}
+
+// appendBinding adds the binding unless an identical one exists
+func appendBinding(bindings []binding, bind binding) []binding {
+	for _, existing := range bindings {
+		if existing == bind {
+			return bindings
+		}
+	}
+
+	return append(bindings, bind)
+}
+
+// matches applies the exchange kind's routing rules to the binding key
+func matches(kind, bindingKey, routingKey string) bool {
+	switch kind {
+	case ExchangeFanout:
+		return true
+	case ExchangeTopic:
+		return matchTopic(strings.Split(bindingKey, "."), strings.Split(routingKey, "."))
+	default:
+		return bindingKey == routingKey
+	}
+}
+
+// matchTopic matches dotted words where "*" is exactly one word and "#" is zero or more
+func matchTopic(pattern, words []string) bool {
+	if len(pattern) == 0 {
+		return len(words) == 0
+	}
+
+	switch pattern[0] {
+	case "#":
+		for i := 0; i <= len(words); i++ {
+			if matchTopic(pattern[1:], words[i:]) {
+				return true
+			}
+		}
+		return false
+	case "*":
+		return len(words) > 0 && matchTopic(pattern[1:], words[1:])
+	default:
+		return len(words) > 0 && pattern[0] == words[0] && matchTopic(pattern[1:], words[1:])
+	}
+}
+
+// newReturn builds the basic.return for an unroutable message
+func newReturn(exchangeName, key string, msg amqp.Publishing) amqp.Return {
+	return amqp.Return{
+		ReplyCode:       amqp.NoRoute,
+		ReplyText:       "NO_ROUTE",
+		Exchange:        exchangeName,
+		RoutingKey:      key,
+		ContentType:     msg.ContentType,
+		ContentEncoding: msg.ContentEncoding,
+		Headers:         msg.Headers,
+		DeliveryMode:    msg.DeliveryMode,
+		Priority:        msg.Priority,
+		CorrelationId:   msg.CorrelationId,
+		ReplyTo:         msg.ReplyTo,
+		Expiration:      msg.Expiration,
+		MessageId:       msg.MessageId,
+		Timestamp:       msg.Timestamp,
+		Type:            msg.Type,
+		UserId:          msg.UserId,
+		AppId:           msg.AppId,
+		Body:            msg.Body,
+	}
+}
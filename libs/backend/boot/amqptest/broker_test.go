@@ -0,0 +1,242 @@
+package amqptest_test
+
+import (
+	"context"
+	boot "libs/backend/boot"
+	"libs/backend/boot/amqptest"
+	"testing"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// receive waits for the next delivery or fails the test
+func receive(t *testing.T, deliveries <-chan amqp.Delivery) amqp.Delivery {
+	t.Helper()
+
+	select {
+	case d, ok := <-deliveries:
+		require.True(t, ok, "delivery channel closed")
+		return d
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for delivery")
+		return amqp.Delivery{}
+	}
+}
+
+func TestBrokerRouting(t *testing.T) {
+	broker := amqptest.NewBroker()
+	defer broker.Close()
+
+	require.NoError(t, broker.ExchangeDeclare("events", amqptest.ExchangeTopic, true, false, false, false, nil))
+	require.NoError(t, broker.ExchangeDeclare("direct", amqptest.ExchangeDirect, true, false, false, false, nil))
+	for _, name := range []string{"all", "auth", "one-word", "direct-queue"} {
+		_, err := broker.QueueDeclare(name, true, false, false, false, nil)
+		require.NoError(t, err)
+	}
+	require.NoError(t, broker.QueueBind("all", "#", "events", false, nil))
+	require.NoError(t, broker.QueueBind("auth", "career-cue.auth.#", "events", false, nil))
+	require.NoError(t, broker.QueueBind("one-word", "career-cue.*", "events", false, nil))
+	require.NoError(t, broker.QueueBind("direct-queue", "key", "direct", false, nil))
+
+	require.NoError(t, broker.Publish("events", "career-cue.auth.userRegistered", false, false, amqp.Publishing{Body: []byte("a")}))
+	require.NoError(t, broker.Publish("events", "career-cue.accounts", false, false, amqp.Publishing{Body: []byte("b")}))
+	require.NoError(t, broker.Publish("direct", "key", false, false, amqp.Publishing{Body: []byte("c")}))
+	require.NoError(t, broker.Publish("direct", "other", false, false, amqp.Publishing{Body: []byte("d")}))
+	require.NoError(t, broker.Publish("", "auth", false, false, amqp.Publishing{Body: []byte("e")}))
+
+	assert.Equal(t, 2, broker.QueueLength("all"))
+	assert.Equal(t, 2, broker.QueueLength("auth"))
+	assert.Equal(t, 1, broker.QueueLength("one-word"))
+	assert.Equal(t, 1, broker.QueueLength("direct-queue"))
+	assert.Len(t, broker.Published(), 5)
+
+	assert.ErrorIs(t, broker.ExchangeDeclare("events", amqptest.ExchangeDirect, true, false, false, false, nil), amqptest.ErrPrecondition)
+	assert.ErrorIs(t, broker.QueueBind("missing", "key", "events", false, nil), amqptest.ErrQueueNotFound)
+}
+
+func TestBrokerMandatoryPublish(t *testing.T) {
+	ctx := context.Background()
+	broker := amqptest.NewBroker()
+	defer broker.Close()
+
+	require.NoError(t, broker.ExchangeDeclare("events", amqptest.ExchangeTopic, true, false, false, false, nil))
+
+	require.NoError(t, broker.Publish("events", "nobody.listens", true, false, amqp.Publishing{}))
+	require.Len(t, broker.Returned(), 1)
+	assert.Equal(t, "nobody.listens", broker.Returned()[0].RoutingKey)
+
+	assert.ErrorIs(t, broker.PublishWithConfirm(ctx, "events", "nobody.listens", true, amqp.Publishing{}), boot.ErrPublishUnroutable)
+	assert.NoError(t, broker.PublishWithConfirm(ctx, "events", "nobody.listens", false, amqp.Publishing{}))
+}
+
+func TestBrokerAcknowledgements(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	broker := amqptest.NewBroker()
+	defer broker.Close()
+
+	require.NoError(t, broker.ExchangeDeclare("dlx", amqptest.ExchangeDirect, true, false, false, false, nil))
+	_, err := broker.QueueDeclare("dlq", true, false, false, false, nil)
+	require.NoError(t, err)
+	require.NoError(t, broker.QueueBind("dlq", "dead", "dlx", false, nil))
+	_, err = broker.QueueDeclare("work", true, false, false, false, amqp.Table{
+		"x-dead-letter-exchange":    "dlx",
+		"x-dead-letter-routing-key": "dead",
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, broker.Publish("", "work", false, false, amqp.Publishing{Body: []byte("payload")}))
+
+	deliveries, err := broker.ConsumeWithContext(ctx, "work", "", false, false, false, false, nil)
+	require.NoError(t, err)
+
+	// Requeued messages are redelivered
+	first := receive(t, deliveries)
+	assert.False(t, first.Redelivered)
+	assert.Equal(t, 1, broker.UnackedCount("work"))
+	require.NoError(t, first.Nack(false, true))
+
+	second := receive(t, deliveries)
+	assert.True(t, second.Redelivered)
+	assert.Equal(t, []byte("payload"), second.Body)
+
+	// Rejected messages move to the dead letter queue with x-death headers
+	require.NoError(t, second.Reject(false))
+	assert.Equal(t, 0, broker.UnackedCount("work"))
+	require.Equal(t, 1, broker.QueueLength("dlq"))
+
+	dead := broker.Messages("dlq")[0]
+	assert.Equal(t, "work", dead.Headers["x-first-death-queue"])
+	assert.Equal(t, "rejected", dead.Headers["x-first-death-reason"])
+
+	// Acked messages are removed
+	require.NoError(t, broker.Publish("", "work", false, false, amqp.Publishing{Body: []byte("next")}))
+	third := receive(t, deliveries)
+	require.NoError(t, third.Ack(false))
+	assert.Equal(t, 0, broker.UnackedCount("work"))
+	assert.Equal(t, 0, broker.QueueLength("work"))
+	assert.ErrorIs(t, third.Ack(false), amqptest.ErrUnknownDelivery)
+}
+
+func TestBrokerMaxLength(t *testing.T) {
+	broker := amqptest.NewBroker()
+	defer broker.Close()
+
+	require.NoError(t, broker.ExchangeDeclare("dlx", amqptest.ExchangeFanout, true, false, false, false, nil))
+	_, err := broker.QueueDeclare("overflow", true, false, false, false, nil)
+	require.NoError(t, err)
+	require.NoError(t, broker.QueueBind("overflow", "", "dlx", false, nil))
+	_, err = broker.QueueDeclare("bounded", true, false, false, false, amqp.Table{
+		"x-max-length":           int32(1),
+		"x-dead-letter-exchange": "dlx",
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, broker.Publish("", "bounded", false, false, amqp.Publishing{Body: []byte("old")}))
+	require.NoError(t, broker.Publish("", "bounded", false, false, amqp.Publishing{Body: []byte("new")}))
+
+	require.Equal(t, 1, broker.QueueLength("bounded"))
+	assert.Equal(t, []byte("new"), broker.Messages("bounded")[0].Body)
+	require.Equal(t, 1, broker.QueueLength("overflow"))
+	assert.Equal(t, "maxlen", broker.Messages("overflow")[0].Headers["x-first-death-reason"])
+}
+
+func TestBrokerClose(t *testing.T) {
+	broker := amqptest.NewBroker()
+
+	_, err := broker.QueueDeclare("work", true, false, false, false, nil)
+	require.NoError(t, err)
+
+	deliveries, err := broker.Consume("work", "", true, false, false, false, nil)
+	require.NoError(t, err)
+	require.NoError(t, broker.Close())
+
+	_, ok := <-deliveries
+	assert.False(t, ok)
+	assert.ErrorIs(t, broker.Publish("", "work", false, false, amqp.Publishing{}), amqptest.ErrBrokerClosed)
+}
+
+func TestBrokerConsistentHash(t *testing.T) {
+	broker := amqptest.NewBroker()
+	defer broker.Close()
+
+	require.NoError(t, broker.ExchangeDeclare("events", amqptest.ExchangeTopic, true, false, false, false, nil))
+	require.NoError(t, broker.ExchangeDeclare("partitions", amqptest.ExchangeConsistentHash, true, false, false, false, amqp.Table{"x-hash-on": "x-common-id"}))
+	require.NoError(t, broker.ExchangeBind("partitions", "career-cue.accounts.*", "events", false, nil))
+
+	partitions := []string{"p0", "p1", "p2", "p3"}
+	for _, name := range partitions {
+		_, err := broker.QueueDeclare(name, true, false, false, false, nil)
+		require.NoError(t, err)
+		require.NoError(t, broker.QueueBind(name, "1", "partitions", false, nil))
+	}
+
+	publish := func(commonID, event string) {
+		require.NoError(t, broker.Publish("events", "career-cue.accounts."+event, false, false, amqp.Publishing{
+			Headers: amqp.Table{"x-common-id": commonID},
+			Body:    []byte(commonID + ":" + event),
+		}))
+	}
+
+	for i := 0; i < 20; i++ {
+		commonID := string(rune('a' + i))
+		publish(commonID, "accountCreated")
+		publish(commonID, "accountUpdated")
+	}
+
+	// Every message lands in exactly one partition, and both events of an
+	// entity land in the same partition in publish order
+	total := 0
+	used := 0
+	for _, name := range partitions {
+		messages := broker.Messages(name)
+		total += len(messages)
+		if len(messages) > 0 {
+			used++
+		}
+
+		for i := 0; i < len(messages); i += 2 {
+			assert.Equal(t, messages[i].Headers["x-common-id"], messages[i+1].Headers["x-common-id"])
+			assert.Equal(t, "career-cue.accounts.accountCreated", messages[i].RoutingKey)
+			assert.Equal(t, "career-cue.accounts.accountUpdated", messages[i+1].RoutingKey)
+		}
+	}
+	assert.Equal(t, 40, total)
+	assert.Greater(t, used, 1)
+
+	// Messages without the hash header are unroutable
+	require.NoError(t, broker.Publish("events", "career-cue.accounts.accountCreated", true, false, amqp.Publishing{}))
+	assert.Len(t, broker.Returned(), 1)
+}
+
+func TestBrokerStreamPreconditions(t *testing.T) {
+	broker := amqptest.NewBroker()
+	defer broker.Close()
+
+	_, err := broker.QueueDeclare("stream", true, false, false, false, amqp.Table{boot.QueueTypeArg: boot.QueueTypeStream})
+	require.NoError(t, err)
+
+	// Streams need a prefetch and manual acknowledgements
+	_, err = broker.Consume("stream", "", false, false, false, false, nil)
+	assert.ErrorIs(t, err, amqptest.ErrPrecondition)
+
+	require.NoError(t, broker.Qos(10, 0, false))
+	_, err = broker.Consume("stream", "", true, false, false, false, nil)
+	assert.ErrorIs(t, err, amqptest.ErrPrecondition)
+
+	_, err = broker.Consume("stream", "", false, false, false, false, amqp.Table{boot.StreamOffsetArg: "yesterday"})
+	assert.ErrorIs(t, err, amqptest.ErrPrecondition)
+
+	deliveries, err := broker.Consume("stream", "", false, false, false, false, amqp.Table{boot.StreamOffsetArg: boot.StreamOffsetFirst})
+	require.NoError(t, err)
+
+	require.NoError(t, broker.Publish("", "stream", false, false, amqp.Publishing{Body: []byte("a")}))
+	d := receive(t, deliveries)
+	assert.Equal(t, int64(0), d.Headers[boot.StreamOffsetArg])
+	require.NoError(t, d.Ack(false))
+	assert.Equal(t, 1, broker.QueueLength("stream"))
+}
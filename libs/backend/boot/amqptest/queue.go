@@ -0,0 +1,414 @@
+package amqptest
+
+import (
+	"context"
+	"fmt"
+	boot "libs/backend/boot"
+	"sort"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// Dead letter reasons written into the x-death header
+const (
+	deathReasonRejected = "rejected"
+	deathReasonMaxLen   = "maxlen"
+)
+
+// message is a message stored in a queue
+type message struct {
+	delivery amqp.Delivery
+	storedAt time.Time
+}
+
+// queue is a declared queue holding ready and unacknowledged messages.
+// Stream queues keep every message in an append only log instead.
+type queue struct {
+	name      string
+	args      amqp.Table
+	ready     []*message
+	unacked   map[uint64]*message
+	consumers int
+	notify    chan struct{}
+
+	stream   bool
+	log      []*message
+	appended chan struct{}
+}
+
+// newQueue constructs an empty queue
+func newQueue(name string, args amqp.Table) *queue {
+	return &queue{
+		name:     name,
+		args:     args,
+		ready:    make([]*message, 0),
+		unacked:  make(map[uint64]*message),
+		notify:   make(chan struct{}, 1),
+		stream:   args[boot.QueueTypeArg] == boot.QueueTypeStream,
+		log:      make([]*message, 0),
+		appended: make(chan struct{}),
+	}
+}
+
+// newMessage converts the publishing into a stored message
+func newMessage(exchangeName, key string, msg amqp.Publishing) *message {
+	return &message{
+		delivery: amqp.Delivery{
+			Headers:         copyTable(msg.Headers),
+			ContentType:     msg.ContentType,
+			ContentEncoding: msg.ContentEncoding,
+			DeliveryMode:    msg.DeliveryMode,
+			Priority:        msg.Priority,
+			CorrelationId:   msg.CorrelationId,
+			ReplyTo:         msg.ReplyTo,
+			Expiration:      msg.Expiration,
+			MessageId:       msg.MessageId,
+			Timestamp:       msg.Timestamp,
+			Type:            msg.Type,
+			UserId:          msg.UserId,
+			AppId:           msg.AppId,
+			Exchange:        exchangeName,
+			RoutingKey:      key,
+			Body:            msg.Body,
+		},
+	}
+}
+
+// signal wakes up one waiting consumer
+func (q *queue) signal() {
+	select {
+	case q.notify <- struct{}{}:
+	default:
+	}
+}
+
+// maxLength returns the x-max-length argument when configured
+func (q *queue) maxLength() (int, bool) {
+	switch v := q.args[argMaxLength].(type) {
+	case int:
+		return v, true
+	case int32:
+		return int(v), true
+	case int64:
+		return int(v), true
+	default:
+		return 0, false
+	}
+}
+
+// enqueue appends the message, dead lettering the oldest messages on overflow.
+// The broker lock must be held.
+func (b *Broker) enqueue(q *queue, m *message) {
+	if q.stream {
+		b.appendToStream(q, m)
+		return
+	}
+
+	q.ready = append(q.ready, m)
+
+	if maxLength, ok := q.maxLength(); ok {
+		for len(q.ready) > maxLength {
+			head := q.ready[0]
+			q.ready = q.ready[1:]
+			b.deadLetter(q, head, deathReasonMaxLen)
+		}
+	}
+
+	q.signal()
+}
+
+// deadLetter republishes the message to the queue's dead letter exchange or drops it.
+// The broker lock must be held.
+func (b *Broker) deadLetter(q *queue, m *message, reason string) {
+	dlx, ok := q.args[argDeadLetterExchange].(string)
+	if !ok {
+		return
+	}
+
+	key := m.delivery.RoutingKey
+	if dlk, ok := q.args[argDeadLetterRoutingKey].(string); ok {
+		key = dlk
+	}
+
+	if _, ok := b.exchanges[dlx]; !ok {
+		return
+	}
+
+	dead := &message{delivery: m.delivery}
+	dead.delivery.Headers = withDeath(m.delivery.Headers, q.name, reason, m.delivery.Exchange, m.delivery.RoutingKey)
+	dead.delivery.Exchange = dlx
+	dead.delivery.RoutingKey = key
+	dead.delivery.Redelivered = false
+
+	for _, queueName := range b.route(dlx, key, dead.delivery.Headers) {
+		copied := &message{delivery: dead.delivery}
+		b.enqueue(b.queues[queueName], copied)
+	}
+}
+
+// Consume starts delivering messages from the queue
+func (b *Broker) Consume(queueName, consumer string, autoAck, exclusive, noLocal, noWait bool, args amqp.Table) (<-chan amqp.Delivery, error) {
+	return b.ConsumeWithContext(context.Background(), queueName, consumer, autoAck, exclusive, noLocal, noWait, args)
+}
+
+// ConsumeWithContext starts delivering messages from the queue until the context
+// is cancelled or the broker is closed
+func (b *Broker) ConsumeWithContext(ctx context.Context, queueName, consumer string, autoAck, exclusive, noLocal, noWait bool, args amqp.Table) (<-chan amqp.Delivery, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.consume(ctx, b.prefetch, queueName, consumer, autoAck, args)
+}
+
+// consume starts a consumer with the prefetch of its channel.
+// The broker lock must be held.
+func (b *Broker) consume(ctx context.Context, prefetch int, queueName, consumer string, autoAck bool, args amqp.Table) (<-chan amqp.Delivery, error) {
+	if b.closed {
+		return nil, ErrBrokerClosed
+	}
+
+	q, ok := b.queues[queueName]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrQueueNotFound, queueName)
+	}
+
+	if consumer == "" {
+		b.generatedIDs++
+		consumer = fmt.Sprintf("ctag-%d", b.generatedIDs)
+	}
+
+	deliveries := make(chan amqp.Delivery)
+	if q.stream {
+		if autoAck || prefetch == 0 {
+			return nil, fmt.Errorf("%w: stream consumers need manual acknowledgements and a prefetch", ErrPrecondition)
+		}
+
+		position, err := q.streamPosition(args[boot.StreamOffsetArg])
+		if err != nil {
+			return nil, err
+		}
+
+		q.consumers++
+		go b.deliverStream(ctx, q, consumer, position, deliveries)
+		return deliveries, nil
+	}
+
+	q.consumers++
+	go b.deliver(ctx, q, consumer, autoAck, deliveries)
+
+	return deliveries, nil
+}
+
+// deliver pushes ready messages to the consumer channel
+func (b *Broker) deliver(ctx context.Context, q *queue, consumer string, autoAck bool, deliveries chan amqp.Delivery) {
+	defer close(deliveries)
+	defer b.removeConsumer(q)
+
+	for {
+		m, delivery, ok := b.next(q, consumer, autoAck)
+		if !ok {
+			select {
+			case <-q.notify:
+				continue
+			case <-ctx.Done():
+				return
+			case <-b.done:
+				return
+			}
+		}
+
+		select {
+		case deliveries <- delivery:
+		case <-ctx.Done():
+			b.putBack(q, m, delivery.DeliveryTag)
+			return
+		case <-b.done:
+			return
+		}
+	}
+}
+
+// next pops the next ready message and tracks it until acknowledged
+func (b *Broker) next(q *queue, consumer string, autoAck bool) (*message, amqp.Delivery, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if len(q.ready) == 0 {
+		return nil, amqp.Delivery{}, false
+	}
+
+	m := q.ready[0]
+	q.ready = q.ready[1:]
+
+	// Keep other consumers draining the queue
+	if len(q.ready) > 0 {
+		q.signal()
+	}
+
+	b.deliveryTag++
+	delivery := m.delivery
+	delivery.DeliveryTag = b.deliveryTag
+	delivery.ConsumerTag = consumer
+	delivery.Acknowledger = &acknowledger{broker: b, queue: q}
+
+	if !autoAck {
+		q.unacked[delivery.DeliveryTag] = m
+	}
+
+	return m, delivery, true
+}
+
+// putBack returns a message that was popped but never handed to the consumer
+func (b *Broker) putBack(q *queue, m *message, deliveryTag uint64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	delete(q.unacked, deliveryTag)
+	q.ready = append([]*message{m}, q.ready...)
+	q.signal()
+}
+
+// removeConsumer decrements the queue's consumer count
+func (b *Broker) removeConsumer(q *queue) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	q.consumers--
+}
+
+// acknowledger settles deliveries for a single queue
+type acknowledger struct {
+	broker *Broker
+	queue  *queue
+}
+
+// Ack removes the acknowledged messages from the queue
+func (a *acknowledger) Ack(tag uint64, multiple bool) error {
+	a.broker.mu.Lock()
+	defer a.broker.mu.Unlock()
+
+	tags, err := a.tags(tag, multiple)
+	if err != nil {
+		return err
+	}
+
+	for _, t := range tags {
+		delete(a.queue.unacked, t)
+	}
+
+	return nil
+}
+
+// Nack requeues the messages at the front of the queue or dead letters them
+func (a *acknowledger) Nack(tag uint64, multiple, requeue bool) error {
+	a.broker.mu.Lock()
+	defer a.broker.mu.Unlock()
+
+	tags, err := a.tags(tag, multiple)
+	if err != nil {
+		return err
+	}
+
+	// Requeue in reverse so the original order is kept at the front of the queue
+	for i := len(tags) - 1; i >= 0; i-- {
+		m := a.queue.unacked[tags[i]]
+		delete(a.queue.unacked, tags[i])
+
+		if requeue {
+			m.delivery.Redelivered = true
+			a.queue.ready = append([]*message{m}, a.queue.ready...)
+			continue
+		}
+
+		a.broker.deadLetter(a.queue, m, deathReasonRejected)
+	}
+
+	if requeue {
+		a.queue.signal()
+	}
+
+	return nil
+}
+
+// Reject requeues or dead letters a single message
+func (a *acknowledger) Reject(tag uint64, requeue bool) error {
+	return a.Nack(tag, false, requeue)
+}
+
+// tags resolves the delivery tags affected by an acknowledgement in ascending order
+func (a *acknowledger) tags(tag uint64, multiple bool) ([]uint64, error) {
+	if !multiple {
+		if _, ok := a.queue.unacked[tag]; !ok {
+			return nil, fmt.Errorf("%w: %d", ErrUnknownDelivery, tag)
+		}
+		return []uint64{tag}, nil
+	}
+
+	tags := make([]uint64, 0)
+	for t := range a.queue.unacked {
+		if t <= tag {
+			tags = append(tags, t)
+		}
+	}
+	sort.Slice(tags, func(i, j int) bool { return tags[i] < tags[j] })
+
+	return tags, nil
+}
+
+// withDeath records the dead lettering in the x-death headers like RabbitMQ and LavinMQ
+func withDeath(headers amqp.Table, queueName, reason, exchangeName, key string) amqp.Table {
+	updated := copyTable(headers)
+	if updated == nil {
+		updated = amqp.Table{}
+	}
+
+	existing, _ := updated["x-death"].([]interface{})
+	deaths := append([]interface{}(nil), existing...)
+	found := false
+	for i, d := range deaths {
+		death, ok := d.(amqp.Table)
+		if !ok || death["queue"] != queueName || death["reason"] != reason {
+			continue
+		}
+
+		count, _ := death["count"].(int64)
+		death = copyTable(death)
+		death["count"] = count + 1
+		deaths[i] = death
+		found = true
+	}
+
+	if !found {
+		deaths = append([]interface{}{amqp.Table{
+			"queue":        queueName,
+			"reason":       reason,
+			"exchange":     exchangeName,
+			"routing-keys": []interface{}{key},
+			"count":        int64(1),
+		}}, deaths...)
+	}
+	updated["x-death"] = deaths
+
+	if _, ok := updated["x-first-death-queue"]; !ok {
+		updated["x-first-death-queue"] = queueName
+		updated["x-first-death-reason"] = reason
+		updated["x-first-death-exchange"] = exchangeName
+	}
+
+	return updated
+}
+
+// copyTable makes a shallow copy of the table
+func copyTable(table amqp.Table) amqp.Table {
+	if table == nil {
+		return nil
+	}
+
+	copied := make(amqp.Table, len(table))
+	for k, v := range table {
+		copied[k] = v
+	}
+
+	return copied
+}
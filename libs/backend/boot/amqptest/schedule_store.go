@@ -0,0 +1,189 @@
+package amqptest
+
+import (
+	"context"
+	"fmt"
+	boot "libs/backend/boot"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Assertion of the proper interface
+var _ boot.ScheduleStore = (*ScheduleStore)(nil)
+
+// scheduledEntry is a stored scheduled publishing and its delivery state
+type scheduledEntry struct {
+	scheduled   boot.ScheduledPublishing
+	lockedUntil time.Time
+	lastError   string
+	delivered   bool
+	cancelled   bool
+	settledAt   time.Time
+}
+
+// ScheduleStore is an in-memory schedule store
+type ScheduleStore struct {
+	mu      *sync.Mutex
+	nextID  int
+	entries []*scheduledEntry
+}
+
+// NewScheduleStore constructs an empty in-memory schedule store
+func NewScheduleStore() *ScheduleStore {
+	return &ScheduleStore{mu: new(sync.Mutex)}
+}
+
+// Save stores the message and cancels pending messages with the same key
+func (s *ScheduleStore) Save(_ context.Context, scheduled boot.ScheduledPublishing) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if scheduled.Key != "" {
+		s.cancel(scheduled.Key)
+	}
+
+	s.nextID++
+	scheduled.ID = fmt.Sprintf("%d", s.nextID)
+	s.entries = append(s.entries, &scheduledEntry{scheduled: scheduled})
+
+	return nil
+}
+
+// Cancel cancels the pending messages of the key and returns how many were cancelled
+func (s *ScheduleStore) Cancel(_ context.Context, key string) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.cancel(key), nil
+}
+
+// ClaimDue leases up to limit due messages, the messages due at the same
+// time in the order they were saved in
+func (s *ScheduleStore) ClaimDue(_ context.Context, now time.Time, limit int, lease time.Duration) ([]boot.ScheduledPublishing, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	due := make([]*scheduledEntry, 0)
+	for _, entry := range s.entries {
+		if entry.delivered || entry.cancelled || entry.scheduled.DeliverAt.After(now) || entry.lockedUntil.After(now) {
+			continue
+		}
+		due = append(due, entry)
+	}
+
+	sort.SliceStable(due, func(i, j int) bool {
+		return due[i].scheduled.DeliverAt.Before(due[j].scheduled.DeliverAt)
+	})
+	if len(due) > limit {
+		due = due[:limit]
+	}
+
+	claimed := make([]boot.ScheduledPublishing, 0, len(due))
+	for _, entry := range due {
+		entry.lockedUntil = now.Add(lease)
+		claimed = append(claimed, entry.scheduled)
+	}
+
+	return claimed, nil
+}
+
+// MarkDelivered records the message as published
+func (s *ScheduleStore) MarkDelivered(_ context.Context, id string, deliveredAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if entry := s.find(id); entry != nil {
+		entry.delivered = true
+		entry.settledAt = deliveredAt
+		entry.lockedUntil = time.Time{}
+	}
+
+	return nil
+}
+
+// MarkFailed records the failure and when to retry the message
+func (s *ScheduleStore) MarkFailed(_ context.Context, id string, deliveryErr error, retryAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if entry := s.find(id); entry != nil {
+		entry.scheduled.Attempts++
+		entry.lastError = deliveryErr.Error()
+		entry.lockedUntil = retryAt
+	}
+
+	return nil
+}
+
+// Postpone hides the claimed messages until retryAt without counting an attempt
+func (s *ScheduleStore) Postpone(_ context.Context, ids []string, retryAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, id := range ids {
+		if entry := s.find(id); entry != nil && !entry.delivered {
+			entry.lockedUntil = retryAt
+		}
+	}
+
+	return nil
+}
+
+// Purge deletes the messages delivered or cancelled before the time and returns how many were deleted
+func (s *ScheduleStore) Purge(_ context.Context, before time.Time) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	kept := make([]*scheduledEntry, 0, len(s.entries))
+	for _, entry := range s.entries {
+		if (entry.delivered || entry.cancelled) && entry.settledAt.Before(before) {
+			continue
+		}
+		kept = append(kept, entry)
+	}
+
+	purged := len(s.entries) - len(kept)
+	s.entries = kept
+	return purged, nil
+}
+
+// Pending returns the messages that are neither delivered nor cancelled
+func (s *ScheduleStore) Pending() []boot.ScheduledPublishing {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	pending := make([]boot.ScheduledPublishing, 0)
+	for _, entry := range s.entries {
+		if !entry.delivered && !entry.cancelled {
+			pending = append(pending, entry.scheduled)
+		}
+	}
+
+	return pending
+}
+
+// cancel cancels the pending messages of the key
+func (s *ScheduleStore) cancel(key string) int {
+	cancelled := 0
+	for _, entry := range s.entries {
+		if entry.scheduled.Key == key && !entry.delivered && !entry.cancelled {
+			entry.cancelled = true
+			entry.settledAt = time.Now()
+			cancelled++
+		}
+	}
+
+	return cancelled
+}
+
+// find returns the entry with the id
+func (s *ScheduleStore) find(id string) *scheduledEntry {
+	for _, entry := range s.entries {
+		if entry.scheduled.ID == id {
+			return entry
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,215 @@
+package amqptest
+
+import (
+	"context"
+	"fmt"
+	boot "libs/backend/boot"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// Qos records the prefetch, which stream consumers require
+func (b *Broker) Qos(prefetchCount, prefetchSize int, global bool) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return ErrBrokerClosed
+	}
+
+	b.prefetch = prefetchCount
+	return nil
+}
+
+// appendToStream stores the message at the next offset and wakes every stream consumer.
+// The broker lock must be held.
+func (b *Broker) appendToStream(q *queue, m *message) {
+	offset := int64(len(q.log))
+
+	m.storedAt = time.Now()
+	m.delivery.Headers = copyTable(m.delivery.Headers)
+	if m.delivery.Headers == nil {
+		m.delivery.Headers = amqp.Table{}
+	}
+	m.delivery.Headers[boot.StreamOffsetArg] = offset
+
+	q.log = append(q.log, m)
+
+	close(q.appended)
+	q.appended = make(chan struct{})
+}
+
+// streamPosition resolves the x-stream-offset argument to a log position.
+// The broker lock must be held.
+func (q *queue) streamPosition(offset any) (int, error) {
+	switch v := offset.(type) {
+	case nil, string:
+		switch v {
+		case nil, boot.StreamOffsetNext:
+			return len(q.log), nil
+		case boot.StreamOffsetFirst:
+			return 0, nil
+		case boot.StreamOffsetLast:
+			if len(q.log) == 0 {
+				return 0, nil
+			}
+			return len(q.log) - 1, nil
+		}
+	case int64:
+		return clampPosition(v, len(q.log)), nil
+	case int:
+		return clampPosition(int64(v), len(q.log)), nil
+	case int32:
+		return clampPosition(int64(v), len(q.log)), nil
+	case time.Time:
+		for i, m := range q.log {
+			if !m.storedAt.Before(v) {
+				return i, nil
+			}
+		}
+		return len(q.log), nil
+	}
+
+	return 0, fmt.Errorf("%w: unsupported stream offset %v", ErrPrecondition, offset)
+}
+
+// clampPosition keeps numeric offsets within the log
+func clampPosition(offset int64, length int) int {
+	switch {
+	case offset < 0:
+		return 0
+	case offset > int64(length):
+		return length
+	default:
+		return int(offset)
+	}
+}
+
+// deliverStream pushes the log from the position to the consumer and waits for new messages
+func (b *Broker) deliverStream(ctx context.Context, q *queue, consumer string, position int, deliveries chan amqp.Delivery) {
+	defer close(deliveries)
+	defer b.removeConsumer(q)
+
+	for {
+		b.mu.Lock()
+		if position >= len(q.log) {
+			appended := q.appended
+			b.mu.Unlock()
+
+			select {
+			case <-appended:
+				continue
+			case <-ctx.Done():
+				return
+			case <-b.done:
+				return
+			}
+		}
+
+		b.deliveryTag++
+		delivery := q.log[position].delivery
+		delivery.DeliveryTag = b.deliveryTag
+		delivery.ConsumerTag = consumer
+		delivery.Acknowledger = streamAcknowledger{}
+		b.mu.Unlock()
+
+		select {
+		case deliveries <- delivery:
+			position++
+		case <-ctx.Done():
+			return
+		case <-b.done:
+			return
+		}
+	}
+}
+
+// streamAcknowledger accepts every settlement, stream messages stay in the log
+type streamAcknowledger struct{}
+
+func (streamAcknowledger) Ack(tag uint64, multiple bool) error           { return nil }
+func (streamAcknowledger) Nack(tag uint64, multiple, requeue bool) error { return nil }
+func (streamAcknowledger) Reject(tag uint64, requeue bool) error         { return nil }
+
+// Channel is a dedicated channel of the broker with its own prefetch
+type Channel struct {
+	broker   *Broker
+	prefetch int
+	closed   bool
+}
+
+// Assertion of the proper interface
+var _ boot.AMQPConsumerChannel = (*Channel)(nil)
+
+// OpenChannel opens a dedicated channel, its prefetch does not change the broker's
+func (b *Broker) OpenChannel() (boot.AMQPConsumerChannel, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return nil, ErrBrokerClosed
+	}
+
+	b.openChannels++
+	return &Channel{broker: b}, nil
+}
+
+// OpenChannels returns the number of dedicated channels that are not closed
+func (b *Broker) OpenChannels() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.openChannels
+}
+
+// Prefetch returns the prefetch set on the broker itself, i.e. the shared channel
+func (b *Broker) Prefetch() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.prefetch
+}
+
+// Qos records the prefetch of the channel
+func (c *Channel) Qos(prefetchCount, prefetchSize int, global bool) error {
+	c.broker.mu.Lock()
+	defer c.broker.mu.Unlock()
+
+	if c.closed || c.broker.closed {
+		return ErrBrokerClosed
+	}
+
+	c.prefetch = prefetchCount
+	return nil
+}
+
+// Consume starts delivering messages from the queue
+func (c *Channel) Consume(queueName, consumer string, autoAck, exclusive, noLocal, noWait bool, args amqp.Table) (<-chan amqp.Delivery, error) {
+	return c.ConsumeWithContext(context.Background(), queueName, consumer, autoAck, exclusive, noLocal, noWait, args)
+}
+
+// ConsumeWithContext starts delivering messages from the queue with the prefetch of the channel
+func (c *Channel) ConsumeWithContext(ctx context.Context, queueName, consumer string, autoAck, exclusive, noLocal, noWait bool, args amqp.Table) (<-chan amqp.Delivery, error) {
+	c.broker.mu.Lock()
+	defer c.broker.mu.Unlock()
+
+	if c.closed || c.broker.closed {
+		return nil, ErrBrokerClosed
+	}
+
+	return c.broker.consume(ctx, c.prefetch, queueName, consumer, autoAck, args)
+}
+
+// Close closes the channel, its consumers stop with the context they were started with
+func (c *Channel) Close() error {
+	c.broker.mu.Lock()
+	defer c.broker.mu.Unlock()
+
+	if !c.closed {
+		c.closed = true
+		c.broker.openChannels--
+	}
+
+	return nil
+}
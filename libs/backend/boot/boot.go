@@ -55,6 +55,19 @@ func (bsb *BootServiceBuilder) SetDBOptions(dbOptions DBOptions) *BootServiceBui
 	return bsb
 }
 
+// SetSchedulerOptions sets the scheduled publishing options on the BootService
+func (bsb *BootServiceBuilder) SetSchedulerOptions(schedulerOptions SchedulerOptions) *BootServiceBuilder {
+	bsb.bootService.schedulerOptions = schedulerOptions
+	return bsb
+}
+
+// SetDBReadyCallbacks sets the callbacks that run once the DB is connected,
+// before any AMQP handler or connectRPC handler uses it, e.g. migrations
+func (bsb *BootServiceBuilder) SetDBReadyCallbacks(dbReadyCallbacks []BootCallback) *BootServiceBuilder {
+	bsb.bootService.dbReadyCallbacks = dbReadyCallbacks
+	return bsb
+}
+
 // SetBootCallbacks sets the boot callback for connection on the BootService
 func (bsb *BootServiceBuilder) SetBootCallbacks(bootCallbacks []BootCallback) *BootServiceBuilder {
 	bsb.bootService.bootCallbacks = bootCallbacks
@@ -80,6 +93,9 @@ type BootService struct {
 	localDB           *sql.DB
 	db                *gorm.DB
 	bootCallbacks     []BootCallback
+	dbReadyCallbacks  []BootCallback
+	schedulerOptions  SchedulerOptions
+	scheduler         *Scheduler
 }
 
 // BootCallback are methods for when the service is booted
@@ -108,6 +124,26 @@ func (s *BootService) startAMQPBrokerConnection(opts AMQPOptions) error {
 	return nil
 }
 
+// initializeScheduler creates the scheduler when it is enabled and the service has a database
+func (s *BootService) initializeScheduler(publisher AMQPConfirmPublisher) error {
+	if s.schedulerOptions.IsZero() {
+		return nil
+	}
+
+	if s.db == nil {
+		s.logger.Warn("AMQP scheduler will not be used without a DB")
+		return nil
+	}
+
+	store, err := NewGormScheduleStore(s.db)
+	if err != nil {
+		return err
+	}
+
+	s.scheduler = NewScheduler(s.logger, store, publisher, s.schedulerOptions)
+	return nil
+}
+
 // startDBConnection will assign the DB options to the Boot Service
 // and start the connection to the DB
 func (s *BootService) startDBConnection(opts DBOptions) error {
@@ -140,16 +176,35 @@ func (s *BootService) Start(ctx context.Context) error {
 		os.Exit(0)
 	}(ctx, s)
 
+	// Start the connection to CockroachDB before AMQP handlers need it
+	if err := s.startDBConnection(s.dbOptions); err != nil {
+		s.logger.Error("Cannot establish connection to DB", slog.Any("error", err))
+		os.Exit(1)
+	}
+
+	// Prepare the DB before the handlers start using it
+	if s.db != nil {
+		for _, cb := range s.dbReadyCallbacks {
+			if err := cb(BootCallbackParams{
+				Logger: s.logger,
+				DB:     s.db,
+			}); err != nil {
+				s.logger.Error("failed to execute DB ready callback", slog.Any("error", err))
+				os.Exit(1)
+			}
+		}
+	}
+
 	// Start the connection to AMQP broker
 	if err := s.startAMQPBrokerConnection(s.amqpOptions); err != nil {
 		s.logger.Error("Cannot establish connection to AMQP broker", slog.Any("error", err))
 		os.Exit(1)
 	}
 
-	// Start the connection to CockroachDB
-	if err := s.startDBConnection(s.dbOptions); err != nil {
-		s.logger.Error("Cannot establish connection to DB", slog.Any("error", err))
-		os.Exit(1)
+	// Deliver scheduled publishings once they are due
+	if s.scheduler != nil {
+		s.logger.Info("Starting AMQP scheduler")
+		go s.scheduler.Run(ctx)
 	}
 
 	// Start the connectRPC Service
@@ -30,22 +30,30 @@ func (dbo DBOptions) IsZero() bool {
 
 }
 
-// BootServiceBuilder is a builder struct for the BootService Instance
-func (bs *BootService) InitializeDB() (err error) {
-	// Construct the DSN
-	dsn := fmt.Sprintf(
+// DSN returns the postgres connection string of the options
+func (dbo DBOptions) DSN() string {
+	return fmt.Sprintf(
 		"postgresql://%s:%s@%s:%s/%s?sslmode=%s&timeZone=%s",
-		bs.dbOptions.User,
-		bs.dbOptions.Password,
-		bs.dbOptions.Host,
-		bs.dbOptions.Port,
-		bs.dbOptions.Name,
-		bs.dbOptions.SSLMode,
-		bs.dbOptions.TimeZone,
+		dbo.User,
+		dbo.Password,
+		dbo.Host,
+		dbo.Port,
+		dbo.Name,
+		dbo.SSLMode,
+		dbo.TimeZone,
 	)
+}
+
+// OpenDB connects to the database outside of a BootService, e.g. in one off commands
+func OpenDB(opts DBOptions) (*gorm.DB, error) {
+	return gorm.Open(postgres.Open(opts.DSN()), &gorm.Config{})
+}
+
+// BootServiceBuilder is a builder struct for the BootService Instance
+func (bs *BootService) InitializeDB() (err error) {
 	bs.logger.Info("Connecting to database")
 
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	db, err := OpenDB(bs.dbOptions)
 	if err != nil {
 		bs.logger.Error("Failed to connect to database", err)
 		return
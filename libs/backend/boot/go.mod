@@ -3,6 +3,7 @@ module libs/backend/boot
 go 1.23
 
 require (
+	github.com/google/uuid v1.6.0
 	github.com/prometheus/client_golang v1.20.5
 	github.com/rabbitmq/amqp091-go v1.10.0
 	github.com/stretchr/testify v1.10.0
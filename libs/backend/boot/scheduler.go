@@ -0,0 +1,295 @@
+package boot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// Scheduler Defaults
+const (
+	DefaultSchedulerPollInterval = 5 * time.Second
+	DefaultSchedulerBatchSize    = 100
+	DefaultSchedulerRetryDelay   = 30 * time.Second
+	DefaultSchedulerLease        = time.Minute
+	DefaultSchedulerRetention    = 7 * 24 * time.Hour
+	DefaultSchedulerPurgeEvery   = time.Hour
+)
+
+// ErrSchedulerUnavailable is returned when a scheduled publishing is requested
+// but the service has no scheduler configured
+var ErrSchedulerUnavailable = errors.New("amqp scheduler is not configured")
+
+// ScheduledPublishing is a message that is published to the exchange once it is due
+type ScheduledPublishing struct {
+	// ID is assigned by the store
+	ID string
+
+	// Key identifies the schedule for cancellation, scheduling the same key
+	// again replaces the pending message
+	Key string
+
+	Exchange   string
+	RoutingKey string
+	DeliverAt  time.Time
+	Publishing amqp.Publishing
+
+	// Attempts counts the failed deliveries
+	Attempts int
+}
+
+// AMQPScheduler defines the scheduled AMQP publish methods
+type AMQPScheduler interface {
+	// Schedule stores the message until it is due
+	Schedule(ctx context.Context, scheduled ScheduledPublishing) error
+
+	// Cancel removes the pending message of the key and reports if one existed
+	Cancel(ctx context.Context, key string) (bool, error)
+}
+
+// ScheduleStore is the durable storage of scheduled messages
+type ScheduleStore interface {
+	// Save stores the message and cancels pending messages with the same key
+	Save(ctx context.Context, scheduled ScheduledPublishing) error
+
+	// Cancel cancels the pending messages of the key and returns how many were cancelled
+	Cancel(ctx context.Context, key string) (int, error)
+
+	// ClaimDue leases up to limit due messages so no other poller delivers them
+	// concurrently, in the order they are due and were saved in
+	ClaimDue(ctx context.Context, now time.Time, limit int, lease time.Duration) ([]ScheduledPublishing, error)
+
+	// Postpone hides the claimed messages until retryAt without counting an attempt
+	Postpone(ctx context.Context, ids []string, retryAt time.Time) error
+
+	// MarkDelivered records the message as published
+	MarkDelivered(ctx context.Context, id string, deliveredAt time.Time) error
+
+	// MarkFailed records the failure and when to retry the message
+	MarkFailed(ctx context.Context, id string, deliveryErr error, retryAt time.Time) error
+
+	// Purge deletes the messages delivered or cancelled before the time and returns how many were deleted
+	Purge(ctx context.Context, before time.Time) (int, error)
+}
+
+// SchedulerOptions configures the scheduled delivery poller
+type SchedulerOptions struct {
+	// Enabled starts the poller when the service has a database and an AMQP connection
+	Enabled bool
+
+	// PollInterval is how often due messages are delivered
+	PollInterval time.Duration
+
+	// BatchSize is the number of due messages delivered per poll
+	BatchSize int
+
+	// RetryDelay is the base delay before a failed delivery is retried
+	RetryDelay time.Duration
+
+	// Lease is how long a claimed message is hidden from other pollers
+	Lease time.Duration
+
+	// Retention is how long delivered and cancelled messages are kept
+	Retention time.Duration
+
+	// PurgeInterval is how often the messages past the retention are deleted
+	PurgeInterval time.Duration
+}
+
+// IsZero will let the caller know if the SchedulerOptions is empty
+func (o SchedulerOptions) IsZero() bool {
+	return !o.Enabled
+}
+
+// withDefaults fills the unset options
+func (o SchedulerOptions) withDefaults() SchedulerOptions {
+	if o.PollInterval <= 0 {
+		o.PollInterval = DefaultSchedulerPollInterval
+	}
+	if o.BatchSize <= 0 {
+		o.BatchSize = DefaultSchedulerBatchSize
+	}
+	if o.RetryDelay <= 0 {
+		o.RetryDelay = DefaultSchedulerRetryDelay
+	}
+	if o.Lease <= 0 {
+		o.Lease = DefaultSchedulerLease
+	}
+	if o.Retention <= 0 {
+		o.Retention = DefaultSchedulerRetention
+	}
+	if o.PurgeInterval <= 0 {
+		o.PurgeInterval = DefaultSchedulerPurgeEvery
+	}
+
+	return o
+}
+
+// Assertion of the proper interface
+var _ AMQPScheduler = (*Scheduler)(nil)
+
+// Scheduler stores messages in the schedule store and publishes them with
+// broker confirmations once they are due. A message is only marked as
+// delivered after the broker confirmed it, so delivery is at least once.
+type Scheduler struct {
+	logger    Logger
+	store     ScheduleStore
+	publisher AMQPConfirmPublisher
+	opts      SchedulerOptions
+	now       func() time.Time
+}
+
+// NewScheduler constructs the scheduler for the store and publisher
+func NewScheduler(logger Logger, store ScheduleStore, publisher AMQPConfirmPublisher, opts SchedulerOptions) *Scheduler {
+	return &Scheduler{
+		logger:    logger,
+		store:     store,
+		publisher: publisher,
+		opts:      opts.withDefaults(),
+		now:       time.Now,
+	}
+}
+
+// Schedule stores the message until it is due
+func (s *Scheduler) Schedule(ctx context.Context, scheduled ScheduledPublishing) error {
+	if scheduled.Exchange == "" && scheduled.RoutingKey == "" {
+		return errors.New("scheduled publishing needs an exchange or routing key")
+	}
+
+	if scheduled.DeliverAt.IsZero() {
+		scheduled.DeliverAt = s.now()
+	}
+
+	if err := s.store.Save(ctx, scheduled); err != nil {
+		return fmt.Errorf("cannot schedule publishing: %w", err)
+	}
+
+	s.logger.Info(
+		"Scheduled publishing",
+		slog.String("key", scheduled.Key),
+		slog.String("routingKey", scheduled.RoutingKey),
+		slog.Time("deliverAt", scheduled.DeliverAt),
+	)
+
+	return nil
+}
+
+// Cancel removes the pending message of the key and reports if one existed
+func (s *Scheduler) Cancel(ctx context.Context, key string) (bool, error) {
+	if key == "" {
+		return false, errors.New("cannot cancel scheduled publishing without key")
+	}
+
+	cancelled, err := s.store.Cancel(ctx, key)
+	if err != nil {
+		return false, fmt.Errorf("cannot cancel scheduled publishing: %w", err)
+	}
+
+	s.logger.Info("Cancelled scheduled publishing", slog.String("key", key), slog.Int("count", cancelled))
+	return cancelled > 0, nil
+}
+
+// Run delivers due messages on every poll interval and purges the messages
+// past the retention on every purge interval until the context is cancelled
+func (s *Scheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.opts.PollInterval)
+	defer ticker.Stop()
+
+	purgeTicker := time.NewTicker(s.opts.PurgeInterval)
+	defer purgeTicker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := s.DeliverDue(ctx); err != nil {
+				s.logger.Error("Cannot deliver scheduled publishings", slog.Any("error", err))
+			}
+		case <-purgeTicker.C:
+			if _, err := s.Purge(ctx); err != nil {
+				s.logger.Error("Cannot purge scheduled publishings", slog.Any("error", err))
+			}
+		}
+	}
+}
+
+// Purge deletes the delivered and cancelled messages past the retention and
+// returns how many were deleted
+func (s *Scheduler) Purge(ctx context.Context) (int, error) {
+	purged, err := s.store.Purge(ctx, s.now().Add(-s.opts.Retention))
+	if err != nil {
+		return 0, fmt.Errorf("cannot purge scheduled publishings: %w", err)
+	}
+
+	if purged > 0 {
+		s.logger.Info("Purged scheduled publishings", slog.Int("count", purged))
+	}
+
+	return purged, nil
+}
+
+// DeliverDue publishes the due messages in order and returns how many were
+// delivered. A failed publish stops the batch, the later messages are
+// postponed with it so they are not published ahead of it.
+func (s *Scheduler) DeliverDue(ctx context.Context) (int, error) {
+	now := s.now()
+
+	due, err := s.store.ClaimDue(ctx, now, s.opts.BatchSize, s.opts.Lease)
+	if err != nil {
+		return 0, err
+	}
+
+	delivered := 0
+	for i, scheduled := range due {
+		err := s.publisher.PublishWithConfirm(ctx, scheduled.Exchange, scheduled.RoutingKey, false, scheduled.Publishing)
+		if err != nil {
+			retryAt := s.now().Add(s.opts.RetryDelay * time.Duration(scheduled.Attempts+1))
+			s.logger.Error(
+				"Cannot deliver scheduled publishing",
+				slog.String("id", scheduled.ID),
+				slog.String("key", scheduled.Key),
+				slog.Time("retryAt", retryAt),
+				slog.Any("error", err),
+			)
+
+			if err := s.store.MarkFailed(ctx, scheduled.ID, err, retryAt); err != nil {
+				s.logger.Error("Cannot record scheduled publishing failure", slog.String("id", scheduled.ID), slog.Any("error", err))
+			}
+
+			if err := s.postpone(ctx, due[i+1:], retryAt); err != nil {
+				s.logger.Error("Cannot postpone scheduled publishings", slog.Any("error", err))
+			}
+			return delivered, nil
+		}
+
+		// A failure here redelivers the message once the lease expires
+		if err := s.store.MarkDelivered(ctx, scheduled.ID, s.now()); err != nil {
+			s.logger.Error("Cannot mark scheduled publishing as delivered", slog.String("id", scheduled.ID), slog.Any("error", err))
+			continue
+		}
+
+		delivered++
+	}
+
+	return delivered, nil
+}
+
+// postpone hides the unpublished messages of the batch until retryAt, a
+// failure here leaves them to their lease
+func (s *Scheduler) postpone(ctx context.Context, remaining []ScheduledPublishing, retryAt time.Time) error {
+	if len(remaining) == 0 {
+		return nil
+	}
+
+	ids := make([]string, 0, len(remaining))
+	for _, scheduled := range remaining {
+		ids = append(ids, scheduled.ID)
+	}
+
+	return s.store.Postpone(ctx, ids, retryAt)
+}
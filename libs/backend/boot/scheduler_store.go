@@ -0,0 +1,251 @@
+package boot
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	amqp "github.com/rabbitmq/amqp091-go"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func init() {
+	// Nested header values are encoded as interfaces
+	gob.Register(amqp.Table{})
+	gob.Register([]interface{}{})
+	gob.Register(amqp.Decimal{})
+	gob.Register(time.Time{})
+}
+
+// ScheduledMessage is our model, which corresponds to the "scheduled_messages" table
+type ScheduledMessage struct {
+	ID            uuid.UUID `gorm:"primaryKey;type:uuid"`
+	Seq           int64     `gorm:"autoIncrement;not null;"`
+	Key           string    `gorm:"index:idx_scheduled_message_key;"`
+	Exchange      string
+	RoutingKey    string
+	ContentType   string
+	Type          string
+	MessageID     string
+	CorrelationID string
+	DeliveryMode  uint8
+	Headers       []byte
+	Body          []byte
+	DeliverAt     time.Time `gorm:"index:idx_scheduled_message_due;not null;"`
+	LockedUntil   *time.Time
+	Attempts      int `gorm:"not null;default:0;"`
+	LastError     string
+	DeliveredAt   *time.Time `gorm:"index:idx_scheduled_message_due;"`
+	CancelledAt   *time.Time `gorm:"index:idx_scheduled_message_due;"`
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// Assertion of the proper interface
+var _ ScheduleStore = GormScheduleStore{}
+
+// GormScheduleStore stores scheduled messages in the service database
+type GormScheduleStore struct {
+	db *gorm.DB
+}
+
+// NewGormScheduleStore constructs the store and migrates the schedule table
+func NewGormScheduleStore(db *gorm.DB) (GormScheduleStore, error) {
+	if err := db.AutoMigrate(&ScheduledMessage{}); err != nil {
+		return GormScheduleStore{}, fmt.Errorf("cannot migrate scheduled messages: %w", err)
+	}
+
+	return GormScheduleStore{db: db}, nil
+}
+
+// NewTransactionalScheduleStore binds the store to a transaction of the caller,
+// so the messages saved with it are only published once the caller's writes
+// commit. The schedule table is migrated by the scheduler of the service.
+func NewTransactionalScheduleStore(tx *gorm.DB) GormScheduleStore {
+	return GormScheduleStore{db: tx}
+}
+
+// Save stores the message and cancels pending messages with the same key
+func (s GormScheduleStore) Save(ctx context.Context, scheduled ScheduledPublishing) error {
+	headers, err := encodeHeaders(scheduled.Publishing.Headers)
+	if err != nil {
+		return err
+	}
+
+	msg := ScheduledMessage{
+		ID:            uuid.New(),
+		Key:           scheduled.Key,
+		Exchange:      scheduled.Exchange,
+		RoutingKey:    scheduled.RoutingKey,
+		ContentType:   scheduled.Publishing.ContentType,
+		Type:          scheduled.Publishing.Type,
+		MessageID:     scheduled.Publishing.MessageId,
+		CorrelationID: scheduled.Publishing.CorrelationId,
+		DeliveryMode:  scheduled.Publishing.DeliveryMode,
+		Headers:       headers,
+		Body:          scheduled.Publishing.Body,
+		DeliverAt:     scheduled.DeliverAt,
+	}
+
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if scheduled.Key != "" {
+			if _, err := s.cancel(tx, scheduled.Key); err != nil {
+				return err
+			}
+		}
+
+		return tx.Create(&msg).Error
+	})
+}
+
+// Cancel cancels the pending messages of the key and returns how many were cancelled
+func (s GormScheduleStore) Cancel(ctx context.Context, key string) (int, error) {
+	return s.cancel(s.db.WithContext(ctx), key)
+}
+
+// ClaimDue leases up to limit due messages so no other poller delivers them
+// concurrently. The sequence keeps the messages due at the same time in the
+// order they were saved in.
+func (s GormScheduleStore) ClaimDue(ctx context.Context, now time.Time, limit int, lease time.Duration) ([]ScheduledPublishing, error) {
+	due := make([]ScheduledMessage, 0)
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.
+			Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("delivered_at IS NULL AND cancelled_at IS NULL AND deliver_at <= ?", now).
+			Where("locked_until IS NULL OR locked_until <= ?", now).
+			Order("deliver_at, seq").
+			Limit(limit).
+			Find(&due).
+			Error
+		if err != nil || len(due) == 0 {
+			return err
+		}
+
+		ids := make([]uuid.UUID, 0, len(due))
+		for _, msg := range due {
+			ids = append(ids, msg.ID)
+		}
+
+		return tx.Model(&ScheduledMessage{}).Where("id IN ?", ids).Update("locked_until", now.Add(lease)).Error
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cannot claim due scheduled messages: %w", err)
+	}
+
+	scheduled := make([]ScheduledPublishing, 0, len(due))
+	for _, msg := range due {
+		headers, err := decodeHeaders(msg.Headers)
+		if err != nil {
+			return nil, err
+		}
+
+		scheduled = append(scheduled, ScheduledPublishing{
+			ID:         msg.ID.String(),
+			Key:        msg.Key,
+			Exchange:   msg.Exchange,
+			RoutingKey: msg.RoutingKey,
+			DeliverAt:  msg.DeliverAt,
+			Attempts:   msg.Attempts,
+			Publishing: amqp.Publishing{
+				ContentType:   msg.ContentType,
+				Type:          msg.Type,
+				MessageId:     msg.MessageID,
+				CorrelationId: msg.CorrelationID,
+				DeliveryMode:  msg.DeliveryMode,
+				Headers:       headers,
+				Body:          msg.Body,
+			},
+		})
+	}
+
+	return scheduled, nil
+}
+
+// MarkDelivered records the message as published
+func (s GormScheduleStore) MarkDelivered(ctx context.Context, id string, deliveredAt time.Time) error {
+	return s.db.WithContext(ctx).
+		Model(&ScheduledMessage{}).
+		Where("id = ?", id).
+		Updates(map[string]any{"delivered_at": deliveredAt, "locked_until": nil}).
+		Error
+}
+
+// MarkFailed records the failure and when to retry the message
+func (s GormScheduleStore) MarkFailed(ctx context.Context, id string, deliveryErr error, retryAt time.Time) error {
+	return s.db.WithContext(ctx).
+		Model(&ScheduledMessage{}).
+		Where("id = ?", id).
+		Updates(map[string]any{
+			"attempts":     gorm.Expr("attempts + 1"),
+			"last_error":   deliveryErr.Error(),
+			"locked_until": retryAt,
+		}).
+		Error
+}
+
+// Postpone hides the claimed messages until retryAt without counting an attempt
+func (s GormScheduleStore) Postpone(ctx context.Context, ids []string, retryAt time.Time) error {
+	return s.db.WithContext(ctx).
+		Model(&ScheduledMessage{}).
+		Where("id IN ? AND delivered_at IS NULL", ids).
+		Update("locked_until", retryAt).
+		Error
+}
+
+// Purge deletes the messages delivered or cancelled before the time and returns how many were deleted
+func (s GormScheduleStore) Purge(ctx context.Context, before time.Time) (int, error) {
+	result := s.db.WithContext(ctx).
+		Where("delivered_at < ? OR cancelled_at < ?", before, before).
+		Delete(&ScheduledMessage{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("cannot purge scheduled messages: %w", result.Error)
+	}
+
+	return int(result.RowsAffected), nil
+}
+
+// cancel cancels the pending messages of the key
+func (s GormScheduleStore) cancel(tx *gorm.DB, key string) (int, error) {
+	result := tx.
+		Model(&ScheduledMessage{}).
+		Where("key = ? AND delivered_at IS NULL AND cancelled_at IS NULL", key).
+		Update("cancelled_at", time.Now())
+	if result.Error != nil {
+		return 0, fmt.Errorf("cannot cancel scheduled messages: %w", result.Error)
+	}
+
+	return int(result.RowsAffected), nil
+}
+
+// encodeHeaders keeps the header value types intact across the database
+func encodeHeaders(headers amqp.Table) ([]byte, error) {
+	if len(headers) == 0 {
+		return nil, nil
+	}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(map[string]interface{}(headers)); err != nil {
+		return nil, fmt.Errorf("cannot encode headers: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// decodeHeaders reads headers written by encodeHeaders
+func decodeHeaders(b []byte) (amqp.Table, error) {
+	if len(b) == 0 {
+		return nil, nil
+	}
+
+	headers := make(map[string]interface{})
+	if err := gob.NewDecoder(bytes.NewReader(b)).Decode(&headers); err != nil {
+		return nil, fmt.Errorf("cannot decode headers: %w", err)
+	}
+
+	return amqp.Table(headers), nil
+}
@@ -0,0 +1,32 @@
+package boot
+
+import (
+	"testing"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduledHeadersRoundTrip(t *testing.T) {
+	headers := amqp.Table{
+		"x-event-version": int32(2),
+		"x-common-id":     "5f1d3c6e-52a7-4b43-a8b5-0c0a1f0f6f11",
+		"x-retries":       int64(3),
+		"x-nested":        amqp.Table{"at": time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	b, err := encodeHeaders(headers)
+	require.NoError(t, err)
+
+	decoded, err := decodeHeaders(b)
+	require.NoError(t, err)
+	assert.Equal(t, headers, decoded)
+
+	empty, err := encodeHeaders(nil)
+	require.NoError(t, err)
+	decoded, err = decodeHeaders(empty)
+	require.NoError(t, err)
+	assert.Nil(t, decoded)
+}
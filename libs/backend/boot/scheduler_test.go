@@ -0,0 +1,228 @@
+package boot_test
+
+import (
+	"context"
+	"errors"
+	boot "libs/backend/boot"
+	"libs/backend/boot/amqptest"
+	"testing"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newSchedulerBroker(t *testing.T) *amqptest.Broker {
+	t.Helper()
+
+	broker := amqptest.NewBroker()
+	t.Cleanup(func() { _ = broker.Close() })
+
+	require.NoError(t, broker.ExchangeDeclare("events", amqptest.ExchangeTopic, true, false, false, false, nil))
+	_, err := broker.QueueDeclare("nudges", true, false, false, false, nil)
+	require.NoError(t, err)
+	require.NoError(t, broker.QueueBind("nudges", "career-cue.#", "events", false, nil))
+
+	return broker
+}
+
+func TestScheduler(t *testing.T) {
+	ctx := context.Background()
+	logger := boot.NewSlogger()
+
+	t.Run("delivers due publishings once", func(t *testing.T) {
+		broker := newSchedulerBroker(t)
+		store := amqptest.NewScheduleStore()
+		scheduler := boot.NewScheduler(logger, store, broker, boot.SchedulerOptions{Enabled: true})
+
+		require.NoError(t, scheduler.Schedule(ctx, boot.ScheduledPublishing{
+			Key:        "due",
+			Exchange:   "events",
+			RoutingKey: "career-cue.accounts.nudge",
+			DeliverAt:  time.Now().Add(-time.Second),
+			Publishing: amqp.Publishing{
+				Type:    "career-cue.accounts.nudge",
+				Headers: amqp.Table{"x-event-version": int32(1)},
+				Body:    []byte("due"),
+			},
+		}))
+		require.NoError(t, scheduler.Schedule(ctx, boot.ScheduledPublishing{
+			Key:        "later",
+			Exchange:   "events",
+			RoutingKey: "career-cue.accounts.nudge",
+			DeliverAt:  time.Now().Add(time.Hour),
+			Publishing: amqp.Publishing{Body: []byte("later")},
+		}))
+
+		delivered, err := scheduler.DeliverDue(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, delivered)
+
+		delivered, err = scheduler.DeliverDue(ctx)
+		require.NoError(t, err)
+		assert.Zero(t, delivered)
+
+		messages := broker.Messages("nudges")
+		require.Len(t, messages, 1)
+		assert.Equal(t, []byte("due"), messages[0].Body)
+		assert.Equal(t, int32(1), messages[0].Headers["x-event-version"])
+		assert.Len(t, store.Pending(), 1)
+	})
+
+	t.Run("cancels and replaces by key", func(t *testing.T) {
+		broker := newSchedulerBroker(t)
+		store := amqptest.NewScheduleStore()
+		scheduler := boot.NewScheduler(logger, store, broker, boot.SchedulerOptions{Enabled: true})
+
+		schedule := func(key string, body string) {
+			require.NoError(t, scheduler.Schedule(ctx, boot.ScheduledPublishing{
+				Key:        key,
+				Exchange:   "events",
+				RoutingKey: "career-cue.accounts.purge",
+				DeliverAt:  time.Now().Add(-time.Second),
+				Publishing: amqp.Publishing{Body: []byte(body)},
+			}))
+		}
+
+		schedule("purge", "first")
+		schedule("purge", "second")
+		schedule("cancelled", "cancelled")
+
+		cancelled, err := scheduler.Cancel(ctx, "cancelled")
+		require.NoError(t, err)
+		assert.True(t, cancelled)
+
+		cancelled, err = scheduler.Cancel(ctx, "unknown")
+		require.NoError(t, err)
+		assert.False(t, cancelled)
+
+		_, err = scheduler.DeliverDue(ctx)
+		require.NoError(t, err)
+
+		messages := broker.Messages("nudges")
+		require.Len(t, messages, 1)
+		assert.Equal(t, []byte("second"), messages[0].Body)
+	})
+
+	t.Run("retries failed deliveries", func(t *testing.T) {
+		broker := newSchedulerBroker(t)
+		store := amqptest.NewScheduleStore()
+		scheduler := boot.NewScheduler(logger, store, broker, boot.SchedulerOptions{
+			Enabled:    true,
+			RetryDelay: 10 * time.Millisecond,
+		})
+
+		require.NoError(t, scheduler.Schedule(ctx, boot.ScheduledPublishing{
+			Exchange:   "events",
+			RoutingKey: "career-cue.accounts.nudge",
+			Publishing: amqp.Publishing{Body: []byte("retry")},
+		}))
+
+		broker.SetPublishError(errors.New("connection lost"))
+		delivered, err := scheduler.DeliverDue(ctx)
+		require.NoError(t, err)
+		assert.Zero(t, delivered)
+		require.Len(t, store.Pending(), 1)
+		assert.Equal(t, 1, store.Pending()[0].Attempts)
+
+		// Not retried before the retry delay passed
+		broker.SetPublishError(nil)
+		delivered, err = scheduler.DeliverDue(ctx)
+		require.NoError(t, err)
+		assert.Zero(t, delivered)
+
+		time.Sleep(20 * time.Millisecond)
+		delivered, err = scheduler.DeliverDue(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, delivered)
+		assert.Empty(t, store.Pending())
+	})
+
+	t.Run("stops the batch at a failed delivery to keep the order", func(t *testing.T) {
+		broker := newSchedulerBroker(t)
+		store := amqptest.NewScheduleStore()
+		scheduler := boot.NewScheduler(logger, store, broker, boot.SchedulerOptions{
+			Enabled:    true,
+			RetryDelay: 10 * time.Millisecond,
+		})
+
+		// Due at the same time, delivered in the order they were saved in
+		deliverAt := time.Now().Add(-time.Second)
+		for _, body := range []string{"created", "updated", "deleted"} {
+			require.NoError(t, scheduler.Schedule(ctx, boot.ScheduledPublishing{
+				Exchange:   "events",
+				RoutingKey: "career-cue.accounts.nudge",
+				DeliverAt:  deliverAt,
+				Publishing: amqp.Publishing{Body: []byte(body)},
+			}))
+		}
+
+		broker.SetPublishError(errors.New("connection lost"))
+		delivered, err := scheduler.DeliverDue(ctx)
+		require.NoError(t, err)
+		assert.Zero(t, delivered)
+
+		pending := store.Pending()
+		require.Len(t, pending, 3)
+		assert.Equal(t, []int{1, 0, 0}, []int{pending[0].Attempts, pending[1].Attempts, pending[2].Attempts})
+
+		// The later messages wait for the failed one
+		broker.SetPublishError(nil)
+		delivered, err = scheduler.DeliverDue(ctx)
+		require.NoError(t, err)
+		assert.Zero(t, delivered)
+
+		time.Sleep(20 * time.Millisecond)
+		delivered, err = scheduler.DeliverDue(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 3, delivered)
+
+		bodies := make([]string, 0, 3)
+		for _, msg := range broker.Messages("nudges") {
+			bodies = append(bodies, string(msg.Body))
+		}
+		assert.Equal(t, []string{"created", "updated", "deleted"}, bodies)
+	})
+
+	t.Run("purges delivered and cancelled publishings past the retention", func(t *testing.T) {
+		broker := newSchedulerBroker(t)
+		store := amqptest.NewScheduleStore()
+		scheduler := boot.NewScheduler(logger, store, broker, boot.SchedulerOptions{
+			Enabled:   true,
+			Retention: 10 * time.Millisecond,
+		})
+
+		schedule := func(key string, deliverAt time.Time) {
+			require.NoError(t, scheduler.Schedule(ctx, boot.ScheduledPublishing{
+				Key:        key,
+				Exchange:   "events",
+				RoutingKey: "career-cue.accounts.nudge",
+				DeliverAt:  deliverAt,
+				Publishing: amqp.Publishing{Body: []byte(key)},
+			}))
+		}
+		schedule("delivered", time.Now().Add(-time.Second))
+		schedule("cancelled", time.Now().Add(time.Hour))
+		schedule("pending", time.Now().Add(time.Hour))
+
+		_, err := scheduler.DeliverDue(ctx)
+		require.NoError(t, err)
+		_, err = scheduler.Cancel(ctx, "cancelled")
+		require.NoError(t, err)
+
+		// Kept within the retention
+		purged, err := scheduler.Purge(ctx)
+		require.NoError(t, err)
+		assert.Zero(t, purged)
+
+		time.Sleep(20 * time.Millisecond)
+		purged, err = scheduler.Purge(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 2, purged)
+
+		pending := store.Pending()
+		require.Len(t, pending, 1)
+		assert.Equal(t, "pending", pending[0].Key)
+	})
+}
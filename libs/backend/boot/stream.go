@@ -0,0 +1,212 @@
+package boot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// Stream queue arguments
+const (
+	QueueTypeArg            = "x-queue-type"
+	QueueTypeStream         = "stream"
+	StreamOffsetArg         = "x-stream-offset"
+	StreamMaxAgeArg         = "x-max-age"
+	StreamMaxLengthBytesArg = "x-max-length-bytes"
+
+	// DefaultStreamPrefetch is the prefetch used when consuming streams, which
+	// cannot be consumed without one
+	DefaultStreamPrefetch = 100
+)
+
+// Named Stream Offsets
+const (
+	StreamOffsetFirst = "first"
+	StreamOffsetLast  = "last"
+	StreamOffsetNext  = "next"
+)
+
+// ErrInvalidStreamOffset is returned when a stream offset cannot be parsed
+var ErrInvalidStreamOffset = errors.New("invalid stream offset")
+
+// StreamOptions configures the retention of a stream queue
+type StreamOptions struct {
+	// MaxAge discards segments older than the age, e.g. "30D" or "12h"
+	MaxAge string
+
+	// MaxLengthBytes discards the oldest segments above the size
+	MaxLengthBytes int64
+}
+
+// Args returns the queue arguments declaring a stream with the retention
+func (o StreamOptions) Args() amqp.Table {
+	args := amqp.Table{QueueTypeArg: QueueTypeStream}
+	if o.MaxAge != "" {
+		args[StreamMaxAgeArg] = o.MaxAge
+	}
+	if o.MaxLengthBytes > 0 {
+		args[StreamMaxLengthBytesArg] = o.MaxLengthBytes
+	}
+
+	return args
+}
+
+// DeclareStreamQueue declares a durable stream queue, streams are append only
+// logs that keep messages after they were consumed
+func DeclareStreamQueue(registerer AMQPRegisterer, name string, opts StreamOptions) (amqp.Queue, error) {
+	queue, err := registerer.QueueDeclare(
+		name,
+		true,  // durable
+		false, // delete when unused
+		false, // exclusive
+		false, // no-wait
+		opts.Args(),
+	)
+	if err != nil {
+		return amqp.Queue{}, fmt.Errorf("cannot declare stream queue %s: %w", name, err)
+	}
+
+	return queue, nil
+}
+
+// StreamOffset is where a stream consumer starts reading
+type StreamOffset struct {
+	value any
+}
+
+// NewStreamOffset starts at the numeric offset of a message
+func NewStreamOffset(offset int64) StreamOffset {
+	return StreamOffset{value: offset}
+}
+
+// NewStreamOffsetFrom starts at the first message stored at or after the time
+func NewStreamOffsetFrom(t time.Time) StreamOffset {
+	return StreamOffset{value: t}
+}
+
+// NewNamedStreamOffset starts at the first, last or next message
+func NewNamedStreamOffset(name string) StreamOffset {
+	return StreamOffset{value: name}
+}
+
+// ParseStreamOffset reads "first", "last", "next", a numeric offset or an RFC 3339 time
+func ParseStreamOffset(s string) (StreamOffset, error) {
+	switch s {
+	case StreamOffsetFirst, StreamOffsetLast, StreamOffsetNext:
+		return NewNamedStreamOffset(s), nil
+	}
+
+	if offset, err := strconv.ParseInt(s, 10, 64); err == nil {
+		if offset < 0 {
+			return StreamOffset{}, fmt.Errorf("%w: %d is negative", ErrInvalidStreamOffset, offset)
+		}
+		return NewStreamOffset(offset), nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return NewStreamOffsetFrom(t), nil
+	}
+
+	return StreamOffset{}, fmt.Errorf("%w: %q", ErrInvalidStreamOffset, s)
+}
+
+// IsZero reports whether no offset was set, which consumes from the next message
+func (o StreamOffset) IsZero() bool {
+	return o.value == nil
+}
+
+// Value returns the x-stream-offset argument value
+func (o StreamOffset) Value() any {
+	if o.value == nil {
+		return StreamOffsetNext
+	}
+
+	return o.value
+}
+
+// String returns the offset for logging
+func (o StreamOffset) String() string {
+	switch v := o.Value().(type) {
+	case time.Time:
+		return v.Format(time.RFC3339)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// ConsumeStream sets the prefetch and consumes the stream from the offset
+// with manual acknowledgements, which streams require. Consumers that can
+// open channels consume the stream on a channel of its own, which is closed
+// once the context is cancelled, so the prefetch of the shared channel is
+// left untouched.
+func ConsumeStream(ctx context.Context, consumer AMQPConsumer, queueName string, offset StreamOffset, prefetch int) (<-chan amqp.Delivery, error) {
+	if prefetch <= 0 {
+		prefetch = DefaultStreamPrefetch
+	}
+
+	opener, ok := consumer.(AMQPChannelOpener)
+	if !ok {
+		return consumeStream(ctx, consumer, queueName, offset, prefetch)
+	}
+
+	channel, err := opener.OpenChannel()
+	if err != nil {
+		return nil, fmt.Errorf("cannot open stream channel: %w", err)
+	}
+
+	msgs, err := consumeStream(ctx, channel, queueName, offset, prefetch)
+	if err != nil {
+		_ = channel.Close()
+		return nil, err
+	}
+
+	go func() {
+		<-ctx.Done()
+		_ = channel.Close()
+	}()
+
+	return msgs, nil
+}
+
+// consumeStream sets the prefetch of the channel and starts the stream consumer
+func consumeStream(ctx context.Context, consumer AMQPConsumer, queueName string, offset StreamOffset, prefetch int) (<-chan amqp.Delivery, error) {
+	if err := consumer.Qos(prefetch, 0, false); err != nil {
+		return nil, fmt.Errorf("cannot set stream prefetch: %w", err)
+	}
+
+	msgs, err := consumer.ConsumeWithContext(
+		ctx,
+		queueName, // queue
+		"",        // consumer
+		false,     // auto-ack
+		false,     // exclusive
+		false,     // no-local
+		false,     // no-wait
+		amqp.Table{StreamOffsetArg: offset.Value()},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("cannot consume stream %s: %w", queueName, err)
+	}
+
+	return msgs, nil
+}
+
+// DeliveryStreamOffset reads the offset the broker assigned to a stream delivery
+func DeliveryStreamOffset(msg amqp.Delivery) (int64, bool) {
+	switch v := msg.Headers[StreamOffsetArg].(type) {
+	case int64:
+		return v, true
+	case int32:
+		return int64(v), true
+	case int:
+		return int64(v), true
+	case uint64:
+		return int64(v), true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,129 @@
+package boot_test
+
+import (
+	"context"
+	boot "libs/backend/boot"
+	"libs/backend/boot/amqptest"
+	"testing"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseStreamOffset(t *testing.T) {
+	at := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		input string
+		want  any
+	}{
+		{"first", boot.StreamOffsetFirst},
+		{"last", boot.StreamOffsetLast},
+		{"next", boot.StreamOffsetNext},
+		{"42", int64(42)},
+		{"2024-05-01T12:00:00Z", at},
+	}
+
+	for _, tt := range tests {
+		offset, err := boot.ParseStreamOffset(tt.input)
+		require.NoError(t, err, tt.input)
+		assert.Equal(t, tt.want, offset.Value(), tt.input)
+	}
+
+	for _, input := range []string{"", "-1", "yesterday"} {
+		_, err := boot.ParseStreamOffset(input)
+		assert.ErrorIs(t, err, boot.ErrInvalidStreamOffset, input)
+	}
+
+	assert.Equal(t, boot.StreamOffsetNext, boot.StreamOffset{}.Value())
+}
+
+func TestConsumeStream(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	broker := amqptest.NewBroker()
+	defer broker.Close()
+
+	queue, err := boot.DeclareStreamQueue(broker, "events", boot.StreamOptions{MaxAge: "7D"})
+	require.NoError(t, err)
+	assert.Equal(t, boot.QueueTypeStream, broker.QueueArgs(queue.Name)[boot.QueueTypeArg])
+	assert.Equal(t, "7D", broker.QueueArgs(queue.Name)[boot.StreamMaxAgeArg])
+
+	for _, body := range []string{"a", "b", "c"} {
+		require.NoError(t, broker.Publish("", queue.Name, false, false, amqp.Publishing{Body: []byte(body)}))
+	}
+
+	receive := func(msgs <-chan amqp.Delivery) amqp.Delivery {
+		t.Helper()
+
+		select {
+		case msg := <-msgs:
+			require.NoError(t, msg.Ack(false))
+			return msg
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for stream delivery")
+			return amqp.Delivery{}
+		}
+	}
+
+	t.Run("consumes from the first offset without removing messages", func(t *testing.T) {
+		for range 2 {
+			msgs, err := boot.ConsumeStream(ctx, broker, queue.Name, boot.NewNamedStreamOffset(boot.StreamOffsetFirst), 0)
+			require.NoError(t, err)
+
+			for i, body := range []string{"a", "b", "c"} {
+				msg := receive(msgs)
+				assert.Equal(t, body, string(msg.Body))
+
+				offset, ok := boot.DeliveryStreamOffset(msg)
+				require.True(t, ok)
+				assert.Equal(t, int64(i), offset)
+			}
+		}
+
+		assert.Equal(t, 3, broker.QueueLength(queue.Name))
+	})
+
+	t.Run("consumes from a numeric offset", func(t *testing.T) {
+		msgs, err := boot.ConsumeStream(ctx, broker, queue.Name, boot.NewStreamOffset(2), 10)
+		require.NoError(t, err)
+		assert.Equal(t, "c", string(receive(msgs).Body))
+	})
+
+	t.Run("consumes from a timestamp", func(t *testing.T) {
+		from := time.Now()
+		require.NoError(t, broker.Publish("", queue.Name, false, false, amqp.Publishing{Body: []byte("d")}))
+
+		msgs, err := boot.ConsumeStream(ctx, broker, queue.Name, boot.NewStreamOffsetFrom(from), 10)
+		require.NoError(t, err)
+		assert.Equal(t, "d", string(receive(msgs).Body))
+	})
+
+	t.Run("consumes on a channel of its own", func(t *testing.T) {
+		streamCtx, stop := context.WithCancel(ctx)
+		openChannels := broker.OpenChannels()
+
+		msgs, err := boot.ConsumeStream(streamCtx, broker, queue.Name, boot.NewStreamOffset(0), 10)
+		require.NoError(t, err)
+		assert.Equal(t, "a", string(receive(msgs).Body))
+		assert.Equal(t, openChannels+1, broker.OpenChannels())
+
+		// The prefetch of the shared channel is left untouched
+		assert.Zero(t, broker.Prefetch())
+
+		// The channel is closed with the consumer
+		stop()
+		assert.Eventually(t, func() bool { return broker.OpenChannels() == openChannels }, time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("next only receives new messages", func(t *testing.T) {
+		msgs, err := boot.ConsumeStream(ctx, broker, queue.Name, boot.StreamOffset{}, 10)
+		require.NoError(t, err)
+
+		require.NoError(t, broker.Publish("", queue.Name, false, false, amqp.Publishing{Body: []byte("e")}))
+		assert.Equal(t, "e", string(receive(msgs).Body))
+	})
+}
@@ -17,8 +17,10 @@ type User struct {
 	PhoneNumber          string
 	PhoneNumberVerified  bool
 	Strategy             string
+	IdentityUserID       string
 	CommonID             valueobjects.CommonID
 	Metadata             map[string]any
+	LastLoginAt          time.Time
 	CreatedAt            time.Time
 	UpdatedAt            time.Time
 }
@@ -89,6 +91,13 @@ func WithStrategy(strategy string) UserOption {
 	}
 }
 
+// WithIdentityUserID adds the user's id in the identity provider to the struct
+func WithIdentityUserID(identityUserID string) UserOption {
+	return func(u *User) {
+		u.IdentityUserID = identityUserID
+	}
+}
+
 // WithCommonID adds the user's common ID to the struct (UUID)
 func WithCommonID(commonID valueobjects.CommonID) UserOption {
 	return func(u *User) {
@@ -103,6 +112,13 @@ func WithMetadata(Metadata map[string]any) UserOption {
 	}
 }
 
+// WithLastLoginAt adds the user's last login timestamp to the struct
+func WithLastLoginAt(lastLoginAt time.Time) UserOption {
+	return func(u *User) {
+		u.LastLoginAt = lastLoginAt
+	}
+}
+
 // WithCreatedAt adds the user's creation timestamp to the struct
 func WithCreatedAt(createdAt time.Time) UserOption {
 	return func(u *User) {
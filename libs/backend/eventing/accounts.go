@@ -0,0 +1,335 @@
+package eventing
+
+import (
+	boot "libs/backend/boot"
+	accountseventsv1 "libs/backend/proto-gen/go/accounts/accountsevents/v1"
+	"log/slog"
+
+	"github.com/rabbitmq/amqp091-go"
+)
+
+// Event Producer/Consumer
+const (
+	AccountsDomain               = "accounts"
+	AccountsExchange             = "accountsExchange"
+	AccountsDeadletterExchange   = "accountsDeadletterExchange"
+	AccountsDeadletterQueue      = "accountsDeadletterQueue"
+	AccountsDeadletterRoutingKey = "accountsDlx"
+	AccountsEventStream          = "accountsEventStream"
+)
+
+// Event Names
+var (
+	EventNameAccountCreated     EventName = EventName(GetEventName(AccountsDomain, "accountCreated"))
+	EventNameAccountUpdated     EventName = EventName(GetEventName(AccountsDomain, "accountUpdated"))
+	EventNameAccountSoftDeleted EventName = EventName(GetEventName(AccountsDomain, "accountSoftDeleted"))
+	EventNameAccountHardDeleted EventName = EventName(GetEventName(AccountsDomain, "accountHardDeleted"))
+	EventNameAccountRestored    EventName = EventName(GetEventName(AccountsDomain, "accountRestored"))
+)
+
+// Event Versions
+const (
+	AccountCreatedVersion     EventVersion = 1
+	AccountUpdatedVersion     EventVersion = 1
+	AccountSoftDeletedVersion EventVersion = 1
+	AccountHardDeletedVersion EventVersion = 1
+	AccountRestoredVersion    EventVersion = 1
+)
+
+// registerAccountsEventSchemas registers the current version and upcasters of every accounts event
+func registerAccountsEventSchemas(r *UpcasterRegistry) {
+	r.RegisterEvent(EventNameAccountCreated, AccountCreatedVersion)
+	r.RegisterEvent(EventNameAccountUpdated, AccountUpdatedVersion)
+	r.RegisterEvent(EventNameAccountSoftDeleted, AccountSoftDeletedVersion)
+	r.RegisterEvent(EventNameAccountHardDeleted, AccountHardDeletedVersion)
+	r.RegisterEvent(EventNameAccountRestored, AccountRestoredVersion)
+}
+
+// registerAccountsCatalog registers the accounts exchanges, events and queues
+func registerAccountsCatalog(c *Catalog) {
+	c.RegisterExchange(ExchangeDefinition{
+		Name:    AccountsExchange,
+		Kind:    ExchangeKindTopic,
+		Durable: true,
+		Summary: "Account lifecycle events",
+	})
+	c.RegisterExchange(ExchangeDefinition{
+		Name:    AccountsDeadletterExchange,
+		Kind:    ExchangeKindDirect,
+		Durable: true,
+		Summary: "Rejected account lifecycle events",
+	})
+
+	events := []EventDefinition{
+		{
+			Name:    EventNameAccountCreated,
+			Version: AccountCreatedVersion,
+			Summary: "An account was created for a registered user",
+			Message: &accountseventsv1.AccountCreated{},
+		},
+		{
+			Name:    EventNameAccountUpdated,
+			Version: AccountUpdatedVersion,
+			Summary: "The username or email address of an account changed",
+			Message: &accountseventsv1.AccountUpdated{},
+		},
+		{
+			Name:    EventNameAccountSoftDeleted,
+			Version: AccountSoftDeletedVersion,
+			Summary: "An account was soft deleted and can be restored",
+			Message: &accountseventsv1.AccountSoftDeleted{},
+		},
+		{
+			Name:    EventNameAccountHardDeleted,
+			Version: AccountHardDeletedVersion,
+			Summary: "An account was permanently deleted",
+			Message: &accountseventsv1.AccountHardDeleted{},
+		},
+		{
+			Name:    EventNameAccountRestored,
+			Version: AccountRestoredVersion,
+			Summary: "A soft deleted account was restored",
+			Message: &accountseventsv1.AccountRestored{},
+		},
+	}
+	for _, event := range events {
+		event.Exchange = AccountsExchange
+		event.Publisher = AccountsAPIService
+		c.RegisterEvent(event)
+	}
+
+	c.RegisterQueue(QueueDefinition{
+		Name:                 GetQueueName(AccountsWorkerService, AccountLifecycleQueue),
+		Exchange:             AccountsExchange,
+		RoutingKeys:          []string{GetAccountCreatedRoutingKey()},
+		Consumer:             AccountsWorkerService,
+		Durable:              true,
+		Summary:              "Completes the account step of the registration saga",
+		Partitions:           DefaultPartitions,
+		DeadletterExchange:   AccountsDeadletterExchange,
+		DeadletterRoutingKey: AccountsDeadletterRoutingKey,
+	})
+	c.RegisterQueue(QueueDefinition{
+		Name:                 GetQueueName(OutboundWebhooksAPIService, AccountNotificationQueue),
+		Exchange:             AccountsExchange,
+		RoutingKeys:          []string{GetDomainRoutingKey(AccountsDomain)},
+		Consumer:             OutboundWebhooksAPIService,
+		Durable:              true,
+		Summary:              "Delivers the account events to the webhook endpoints of the user",
+		Partitions:           DefaultPartitions,
+		DeadletterExchange:   AccountsDeadletterExchange,
+		DeadletterRoutingKey: AccountsDeadletterRoutingKey,
+	})
+	c.RegisterQueue(QueueDefinition{
+		Name:        AccountsEventStream,
+		Exchange:    AccountsExchange,
+		RoutingKeys: []string{GetDomainRoutingKey(AccountsDomain)},
+		Durable:     true,
+		Stream:      true,
+		Summary:     "History of every accounts event for replays",
+	})
+	c.RegisterQueue(QueueDefinition{
+		Name:        AccountsDeadletterQueue,
+		Exchange:    AccountsDeadletterExchange,
+		RoutingKeys: []string{AccountsDeadletterRoutingKey},
+		Durable:     true,
+		Summary:     "Rejected account lifecycle events kept for inspection",
+	})
+}
+
+// GetAccountCreatedRoutingKey returns the routing key for account created event
+func GetAccountCreatedRoutingKey() string {
+	return EventNameAccountCreated.String()
+}
+
+// GetAccountUpdatedRoutingKey returns the routing key for account updated event
+func GetAccountUpdatedRoutingKey() string {
+	return EventNameAccountUpdated.String()
+}
+
+// GetAccountSoftDeletedRoutingKey returns the routing key for account soft deleted event
+func GetAccountSoftDeletedRoutingKey() string {
+	return EventNameAccountSoftDeleted.String()
+}
+
+// GetAccountHardDeletedRoutingKey returns the routing key for account hard deleted event
+func GetAccountHardDeletedRoutingKey() string {
+	return EventNameAccountHardDeleted.String()
+}
+
+// GetAccountRestoredRoutingKey returns the routing key for account restored event
+func GetAccountRestoredRoutingKey() string {
+	return EventNameAccountRestored.String()
+}
+
+// GetAccountLifecycleRoutingKey returns the routing key matching every accounts event
+func GetAccountLifecycleRoutingKey() string {
+	return GetRoutingKeyPrefix(AccountsDomain) + ".*"
+}
+
+// RegisterAccountCreatedHandler routes account created events to the handler
+func RegisterAccountCreatedHandler(d *Dispatcher, handler EventHandler[*accountseventsv1.AccountCreated]) {
+	RegisterHandler(d, EventNameAccountCreated, handler)
+}
+
+// RegisterAccountUpdatedHandler routes account updated events to the handler
+func RegisterAccountUpdatedHandler(d *Dispatcher, handler EventHandler[*accountseventsv1.AccountUpdated]) {
+	RegisterHandler(d, EventNameAccountUpdated, handler)
+}
+
+// RegisterAccountSoftDeletedHandler routes account soft deleted events to the handler
+func RegisterAccountSoftDeletedHandler(d *Dispatcher, handler EventHandler[*accountseventsv1.AccountSoftDeleted]) {
+	RegisterHandler(d, EventNameAccountSoftDeleted, handler)
+}
+
+// RegisterAccountHardDeletedHandler routes account hard deleted events to the handler
+func RegisterAccountHardDeletedHandler(d *Dispatcher, handler EventHandler[*accountseventsv1.AccountHardDeleted]) {
+	RegisterHandler(d, EventNameAccountHardDeleted, handler)
+}
+
+// RegisterAccountRestoredHandler routes account restored events to the handler
+func RegisterAccountRestoredHandler(d *Dispatcher, handler EventHandler[*accountseventsv1.AccountRestored]) {
+	RegisterHandler(d, EventNameAccountRestored, handler)
+}
+
+// AccountsEventSetup is a struct for accounts queues and exchanges constructor
+type AccountsEventSetup struct {
+	registerer boot.AMQPRegisterer
+	log        boot.Logger
+	queueNames []string
+}
+
+func NewAccountsEventSetup(registerer boot.AMQPRegisterer, log boot.Logger) AccountsEventSetup {
+	return AccountsEventSetup{
+		registerer: registerer,
+		log:        log,
+		queueNames: make([]string, 0),
+	}
+}
+
+// CreateExchange creates an exchange for the accounts event setup
+func (a *AccountsEventSetup) CreateExchange() *AccountsEventSetup {
+	// Initialize Accounts Exchange - topic
+	err := a.registerer.ExchangeDeclare(AccountsExchange, "topic", true, false, false, false, nil)
+	if err != nil {
+		a.log.Error("Cannot create exchange")
+		return a
+	}
+
+	a.log.Info("Created accounts exchange", slog.String("exchangeName", AccountsExchange))
+	return a
+}
+
+// CreateDeadletter creates a dead letter exchange and queue for the accounts event setup
+func (a *AccountsEventSetup) CreateDeadletter() *AccountsEventSetup {
+	// Initialize Dead Letter Exchange
+	err := a.registerer.ExchangeDeclare(AccountsDeadletterExchange, "direct", true, false, false, false, nil)
+	if err != nil {
+		a.log.Error("Cannot create dead letter exchange")
+		return a
+	}
+
+	// Initialize Dead Letter Queue
+	accountsDeadletterQueue, err := a.registerer.QueueDeclare(
+		AccountsDeadletterQueue, // name
+		true,                    // durable
+		false,                   // delete when unused
+		false,                   // exclusive
+		false,                   // no-wait
+		nil,                     // arguments
+	)
+	if err != nil {
+		a.log.Error("Failed to declare the accounts dead letter queue")
+		return a
+	}
+
+	// Bind Accounts Dead Letter Queue to Accounts Dead Letter Exchange
+	err = a.registerer.QueueBind(
+		accountsDeadletterQueue.Name, // queue name
+		AccountsDeadletterRoutingKey, // routing key
+		AccountsDeadletterExchange,   // exchange
+		false,
+		nil,
+	)
+	if err != nil {
+		a.log.Error("Cannot bind accounts deadletter queue to exchange")
+		return a
+	}
+
+	a.log.Info("Created accounts dead letter exchange and queue", slog.String("exchangeName", AccountsDeadletterExchange), slog.String("queueName", accountsDeadletterQueue.Name))
+
+	return a
+}
+
+// CreateQueue creates a queue dead lettering into the accounts dead letter exchange
+func (a *AccountsEventSetup) CreateQueue(queueName string) *AccountsEventSetup {
+	if queueName == "" {
+		a.log.Warn("Accounts queue name is empty")
+		return a
+	}
+
+	queue, err := a.registerer.QueueDeclare(
+		queueName,
+		true,  // durable
+		false, // delete when unused
+		false, // exclusive
+		false, // no-wait
+		amqp091.Table{
+			"x-dead-letter-exchange":    AccountsDeadletterExchange,
+			"x-dead-letter-routing-key": AccountsDeadletterRoutingKey,
+		},
+	)
+	if err != nil {
+		a.log.Error("Cannot create queue", slog.String("queueName", queueName))
+		return a
+	}
+
+	a.log.Info("Created accounts queue", slog.String("queueName", queue.Name))
+
+	a.queueNames = append(a.queueNames, queue.Name)
+
+	return a
+}
+
+// BindQueues binds the queues to the exchange for each routing key
+func (a *AccountsEventSetup) BindQueues(routingKeys []string) *AccountsEventSetup {
+	a.log.Info("Binding queues to exchange", slog.String("exchangeName", AccountsExchange), slog.Any("queueNames", a.queueNames))
+
+	for _, queueName := range a.queueNames {
+		for _, routingKey := range routingKeys {
+			if err := a.registerer.QueueBind(queueName, routingKey, AccountsExchange, false, nil); err != nil {
+				a.log.Error(
+					"Cannot bind accounts queue to exchange",
+					slog.String("queueName", queueName),
+					slog.String("exchangeName", AccountsExchange),
+				)
+				return a
+			}
+		}
+	}
+
+	return a
+}
+
+// CreateStream creates the stream queue keeping the history of every accounts event for replays
+func (a *AccountsEventSetup) CreateStream() *AccountsEventSetup {
+	stream, err := boot.DeclareStreamQueue(a.registerer, AccountsEventStream, boot.StreamOptions{})
+	if err != nil {
+		a.log.Error("Cannot create accounts event stream", slog.Any("error", err))
+		return a
+	}
+
+	routingKey := GetDomainRoutingKey(AccountsDomain)
+	if err := a.registerer.QueueBind(stream.Name, routingKey, AccountsExchange, false, nil); err != nil {
+		a.log.Error("Cannot bind accounts event stream to exchange", slog.String("queueName", stream.Name))
+		return a
+	}
+
+	a.log.Info("Created accounts event stream", slog.String("queueName", stream.Name))
+	return a
+}
+
+// Complete completes the accounts event setup
+func (a *AccountsEventSetup) Complete() {
+	a.log.Info("Completed accounts event setup")
+}
@@ -0,0 +1,42 @@
+package eventing
+
+import (
+	boot "libs/backend/boot"
+	"libs/backend/boot/amqptest"
+	"testing"
+
+	"github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccountsEventSetup(t *testing.T) {
+	broker := amqptest.NewBroker()
+	defer broker.Close()
+
+	queueName := "notifications-account-lifecycle"
+	setup := NewAccountsEventSetup(broker, boot.NewSlogger())
+	setup.
+		CreateExchange().
+		CreateDeadletter().
+		CreateQueue(queueName).
+		BindQueues([]string{GetAccountLifecycleRoutingKey()}).
+		Complete()
+
+	assert.True(t, broker.HasExchange(AccountsExchange, amqptest.ExchangeTopic))
+	assert.True(t, broker.HasExchange(AccountsDeadletterExchange, amqptest.ExchangeDirect))
+	assert.True(t, broker.IsBound(AccountsDeadletterQueue, AccountsDeadletterRoutingKey, AccountsDeadletterExchange))
+	assert.True(t, broker.IsBound(queueName, GetAccountLifecycleRoutingKey(), AccountsExchange))
+	assert.Equal(t, AccountsDeadletterExchange, broker.QueueArgs(queueName)["x-dead-letter-exchange"])
+
+	for _, routingKey := range []string{
+		GetAccountCreatedRoutingKey(),
+		GetAccountUpdatedRoutingKey(),
+		GetAccountSoftDeletedRoutingKey(),
+		GetAccountHardDeletedRoutingKey(),
+		GetAccountRestoredRoutingKey(),
+	} {
+		assert.NoError(t, broker.Publish(AccountsExchange, routingKey, true, false, amqp091.Publishing{}))
+	}
+	assert.Equal(t, 5, broker.QueueLength(queueName))
+	assert.Empty(t, broker.Returned())
+}
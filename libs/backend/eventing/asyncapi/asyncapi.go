@@ -0,0 +1,251 @@
+// Package asyncapi generates an AsyncAPI document from the eventing catalog
+package asyncapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"libs/backend/eventing"
+	"strings"
+)
+
+// Defaults of the generated document
+const (
+	DefaultTitle       = "Career Cue Events"
+	DefaultDescription = "Events published to the LavinMQ exchanges and the queues consuming them. Generated from libs/backend/eventing, do not edit."
+	DefaultVersion     = "1.0.0"
+	DefaultServerName  = "lavinmq"
+	DefaultServerHost  = "localhost:5672"
+	DefaultVHost       = "/"
+	ProtobufMediaType  = "application/x-protobuf"
+	persistentDelivery = 2
+)
+
+// Options configures the generated document
+type Options struct {
+	Title       string
+	Version     string
+	Description string
+	ServerHost  string
+}
+
+// withDefaults fills the unset options
+func (o Options) withDefaults() Options {
+	if o.Title == "" {
+		o.Title = DefaultTitle
+	}
+	if o.Description == "" {
+		o.Description = DefaultDescription
+	}
+	if o.Version == "" {
+		o.Version = DefaultVersion
+	}
+	if o.ServerHost == "" {
+		o.ServerHost = DefaultServerHost
+	}
+
+	return o
+}
+
+// Generate builds the AsyncAPI document of the catalog. Every event gets a
+// channel on its exchange addressed by its routing key, every queue gets a
+// channel listing the events its bindings route to it.
+func Generate(catalog *eventing.Catalog, opts Options) (Document, error) {
+	if err := catalog.Validate(); err != nil {
+		return Document{}, fmt.Errorf("invalid event catalog: %w", err)
+	}
+
+	opts = opts.withDefaults()
+
+	doc := Document{
+		AsyncAPI: SpecVersion,
+		Info: Info{
+			Title:       opts.Title,
+			Version:     opts.Version,
+			Description: opts.Description,
+		},
+		Servers: map[string]Server{
+			DefaultServerName: {
+				Host:        opts.ServerHost,
+				Protocol:    "amqp",
+				Description: "LavinMQ message broker",
+			},
+		},
+		DefaultContentType: ProtobufMediaType,
+		Channels:           make(map[string]Channel),
+		Operations:         make(map[string]Operation),
+		Components: Components{
+			Messages: make(map[string]Message),
+			Schemas:  make(map[string]*Schema),
+		},
+	}
+
+	for _, event := range catalog.Events() {
+		addEvent(&doc, catalog, event)
+	}
+
+	for _, queue := range catalog.Queues() {
+		addQueue(&doc, catalog, queue)
+	}
+
+	return doc, nil
+}
+
+// Marshal encodes the document as indented JSON
+func Marshal(doc Document) ([]byte, error) {
+	b, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("cannot marshal asyncapi document: %w", err)
+	}
+
+	return append(b, '\n'), nil
+}
+
+// addEvent adds the message, the routing key channel and the send operation of the event
+func addEvent(doc *Document, catalog *eventing.Catalog, event eventing.EventDefinition) {
+	messageID := event.Name.String()
+	desc := event.Message.ProtoReflect().Descriptor()
+
+	addMessageSchema(doc.Components.Schemas, desc)
+	doc.Components.Messages[messageID] = Message{
+		Name:        messageID,
+		Title:       string(desc.Name()),
+		Summary:     event.Summary,
+		ContentType: ProtobufMediaType,
+		Headers: &Schema{
+			Type: "object",
+			Properties: map[string]*Schema{
+				eventing.EventVersionHeader: {
+					Type:        "integer",
+					Format:      "int32",
+					Description: "Schema version of the payload",
+					Const:       int32(event.Version),
+				},
+			},
+			Required: []string{eventing.EventVersionHeader},
+		},
+		Payload: &Schema{Ref: schemaRef(desc.FullName())},
+		Bindings: &MessageBindings{AMQP: AMQPMessageBinding{
+			MessageType:    messageID,
+			BindingVersion: AMQPBindingVersion,
+		}},
+	}
+
+	exchange, _ := catalog.Exchange(event.Exchange)
+	doc.Channels[messageID] = Channel{
+		Address:     event.Name.String(),
+		Title:       fmt.Sprintf("%s on %s", event.Name, exchange.Name),
+		Description: exchange.Summary,
+		Messages:    map[string]Reference{messageID: messageRef(messageID)},
+		Bindings: &ChannelBindings{AMQP: AMQPChannelBinding{
+			Is: "routingKey",
+			Exchange: &AMQPExchange{
+				Name:    exchange.Name,
+				Type:    exchange.Kind,
+				Durable: exchange.Durable,
+				VHost:   DefaultVHost,
+			},
+			BindingVersion: AMQPBindingVersion,
+		}},
+	}
+
+	doc.Operations["send."+messageID] = Operation{
+		Action:   "send",
+		Channel:  channelRef(messageID),
+		Title:    "Publish " + messageID,
+		Summary:  event.Summary,
+		Tags:     serviceTags(event.Publisher),
+		Messages: []Reference{channelMessageRef(messageID, messageID)},
+		Bindings: &OperationBindings{AMQP: AMQPOperationBinding{
+			Cc:             []string{event.Name.String()},
+			DeliveryMode:   persistentDelivery,
+			BindingVersion: AMQPBindingVersion,
+		}},
+	}
+}
+
+// addQueue adds the queue channel and the receive operation of its consumer
+func addQueue(doc *Document, catalog *eventing.Catalog, queue eventing.QueueDefinition) {
+	events := catalog.EventsForQueue(queue)
+
+	messages := make(map[string]Reference, len(events))
+	operationMessages := make([]Reference, 0, len(events))
+	for _, event := range events {
+		messageID := event.Name.String()
+		messages[messageID] = messageRef(messageID)
+		operationMessages = append(operationMessages, channelMessageRef(queue.Name, messageID))
+	}
+
+	doc.Channels[queue.Name] = Channel{
+		Address:     queue.Name,
+		Title:       queue.Name,
+		Description: queueDescription(queue),
+		Messages:    messages,
+		Bindings: &ChannelBindings{AMQP: AMQPChannelBinding{
+			Is: "queue",
+			Queue: &AMQPQueue{
+				Name:    queue.Name,
+				Durable: queue.Durable,
+				VHost:   DefaultVHost,
+			},
+			BindingVersion: AMQPBindingVersion,
+		}},
+	}
+
+	// Dead letter queues are inspected by hand
+	if queue.Consumer == "" {
+		return
+	}
+
+	doc.Operations["receive."+queue.Name] = Operation{
+		Action:   "receive",
+		Channel:  channelRef(queue.Name),
+		Title:    "Consume " + queue.Name,
+		Summary:  queue.Summary,
+		Tags:     serviceTags(queue.Consumer),
+		Messages: operationMessages,
+		Bindings: &OperationBindings{AMQP: AMQPOperationBinding{
+			Ack:            true,
+			BindingVersion: AMQPBindingVersion,
+		}},
+	}
+}
+
+// queueDescription documents the bindings and dead lettering of the queue
+func queueDescription(queue eventing.QueueDefinition) string {
+	description := fmt.Sprintf("%s. Bound to %s with %s.", queue.Summary, queue.Exchange, strings.Join(queue.RoutingKeys, ", "))
+	if queue.Stream {
+		description += " Stream queue, consumed from an offset without removing messages."
+	}
+	if queue.Partitions > 0 {
+		description += fmt.Sprintf(" Partitioned over %d queues by the %s header, each consumed in order.", queue.Partitions, eventing.CommonIDHeader)
+	}
+	if queue.DeadletterExchange != "" {
+		description += fmt.Sprintf(" Rejected messages are dead lettered to %s with %s.", queue.DeadletterExchange, queue.DeadletterRoutingKey)
+	}
+
+	return description
+}
+
+// serviceTags tags the operation with the owning service
+func serviceTags(service string) []Tag {
+	if service == "" {
+		return nil
+	}
+
+	return []Tag{{Name: service}}
+}
+
+// messageRef points to a component message
+func messageRef(messageID string) Reference {
+	return Reference{Ref: "#/components/messages/" + messageID}
+}
+
+// channelRef points to a channel
+func channelRef(channelID string) Reference {
+	return Reference{Ref: "#/channels/" + channelID}
+}
+
+// channelMessageRef points to a message of a channel
+func channelMessageRef(channelID, messageID string) Reference {
+	return Reference{Ref: "#/channels/" + channelID + "/messages/" + messageID}
+}
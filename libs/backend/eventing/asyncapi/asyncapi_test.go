@@ -0,0 +1,112 @@
+package asyncapi_test
+
+import (
+	"libs/backend/eventing"
+	"libs/backend/eventing/asyncapi"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// documentPath is the committed document, regenerated with `pnpm asyncapi:gen`
+const documentPath = "../../proto-gen/asyncapi/asyncapi.json"
+
+func TestGenerate(t *testing.T) {
+	doc, err := asyncapi.Generate(eventing.NewCatalog(), asyncapi.Options{})
+	require.NoError(t, err)
+
+	assert.Equal(t, asyncapi.SpecVersion, doc.AsyncAPI)
+
+	t.Run("events have a routing key channel on their exchange", func(t *testing.T) {
+		channel, ok := doc.Channels[eventing.EventNameUserRegistered.String()]
+		require.True(t, ok)
+
+		assert.Equal(t, eventing.GetUserRegisteredRoutingKey(), channel.Address)
+		assert.Equal(t, "routingKey", channel.Bindings.AMQP.Is)
+		assert.Equal(t, eventing.AuthExchange, channel.Bindings.AMQP.Exchange.Name)
+		assert.Equal(t, eventing.ExchangeKindTopic, channel.Bindings.AMQP.Exchange.Type)
+
+		operation := doc.Operations["send."+eventing.EventNameUserRegistered.String()]
+		assert.Equal(t, "send", operation.Action)
+		assert.Equal(t, []asyncapi.Tag{{Name: eventing.InboundWebhooksAPIService}}, operation.Tags)
+	})
+
+	t.Run("queues list the events routed to them", func(t *testing.T) {
+		queueName := eventing.GetQueueName(eventing.AccountsWorkerService, eventing.AccountLifecycleQueue)
+
+		channel, ok := doc.Channels[queueName]
+		require.True(t, ok)
+		assert.Equal(t, "queue", channel.Bindings.AMQP.Is)
+		assert.Contains(t, channel.Messages, eventing.EventNameAccountCreated.String())
+		assert.NotContains(t, channel.Messages, eventing.EventNameAccountUpdated.String())
+
+		operation := doc.Operations["receive."+queueName]
+		assert.Equal(t, "receive", operation.Action)
+
+		// Dead letter queues have a channel but no consumer
+		assert.Contains(t, doc.Channels, eventing.AuthDeadletterQueue)
+		assert.NotContains(t, doc.Operations, "receive."+eventing.AuthDeadletterQueue)
+	})
+
+	t.Run("payload schemas follow the protobuf json mapping", func(t *testing.T) {
+		message := doc.Components.Messages[eventing.EventNameAccountUpdated.String()]
+		assert.Equal(t, int32(eventing.AccountUpdatedVersion), message.Headers.Properties[eventing.EventVersionHeader].Const)
+
+		payload := resolve(t, doc, message.Payload)
+		assert.Equal(t, "array", payload.Properties["updatedFields"].Type)
+
+		account := resolve(t, doc, payload.Properties["account"])
+		assert.Equal(t, "string", account.Properties["commonId"].Type)
+		assert.Equal(t, "date-time", account.Properties["createdAt"].Format)
+	})
+
+	t.Run("every reference resolves", func(t *testing.T) {
+		for _, message := range doc.Components.Messages {
+			resolve(t, doc, message.Payload)
+		}
+
+		for _, schema := range doc.Components.Schemas {
+			for _, property := range schema.Properties {
+				if property.Ref != "" {
+					resolve(t, doc, property)
+				}
+			}
+		}
+
+		for id, operation := range doc.Operations {
+			channelID := strings.TrimPrefix(operation.Channel.Ref, "#/channels/")
+			channel, ok := doc.Channels[channelID]
+			require.True(t, ok, id)
+
+			for _, ref := range operation.Messages {
+				messageID := strings.TrimPrefix(ref.Ref, "#/channels/"+channelID+"/messages/")
+				assert.Contains(t, channel.Messages, messageID, id)
+			}
+		}
+	})
+}
+
+func TestDocumentIsCurrent(t *testing.T) {
+	doc, err := asyncapi.Generate(eventing.NewCatalog(), asyncapi.Options{})
+	require.NoError(t, err)
+
+	want, err := asyncapi.Marshal(doc)
+	require.NoError(t, err)
+
+	got, err := os.ReadFile(documentPath)
+	require.NoError(t, err)
+	assert.Equal(t, string(want), string(got), "asyncapi document is stale, run `pnpm asyncapi:gen`")
+}
+
+// resolve follows a components schema reference
+func resolve(t *testing.T, doc asyncapi.Document, schema *asyncapi.Schema) *asyncapi.Schema {
+	t.Helper()
+
+	resolved, ok := doc.Components.Schemas[strings.TrimPrefix(schema.Ref, "#/components/schemas/")]
+	require.True(t, ok, "unresolved reference %s", schema.Ref)
+
+	return resolved
+}
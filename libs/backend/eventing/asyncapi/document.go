@@ -0,0 +1,150 @@
+package asyncapi
+
+// Versions of the specification and the AMQP bindings
+const (
+	SpecVersion        = "3.0.0"
+	AMQPBindingVersion = "0.3.0"
+)
+
+// Document is an AsyncAPI document
+type Document struct {
+	AsyncAPI           string               `json:"asyncapi"`
+	Info               Info                 `json:"info"`
+	Servers            map[string]Server    `json:"servers,omitempty"`
+	DefaultContentType string               `json:"defaultContentType,omitempty"`
+	Channels           map[string]Channel   `json:"channels"`
+	Operations         map[string]Operation `json:"operations"`
+	Components         Components           `json:"components"`
+}
+
+// Info is the metadata of the document
+type Info struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
+}
+
+// Server is a message broker the channels live on
+type Server struct {
+	Host        string `json:"host"`
+	Protocol    string `json:"protocol"`
+	Description string `json:"description,omitempty"`
+}
+
+// Reference points to a definition elsewhere in the document
+type Reference struct {
+	Ref string `json:"$ref"`
+}
+
+// Channel is an exchange routing key or a queue
+type Channel struct {
+	Address     string               `json:"address"`
+	Title       string               `json:"title,omitempty"`
+	Description string               `json:"description,omitempty"`
+	Messages    map[string]Reference `json:"messages"`
+	Bindings    *ChannelBindings     `json:"bindings,omitempty"`
+}
+
+// ChannelBindings are the protocol specific channel details
+type ChannelBindings struct {
+	AMQP AMQPChannelBinding `json:"amqp"`
+}
+
+// AMQPChannelBinding describes the exchange or queue of the channel
+type AMQPChannelBinding struct {
+	Is             string        `json:"is"`
+	Exchange       *AMQPExchange `json:"exchange,omitempty"`
+	Queue          *AMQPQueue    `json:"queue,omitempty"`
+	BindingVersion string        `json:"bindingVersion"`
+}
+
+// AMQPExchange describes an exchange
+type AMQPExchange struct {
+	Name       string `json:"name"`
+	Type       string `json:"type"`
+	Durable    bool   `json:"durable"`
+	AutoDelete bool   `json:"autoDelete"`
+	VHost      string `json:"vhost"`
+}
+
+// AMQPQueue describes a queue
+type AMQPQueue struct {
+	Name       string `json:"name"`
+	Durable    bool   `json:"durable"`
+	Exclusive  bool   `json:"exclusive"`
+	AutoDelete bool   `json:"autoDelete"`
+	VHost      string `json:"vhost"`
+}
+
+// Operation is a service sending to or receiving from a channel
+type Operation struct {
+	Action   string             `json:"action"`
+	Channel  Reference          `json:"channel"`
+	Title    string             `json:"title,omitempty"`
+	Summary  string             `json:"summary,omitempty"`
+	Tags     []Tag              `json:"tags,omitempty"`
+	Messages []Reference        `json:"messages"`
+	Bindings *OperationBindings `json:"bindings,omitempty"`
+}
+
+// Tag groups operations, we use it for the owning service
+type Tag struct {
+	Name string `json:"name"`
+}
+
+// OperationBindings are the protocol specific operation details
+type OperationBindings struct {
+	AMQP AMQPOperationBinding `json:"amqp"`
+}
+
+// AMQPOperationBinding describes how messages are published
+type AMQPOperationBinding struct {
+	Cc             []string `json:"cc,omitempty"`
+	DeliveryMode   int      `json:"deliveryMode,omitempty"`
+	Ack            bool     `json:"ack,omitempty"`
+	BindingVersion string   `json:"bindingVersion"`
+}
+
+// Message is an event published to the broker
+type Message struct {
+	Name        string           `json:"name"`
+	Title       string           `json:"title,omitempty"`
+	Summary     string           `json:"summary,omitempty"`
+	ContentType string           `json:"contentType,omitempty"`
+	Headers     *Schema          `json:"headers,omitempty"`
+	Payload     *Schema          `json:"payload"`
+	Bindings    *MessageBindings `json:"bindings,omitempty"`
+}
+
+// MessageBindings are the protocol specific message details
+type MessageBindings struct {
+	AMQP AMQPMessageBinding `json:"amqp"`
+}
+
+// AMQPMessageBinding describes the AMQP properties of the message
+type AMQPMessageBinding struct {
+	ContentEncoding string `json:"contentEncoding,omitempty"`
+	MessageType     string `json:"messageType,omitempty"`
+	BindingVersion  string `json:"bindingVersion"`
+}
+
+// Components holds the reusable messages and schemas
+type Components struct {
+	Messages map[string]Message `json:"messages"`
+	Schemas  map[string]*Schema `json:"schemas"`
+}
+
+// Schema is the JSON schema subset used for headers and payloads
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Title                string             `json:"title,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	Const                any                `json:"const,omitempty"`
+}
@@ -0,0 +1,131 @@
+package asyncapi
+
+import (
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// schemaRefPrefix points into the components schemas
+const schemaRefPrefix = "#/components/schemas/"
+
+// wellKnownSchemas follow the protobuf JSON mapping of the well known types
+var wellKnownSchemas = map[protoreflect.FullName]func() *Schema{
+	"google.protobuf.Timestamp": func() *Schema { return &Schema{Type: "string", Format: "date-time"} },
+	"google.protobuf.Duration":  func() *Schema { return &Schema{Type: "string", Format: "duration"} },
+	"google.protobuf.FieldMask": func() *Schema { return &Schema{Type: "string"} },
+	"google.protobuf.Struct":    func() *Schema { return &Schema{Type: "object"} },
+	"google.protobuf.Value":     func() *Schema { return &Schema{} },
+	"google.protobuf.ListValue": func() *Schema { return &Schema{Type: "array", Items: &Schema{}} },
+	"google.protobuf.Any":       func() *Schema { return &Schema{Type: "object"} },
+	"google.protobuf.Empty":     func() *Schema { return &Schema{Type: "object"} },
+
+	"google.protobuf.BoolValue":   func() *Schema { return &Schema{Type: "boolean"} },
+	"google.protobuf.StringValue": func() *Schema { return &Schema{Type: "string"} },
+	"google.protobuf.BytesValue":  func() *Schema { return &Schema{Type: "string", Format: "byte"} },
+	"google.protobuf.Int32Value":  func() *Schema { return &Schema{Type: "integer", Format: "int32"} },
+	"google.protobuf.UInt32Value": func() *Schema { return &Schema{Type: "integer", Format: "uint32"} },
+	"google.protobuf.Int64Value":  func() *Schema { return &Schema{Type: "string", Format: "int64"} },
+	"google.protobuf.UInt64Value": func() *Schema { return &Schema{Type: "string", Format: "uint64"} },
+	"google.protobuf.FloatValue":  func() *Schema { return &Schema{Type: "number", Format: "float"} },
+	"google.protobuf.DoubleValue": func() *Schema { return &Schema{Type: "number", Format: "double"} },
+}
+
+// schemaRef returns the reference to the schema of the message
+func schemaRef(name protoreflect.FullName) string {
+	return schemaRefPrefix + string(name)
+}
+
+// addMessageSchema converts the message and every message it references into
+// schemas, following the protobuf JSON mapping so the schema matches the JSON
+// form of the payload
+func addMessageSchema(schemas map[string]*Schema, desc protoreflect.MessageDescriptor) {
+	name := string(desc.FullName())
+	if _, ok := schemas[name]; ok {
+		return
+	}
+
+	schema := &Schema{
+		Type:       "object",
+		Title:      string(desc.Name()),
+		Properties: make(map[string]*Schema),
+	}
+
+	// Register before the fields so recursive messages terminate
+	schemas[name] = schema
+
+	fields := desc.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		schema.Properties[field.JSONName()] = fieldSchema(schemas, field)
+	}
+}
+
+// fieldSchema converts the field, including repeated and map fields
+func fieldSchema(schemas map[string]*Schema, field protoreflect.FieldDescriptor) *Schema {
+	switch {
+	case field.IsMap():
+		return &Schema{
+			Type:                 "object",
+			AdditionalProperties: valueSchema(schemas, field.MapValue()),
+		}
+	case field.IsList():
+		return &Schema{
+			Type:  "array",
+			Items: valueSchema(schemas, field),
+		}
+	default:
+		return valueSchema(schemas, field)
+	}
+}
+
+// valueSchema converts a single value of the field kind
+func valueSchema(schemas map[string]*Schema, field protoreflect.FieldDescriptor) *Schema {
+	switch field.Kind() {
+	case protoreflect.BoolKind:
+		return &Schema{Type: "boolean"}
+	case protoreflect.StringKind:
+		return &Schema{Type: "string"}
+	case protoreflect.BytesKind:
+		return &Schema{Type: "string", Format: "byte"}
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return &Schema{Type: "integer", Format: "int32"}
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return &Schema{Type: "integer", Format: "uint32"}
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		// 64 bit integers are strings in the JSON mapping
+		return &Schema{Type: "string", Format: "int64"}
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return &Schema{Type: "string", Format: "uint64"}
+	case protoreflect.FloatKind:
+		return &Schema{Type: "number", Format: "float"}
+	case protoreflect.DoubleKind:
+		return &Schema{Type: "number", Format: "double"}
+	case protoreflect.EnumKind:
+		return enumSchema(field.Enum())
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		msg := field.Message()
+		if wellKnown, ok := wellKnownSchemas[msg.FullName()]; ok {
+			return wellKnown()
+		}
+
+		addMessageSchema(schemas, msg)
+		return &Schema{Ref: schemaRef(msg.FullName())}
+	default:
+		return &Schema{}
+	}
+}
+
+// enumSchema lists the enum value names, which is how enums are encoded in JSON
+func enumSchema(desc protoreflect.EnumDescriptor) *Schema {
+	values := desc.Values()
+
+	schema := &Schema{
+		Type:  "string",
+		Title: string(desc.Name()),
+		Enum:  make([]string, 0, values.Len()),
+	}
+	for i := 0; i < values.Len(); i++ {
+		schema.Enum = append(schema.Enum, string(values.Get(i).Name()))
+	}
+
+	return schema
+}
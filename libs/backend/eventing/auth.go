@@ -1,8 +1,11 @@
 package eventing
 
 import (
+	"context"
 	boot "libs/backend/boot"
+	accountseventsv1 "libs/backend/proto-gen/go/accounts/accountsevents/v1"
 	"log/slog"
+	"time"
 
 	"github.com/rabbitmq/amqp091-go"
 )
@@ -14,18 +17,279 @@ const (
 	AuthDeadletterExchange   = "authDeadletterExchange"
 	AuthDeadletterQueue      = "authDeadletterQueue"
 	AuthDeadletterRoutingKey = "authDlx"
+	AuthEventStream          = "authEventStream"
 )
 
 // Event Names
 var (
-	EventNameUserRegistered EventName = EventName(GetEventName(AuthDomain, "userRegistered"))
+	EventNameUserRegistered  EventName = EventName(GetEventName(AuthDomain, "userRegistered"))
+	EventNameSessionsRevoked EventName = EventName(GetEventName(AuthDomain, "sessionsRevoked"))
+
+	EventNameUserLoggedIn        EventName = EventName(GetEventName(AuthDomain, "userLoggedIn"))
+	EventNameUserEmailVerified   EventName = EventName(GetEventName(AuthDomain, "userEmailVerified"))
+	EventNameUserPasswordChanged EventName = EventName(GetEventName(AuthDomain, "userPasswordChanged"))
+	EventNameUserBlocked         EventName = EventName(GetEventName(AuthDomain, "userBlocked"))
+	EventNameUserDeletedInIdP    EventName = EventName(GetEventName(AuthDomain, "userDeletedInIdP"))
+)
+
+// Event Versions
+const (
+	UserRegisteredVersion  EventVersion = 1
+	SessionsRevokedVersion EventVersion = 1
+
+	UserLoggedInVersion        EventVersion = 1
+	UserEmailVerifiedVersion   EventVersion = 1
+	UserPasswordChangedVersion EventVersion = 1
+	UserBlockedVersion         EventVersion = 1
+	UserDeletedInIdPVersion    EventVersion = 1
 )
 
+// registerAuthEventSchemas registers the current version and upcasters of every auth event
+func registerAuthEventSchemas(r *UpcasterRegistry) {
+	r.RegisterEvent(EventNameUserRegistered, UserRegisteredVersion)
+	r.RegisterEvent(EventNameSessionsRevoked, SessionsRevokedVersion)
+	r.RegisterEvent(EventNameUserLoggedIn, UserLoggedInVersion)
+	r.RegisterEvent(EventNameUserEmailVerified, UserEmailVerifiedVersion)
+	r.RegisterEvent(EventNameUserPasswordChanged, UserPasswordChangedVersion)
+	r.RegisterEvent(EventNameUserBlocked, UserBlockedVersion)
+	r.RegisterEvent(EventNameUserDeletedInIdP, UserDeletedInIdPVersion)
+}
+
+// registerAuthCatalog registers the auth exchanges, events and queues
+func registerAuthCatalog(c *Catalog) {
+	c.RegisterExchange(ExchangeDefinition{
+		Name:    AuthExchange,
+		Kind:    ExchangeKindTopic,
+		Durable: true,
+		Summary: "Identity events raised by the authentication provider",
+	})
+	c.RegisterExchange(ExchangeDefinition{
+		Name:    AuthDeadletterExchange,
+		Kind:    ExchangeKindDirect,
+		Durable: true,
+		Summary: "Rejected auth events",
+	})
+
+	c.RegisterEvent(EventDefinition{
+		Name:      EventNameUserRegistered,
+		Version:   UserRegisteredVersion,
+		Exchange:  AuthExchange,
+		Publisher: InboundWebhooksAPIService,
+		Summary:   "A user signed up with the authentication provider",
+		Message:   &accountseventsv1.UserRegistered{},
+	})
+	c.RegisterEvent(EventDefinition{
+		Name:      EventNameSessionsRevoked,
+		Version:   SessionsRevokedVersion,
+		Exchange:  AuthExchange,
+		Publisher: AccountsAPIService,
+		Summary:   "An admin revoked an access token or every session of an account",
+		Message:   &accountseventsv1.SessionsRevoked{},
+	})
+	c.RegisterEvent(EventDefinition{
+		Name:      EventNameUserLoggedIn,
+		Version:   UserLoggedInVersion,
+		Exchange:  AuthExchange,
+		Publisher: InboundWebhooksAPIService,
+		Summary:   "The authentication provider logged a successful login",
+		Message:   &accountseventsv1.UserLoggedIn{},
+	})
+	c.RegisterEvent(EventDefinition{
+		Name:      EventNameUserEmailVerified,
+		Version:   UserEmailVerifiedVersion,
+		Exchange:  AuthExchange,
+		Publisher: InboundWebhooksAPIService,
+		Summary:   "The authentication provider logged a verified email address",
+		Message:   &accountseventsv1.UserEmailVerified{},
+	})
+	c.RegisterEvent(EventDefinition{
+		Name:      EventNameUserPasswordChanged,
+		Version:   UserPasswordChangedVersion,
+		Exchange:  AuthExchange,
+		Publisher: InboundWebhooksAPIService,
+		Summary:   "The authentication provider logged a changed password",
+		Message:   &accountseventsv1.UserPasswordChanged{},
+	})
+	c.RegisterEvent(EventDefinition{
+		Name:      EventNameUserBlocked,
+		Version:   UserBlockedVersion,
+		Exchange:  AuthExchange,
+		Publisher: InboundWebhooksAPIService,
+		Summary:   "The authentication provider blocked a user after too many failed logins",
+		Message:   &accountseventsv1.UserBlocked{},
+	})
+	c.RegisterEvent(EventDefinition{
+		Name:      EventNameUserDeletedInIdP,
+		Version:   UserDeletedInIdPVersion,
+		Exchange:  AuthExchange,
+		Publisher: InboundWebhooksAPIService,
+		Summary:   "A user was deleted in the authentication provider",
+		Message:   &accountseventsv1.UserDeletedInIdP{},
+	})
+
+	c.RegisterQueue(QueueDefinition{
+		Name:                 GetQueueName(AccountsWorkerService, UserRegistrationQueue),
+		Exchange:             AuthExchange,
+		RoutingKeys:          []string{GetUserRegisteredRoutingKey()},
+		Consumer:             AccountsWorkerService,
+		Durable:              true,
+		Summary:              "Starts the registration saga of new users",
+		DeadletterExchange:   AuthDeadletterExchange,
+		DeadletterRoutingKey: AuthDeadletterRoutingKey,
+	})
+	c.RegisterQueue(QueueDefinition{
+		Name:                 GetQueueName(AccountsAPIService, IdentityEventsQueue),
+		Exchange:             AuthExchange,
+		RoutingKeys:          GetIdentityEventsRoutingKeys(),
+		Consumer:             AccountsAPIService,
+		Durable:              true,
+		Summary:              "Records the logins and verified email addresses on the accounts, revokes the sessions of blocked users and deletes the accounts of deleted users",
+		DeadletterExchange:   AuthDeadletterExchange,
+		DeadletterRoutingKey: AuthDeadletterRoutingKey,
+	})
+	c.RegisterQueue(QueueDefinition{
+		Name:        AuthEventStream,
+		Exchange:    AuthExchange,
+		RoutingKeys: []string{GetDomainRoutingKey(AuthDomain)},
+		Durable:     true,
+		Stream:      true,
+		Summary:     "History of every auth event for replays and the session revocations followed by every service",
+	})
+	c.RegisterQueue(QueueDefinition{
+		Name:        AuthDeadletterQueue,
+		Exchange:    AuthDeadletterExchange,
+		RoutingKeys: []string{AuthDeadletterRoutingKey},
+		Durable:     true,
+		Summary:     "Rejected auth events kept for inspection",
+	})
+}
+
 // GetUserRegisteredRoutingKey returns the routing key for user registered event
 func GetUserRegisteredRoutingKey() string {
 	return EventNameUserRegistered.String()
 }
 
+// RegisterUserRegisteredHandler routes user registered events to the handler
+func RegisterUserRegisteredHandler(d *Dispatcher, handler EventHandler[*accountseventsv1.UserRegistered]) {
+	RegisterHandler(d, EventNameUserRegistered, handler)
+}
+
+// GetIdentityEventsRoutingKeys returns the routing keys of the identity events
+// the accounts are kept up to date with
+func GetIdentityEventsRoutingKeys() []string {
+	return []string{
+		GetUserLoggedInRoutingKey(),
+		GetUserEmailVerifiedRoutingKey(),
+		GetUserBlockedRoutingKey(),
+		GetUserDeletedInIdPRoutingKey(),
+	}
+}
+
+// GetSessionsRevokedRoutingKey returns the routing key for sessions revoked event
+func GetSessionsRevokedRoutingKey() string {
+	return EventNameSessionsRevoked.String()
+}
+
+// RegisterSessionsRevokedHandler routes sessions revoked events to the handler
+func RegisterSessionsRevokedHandler(d *Dispatcher, handler EventHandler[*accountseventsv1.SessionsRevoked]) {
+	RegisterHandler(d, EventNameSessionsRevoked, handler)
+}
+
+// GetUserLoggedInRoutingKey returns the routing key for user logged in event
+func GetUserLoggedInRoutingKey() string {
+	return EventNameUserLoggedIn.String()
+}
+
+// RegisterUserLoggedInHandler routes user logged in events to the handler
+func RegisterUserLoggedInHandler(d *Dispatcher, handler EventHandler[*accountseventsv1.UserLoggedIn]) {
+	RegisterHandler(d, EventNameUserLoggedIn, handler)
+}
+
+// GetUserEmailVerifiedRoutingKey returns the routing key for user email verified event
+func GetUserEmailVerifiedRoutingKey() string {
+	return EventNameUserEmailVerified.String()
+}
+
+// RegisterUserEmailVerifiedHandler routes user email verified events to the handler
+func RegisterUserEmailVerifiedHandler(d *Dispatcher, handler EventHandler[*accountseventsv1.UserEmailVerified]) {
+	RegisterHandler(d, EventNameUserEmailVerified, handler)
+}
+
+// GetUserPasswordChangedRoutingKey returns the routing key for user password changed event
+func GetUserPasswordChangedRoutingKey() string {
+	return EventNameUserPasswordChanged.String()
+}
+
+// RegisterUserPasswordChangedHandler routes user password changed events to the handler
+func RegisterUserPasswordChangedHandler(d *Dispatcher, handler EventHandler[*accountseventsv1.UserPasswordChanged]) {
+	RegisterHandler(d, EventNameUserPasswordChanged, handler)
+}
+
+// GetUserBlockedRoutingKey returns the routing key for user blocked event
+func GetUserBlockedRoutingKey() string {
+	return EventNameUserBlocked.String()
+}
+
+// RegisterUserBlockedHandler routes user blocked events to the handler
+func RegisterUserBlockedHandler(d *Dispatcher, handler EventHandler[*accountseventsv1.UserBlocked]) {
+	RegisterHandler(d, EventNameUserBlocked, handler)
+}
+
+// GetUserDeletedInIdPRoutingKey returns the routing key for user deleted in IdP event
+func GetUserDeletedInIdPRoutingKey() string {
+	return EventNameUserDeletedInIdP.String()
+}
+
+// RegisterUserDeletedInIdPHandler routes user deleted in IdP events to the handler
+func RegisterUserDeletedInIdPHandler(d *Dispatcher, handler EventHandler[*accountseventsv1.UserDeletedInIdP]) {
+	RegisterHandler(d, EventNameUserDeletedInIdP, handler)
+}
+
+// SessionRevoker records revocations, e.g. the revocation cache of httpauth
+type SessionRevoker interface {
+	// RevokeToken rejects the token of the jti until it expires
+	RevokeToken(jti string, expiresAt time.Time)
+
+	// RevokeSessions rejects the tokens of the account or the sub issued before the time
+	RevokeSessions(subject string, issuedBefore time.Time, expiresAt time.Time)
+}
+
+// NewSessionsRevokedHandler records the revoked sessions with the revoker
+func NewSessionsRevokedHandler(revoker SessionRevoker) EventHandler[*accountseventsv1.SessionsRevoked] {
+	return func(ctx context.Context, event *accountseventsv1.SessionsRevoked) error {
+		expiresAt := event.GetExpiresAt().AsTime()
+
+		if event.GetJti() != "" {
+			revoker.RevokeToken(event.GetJti(), expiresAt)
+		}
+		if event.GetTokensIssuedBefore() != nil {
+			if event.GetCommonId() != "" {
+				revoker.RevokeSessions(event.GetCommonId(), event.GetTokensIssuedBefore().AsTime(), expiresAt)
+			}
+			if event.GetSubject() != "" {
+				revoker.RevokeSessions(event.GetSubject(), event.GetTokensIssuedBefore().AsTime(), expiresAt)
+			}
+		}
+
+		return nil
+	}
+}
+
+// FollowSessionRevocations keeps the revoker up to date with the session
+// revocations of every service until the context ends. Each instance reads
+// the auth event stream on its own, starting lookback in the past so the
+// revocations of the tokens that are still valid are known after a restart.
+// The lookback must cover the lifetime of the access tokens.
+func FollowSessionRevocations(ctx context.Context, consumer boot.AMQPConsumer, logger boot.Logger, revoker SessionRevoker, lookback time.Duration) error {
+	dispatcher, err := NewDispatcher(logger)
+	if err != nil {
+		return err
+	}
+	RegisterSessionsRevokedHandler(dispatcher, NewSessionsRevokedHandler(revoker))
+
+	return dispatcher.Follow(ctx, consumer, AuthEventStream, boot.NewStreamOffsetFrom(time.Now().Add(-lookback)))
+}
+
 // RegisterAuthParams are params for the auth queue constructor
 type RegisterAuthParams struct {
 	Registerer boot.AMQPRegisterer
@@ -156,6 +420,24 @@ func (a *AuthEventSetup) BindQueues(routingKeys []string) *AuthEventSetup {
 	return a
 }
 
+// CreateStream creates the stream queue keeping the history of every auth event for replays
+func (a *AuthEventSetup) CreateStream() *AuthEventSetup {
+	stream, err := boot.DeclareStreamQueue(a.registerer, AuthEventStream, boot.StreamOptions{})
+	if err != nil {
+		a.log.Error("Cannot create auth event stream", slog.Any("error", err))
+		return a
+	}
+
+	routingKey := GetDomainRoutingKey(AuthDomain)
+	if err := a.registerer.QueueBind(stream.Name, routingKey, AuthExchange, false, nil); err != nil {
+		a.log.Error("Cannot bind auth event stream to exchange", slog.String("queueName", stream.Name))
+		return a
+	}
+
+	a.log.Info("Created auth event stream", slog.String("queueName", stream.Name))
+	return a
+}
+
 // Complete completes the auth event setup
 func (a *AuthEventSetup) Complete() {
 	a.log.Info("Completed auth event setup")
@@ -0,0 +1,113 @@
+package eventing
+
+import (
+	"context"
+	boot "libs/backend/boot"
+	"libs/backend/boot/amqptest"
+	accountseventsv1 "libs/backend/proto-gen/go/accounts/accountsevents/v1"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestAuthEventSetup(t *testing.T) {
+	broker := amqptest.NewBroker()
+	defer broker.Close()
+
+	queueName := "accounts-worker-user-registration"
+	setup := NewAuthEventSetup(broker, boot.NewSlogger())
+	setup.
+		CreateExchange().
+		CreateDeadletter().
+		CreateQueue(queueName).
+		BindQueues([]string{GetUserRegisteredRoutingKey()}).
+		Complete()
+
+	assert.True(t, broker.HasExchange(AuthExchange, amqptest.ExchangeTopic))
+	assert.True(t, broker.HasExchange(AuthDeadletterExchange, amqptest.ExchangeDirect))
+	assert.True(t, broker.IsBound(AuthDeadletterQueue, AuthDeadletterRoutingKey, AuthDeadletterExchange))
+	assert.True(t, broker.IsBound(queueName, GetUserRegisteredRoutingKey(), AuthExchange))
+	assert.Equal(t, AuthDeadletterExchange, broker.QueueArgs(queueName)["x-dead-letter-exchange"])
+	assert.Equal(t, AuthDeadletterRoutingKey, broker.QueueArgs(queueName)["x-dead-letter-routing-key"])
+}
+
+// recordingRevoker records the revocations it receives
+type recordingRevoker struct {
+	mu       sync.Mutex
+	jtis     []string
+	subjects []string
+}
+
+func (r *recordingRevoker) RevokeToken(jti string, expiresAt time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.jtis = append(r.jtis, jti)
+}
+
+func (r *recordingRevoker) RevokeSessions(subject string, issuedBefore time.Time, expiresAt time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.subjects = append(r.subjects, subject)
+}
+
+func (r *recordingRevoker) revoked() ([]string, []string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return slices.Clone(r.jtis), slices.Clone(r.subjects)
+}
+
+func publishSessionsRevoked(t *testing.T, broker *amqptest.Broker, event *accountseventsv1.SessionsRevoked) {
+	t.Helper()
+
+	msg, err := NewProtoPublishing(EventNameSessionsRevoked, SessionsRevokedVersion, event)
+	require.NoError(t, err)
+	require.NoError(t, broker.PublishWithConfirm(context.Background(), AuthExchange, GetSessionsRevokedRoutingKey(), true, msg))
+}
+
+func TestFollowSessionRevocations(t *testing.T) {
+	broker := amqptest.NewBroker()
+	defer broker.Close()
+
+	setup := NewAuthEventSetup(broker, boot.NewSlogger())
+	setup.
+		CreateExchange().
+		CreateStream().
+		Complete()
+
+	expiresAt := timestamppb.New(time.Now().Add(time.Hour))
+
+	// Revocations published before the follower starts are read from the history
+	publishSessionsRevoked(t, broker, &accountseventsv1.SessionsRevoked{
+		CommonId:           testCommonID,
+		Subject:            "auth0|user",
+		TokensIssuedBefore: timestamppb.Now(),
+		ExpiresAt:          expiresAt,
+	})
+
+	revoker := &recordingRevoker{}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- FollowSessionRevocations(ctx, broker, boot.NewSlogger(), revoker, time.Hour)
+	}()
+
+	publishSessionsRevoked(t, broker, &accountseventsv1.SessionsRevoked{Jti: "token-1", ExpiresAt: expiresAt})
+
+	assert.Eventually(t, func() bool {
+		jtis, subjects := revoker.revoked()
+		return slices.Equal(jtis, []string{"token-1"}) && slices.Equal(subjects, []string{testCommonID, "auth0|user"})
+	}, time.Second, 10*time.Millisecond)
+
+	cancel()
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("follower did not stop")
+	}
+}
@@ -0,0 +1,264 @@
+package eventing
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// Service Names of the event producers and consumers
+const (
+	AccountsAPIService         = "accounts-api"
+	AccountsWorkerService      = "accounts-worker"
+	InboundWebhooksAPIService  = "inbound-webhooks-api"
+	OutboundWebhooksAPIService = "outbound-webhooks-api"
+)
+
+// Consumer Queues, prefixed with the service name by GetQueueName
+const (
+	UserRegistrationQueue    = "user-registration"
+	AccountLifecycleQueue    = "account-lifecycle"
+	AccountNotificationQueue = "account-notifications"
+	IdentityEventsQueue      = "identity-events"
+)
+
+// Exchange Kinds
+const (
+	ExchangeKindTopic  = "topic"
+	ExchangeKindDirect = "direct"
+)
+
+// GetQueueName concats the service name and queue name
+func GetQueueName(serviceName, queueName string) string {
+	return fmt.Sprintf("%s-%s", serviceName, queueName)
+}
+
+// ExchangeDefinition describes an exchange declared by an event setup
+type ExchangeDefinition struct {
+	Name    string
+	Kind    string
+	Durable bool
+	Summary string
+}
+
+// EventDefinition describes an event published to an exchange.
+// The event name doubles as the routing key.
+type EventDefinition struct {
+	Name      EventName
+	Version   EventVersion
+	Exchange  string
+	Publisher string
+	Summary   string
+
+	// Message is an empty instance of the protobuf payload
+	Message proto.Message
+}
+
+// QueueDefinition describes a queue and the routing keys bound to it
+type QueueDefinition struct {
+	Name        string
+	Exchange    string
+	RoutingKeys []string
+	Consumer    string
+	Durable     bool
+	Summary     string
+
+	// Stream keeps every message in an append only log that is consumed from an offset
+	Stream bool
+
+	// Partitions spreads the queue over partition queues by the common id header
+	Partitions int
+
+	// DeadletterExchange receives the rejected messages of the queue
+	DeadletterExchange   string
+	DeadletterRoutingKey string
+}
+
+// Catalog is the registered event topology of the platform
+type Catalog struct {
+	exchanges map[string]ExchangeDefinition
+	events    map[EventName]EventDefinition
+	queues    map[string]QueueDefinition
+}
+
+// NewCatalog constructs a catalog of every exchange, event and queue in this package
+func NewCatalog() *Catalog {
+	c := NewEmptyCatalog()
+	registerAuthCatalog(c)
+	registerAccountsCatalog(c)
+
+	return c
+}
+
+// NewEmptyCatalog constructs a catalog without any definitions
+func NewEmptyCatalog() *Catalog {
+	return &Catalog{
+		exchanges: make(map[string]ExchangeDefinition),
+		events:    make(map[EventName]EventDefinition),
+		queues:    make(map[string]QueueDefinition),
+	}
+}
+
+// RegisterExchange adds or replaces the exchange
+func (c *Catalog) RegisterExchange(exchange ExchangeDefinition) {
+	c.exchanges[exchange.Name] = exchange
+}
+
+// RegisterEvent adds or replaces the event
+func (c *Catalog) RegisterEvent(event EventDefinition) {
+	c.events[event.Name] = event
+}
+
+// RegisterQueue adds or replaces the queue
+func (c *Catalog) RegisterQueue(queue QueueDefinition) {
+	c.queues[queue.Name] = queue
+}
+
+// Exchanges returns the exchanges ordered by name
+func (c *Catalog) Exchanges() []ExchangeDefinition {
+	exchanges := make([]ExchangeDefinition, 0, len(c.exchanges))
+	for _, exchange := range c.exchanges {
+		exchanges = append(exchanges, exchange)
+	}
+
+	sort.Slice(exchanges, func(i, j int) bool { return exchanges[i].Name < exchanges[j].Name })
+	return exchanges
+}
+
+// Exchange returns the exchange by name
+func (c *Catalog) Exchange(name string) (ExchangeDefinition, bool) {
+	exchange, ok := c.exchanges[name]
+	return exchange, ok
+}
+
+// Events returns the events ordered by name
+func (c *Catalog) Events() []EventDefinition {
+	events := make([]EventDefinition, 0, len(c.events))
+	for _, event := range c.events {
+		events = append(events, event)
+	}
+
+	sort.Slice(events, func(i, j int) bool { return events[i].Name < events[j].Name })
+	return events
+}
+
+// Queues returns the queues ordered by name
+func (c *Catalog) Queues() []QueueDefinition {
+	queues := make([]QueueDefinition, 0, len(c.queues))
+	for _, queue := range c.queues {
+		queues = append(queues, queue)
+	}
+
+	sort.Slice(queues, func(i, j int) bool { return queues[i].Name < queues[j].Name })
+	return queues
+}
+
+// EventsForQueue returns the events the queue receives through its bindings,
+// including the events dead lettered into it by other queues
+func (c *Catalog) EventsForQueue(queue QueueDefinition) []EventDefinition {
+	exchange := c.exchanges[queue.Exchange]
+
+	routed := make(map[EventName]bool)
+	for _, event := range c.Events() {
+		if event.Exchange != queue.Exchange {
+			continue
+		}
+
+		for _, bindingKey := range queue.RoutingKeys {
+			if exchange.matches(bindingKey, event.Name.String()) {
+				routed[event.Name] = true
+				break
+			}
+		}
+	}
+
+	for _, source := range c.Queues() {
+		if source.Name == queue.Name || source.DeadletterExchange != queue.Exchange {
+			continue
+		}
+
+		for _, bindingKey := range queue.RoutingKeys {
+			if !exchange.matches(bindingKey, source.DeadletterRoutingKey) {
+				continue
+			}
+
+			for _, event := range c.EventsForQueue(source) {
+				routed[event.Name] = true
+			}
+			break
+		}
+	}
+
+	events := make([]EventDefinition, 0, len(routed))
+	for _, event := range c.Events() {
+		if routed[event.Name] {
+			events = append(events, event)
+		}
+	}
+
+	return events
+}
+
+// Validate ensures every event and queue references a registered exchange
+func (c *Catalog) Validate() error {
+	for _, event := range c.Events() {
+		if _, ok := c.exchanges[event.Exchange]; !ok {
+			return fmt.Errorf("event %s references unknown exchange %s", event.Name, event.Exchange)
+		}
+		if event.Message == nil {
+			return fmt.Errorf("event %s has no message type", event.Name)
+		}
+	}
+
+	for _, queue := range c.Queues() {
+		if _, ok := c.exchanges[queue.Exchange]; !ok {
+			return fmt.Errorf("queue %s references unknown exchange %s", queue.Name, queue.Exchange)
+		}
+		if queue.DeadletterExchange == "" {
+			continue
+		}
+		if _, ok := c.exchanges[queue.DeadletterExchange]; !ok {
+			return fmt.Errorf("queue %s references unknown dead letter exchange %s", queue.Name, queue.DeadletterExchange)
+		}
+	}
+
+	return nil
+}
+
+// matches applies the routing rules of the exchange kind to the binding key
+func (e ExchangeDefinition) matches(bindingKey, routingKey string) bool {
+	if e.Kind == ExchangeKindTopic {
+		return MatchRoutingKey(bindingKey, routingKey)
+	}
+
+	return bindingKey == routingKey
+}
+
+// MatchRoutingKey applies the topic exchange rules, where "*" matches exactly
+// one word and "#" matches zero or more words
+func MatchRoutingKey(bindingKey, routingKey string) bool {
+	return matchTopic(strings.Split(bindingKey, "."), strings.Split(routingKey, "."))
+}
+
+// matchTopic matches the dotted words against the pattern
+func matchTopic(pattern, words []string) bool {
+	if len(pattern) == 0 {
+		return len(words) == 0
+	}
+
+	switch pattern[0] {
+	case "#":
+		for i := 0; i <= len(words); i++ {
+			if matchTopic(pattern[1:], words[i:]) {
+				return true
+			}
+		}
+		return false
+	case "*":
+		return len(words) > 0 && matchTopic(pattern[1:], words[1:])
+	default:
+		return len(words) > 0 && pattern[0] == words[0] && matchTopic(pattern[1:], words[1:])
+	}
+}
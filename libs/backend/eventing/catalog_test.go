@@ -0,0 +1,88 @@
+package eventing
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCatalog(t *testing.T) {
+	catalog := NewCatalog()
+	require.NoError(t, catalog.Validate())
+
+	t.Run("every registered event schema is in the catalog", func(t *testing.T) {
+		registry := NewUpcasterRegistry()
+
+		events := make(map[EventName]EventDefinition)
+		for _, event := range catalog.Events() {
+			events[event.Name] = event
+		}
+
+		for _, eventName := range registry.EventNames() {
+			event, ok := events[eventName]
+			require.True(t, ok, "missing catalog entry for %s", eventName)
+			assert.Equal(t, registry.CurrentVersion(eventName), event.Version, eventName)
+		}
+		assert.Len(t, events, len(registry.EventNames()))
+	})
+
+	t.Run("queues receive the events of their bindings", func(t *testing.T) {
+		queues := make(map[string]QueueDefinition)
+		for _, queue := range catalog.Queues() {
+			queues[queue.Name] = queue
+		}
+
+		eventNames := func(queueName string) []EventName {
+			names := make([]EventName, 0)
+			for _, event := range catalog.EventsForQueue(queues[queueName]) {
+				names = append(names, event.Name)
+			}
+			return names
+		}
+
+		assert.Equal(t, []EventName{EventNameUserRegistered}, eventNames(GetQueueName(AccountsWorkerService, UserRegistrationQueue)))
+		assert.Equal(t, []EventName{EventNameAccountCreated}, eventNames(GetQueueName(AccountsWorkerService, AccountLifecycleQueue)))
+
+		identityEvents := []EventName{EventNameUserBlocked, EventNameUserDeletedInIdP, EventNameUserEmailVerified, EventNameUserLoggedIn}
+		assert.Equal(t, identityEvents, eventNames(GetQueueName(AccountsAPIService, IdentityEventsQueue)))
+
+		accountEvents := []EventName{
+			EventNameAccountCreated,
+			EventNameAccountHardDeleted,
+			EventNameAccountRestored,
+			EventNameAccountSoftDeleted,
+			EventNameAccountUpdated,
+		}
+		assert.Equal(t, accountEvents, eventNames(GetQueueName(OutboundWebhooksAPIService, AccountNotificationQueue)))
+
+		// Dead letter queues receive what their source queues reject
+		assert.Equal(t, append(identityEvents, EventNameUserRegistered), eventNames(AuthDeadletterQueue))
+		assert.Equal(t, accountEvents, eventNames(AccountsDeadletterQueue))
+	})
+
+	t.Run("unknown exchanges are invalid", func(t *testing.T) {
+		invalid := NewEmptyCatalog()
+		invalid.RegisterQueue(QueueDefinition{Name: "orphan", Exchange: "missingExchange"})
+		assert.Error(t, invalid.Validate())
+	})
+}
+
+func TestMatchRoutingKey(t *testing.T) {
+	tests := []struct {
+		bindingKey string
+		routingKey string
+		want       bool
+	}{
+		{"career-cue.accounts.accountCreated", "career-cue.accounts.accountCreated", true},
+		{"career-cue.accounts.*", "career-cue.accounts.accountCreated", true},
+		{"career-cue.*", "career-cue.accounts.accountCreated", false},
+		{"career-cue.#", "career-cue.accounts.accountCreated", true},
+		{"#", "career-cue.auth.userRegistered", true},
+		{"career-cue.auth.*", "career-cue.accounts.accountCreated", false},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, MatchRoutingKey(tt.bindingKey, tt.routingKey), "%s => %s", tt.bindingKey, tt.routingKey)
+	}
+}
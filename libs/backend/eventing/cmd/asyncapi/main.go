@@ -0,0 +1,45 @@
+// Command asyncapi writes the AsyncAPI document of the registered events
+package main
+
+import (
+	"flag"
+	"libs/backend/eventing"
+	"libs/backend/eventing/asyncapi"
+	"log"
+	"os"
+	"path/filepath"
+)
+
+func main() {
+	output := flag.String("o", "", "output file, defaults to stdout")
+	version := flag.String("version", asyncapi.DefaultVersion, "version of the document")
+	host := flag.String("host", asyncapi.DefaultServerHost, "host of the message broker")
+	flag.Parse()
+
+	doc, err := asyncapi.Generate(eventing.NewCatalog(), asyncapi.Options{
+		Version:    *version,
+		ServerHost: *host,
+	})
+	if err != nil {
+		log.Fatalf("cannot generate asyncapi document: %v", err)
+	}
+
+	b, err := asyncapi.Marshal(doc)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if *output == "" {
+		if _, err := os.Stdout.Write(b); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	if err := os.MkdirAll(filepath.Dir(*output), 0o755); err != nil {
+		log.Fatalf("cannot create output directory: %v", err)
+	}
+	if err := os.WriteFile(*output, b, 0o644); err != nil {
+		log.Fatalf("cannot write asyncapi document: %v", err)
+	}
+}
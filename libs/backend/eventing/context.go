@@ -0,0 +1,23 @@
+package eventing
+
+import "context"
+
+// messageIDKey is the context key of the message id of the handled delivery
+type messageIDKey struct{}
+
+// withMessageID stores the message id of the delivery in the context
+func withMessageID(ctx context.Context, messageID string) context.Context {
+	if messageID == "" {
+		return ctx
+	}
+
+	return context.WithValue(ctx, messageIDKey{}, messageID)
+}
+
+// MessageIDFromContext returns the message id of the delivery a handler is
+// called with. It is the same for every redelivery of the message, so
+// handlers can derive idempotency keys from it.
+func MessageIDFromContext(ctx context.Context) (string, bool) {
+	messageID, ok := ctx.Value(messageIDKey{}).(string)
+	return messageID, ok
+}
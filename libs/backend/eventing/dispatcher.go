@@ -0,0 +1,255 @@
+package eventing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	boot "libs/backend/boot"
+	"log/slog"
+	"sync"
+
+	"github.com/bufbuild/protovalidate-go"
+	"github.com/rabbitmq/amqp091-go"
+	"google.golang.org/protobuf/proto"
+)
+
+// Dispatch Errors
+var (
+	ErrUnknownEvent   = errors.New("no handler registered for event")
+	ErrInvalidPayload = errors.New("event payload is invalid")
+)
+
+// DefaultConcurrency is the number of deliveries a queue handles at the same
+// time when none is configured, it is also the prefetch of the consumer
+const DefaultConcurrency = 8
+
+// EventHandler handles a single decoded and validated event
+type EventHandler[T proto.Message] func(ctx context.Context, event T) error
+
+// route decodes the raw payload and hands it to the typed handler
+type route struct {
+	newMessage func() proto.Message
+	handle     func(ctx context.Context, msg proto.Message) error
+}
+
+// Dispatcher decodes incoming deliveries, validates them against their
+// buf.validate rules and routes them to the handler registered for the event name.
+// Unknown and invalid payloads are rejected without requeue so the queue's
+// dead letter exchange receives them.
+type Dispatcher struct {
+	logger    boot.Logger
+	validator *protovalidate.Validator
+	upcasters *UpcasterRegistry
+	workers   int
+	mu        *sync.RWMutex
+	routes    map[EventName]route
+}
+
+// DispatcherOption allows us to configure the Dispatcher
+type DispatcherOption func(*Dispatcher)
+
+// WithUpcasterRegistry replaces the default upcaster registry
+func WithUpcasterRegistry(upcasters *UpcasterRegistry) DispatcherOption {
+	return func(d *Dispatcher) {
+		d.upcasters = upcasters
+	}
+}
+
+// WithConcurrency sets how many deliveries of a queue are handled at the same time
+func WithConcurrency(workers int) DispatcherOption {
+	return func(d *Dispatcher) {
+		if workers > 0 {
+			d.workers = workers
+		}
+	}
+}
+
+// NewDispatcher constructs an event dispatcher with a protovalidate validator
+func NewDispatcher(logger boot.Logger, opts ...DispatcherOption) (*Dispatcher, error) {
+	validator, err := protovalidate.New()
+	if err != nil {
+		return nil, fmt.Errorf("cannot create event validator: %w", err)
+	}
+
+	d := &Dispatcher{
+		logger:    logger,
+		validator: validator,
+		upcasters: NewUpcasterRegistry(),
+		workers:   DefaultConcurrency,
+		mu:        new(sync.RWMutex),
+		routes:    make(map[EventName]route),
+	}
+
+	for _, opt := range opts {
+		opt(d)
+	}
+
+	if err := d.upcasters.Validate(); err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
+
+// RegisterHandler registers a typed handler for the event name. Registering the
+// same event name twice replaces the previous handler.
+func RegisterHandler[T proto.Message](d *Dispatcher, eventName EventName, handler EventHandler[T]) {
+	var zero T
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.routes[eventName] = route{
+		newMessage: func() proto.Message {
+			return zero.ProtoReflect().New().Interface()
+		},
+		handle: func(ctx context.Context, msg proto.Message) error {
+			return handler(ctx, msg.(T))
+		},
+	}
+}
+
+// EventNames returns all event names with a registered handler
+func (d *Dispatcher) EventNames() []EventName {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	names := make([]EventName, 0, len(d.routes))
+	for name := range d.routes {
+		names = append(names, name)
+	}
+
+	return names
+}
+
+// Run consumes the queue with manual acknowledgements and dispatches the
+// deliveries on a fixed pool of workers until the context is cancelled or the
+// delivery channel closes. The prefetch matches the pool, so the broker never
+// hands out more deliveries than the workers can handle. Consumers that can
+// open channels consume the queue on a channel of its own, so the prefetch
+// does not apply to the other consumers of the shared channel.
+func (d *Dispatcher) Run(ctx context.Context, consumer boot.AMQPConsumer, queueName string) error {
+	if opener, ok := consumer.(boot.AMQPChannelOpener); ok {
+		channel, err := opener.OpenChannel()
+		if err != nil {
+			d.logger.Error("Cannot open a consumer channel", slog.String("queueName", queueName), slog.Any("error", err))
+			return err
+		}
+		defer channel.Close()
+
+		consumer = channel
+	}
+
+	if err := consumer.Qos(d.workers, 0, false); err != nil {
+		d.logger.Error("Cannot set the prefetch", slog.String("queueName", queueName), slog.Any("error", err))
+		return err
+	}
+
+	msgs, err := consumer.ConsumeWithContext(
+		ctx,
+		queueName, // queue
+		"",        // consumer
+		false,     // auto-ack
+		false,     // exclusive
+		false,     // no-local
+		false,     // no-wait
+		nil,       // args
+	)
+	if err != nil {
+		d.logger.Error("Cannot consume messages", slog.String("queueName", queueName), slog.Any("error", err))
+		return err
+	}
+
+	wg := new(sync.WaitGroup)
+	for i := 0; i < d.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for msg := range msgs {
+				_ = d.Dispatch(ctx, msg)
+			}
+		}()
+	}
+	wg.Wait()
+
+	return nil
+}
+
+// Dispatch upcasts, decodes, validates and routes a single delivery, then settles it.
+// Handler failures are requeued once and dead lettered when redelivered.
+func (d *Dispatcher) Dispatch(ctx context.Context, msg amqp091.Delivery) error {
+	eventName := deliveryEventName(msg)
+
+	event, handle, err := d.decode(eventName, msg.Headers, msg.Body)
+	if err != nil {
+		d.logger.Error("Dead lettering event", slog.String("eventName", eventName.String()), slog.Any("error", err))
+		d.settle(msg, msg.Reject(false))
+		return err
+	}
+
+	if err := handle(withMessageID(ctx, msg.MessageId), event); err != nil {
+		requeue := !msg.Redelivered
+		d.logger.Error(
+			"Event handler failed",
+			slog.String("eventName", eventName.String()),
+			slog.Bool("requeue", requeue),
+			slog.Any("error", err),
+		)
+		d.settle(msg, msg.Nack(false, requeue))
+		return err
+	}
+
+	d.settle(msg, msg.Ack(false))
+	return nil
+}
+
+// decode finds the route for the event, upcasts the payload to the
+// current version and returns the validated message
+func (d *Dispatcher) decode(eventName EventName, headers amqp091.Table, body []byte) (proto.Message, func(context.Context, proto.Message) error, error) {
+	d.mu.RLock()
+	r, ok := d.routes[eventName]
+	d.mu.RUnlock()
+
+	if !ok {
+		return nil, nil, fmt.Errorf("%w: %s", ErrUnknownEvent, eventName)
+	}
+
+	version, err := deliveryEventVersion(headers)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	body, err = d.upcasters.Upcast(eventName, version, body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	event := r.newMessage()
+	if err := proto.Unmarshal(body, event); err != nil {
+		return nil, nil, fmt.Errorf("%w: %w", ErrInvalidPayload, err)
+	}
+
+	if err := d.validator.Validate(event); err != nil {
+		return nil, nil, fmt.Errorf("%w: %w", ErrInvalidPayload, err)
+	}
+
+	return event, r.handle, nil
+}
+
+// settle logs acknowledgement failures
+func (d *Dispatcher) settle(msg amqp091.Delivery, err error) {
+	if err != nil {
+		d.logger.Error("Cannot settle delivery", slog.Uint64("deliveryTag", msg.DeliveryTag), slog.Any("error", err))
+	}
+}
+
+// deliveryEventName reads the event name from the message type
+// and falls back to the routing key
+func deliveryEventName(msg amqp091.Delivery) EventName {
+	if msg.Type != "" {
+		return EventName(msg.Type)
+	}
+
+	return EventName(msg.RoutingKey)
+}
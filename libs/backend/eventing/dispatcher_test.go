@@ -0,0 +1,256 @@
+package eventing
+
+import (
+	"context"
+	"errors"
+	boot "libs/backend/boot"
+	accountseventsv1 "libs/backend/proto-gen/go/accounts/accountsevents/v1"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+// recordingAcknowledger records how a delivery was settled
+type recordingAcknowledger struct {
+	acked    bool
+	rejected bool
+	nacked   bool
+	requeued bool
+}
+
+func (a *recordingAcknowledger) Ack(tag uint64, multiple bool) error {
+	a.acked = true
+	return nil
+}
+
+func (a *recordingAcknowledger) Nack(tag uint64, multiple, requeue bool) error {
+	a.nacked = true
+	a.requeued = requeue
+	return nil
+}
+
+func (a *recordingAcknowledger) Reject(tag uint64, requeue bool) error {
+	a.rejected = true
+	a.requeued = requeue
+	return nil
+}
+
+func newDelivery(t *testing.T, eventName EventName, event proto.Message) (amqp091.Delivery, *recordingAcknowledger) {
+	t.Helper()
+
+	body, err := proto.Marshal(event)
+	require.NoError(t, err)
+
+	ack := &recordingAcknowledger{}
+	return amqp091.Delivery{
+		Acknowledger: ack,
+		RoutingKey:   eventName.String(),
+		Body:         body,
+	}, ack
+}
+
+func TestDispatcher(t *testing.T) {
+	ctx := context.Background()
+	validEvent := &accountseventsv1.UserRegistered{
+		Username:     "user",
+		EmailAddress: "user@example.com",
+		CommonId:     "5f1d3c6e-52a7-4b43-a8b5-0c0a1f0f6f11",
+	}
+
+	newDispatcher := func(t *testing.T, handlerErr error) (*Dispatcher, *[]*accountseventsv1.UserRegistered) {
+		dispatcher, err := NewDispatcher(boot.NewSlogger())
+		require.NoError(t, err)
+
+		received := make([]*accountseventsv1.UserRegistered, 0)
+		RegisterUserRegisteredHandler(dispatcher, func(ctx context.Context, event *accountseventsv1.UserRegistered) error {
+			received = append(received, event)
+			return handlerErr
+		})
+
+		return dispatcher, &received
+	}
+
+	t.Run("routes valid events and acks", func(t *testing.T) {
+		dispatcher, received := newDispatcher(t, nil)
+		msg, ack := newDelivery(t, EventNameUserRegistered, validEvent)
+
+		require.NoError(t, dispatcher.Dispatch(ctx, msg))
+		require.Len(t, *received, 1)
+		assert.True(t, proto.Equal(validEvent, (*received)[0]))
+		assert.True(t, ack.acked)
+	})
+
+	t.Run("prefers the message type over the routing key", func(t *testing.T) {
+		dispatcher, received := newDispatcher(t, nil)
+		msg, ack := newDelivery(t, EventName("some.other.key"), validEvent)
+		msg.Type = EventNameUserRegistered.String()
+
+		require.NoError(t, dispatcher.Dispatch(ctx, msg))
+		assert.Len(t, *received, 1)
+		assert.True(t, ack.acked)
+	})
+
+	t.Run("passes the message id to the handler", func(t *testing.T) {
+		dispatcher, err := NewDispatcher(boot.NewSlogger())
+		require.NoError(t, err)
+
+		var messageID string
+		RegisterUserRegisteredHandler(dispatcher, func(ctx context.Context, _ *accountseventsv1.UserRegistered) error {
+			messageID, _ = MessageIDFromContext(ctx)
+			return nil
+		})
+
+		msg, _ := newDelivery(t, EventNameUserRegistered, validEvent)
+		msg.MessageId = "b8d6e1c2-9f0a-4d3b-8e7f-1a2b3c4d5e6f"
+
+		require.NoError(t, dispatcher.Dispatch(ctx, msg))
+		assert.Equal(t, msg.MessageId, messageID)
+	})
+
+	t.Run("dead letters unknown events", func(t *testing.T) {
+		dispatcher, received := newDispatcher(t, nil)
+		msg, ack := newDelivery(t, EventName("career-cue.auth.unknown"), validEvent)
+
+		assert.ErrorIs(t, dispatcher.Dispatch(ctx, msg), ErrUnknownEvent)
+		assert.Empty(t, *received)
+		assert.True(t, ack.rejected)
+		assert.False(t, ack.requeued)
+	})
+
+	t.Run("dead letters events failing validation", func(t *testing.T) {
+		dispatcher, received := newDispatcher(t, nil)
+		msg, ack := newDelivery(t, EventNameUserRegistered, &accountseventsv1.UserRegistered{EmailAddress: "not-an-email"})
+
+		assert.ErrorIs(t, dispatcher.Dispatch(ctx, msg), ErrInvalidPayload)
+		assert.Empty(t, *received)
+		assert.True(t, ack.rejected)
+		assert.False(t, ack.requeued)
+	})
+
+	t.Run("dead letters undecodable payloads", func(t *testing.T) {
+		dispatcher, received := newDispatcher(t, nil)
+		ack := &recordingAcknowledger{}
+		msg := amqp091.Delivery{Acknowledger: ack, RoutingKey: EventNameUserRegistered.String(), Body: []byte{0xff, 0xff}}
+
+		assert.ErrorIs(t, dispatcher.Dispatch(ctx, msg), ErrInvalidPayload)
+		assert.Empty(t, *received)
+		assert.True(t, ack.rejected)
+	})
+
+	t.Run("requeues handler failures once", func(t *testing.T) {
+		handlerErr := errors.New("accounts-api unavailable")
+		dispatcher, _ := newDispatcher(t, handlerErr)
+
+		msg, ack := newDelivery(t, EventNameUserRegistered, validEvent)
+		assert.ErrorIs(t, dispatcher.Dispatch(ctx, msg), handlerErr)
+		assert.True(t, ack.nacked)
+		assert.True(t, ack.requeued)
+
+		redelivered, ack := newDelivery(t, EventNameUserRegistered, validEvent)
+		redelivered.Redelivered = true
+		assert.ErrorIs(t, dispatcher.Dispatch(ctx, redelivered), handlerErr)
+		assert.True(t, ack.nacked)
+		assert.False(t, ack.requeued)
+	})
+}
+
+// fakeConsumer hands out the deliveries of its channel and records the prefetch
+type fakeConsumer struct {
+	prefetch int
+	msgs     chan amqp091.Delivery
+	closed   bool
+}
+
+func (c *fakeConsumer) Close() error {
+	c.closed = true
+	return nil
+}
+
+func (c *fakeConsumer) Qos(prefetchCount, prefetchSize int, global bool) error {
+	c.prefetch = prefetchCount
+	return nil
+}
+
+func (c *fakeConsumer) Consume(queue, consumer string, autoAck, exclusive, noLocal, noWait bool, args amqp091.Table) (<-chan amqp091.Delivery, error) {
+	return c.msgs, nil
+}
+
+func (c *fakeConsumer) ConsumeWithContext(ctx context.Context, queue, consumer string, autoAck, exclusive, noLocal, noWait bool, args amqp091.Table) (<-chan amqp091.Delivery, error) {
+	return c.msgs, nil
+}
+
+// fakeConnection is a shared consumer that opens a dedicated channel
+type fakeConnection struct {
+	*fakeConsumer
+	channel *fakeConsumer
+}
+
+func (c fakeConnection) OpenChannel() (boot.AMQPConsumerChannel, error) {
+	return c.channel, nil
+}
+
+func TestDispatcherRun(t *testing.T) {
+	ctx := context.Background()
+	validEvent := &accountseventsv1.UserRegistered{
+		Username:     "user",
+		EmailAddress: "user@example.com",
+		CommonId:     "5f1d3c6e-52a7-4b43-a8b5-0c0a1f0f6f11",
+	}
+
+	t.Run("handles deliveries on a bounded pool matching the prefetch", func(t *testing.T) {
+		dispatcher, err := NewDispatcher(boot.NewSlogger(), WithConcurrency(2))
+		require.NoError(t, err)
+
+		var mu sync.Mutex
+		running, maxRunning, handled := 0, 0, 0
+		RegisterUserRegisteredHandler(dispatcher, func(ctx context.Context, event *accountseventsv1.UserRegistered) error {
+			mu.Lock()
+			running++
+			maxRunning = max(maxRunning, running)
+			mu.Unlock()
+
+			time.Sleep(5 * time.Millisecond)
+
+			mu.Lock()
+			running--
+			handled++
+			mu.Unlock()
+			return nil
+		})
+
+		consumer := &fakeConsumer{msgs: make(chan amqp091.Delivery, 10)}
+		for i := 0; i < 10; i++ {
+			msg, _ := newDelivery(t, EventNameUserRegistered, validEvent)
+			consumer.msgs <- msg
+		}
+		close(consumer.msgs)
+
+		require.NoError(t, dispatcher.Run(ctx, consumer, "queue"))
+		assert.Equal(t, 2, consumer.prefetch)
+		assert.Equal(t, 10, handled)
+		assert.LessOrEqual(t, maxRunning, 2)
+	})
+	t.Run("consumes on a channel of its own", func(t *testing.T) {
+		dispatcher, err := NewDispatcher(boot.NewSlogger(), WithConcurrency(3))
+		require.NoError(t, err)
+		RegisterUserRegisteredHandler(dispatcher, func(ctx context.Context, event *accountseventsv1.UserRegistered) error {
+			return nil
+		})
+
+		connection := fakeConnection{
+			fakeConsumer: &fakeConsumer{},
+			channel:      &fakeConsumer{msgs: make(chan amqp091.Delivery)},
+		}
+		close(connection.channel.msgs)
+
+		require.NoError(t, dispatcher.Run(ctx, connection, "queue"))
+		assert.Zero(t, connection.prefetch)
+		assert.Equal(t, 3, connection.channel.prefetch)
+		assert.True(t, connection.channel.closed)
+	})
+}
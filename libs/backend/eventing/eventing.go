@@ -1,6 +1,12 @@
 package eventing
 
-import "fmt"
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/rabbitmq/amqp091-go"
+	"google.golang.org/protobuf/proto"
+)
 
 func Eventing(name string) string {
 	result := "Eventing " + name
@@ -24,11 +30,35 @@ func GetRoutingKeyPrefix(domainName string) string {
 	return fmt.Sprintf("%s.%s", CareerCueEventPrefix, domainName)
 }
 
+// GetDomainRoutingKey returns the routing key matching every event of the domain
+func GetDomainRoutingKey(domainName string) string {
+	return GetRoutingKeyPrefix(domainName) + ".#"
+}
+
 // GetEventName will construct the event name from the app name, domain and specific event name
 func GetEventName(domain, eventName string) string {
 	return fmt.Sprintf("%s.%s.%s", CareerCueEventPrefix, domain, eventName)
 }
 
+// NewProtoPublishing encodes the event as a persistent protobuf message
+// carrying its event name and schema version. The message id is unique per
+// event and kept by the outbox, so consumers can deduplicate redeliveries.
+func NewProtoPublishing(eventName EventName, version EventVersion, event proto.Message) (amqp091.Publishing, error) {
+	b, err := proto.Marshal(event)
+	if err != nil {
+		return amqp091.Publishing{}, fmt.Errorf("cannot marshal %s event: %w", eventName, err)
+	}
+
+	return amqp091.Publishing{
+		MessageId:    uuid.NewString(),
+		ContentType:  "application/x-protobuf",
+		Type:         eventName.String(),
+		Headers:      version.Headers(),
+		DeliveryMode: amqp091.Persistent,
+		Body:         b,
+	}, nil
+}
+
 // EventBuilder is an interface for building events and event infrastructure
 type EventBuilder interface {
 	CreateExchange() EventBuilder
@@ -0,0 +1,57 @@
+package eventing
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// NewMetadataStruct converts user metadata to the Struct of the events. Values
+// a Struct cannot hold directly, such as typed slices, typed maps and times,
+// are converted through their JSON representation. Empty metadata is nil.
+func NewMetadataStruct(metadata map[string]any) (*structpb.Struct, error) {
+	if len(metadata) == 0 {
+		return nil, nil
+	}
+
+	fields := make(map[string]*structpb.Value, len(metadata))
+	for key, val := range metadata {
+		value, err := newMetadataValue(val)
+		if err != nil {
+			return nil, fmt.Errorf("cannot convert metadata %q: %w", key, err)
+		}
+		fields[key] = value
+	}
+
+	return &structpb.Struct{Fields: fields}, nil
+}
+
+// MetadataFromStruct converts the Struct of the events to user metadata, the
+// numbers are float64 as in JSON
+func MetadataFromStruct(metadata *structpb.Struct) map[string]any {
+	if metadata == nil {
+		return make(map[string]any)
+	}
+
+	return metadata.AsMap()
+}
+
+// newMetadataValue converts the value, falling back to its JSON representation
+func newMetadataValue(val any) (*structpb.Value, error) {
+	if value, err := structpb.NewValue(val); err == nil {
+		return value, nil
+	}
+
+	b, err := json.Marshal(val)
+	if err != nil {
+		return nil, err
+	}
+
+	var decoded any
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		return nil, err
+	}
+
+	return structpb.NewValue(decoded)
+}
@@ -0,0 +1,48 @@
+package eventing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetadataStruct(t *testing.T) {
+	t.Run("converts values of any type", func(t *testing.T) {
+		metadata, err := NewMetadataStruct(map[string]any{
+			"plan":        "pro",
+			"seats":       3,
+			"beta":        true,
+			"tags":        []string{"a", "b"},
+			"limits":      map[string]int{"projects": 5},
+			"preferences": map[string]any{"theme": "dark"},
+			"signedUpAt":  time.Date(2024, 12, 3, 15, 15, 0, 0, time.UTC),
+			"referrer":    nil,
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, map[string]any{
+			"plan":        "pro",
+			"seats":       float64(3),
+			"beta":        true,
+			"tags":        []any{"a", "b"},
+			"limits":      map[string]any{"projects": float64(5)},
+			"preferences": map[string]any{"theme": "dark"},
+			"signedUpAt":  "2024-12-03T15:15:00Z",
+			"referrer":    nil,
+		}, MetadataFromStruct(metadata))
+	})
+
+	t.Run("keeps empty metadata empty", func(t *testing.T) {
+		metadata, err := NewMetadataStruct(nil)
+		require.NoError(t, err)
+		assert.Nil(t, metadata)
+		assert.Equal(t, map[string]any{}, MetadataFromStruct(metadata))
+	})
+
+	t.Run("fails for values without a JSON representation", func(t *testing.T) {
+		_, err := NewMetadataStruct(map[string]any{"callback": func() {}})
+		assert.ErrorContains(t, err, `"callback"`)
+	})
+}
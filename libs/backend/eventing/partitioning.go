@@ -0,0 +1,245 @@
+package eventing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	boot "libs/backend/boot"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/rabbitmq/amqp091-go"
+)
+
+const (
+	// CommonIDHeader carries the common id of the user the event belongs to,
+	// partitioned queues hash on it to keep the events of a user in order
+	CommonIDHeader = "x-common-id"
+
+	// ExchangeKindConsistentHash routes every message to one bound queue by hashing a header
+	ExchangeKindConsistentHash = "x-consistent-hash"
+
+	// DefaultPartitions is the number of partition queues when none is configured
+	DefaultPartitions = 4
+
+	// PartitionHandlerAttempts is how often a partition retries a failed handler
+	// before dead lettering the event
+	PartitionHandlerAttempts = 3
+
+	// PartitionRetryDelay is the base delay between the attempts
+	PartitionRetryDelay = 200 * time.Millisecond
+)
+
+// ErrInvalidPartitionedQueue is returned when the partitioned queue cannot be declared
+var ErrInvalidPartitionedQueue = errors.New("invalid partitioned queue")
+
+// WithCommonID sets the partition key of the publishing. The headers are
+// copied so a shared version header table is never modified.
+func WithCommonID(msg amqp091.Publishing, commonID string) amqp091.Publishing {
+	headers := make(amqp091.Table, len(msg.Headers)+1)
+	for k, v := range msg.Headers {
+		headers[k] = v
+	}
+	headers[CommonIDHeader] = commonID
+
+	msg.Headers = headers
+	return msg
+}
+
+// PartitionedQueue spreads the events bound from an exchange over a fixed
+// number of queues by the common id header. Every partition is consumed
+// serially, so the events of one user are handled in publish order while
+// different users are handled in parallel.
+type PartitionedQueue struct {
+	// Name is the base name of the partition queues
+	Name string
+
+	// Exchange is the topic exchange the events are published to
+	Exchange string
+
+	// RoutingKeys are the events routed to the partitions
+	RoutingKeys []string
+
+	// Partitions is the number of partition queues, changing it moves users between partitions
+	Partitions int
+
+	// DeadletterExchange receives the rejected messages of every partition
+	DeadletterExchange   string
+	DeadletterRoutingKey string
+}
+
+// HashExchange returns the name of the consistent hash exchange in front of the partitions
+func (p PartitionedQueue) HashExchange() string {
+	return fmt.Sprintf("%s.partitions", p.Name)
+}
+
+// QueueNames returns the names of the partition queues
+func (p PartitionedQueue) QueueNames() []string {
+	names := make([]string, 0, p.partitions())
+	for i := 0; i < p.partitions(); i++ {
+		names = append(names, fmt.Sprintf("%s.p%d", p.Name, i))
+	}
+
+	return names
+}
+
+// partitions falls back to the default number of partitions
+func (p PartitionedQueue) partitions() int {
+	if p.Partitions < 1 {
+		return DefaultPartitions
+	}
+
+	return p.Partitions
+}
+
+// Declare creates the hash exchange, binds it to the exchange for every
+// routing key and creates the equally weighted partition queues
+func (p PartitionedQueue) Declare(registerer boot.AMQPRegisterer) error {
+	if p.Name == "" || p.Exchange == "" {
+		return fmt.Errorf("%w: name and exchange are required", ErrInvalidPartitionedQueue)
+	}
+
+	// LavinMQ reads x-hash-on and the RabbitMQ plugin reads hash-header
+	err := registerer.ExchangeDeclare(p.HashExchange(), ExchangeKindConsistentHash, true, false, false, false, amqp091.Table{
+		"x-hash-on":   CommonIDHeader,
+		"hash-header": CommonIDHeader,
+	})
+	if err != nil {
+		return fmt.Errorf("cannot declare partition exchange %s: %w", p.HashExchange(), err)
+	}
+
+	for _, routingKey := range p.RoutingKeys {
+		if err := registerer.ExchangeBind(p.HashExchange(), routingKey, p.Exchange, false, nil); err != nil {
+			return fmt.Errorf("cannot bind partition exchange %s: %w", p.HashExchange(), err)
+		}
+	}
+
+	// A single active consumer per partition keeps the events of a user in
+	// order when several replicas consume the queue
+	args := amqp091.Table{
+		"x-single-active-consumer": true,
+	}
+	if p.DeadletterExchange != "" {
+		args["x-dead-letter-exchange"] = p.DeadletterExchange
+		args["x-dead-letter-routing-key"] = p.DeadletterRoutingKey
+	}
+
+	for _, queueName := range p.QueueNames() {
+		if _, err := registerer.QueueDeclare(queueName, true, false, false, false, args); err != nil {
+			return fmt.Errorf("cannot declare partition queue %s: %w", queueName, err)
+		}
+
+		// The binding key is the weight of the partition
+		if err := registerer.QueueBind(queueName, "1", p.HashExchange(), false, nil); err != nil {
+			return fmt.Errorf("cannot bind partition queue %s: %w", queueName, err)
+		}
+	}
+
+	return nil
+}
+
+// RunPartitioned consumes every partition of the queue and dispatches the
+// deliveries of a partition one after another, until the context is
+// cancelled or the delivery channels close. Consumers that can open channels
+// consume every partition on a channel of its own with a prefetch of one, so
+// a partition never holds more deliveries than it handles.
+func (d *Dispatcher) RunPartitioned(ctx context.Context, consumer boot.AMQPConsumer, queue PartitionedQueue) error {
+	partitions := make([]<-chan amqp091.Delivery, 0, queue.partitions())
+	for _, queueName := range queue.QueueNames() {
+		partitionConsumer := consumer
+		if opener, ok := consumer.(boot.AMQPChannelOpener); ok {
+			channel, err := opener.OpenChannel()
+			if err != nil {
+				d.logger.Error("Cannot open a consumer channel", slog.String("queueName", queueName), slog.Any("error", err))
+				return err
+			}
+			defer channel.Close()
+
+			partitionConsumer = channel
+		}
+
+		if err := partitionConsumer.Qos(1, 0, false); err != nil {
+			d.logger.Error("Cannot set the prefetch", slog.String("queueName", queueName), slog.Any("error", err))
+			return err
+		}
+
+		msgs, err := partitionConsumer.ConsumeWithContext(
+			ctx,
+			queueName, // queue
+			"",        // consumer
+			false,     // auto-ack
+			false,     // exclusive
+			false,     // no-local
+			false,     // no-wait
+			nil,       // args
+		)
+		if err != nil {
+			d.logger.Error("Cannot consume messages", slog.String("queueName", queueName), slog.Any("error", err))
+			return err
+		}
+
+		partitions = append(partitions, msgs)
+	}
+
+	d.logger.Info("Consuming partitioned queue", slog.String("queueName", queue.Name), slog.Int("partitions", len(partitions)))
+
+	// Handle the messages of each partition in order
+	wg := new(sync.WaitGroup)
+	for _, msgs := range partitions {
+		wg.Add(1)
+		go func(msgs <-chan amqp091.Delivery) {
+			defer wg.Done()
+
+			for msg := range msgs {
+				_ = d.dispatchInOrder(ctx, msg)
+			}
+		}(msgs)
+	}
+	wg.Wait()
+
+	return nil
+}
+
+// dispatchInOrder dispatches like Dispatch, but retries failed handlers in
+// place instead of requeueing, since a requeued event would be handled after
+// the later events of the same user
+func (d *Dispatcher) dispatchInOrder(ctx context.Context, msg amqp091.Delivery) error {
+	eventName := deliveryEventName(msg)
+
+	event, handle, err := d.decode(eventName, msg.Headers, msg.Body)
+	if err != nil {
+		d.logger.Error("Dead lettering event", slog.String("eventName", eventName.String()), slog.Any("error", err))
+		d.settle(msg, msg.Reject(false))
+		return err
+	}
+
+	ctx = withMessageID(ctx, msg.MessageId)
+	for attempt := 1; ; attempt++ {
+		err := handle(ctx, event)
+		if err == nil {
+			d.settle(msg, msg.Ack(false))
+			return nil
+		}
+
+		d.logger.Error(
+			"Event handler failed",
+			slog.String("eventName", eventName.String()),
+			slog.Int("attempt", attempt),
+			slog.Any("error", err),
+		)
+
+		if attempt >= PartitionHandlerAttempts {
+			d.settle(msg, msg.Reject(false))
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			// Leave the event at the head of the partition for the next consumer
+			d.settle(msg, msg.Nack(false, true))
+			return ctx.Err()
+		case <-time.After(PartitionRetryDelay * time.Duration(attempt)):
+		}
+	}
+}
@@ -0,0 +1,223 @@
+package eventing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	boot "libs/backend/boot"
+	"libs/backend/boot/amqptest"
+	accountseventsv1 "libs/backend/proto-gen/go/accounts/accountsevents/v1"
+	accountsDomain "libs/backend/proto-gen/go/accounts/domain"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func newPartitionedBroker(t *testing.T) (*amqptest.Broker, PartitionedQueue) {
+	t.Helper()
+
+	broker := amqptest.NewBroker()
+	t.Cleanup(func() { _ = broker.Close() })
+
+	setup := NewAccountsEventSetup(broker, boot.NewSlogger())
+	setup.
+		CreateExchange().
+		CreateDeadletter().
+		Complete()
+
+	queue := PartitionedQueue{
+		Name:                 "accounts-worker-account-lifecycle",
+		Exchange:             AccountsExchange,
+		RoutingKeys:          []string{GetAccountLifecycleRoutingKey()},
+		Partitions:           4,
+		DeadletterExchange:   AccountsDeadletterExchange,
+		DeadletterRoutingKey: AccountsDeadletterRoutingKey,
+	}
+	require.NoError(t, queue.Declare(broker))
+
+	return broker, queue
+}
+
+func publishAccountEvent(t *testing.T, broker *amqptest.Broker, commonID string, eventName EventName, event proto.Message) {
+	t.Helper()
+
+	msg, err := NewProtoPublishing(eventName, InitialEventVersion, event)
+	require.NoError(t, err)
+	require.NoError(t, broker.PublishWithConfirm(context.Background(), AccountsExchange, eventName.String(), true, WithCommonID(msg, commonID)))
+}
+
+func TestPartitionedQueueDeclare(t *testing.T) {
+	broker, queue := newPartitionedBroker(t)
+
+	assert.True(t, broker.HasExchange(queue.HashExchange(), ExchangeKindConsistentHash))
+	assert.Equal(t, []string{
+		"accounts-worker-account-lifecycle.p0",
+		"accounts-worker-account-lifecycle.p1",
+		"accounts-worker-account-lifecycle.p2",
+		"accounts-worker-account-lifecycle.p3",
+	}, queue.QueueNames())
+
+	for _, queueName := range queue.QueueNames() {
+		assert.True(t, broker.IsBound(queueName, "1", queue.HashExchange()))
+		assert.Equal(t, AccountsDeadletterExchange, broker.QueueArgs(queueName)["x-dead-letter-exchange"])
+		assert.Equal(t, true, broker.QueueArgs(queueName)["x-single-active-consumer"])
+	}
+
+	// Every event of a user lands in the same partition
+	commonID := "0b7f7c52-5a2c-4c4e-9b1e-8c1c3f3f1a01"
+	publishAccountEvent(t, broker, commonID, EventNameAccountCreated, &accountseventsv1.AccountCreated{Account: &accountsDomain.Account{CommonId: commonID}})
+	publishAccountEvent(t, broker, commonID, EventNameAccountSoftDeleted, &accountseventsv1.AccountSoftDeleted{CommonId: commonID})
+
+	for _, queueName := range queue.QueueNames() {
+		length := broker.QueueLength(queueName)
+		assert.True(t, length == 0 || length == 2, "%s has %d messages", queueName, length)
+	}
+
+	assert.ErrorIs(t, PartitionedQueue{}.Declare(broker), ErrInvalidPartitionedQueue)
+}
+
+func TestWithCommonID(t *testing.T) {
+	headers := AccountCreatedVersion.Headers()
+	msg := WithCommonID(amqp091.Publishing{Headers: headers}, "common-id")
+
+	assert.Equal(t, "common-id", msg.Headers[CommonIDHeader])
+	assert.Equal(t, int32(AccountCreatedVersion), msg.Headers[EventVersionHeader])
+	assert.NotContains(t, headers, CommonIDHeader)
+}
+
+func TestRunPartitioned(t *testing.T) {
+	t.Run("handles the events of a user in publish order", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		broker, queue := newPartitionedBroker(t)
+
+		dispatcher, err := NewDispatcher(boot.NewSlogger())
+		require.NoError(t, err)
+
+		mu := new(sync.Mutex)
+		handled := make(map[string][]EventName)
+		done := make(chan struct{}, 64)
+		record := func(commonID string, eventName EventName) {
+			mu.Lock()
+			handled[commonID] = append(handled[commonID], eventName)
+			mu.Unlock()
+			done <- struct{}{}
+		}
+
+		RegisterAccountCreatedHandler(dispatcher, func(ctx context.Context, event *accountseventsv1.AccountCreated) error {
+			// Slow enough for the update to overtake it without partitions
+			time.Sleep(5 * time.Millisecond)
+			record(event.GetAccount().GetCommonId(), EventNameAccountCreated)
+			return nil
+		})
+		RegisterAccountUpdatedHandler(dispatcher, func(ctx context.Context, event *accountseventsv1.AccountUpdated) error {
+			record(event.GetAccount().GetCommonId(), EventNameAccountUpdated)
+			return nil
+		})
+
+		go func() {
+			_ = dispatcher.RunPartitioned(ctx, broker, queue)
+		}()
+
+		users := 8
+		for i := 0; i < users; i++ {
+			commonID := fmt.Sprintf("0b7f7c52-5a2c-4c4e-9b1e-8c1c3f3f1a%02d", i)
+			account := &accountsDomain.Account{CommonId: commonID}
+			publishAccountEvent(t, broker, commonID, EventNameAccountCreated, &accountseventsv1.AccountCreated{Account: account})
+			publishAccountEvent(t, broker, commonID, EventNameAccountUpdated, &accountseventsv1.AccountUpdated{Account: account, UpdatedFields: []string{"username"}})
+		}
+
+		for i := 0; i < users*2; i++ {
+			select {
+			case <-done:
+			case <-time.After(2 * time.Second):
+				t.Fatal("events were not handled")
+			}
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		require.Len(t, handled, users)
+		for commonID, eventNames := range handled {
+			assert.Equal(t, []EventName{EventNameAccountCreated, EventNameAccountUpdated}, eventNames, commonID)
+		}
+	})
+
+	t.Run("consumes every partition on a channel of its own with the message id", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		broker, queue := newPartitionedBroker(t)
+		openChannels := broker.OpenChannels()
+
+		dispatcher, err := NewDispatcher(boot.NewSlogger())
+		require.NoError(t, err)
+
+		messageIDs := make(chan string, 1)
+		RegisterAccountSoftDeletedHandler(dispatcher, func(ctx context.Context, event *accountseventsv1.AccountSoftDeleted) error {
+			messageID, _ := MessageIDFromContext(ctx)
+			messageIDs <- messageID
+			return nil
+		})
+
+		go func() {
+			_ = dispatcher.RunPartitioned(ctx, broker, queue)
+		}()
+
+		require.Eventually(t, func() bool {
+			return broker.OpenChannels() == openChannels+len(queue.QueueNames())
+		}, 2*time.Second, 10*time.Millisecond)
+		assert.Zero(t, broker.Prefetch())
+
+		commonID := "0b7f7c52-5a2c-4c4e-9b1e-8c1c3f3f1a01"
+		publishAccountEvent(t, broker, commonID, EventNameAccountSoftDeleted, &accountseventsv1.AccountSoftDeleted{CommonId: commonID})
+
+		select {
+		case messageID := <-messageIDs:
+			assert.NotEmpty(t, messageID)
+		case <-time.After(2 * time.Second):
+			t.Fatal("event was not handled")
+		}
+	})
+
+	t.Run("retries failed handlers in place and dead letters them", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		broker, queue := newPartitionedBroker(t)
+
+		dispatcher, err := NewDispatcher(boot.NewSlogger())
+		require.NoError(t, err)
+
+		mu := new(sync.Mutex)
+		attempts := 0
+		RegisterAccountSoftDeletedHandler(dispatcher, func(ctx context.Context, event *accountseventsv1.AccountSoftDeleted) error {
+			mu.Lock()
+			defer mu.Unlock()
+
+			attempts++
+			return errors.New("accounts api unavailable")
+		})
+
+		go func() {
+			_ = dispatcher.RunPartitioned(ctx, broker, queue)
+		}()
+
+		commonID := "0b7f7c52-5a2c-4c4e-9b1e-8c1c3f3f1a01"
+		publishAccountEvent(t, broker, commonID, EventNameAccountSoftDeleted, &accountseventsv1.AccountSoftDeleted{CommonId: commonID})
+
+		require.Eventually(t, func() bool {
+			return broker.QueueLength(AccountsDeadletterQueue) == 1
+		}, 2*time.Second, 10*time.Millisecond)
+
+		mu.Lock()
+		defer mu.Unlock()
+		assert.Equal(t, PartitionHandlerAttempts, attempts)
+	})
+}
@@ -0,0 +1,201 @@
+package eventing
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	boot "libs/backend/boot"
+	"log/slog"
+	"time"
+
+	"github.com/rabbitmq/amqp091-go"
+)
+
+// DefaultReplayIdleTimeout ends a replay once the stream delivered nothing for the duration
+const DefaultReplayIdleTimeout = 5 * time.Second
+
+// ReplayOptions configures a replay of an event stream
+type ReplayOptions struct {
+	// Offset is where the replay starts, the zero value replays only new events
+	Offset boot.StreamOffset
+
+	// IdleTimeout ends the replay once the history was read, streams never
+	// end so the replay stops when no event arrived for the duration
+	IdleTimeout time.Duration
+
+	// Limit ends the replay after the number of events, zero replays everything
+	Limit int
+
+	// Prefetch is the number of unacknowledged stream deliveries
+	Prefetch int
+
+	// ContinueOnError keeps replaying when a handler fails instead of stopping
+	ContinueOnError bool
+}
+
+// ReplayResult summarizes a replay
+type ReplayResult struct {
+	// Handled is the number of events the handlers processed
+	Handled int
+
+	// Skipped is the number of events without a registered handler
+	Skipped int
+
+	// Failed is the number of events that could not be decoded or handled
+	Failed int
+
+	// LastOffset is the stream offset of the last event read, the next
+	// replay resumes from LastOffset + 1
+	LastOffset int64
+}
+
+// Replay feeds the historical events of the stream through the dispatcher
+// in stream order. Events without a handler are skipped, so a read model
+// only registers the events it is built from. Stream deliveries are always
+// acknowledged since acknowledgements do not remove messages from a stream.
+func (d *Dispatcher) Replay(ctx context.Context, consumer boot.AMQPConsumer, stream string, opts ReplayOptions) (ReplayResult, error) {
+	if opts.IdleTimeout <= 0 {
+		opts.IdleTimeout = DefaultReplayIdleTimeout
+	}
+
+	result := ReplayResult{LastOffset: -1}
+
+	// Stop consuming once the replay returns
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	msgs, err := boot.ConsumeStream(ctx, consumer, stream, opts.Offset, opts.Prefetch)
+	if err != nil {
+		return result, err
+	}
+
+	d.logger.Info("Replaying event stream", slog.String("stream", stream), slog.String("offset", opts.Offset.String()))
+
+	idle := time.NewTimer(opts.IdleTimeout)
+	defer idle.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return result, ctx.Err()
+		case <-idle.C:
+			d.logReplay(stream, result)
+			return result, nil
+		case msg, ok := <-msgs:
+			if !ok {
+				d.logReplay(stream, result)
+				return result, nil
+			}
+
+			if err := d.replay(ctx, msg, &result); err != nil && !opts.ContinueOnError {
+				return result, fmt.Errorf("cannot replay offset %d of %s: %w", result.LastOffset, stream, err)
+			}
+
+			if opts.Limit > 0 && result.Handled+result.Skipped+result.Failed >= opts.Limit {
+				d.logReplay(stream, result)
+				return result, nil
+			}
+
+			idle.Reset(opts.IdleTimeout)
+		}
+	}
+}
+
+// Follow feeds the events of the stream through the dispatcher from the
+// offset on, first the history and then every new event, until the context
+// is cancelled or the delivery channel closes. Unlike Replay a failing event
+// is logged and skipped, so one bad event does not stop the follower.
+func (d *Dispatcher) Follow(ctx context.Context, consumer boot.AMQPConsumer, stream string, offset boot.StreamOffset) error {
+	msgs, err := boot.ConsumeStream(ctx, consumer, stream, offset, 0)
+	if err != nil {
+		return err
+	}
+
+	d.logger.Info("Following event stream", slog.String("stream", stream), slog.String("offset", offset.String()))
+
+	result := ReplayResult{LastOffset: -1}
+	for msg := range msgs {
+		_ = d.replay(ctx, msg, &result)
+	}
+
+	d.logReplay(stream, result)
+	return nil
+}
+
+// replay dispatches a single stream delivery and records the outcome
+func (d *Dispatcher) replay(ctx context.Context, msg amqp091.Delivery, result *ReplayResult) error {
+	defer func() {
+		d.settle(msg, msg.Ack(false))
+	}()
+
+	if offset, ok := boot.DeliveryStreamOffset(msg); ok {
+		result.LastOffset = offset
+	}
+
+	eventName := deliveryEventName(msg)
+
+	event, handle, err := d.decode(eventName, msg.Headers, msg.Body)
+	if errors.Is(err, ErrUnknownEvent) {
+		result.Skipped++
+		return nil
+	}
+	if err != nil {
+		result.Failed++
+		d.logger.Error("Cannot decode replayed event", slog.String("eventName", eventName.String()), slog.Int64("offset", result.LastOffset), slog.Any("error", err))
+		return err
+	}
+
+	if err := handle(ctx, event); err != nil {
+		result.Failed++
+		d.logger.Error("Replayed event handler failed", slog.String("eventName", eventName.String()), slog.Int64("offset", result.LastOffset), slog.Any("error", err))
+		return err
+	}
+
+	result.Handled++
+	return nil
+}
+
+// logReplay logs the summary of a finished replay
+func (d *Dispatcher) logReplay(stream string, result ReplayResult) {
+	d.logger.Info(
+		"Replayed event stream",
+		slog.String("stream", stream),
+		slog.Int("handled", result.Handled),
+		slog.Int("skipped", result.Skipped),
+		slog.Int("failed", result.Failed),
+		slog.Int64("lastOffset", result.LastOffset),
+	)
+}
+
+// ParseReplayFlags reads the stream and replay options of a replay command:
+//
+//	-stream authEventStream -from first|last|next|<offset>|<RFC 3339 time> -idle 5s -limit 0 -continue-on-error
+func ParseReplayFlags(name string, args []string) (string, ReplayOptions, error) {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	stream := fs.String("stream", "", fmt.Sprintf("stream to replay, e.g. %s or %s", AuthEventStream, AccountsEventStream))
+	from := fs.String("from", boot.StreamOffsetFirst, "offset to start from: first, last, next, a numeric offset or an RFC 3339 time")
+	idle := fs.Duration("idle", DefaultReplayIdleTimeout, "stop once no event arrived for the duration")
+	limit := fs.Int("limit", 0, "stop after the number of events, 0 replays everything")
+	continueOnError := fs.Bool("continue-on-error", false, "keep replaying when a handler fails")
+
+	if err := fs.Parse(args); err != nil {
+		return "", ReplayOptions{}, err
+	}
+
+	if *stream == "" {
+		return "", ReplayOptions{}, errors.New("stream is required")
+	}
+
+	offset, err := boot.ParseStreamOffset(*from)
+	if err != nil {
+		return "", ReplayOptions{}, err
+	}
+
+	return *stream, ReplayOptions{
+		Offset:          offset,
+		IdleTimeout:     *idle,
+		Limit:           *limit,
+		ContinueOnError: *continueOnError,
+	}, nil
+}
@@ -0,0 +1,158 @@
+package eventing
+
+import (
+	"context"
+	"errors"
+	boot "libs/backend/boot"
+	"libs/backend/boot/amqptest"
+	accountseventsv1 "libs/backend/proto-gen/go/accounts/accountsevents/v1"
+	accountsDomain "libs/backend/proto-gen/go/accounts/domain"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const replayIdleTimeout = 50 * time.Millisecond
+
+func newReplayBroker(t *testing.T) *amqptest.Broker {
+	t.Helper()
+
+	broker := amqptest.NewBroker()
+	t.Cleanup(func() { _ = broker.Close() })
+
+	setup := NewAccountsEventSetup(broker, boot.NewSlogger())
+	setup.
+		CreateExchange().
+		CreateStream().
+		Complete()
+
+	commonIDs := []string{"user-1", "user-2", "user-3"}
+	for _, commonID := range commonIDs {
+		publishAccountEvent(t, broker, commonID, EventNameAccountCreated, &accountseventsv1.AccountCreated{Account: &accountsDomain.Account{CommonId: commonID}})
+	}
+	publishAccountEvent(t, broker, "user-1", EventNameAccountSoftDeleted, &accountseventsv1.AccountSoftDeleted{CommonId: "user-1"})
+
+	return broker
+}
+
+func newReplayDispatcher(t *testing.T, handler EventHandler[*accountseventsv1.AccountCreated]) *Dispatcher {
+	t.Helper()
+
+	dispatcher, err := NewDispatcher(boot.NewSlogger())
+	require.NoError(t, err)
+	RegisterAccountCreatedHandler(dispatcher, handler)
+
+	return dispatcher
+}
+
+func TestReplayFromFirst(t *testing.T) {
+	broker := newReplayBroker(t)
+	assert.Equal(t, 4, broker.QueueLength(AccountsEventStream))
+
+	var replayed []string
+	dispatcher := newReplayDispatcher(t, func(_ context.Context, event *accountseventsv1.AccountCreated) error {
+		replayed = append(replayed, event.GetAccount().GetCommonId())
+		return nil
+	})
+
+	result, err := dispatcher.Replay(context.Background(), broker, AccountsEventStream, ReplayOptions{
+		Offset:      boot.NewNamedStreamOffset(boot.StreamOffsetFirst),
+		IdleTimeout: replayIdleTimeout,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"user-1", "user-2", "user-3"}, replayed)
+	assert.Equal(t, ReplayResult{Handled: 3, Skipped: 1, LastOffset: 3}, result)
+
+	// Replays do not consume the stream
+	assert.Equal(t, 4, broker.QueueLength(AccountsEventStream))
+}
+
+func TestReplayFromOffsetWithLimit(t *testing.T) {
+	broker := newReplayBroker(t)
+
+	var replayed []string
+	dispatcher := newReplayDispatcher(t, func(_ context.Context, event *accountseventsv1.AccountCreated) error {
+		replayed = append(replayed, event.GetAccount().GetCommonId())
+		return nil
+	})
+
+	result, err := dispatcher.Replay(context.Background(), broker, AccountsEventStream, ReplayOptions{
+		Offset:      boot.NewStreamOffset(1),
+		IdleTimeout: replayIdleTimeout,
+		Limit:       1,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"user-2"}, replayed)
+	assert.Equal(t, int64(1), result.LastOffset)
+
+	// Resume after the last offset
+	result, err = dispatcher.Replay(context.Background(), broker, AccountsEventStream, ReplayOptions{
+		Offset:      boot.NewStreamOffset(result.LastOffset + 1),
+		IdleTimeout: replayIdleTimeout,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"user-2", "user-3"}, replayed)
+	assert.Equal(t, ReplayResult{Handled: 1, Skipped: 1, LastOffset: 3}, result)
+}
+
+func TestReplayHandlerError(t *testing.T) {
+	broker := newReplayBroker(t)
+	errHandler := errors.New("read model unavailable")
+
+	dispatcher := newReplayDispatcher(t, func(_ context.Context, event *accountseventsv1.AccountCreated) error {
+		if event.GetAccount().GetCommonId() == "user-2" {
+			return errHandler
+		}
+		return nil
+	})
+
+	opts := ReplayOptions{
+		Offset:      boot.NewNamedStreamOffset(boot.StreamOffsetFirst),
+		IdleTimeout: replayIdleTimeout,
+	}
+
+	result, err := dispatcher.Replay(context.Background(), broker, AccountsEventStream, opts)
+	require.ErrorIs(t, err, errHandler)
+	assert.Equal(t, ReplayResult{Handled: 1, Failed: 1, LastOffset: 1}, result)
+
+	opts.ContinueOnError = true
+	result, err = dispatcher.Replay(context.Background(), broker, AccountsEventStream, opts)
+	require.NoError(t, err)
+	assert.Equal(t, ReplayResult{Handled: 2, Skipped: 1, Failed: 1, LastOffset: 3}, result)
+}
+
+func TestReplayUnknownStream(t *testing.T) {
+	broker := amqptest.NewBroker()
+	t.Cleanup(func() { _ = broker.Close() })
+
+	dispatcher, err := NewDispatcher(boot.NewSlogger())
+	require.NoError(t, err)
+
+	_, err = dispatcher.Replay(context.Background(), broker, AccountsEventStream, ReplayOptions{IdleTimeout: replayIdleTimeout})
+	assert.Error(t, err)
+}
+
+func TestParseReplayFlags(t *testing.T) {
+	stream, opts, err := ParseReplayFlags("replay", []string{"-stream", AuthEventStream, "-from", "42", "-idle", "2s", "-limit", "10", "-continue-on-error"})
+	require.NoError(t, err)
+
+	assert.Equal(t, AuthEventStream, stream)
+	assert.Equal(t, boot.NewStreamOffset(42), opts.Offset)
+	assert.Equal(t, 2*time.Second, opts.IdleTimeout)
+	assert.Equal(t, 10, opts.Limit)
+	assert.True(t, opts.ContinueOnError)
+
+	// Replays start from the first offset by default
+	_, opts, err = ParseReplayFlags("replay", []string{"-stream", AccountsEventStream})
+	require.NoError(t, err)
+	assert.Equal(t, boot.NewNamedStreamOffset(boot.StreamOffsetFirst), opts.Offset)
+
+	_, _, err = ParseReplayFlags("replay", nil)
+	assert.Error(t, err)
+
+	_, _, err = ParseReplayFlags("replay", []string{"-stream", AccountsEventStream, "-from", "yesterday"})
+	assert.ErrorIs(t, err, boot.ErrInvalidStreamOffset)
+}
@@ -0,0 +1,60 @@
+package eventing
+
+import (
+	"context"
+	"libs/backend/boot"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// Scheduler publishes events into the normal exchanges at a later time.
+// The event name doubles as the routing key, like the direct publishers.
+type Scheduler struct {
+	scheduler boot.AMQPScheduler
+}
+
+// NewScheduler wraps the boot scheduler, which is nil when the service has no scheduler configured
+func NewScheduler(scheduler boot.AMQPScheduler) Scheduler {
+	return Scheduler{scheduler: scheduler}
+}
+
+// PublishAt schedules the event for the time. Scheduling the same key again
+// replaces the pending event, an empty key cannot be cancelled.
+func (s Scheduler) PublishAt(ctx context.Context, key string, at time.Time, exchange string, eventName EventName, version EventVersion, event proto.Message) error {
+	if s.scheduler == nil {
+		return boot.ErrSchedulerUnavailable
+	}
+
+	msg, err := NewProtoPublishing(eventName, version, event)
+	if err != nil {
+		return err
+	}
+
+	return s.scheduler.Schedule(ctx, boot.ScheduledPublishing{
+		Key:        key,
+		Exchange:   exchange,
+		RoutingKey: eventName.String(),
+		DeliverAt:  at,
+		Publishing: msg,
+	})
+}
+
+// PublishAfter schedules the event once the delay passed
+func (s Scheduler) PublishAfter(ctx context.Context, key string, delay time.Duration, exchange string, eventName EventName, version EventVersion, event proto.Message) error {
+	return s.PublishAt(ctx, key, time.Now().Add(delay), exchange, eventName, version, event)
+}
+
+// Cancel removes the pending event of the key and reports if one existed
+func (s Scheduler) Cancel(ctx context.Context, key string) (bool, error) {
+	if s.scheduler == nil {
+		return false, boot.ErrSchedulerUnavailable
+	}
+
+	return s.scheduler.Cancel(ctx, key)
+}
+
+// ScheduleKey builds a cancellation key from the event name and the entity identifier
+func ScheduleKey(eventName EventName, id string) string {
+	return eventName.String() + ":" + id
+}
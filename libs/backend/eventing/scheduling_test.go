@@ -0,0 +1,75 @@
+package eventing
+
+import (
+	"context"
+	boot "libs/backend/boot"
+	"libs/backend/boot/amqptest"
+	accountseventsv1 "libs/backend/proto-gen/go/accounts/accountsevents/v1"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestScheduler(t *testing.T) {
+	ctx := context.Background()
+
+	broker := amqptest.NewBroker()
+	defer broker.Close()
+
+	queueName := "accounts-purge"
+	setup := NewAccountsEventSetup(broker, boot.NewSlogger())
+	setup.
+		CreateExchange().
+		CreateDeadletter().
+		CreateQueue(queueName).
+		BindQueues([]string{GetAccountLifecycleRoutingKey()}).
+		Complete()
+
+	store := amqptest.NewScheduleStore()
+	bootScheduler := boot.NewScheduler(boot.NewSlogger(), store, broker, boot.SchedulerOptions{Enabled: true})
+	scheduler := NewScheduler(bootScheduler)
+
+	commonID := "b1f3c7a2-9f0e-4d3a-8c55-2f8e6c1d7a90"
+	key := ScheduleKey(EventNameAccountHardDeleted, commonID)
+	event := &accountseventsv1.AccountHardDeleted{CommonId: commonID}
+
+	// Due now, but replaced by the later schedule
+	require.NoError(t, scheduler.PublishAt(ctx, key, time.Now().Add(-time.Second), AccountsExchange, EventNameAccountHardDeleted, AccountHardDeletedVersion, event))
+	require.NoError(t, scheduler.PublishAfter(ctx, key, time.Hour, AccountsExchange, EventNameAccountHardDeleted, AccountHardDeletedVersion, event))
+
+	delivered, err := bootScheduler.DeliverDue(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, delivered)
+
+	cancelled, err := scheduler.Cancel(ctx, key)
+	require.NoError(t, err)
+	assert.True(t, cancelled)
+	assert.Empty(t, store.Pending())
+
+	require.NoError(t, scheduler.PublishAfter(ctx, key, 0, AccountsExchange, EventNameAccountHardDeleted, AccountHardDeletedVersion, event))
+	delivered, err = bootScheduler.DeliverDue(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, delivered)
+
+	messages := broker.Messages(queueName)
+	require.Len(t, messages, 1)
+	assert.Equal(t, EventNameAccountHardDeleted.String(), messages[0].RoutingKey)
+	assert.Equal(t, AccountHardDeletedVersion.Headers(), messages[0].Headers)
+
+	received := &accountseventsv1.AccountHardDeleted{}
+	require.NoError(t, proto.Unmarshal(messages[0].Body, received))
+	assert.Equal(t, commonID, received.GetCommonId())
+}
+
+func TestSchedulerUnavailable(t *testing.T) {
+	scheduler := NewScheduler(nil)
+
+	err := scheduler.PublishAfter(context.Background(), "key", time.Minute, AccountsExchange, EventNameAccountCreated, AccountCreatedVersion, &accountseventsv1.AccountCreated{})
+	assert.ErrorIs(t, err, boot.ErrSchedulerUnavailable)
+
+	_, err = scheduler.Cancel(context.Background(), "key")
+	assert.ErrorIs(t, err, boot.ErrSchedulerUnavailable)
+}
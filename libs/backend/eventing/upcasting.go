@@ -0,0 +1,219 @@
+package eventing
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+
+	"github.com/rabbitmq/amqp091-go"
+)
+
+const (
+	// EventVersionHeader carries the schema version of the published payload
+	EventVersionHeader = "x-event-version"
+
+	// InitialEventVersion is assumed for messages published without a version header
+	InitialEventVersion EventVersion = 1
+)
+
+// Upcasting Errors
+var (
+	ErrUnsupportedEventVersion = errors.New("unsupported event version")
+	ErrMissingUpcaster         = errors.New("missing upcaster for event version")
+)
+
+// EventVersion is the schema version of an event payload
+type EventVersion int
+
+// Headers returns the AMQP headers announcing the event version
+func (v EventVersion) Headers() amqp091.Table {
+	return amqp091.Table{EventVersionHeader: int32(v)}
+}
+
+// Upcaster transforms an encoded payload from one version to the next version
+type Upcaster func(payload []byte) ([]byte, error)
+
+// eventSchema tracks the current version of an event and how to reach it
+type eventSchema struct {
+	current   EventVersion
+	upcasters map[EventVersion]Upcaster
+}
+
+// UpcasterRegistry upgrades payloads of older event versions to the current
+// version so handlers only ever see the current message type
+type UpcasterRegistry struct {
+	mu     *sync.RWMutex
+	events map[EventName]*eventSchema
+}
+
+// NewUpcasterRegistry constructs a registry that knows every event schema in this package
+func NewUpcasterRegistry() *UpcasterRegistry {
+	r := NewEmptyUpcasterRegistry()
+	registerAuthEventSchemas(r)
+	registerAccountsEventSchemas(r)
+
+	return r
+}
+
+// NewEmptyUpcasterRegistry constructs a registry without any event schemas
+func NewEmptyUpcasterRegistry() *UpcasterRegistry {
+	return &UpcasterRegistry{
+		mu:     new(sync.RWMutex),
+		events: make(map[EventName]*eventSchema),
+	}
+}
+
+// RegisterEvent sets the current version of the event
+func (r *UpcasterRegistry) RegisterEvent(eventName EventName, current EventVersion) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	schema, ok := r.events[eventName]
+	if !ok {
+		schema = &eventSchema{upcasters: make(map[EventVersion]Upcaster)}
+		r.events[eventName] = schema
+	}
+
+	schema.current = current
+}
+
+// RegisterUpcaster registers the transformation from the version to the next version
+func (r *UpcasterRegistry) RegisterUpcaster(eventName EventName, from EventVersion, upcaster Upcaster) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	schema, ok := r.events[eventName]
+	if !ok {
+		return fmt.Errorf("event %s is not registered", eventName)
+	}
+
+	if from < InitialEventVersion || from >= schema.current {
+		return fmt.Errorf("%w: cannot upcast %s from version %d", ErrUnsupportedEventVersion, eventName, from)
+	}
+
+	schema.upcasters[from] = upcaster
+	return nil
+}
+
+// CurrentVersion returns the current version of the event
+func (r *UpcasterRegistry) CurrentVersion(eventName EventName) EventVersion {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if schema, ok := r.events[eventName]; ok {
+		return schema.current
+	}
+
+	return InitialEventVersion
+}
+
+// Versions returns every version of the event that can be dispatched, oldest first
+func (r *UpcasterRegistry) Versions(eventName EventName) []EventVersion {
+	current := r.CurrentVersion(eventName)
+
+	versions := make([]EventVersion, 0, current)
+	for v := InitialEventVersion; v <= current; v++ {
+		versions = append(versions, v)
+	}
+
+	return versions
+}
+
+// EventNames returns all registered events
+func (r *UpcasterRegistry) EventNames() []EventName {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	names := make([]EventName, 0, len(r.events))
+	for name := range r.events {
+		names = append(names, name)
+	}
+
+	return names
+}
+
+// Validate ensures every older version of every event has an upcaster
+func (r *UpcasterRegistry) Validate() error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for name, schema := range r.events {
+		for v := InitialEventVersion; v < schema.current; v++ {
+			if _, ok := schema.upcasters[v]; !ok {
+				return fmt.Errorf("%w: %s version %d", ErrMissingUpcaster, name, v)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Upcast applies upcasters in order until the payload is at the current version
+func (r *UpcasterRegistry) Upcast(eventName EventName, version EventVersion, payload []byte) ([]byte, error) {
+	r.mu.RLock()
+	schema, ok := r.events[eventName]
+	r.mu.RUnlock()
+
+	current := InitialEventVersion
+	if ok {
+		current = schema.current
+	}
+
+	if version < InitialEventVersion || version > current {
+		return nil, fmt.Errorf("%w: %s version %d, current version %d", ErrUnsupportedEventVersion, eventName, version, current)
+	}
+
+	for v := version; v < current; v++ {
+		r.mu.RLock()
+		upcaster, ok := schema.upcasters[v]
+		r.mu.RUnlock()
+
+		if !ok {
+			return nil, fmt.Errorf("%w: %s version %d", ErrMissingUpcaster, eventName, v)
+		}
+
+		upcasted, err := upcaster(payload)
+		if err != nil {
+			return nil, fmt.Errorf("cannot upcast %s from version %d: %w", eventName, v, err)
+		}
+		payload = upcasted
+	}
+
+	return payload, nil
+}
+
+// deliveryEventVersion reads the event version header and defaults to the initial version
+func deliveryEventVersion(headers amqp091.Table) (EventVersion, error) {
+	value, ok := headers[EventVersionHeader]
+	if !ok {
+		return InitialEventVersion, nil
+	}
+
+	switch v := value.(type) {
+	case int:
+		return EventVersion(v), nil
+	case int8:
+		return EventVersion(v), nil
+	case int16:
+		return EventVersion(v), nil
+	case int32:
+		return EventVersion(v), nil
+	case int64:
+		return EventVersion(v), nil
+	case uint8:
+		return EventVersion(v), nil
+	case uint16:
+		return EventVersion(v), nil
+	case uint32:
+		return EventVersion(v), nil
+	case string:
+		parsed, err := strconv.Atoi(v)
+		if err != nil {
+			return 0, fmt.Errorf("%w: %q", ErrUnsupportedEventVersion, v)
+		}
+		return EventVersion(parsed), nil
+	default:
+		return 0, fmt.Errorf("%w: header type %T", ErrUnsupportedEventVersion, value)
+	}
+}
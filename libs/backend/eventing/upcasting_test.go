@@ -0,0 +1,232 @@
+package eventing
+
+import (
+	"context"
+	"encoding/json"
+	boot "libs/backend/boot"
+	accountseventsv1 "libs/backend/proto-gen/go/accounts/accountsevents/v1"
+	accountsDomain "libs/backend/proto-gen/go/accounts/domain"
+	"testing"
+
+	"github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const testEventName EventName = "career-cue.test.user-registered"
+
+// legacy payloads of the synthetic test event
+type (
+	testEventV1 struct {
+		Email string `json:"email"`
+		Name  string `json:"name"`
+	}
+	testEventV2 struct {
+		Username     string `json:"username"`
+		EmailAddress string `json:"emailAddress"`
+		CommonID     string `json:"commonId"`
+	}
+)
+
+const testCommonID = "5f1d3c6e-52a7-4b43-a8b5-0c0a1f0f6f11"
+
+// newTestUpcasterRegistry registers a synthetic event with three versions:
+// v1 JSON, v2 JSON with renamed fields and v3 protobuf
+func newTestUpcasterRegistry(t *testing.T) *UpcasterRegistry {
+	t.Helper()
+
+	r := NewEmptyUpcasterRegistry()
+	r.RegisterEvent(testEventName, 3)
+
+	require.NoError(t, r.RegisterUpcaster(testEventName, 1, func(payload []byte) ([]byte, error) {
+		var v1 testEventV1
+		if err := json.Unmarshal(payload, &v1); err != nil {
+			return nil, err
+		}
+
+		return json.Marshal(testEventV2{Username: v1.Name, EmailAddress: v1.Email, CommonID: testCommonID})
+	}))
+	require.NoError(t, r.RegisterUpcaster(testEventName, 2, func(payload []byte) ([]byte, error) {
+		var v2 testEventV2
+		if err := json.Unmarshal(payload, &v2); err != nil {
+			return nil, err
+		}
+
+		return proto.Marshal(&accountseventsv1.UserRegistered{
+			Username:     v2.Username,
+			EmailAddress: v2.EmailAddress,
+			CommonId:     v2.CommonID,
+		})
+	}))
+
+	return r
+}
+
+func mustMarshal(t *testing.T, marshal func() ([]byte, error)) []byte {
+	t.Helper()
+
+	b, err := marshal()
+	require.NoError(t, err)
+	return b
+}
+
+func TestUpcasterRegistry(t *testing.T) {
+	r := newTestUpcasterRegistry(t)
+	require.NoError(t, r.Validate())
+
+	fixtures := map[EventVersion][]byte{
+		1: mustMarshal(t, func() ([]byte, error) {
+			return json.Marshal(testEventV1{Email: "user@example.com", Name: "user"})
+		}),
+		2: mustMarshal(t, func() ([]byte, error) {
+			return json.Marshal(testEventV2{Username: "user", EmailAddress: "user@example.com", CommonID: testCommonID})
+		}),
+		3: mustMarshal(t, func() ([]byte, error) {
+			return proto.Marshal(&accountseventsv1.UserRegistered{Username: "user", EmailAddress: "user@example.com", CommonId: testCommonID})
+		}),
+	}
+
+	dispatcher, err := NewDispatcher(boot.NewSlogger(), WithUpcasterRegistry(r))
+	require.NoError(t, err)
+
+	var handled *accountseventsv1.UserRegistered
+	RegisterHandler(dispatcher, testEventName, func(ctx context.Context, event *accountseventsv1.UserRegistered) error {
+		handled = event
+		return nil
+	})
+
+	for _, version := range r.Versions(testEventName) {
+		payload, ok := fixtures[version]
+		require.True(t, ok, "missing fixture for version %d", version)
+
+		ack := &recordingAcknowledger{}
+		handled = nil
+		require.NoError(t, dispatcher.Dispatch(context.Background(), amqp091.Delivery{
+			Acknowledger: ack,
+			Type:         testEventName.String(),
+			Headers:      version.Headers(),
+			Body:         payload,
+		}))
+
+		assert.True(t, ack.acked, "version %d", version)
+		require.NotNil(t, handled, "version %d", version)
+		assert.Equal(t, "user", handled.Username)
+		assert.Equal(t, "user@example.com", handled.EmailAddress)
+		assert.Equal(t, testCommonID, handled.CommonId)
+	}
+
+	t.Run("dead letters unsupported versions", func(t *testing.T) {
+		for _, version := range []EventVersion{0, 4} {
+			ack := &recordingAcknowledger{}
+			err := dispatcher.Dispatch(context.Background(), amqp091.Delivery{
+				Acknowledger: ack,
+				Type:         testEventName.String(),
+				Headers:      version.Headers(),
+				Body:         fixtures[3],
+			})
+
+			assert.ErrorIs(t, err, ErrUnsupportedEventVersion)
+			assert.True(t, ack.rejected)
+			assert.False(t, ack.requeued)
+		}
+	})
+
+	t.Run("rejects missing upcasters", func(t *testing.T) {
+		r := NewEmptyUpcasterRegistry()
+		r.RegisterEvent(testEventName, 2)
+
+		assert.ErrorIs(t, r.Validate(), ErrMissingUpcaster)
+		_, err := NewDispatcher(boot.NewSlogger(), WithUpcasterRegistry(r))
+		assert.ErrorIs(t, err, ErrMissingUpcaster)
+	})
+
+	t.Run("defaults to the initial version", func(t *testing.T) {
+		version, err := deliveryEventVersion(nil)
+		require.NoError(t, err)
+		assert.Equal(t, InitialEventVersion, version)
+	})
+}
+
+// registeredEventFixtures holds a valid payload for every version of every event
+// registered in this package. Adding a version without a fixture fails the tests.
+var registeredEventFixtures = map[EventName]map[EventVersion]proto.Message{
+	EventNameUserRegistered: {
+		1: &accountseventsv1.UserRegistered{Username: "user", EmailAddress: "user@example.com", CommonId: testCommonID},
+	},
+	EventNameSessionsRevoked: {
+		1: &accountseventsv1.SessionsRevoked{CommonId: testCommonID, TokensIssuedBefore: timestamppb.Now(), ExpiresAt: timestamppb.Now()},
+	},
+	EventNameUserLoggedIn: {
+		1: &accountseventsv1.UserLoggedIn{UserId: "auth0|user-1", LoggedInAt: timestamppb.Now(), Log: &accountseventsv1.Auth0Log{LogId: "90020241203151500000000000000000000000000000000001"}},
+	},
+	EventNameUserEmailVerified: {
+		1: &accountseventsv1.UserEmailVerified{UserId: "auth0|user-1", VerifiedAt: timestamppb.Now(), Log: &accountseventsv1.Auth0Log{LogId: "90020241203151500000000000000000000000000000000001"}},
+	},
+	EventNameUserPasswordChanged: {
+		1: &accountseventsv1.UserPasswordChanged{UserId: "auth0|user-1", ChangedAt: timestamppb.Now(), Log: &accountseventsv1.Auth0Log{LogId: "90020241203151500000000000000000000000000000000001"}},
+	},
+	EventNameUserBlocked: {
+		1: &accountseventsv1.UserBlocked{UserId: "auth0|user-1", BlockedAt: timestamppb.Now(), Log: &accountseventsv1.Auth0Log{LogId: "90020241203151500000000000000000000000000000000001"}},
+	},
+	EventNameUserDeletedInIdP: {
+		1: &accountseventsv1.UserDeletedInIdP{UserId: "auth0|user-1", DeletedAt: timestamppb.Now(), Log: &accountseventsv1.Auth0Log{LogId: "90020241203151500000000000000000000000000000000001"}},
+	},
+	EventNameAccountCreated: {
+		1: &accountseventsv1.AccountCreated{Account: &accountsDomain.Account{CommonId: testCommonID}},
+	},
+	EventNameAccountUpdated: {
+		1: &accountseventsv1.AccountUpdated{Account: &accountsDomain.Account{CommonId: testCommonID}, UpdatedFields: []string{"username"}},
+	},
+	EventNameAccountSoftDeleted: {
+		1: &accountseventsv1.AccountSoftDeleted{CommonId: testCommonID},
+	},
+	EventNameAccountHardDeleted: {
+		1: &accountseventsv1.AccountHardDeleted{CommonId: testCommonID},
+	},
+	EventNameAccountRestored: {
+		1: &accountseventsv1.AccountRestored{Account: &accountsDomain.Account{CommonId: testCommonID}},
+	},
+}
+
+func TestRegisteredEventVersions(t *testing.T) {
+	r := NewUpcasterRegistry()
+	require.NoError(t, r.Validate())
+
+	for _, eventName := range r.EventNames() {
+		for _, version := range r.Versions(eventName) {
+			fixture, ok := registeredEventFixtures[eventName][version]
+			require.True(t, ok, "missing fixture for %s version %d", eventName, version)
+
+			payload, err := proto.Marshal(fixture)
+			require.NoError(t, err)
+
+			upcasted, err := r.Upcast(eventName, version, payload)
+			require.NoError(t, err, "%s version %d", eventName, version)
+			assert.NotEmpty(t, upcasted)
+		}
+	}
+
+	t.Run("dispatches every user registered version", func(t *testing.T) {
+		dispatcher, err := NewDispatcher(boot.NewSlogger())
+		require.NoError(t, err)
+		RegisterUserRegisteredHandler(dispatcher, func(ctx context.Context, event *accountseventsv1.UserRegistered) error {
+			return nil
+		})
+
+		for _, version := range r.Versions(EventNameUserRegistered) {
+			payload, err := proto.Marshal(registeredEventFixtures[EventNameUserRegistered][version])
+			require.NoError(t, err)
+
+			ack := &recordingAcknowledger{}
+			require.NoError(t, dispatcher.Dispatch(context.Background(), amqp091.Delivery{
+				Acknowledger: ack,
+				Type:         EventNameUserRegistered.String(),
+				Headers:      version.Headers(),
+				Body:         payload,
+			}))
+			assert.True(t, ack.acked)
+		}
+	})
+}
@@ -0,0 +1,73 @@
+package httpauth
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/auth0/go-jwt-middleware/v2/validator"
+)
+
+// Assertion of the proper interfaces
+var _ Validator = (*APIKeyValidator)(nil)
+
+// APIKeyPrincipal is the account and the grants of a verified API key
+type APIKeyPrincipal struct {
+	KeyID    string
+	CommonID string
+	Scopes   []string
+
+	// ExpiresAt is zero for keys that never expire
+	ExpiresAt time.Time
+}
+
+// APIKeyVerifier verifies an API key against the stored keys, rejecting
+// unknown, revoked and expired keys
+type APIKeyVerifier interface {
+	VerifyAPIKey(ctx context.Context, key string) (APIKeyPrincipal, error)
+}
+
+// APIKeyVerifierFunc is a function verifying API keys
+type APIKeyVerifierFunc func(ctx context.Context, key string) (APIKeyPrincipal, error)
+
+// VerifyAPIKey calls the function
+func (f APIKeyVerifierFunc) VerifyAPIKey(ctx context.Context, key string) (APIKeyPrincipal, error) {
+	return f(ctx, key)
+}
+
+// APIKeyValidator validates the keys of the ApiKey authorization scheme and
+// returns the same claims as the JWTValidator, so handlers authorize API
+// keys like the tokens of their account
+type APIKeyValidator struct {
+	verifier APIKeyVerifier
+}
+
+// NewAPIKeyValidator constructs the validator of the keys of the verifier
+func NewAPIKeyValidator(verifier APIKeyVerifier) *APIKeyValidator {
+	return &APIKeyValidator{verifier: verifier}
+}
+
+// EnsureValidToken verifies the API key, returning the *validator.ValidatedClaims
+func (v *APIKeyValidator) EnsureValidToken(ctx context.Context, key string) (interface{}, error) {
+	principal, err := v.verifier.VerifyAPIKey(ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidAPIKey, err)
+	}
+
+	registered := validator.RegisteredClaims{
+		Subject: "apikey|" + principal.KeyID,
+	}
+	if !principal.ExpiresAt.IsZero() {
+		registered.Expiry = principal.ExpiresAt.Unix()
+	}
+
+	return &validator.ValidatedClaims{
+		RegisteredClaims: registered,
+		CustomClaims: &CustomClaims{
+			Scope:     strings.Join(principal.Scopes, " "),
+			GrantType: APIKeyGrantType,
+			CommonID:  principal.CommonID,
+		},
+	}, nil
+}
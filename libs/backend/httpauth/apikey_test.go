@@ -0,0 +1,171 @@
+package httpauth_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"libs/backend/boot"
+	"libs/backend/httpauth"
+	"libs/backend/httpauth/authtest"
+	accountsapiv1 "libs/backend/proto-gen/go/accounts/accountsapi/v1"
+	"libs/backend/proto-gen/go/accounts/accountsapi/v1/accountsapiv1connect"
+
+	"connectrpc.com/connect"
+	"github.com/auth0/go-jwt-middleware/v2/validator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	apiKey         = "cc_0123456789ab_secret"
+	apiKeyCommonID = "0b0d2f5e-7f55-4a4c-9f55-1bde1f4d1c11"
+)
+
+// claimsAccountHandler answers GetAccount when the caller owns the account
+type claimsAccountHandler struct {
+	accountsapiv1connect.UnimplementedAccountServiceHandler
+}
+
+func (claimsAccountHandler) GetAccount(ctx context.Context, req *connect.Request[accountsapiv1.GetAccountRequest]) (*connect.Response[accountsapiv1.GetAccountResponse], error) {
+	if err := httpauth.AuthorizeOwnerFromContext(ctx, req.Msg.GetCommonId()); err != nil {
+		return nil, connect.NewError(connect.CodePermissionDenied, err)
+	}
+
+	return connect.NewResponse(&accountsapiv1.GetAccountResponse{}), nil
+}
+
+func TestAPIKeyValidator(t *testing.T) {
+	ctx := context.Background()
+
+	verifier := httpauth.APIKeyVerifierFunc(func(_ context.Context, key string) (httpauth.APIKeyPrincipal, error) {
+		if key != apiKey {
+			return httpauth.APIKeyPrincipal{}, errors.New("unknown key")
+		}
+
+		return httpauth.APIKeyPrincipal{KeyID: "key", CommonID: apiKeyCommonID, Scopes: []string{"read:accounts"}}, nil
+	})
+
+	t.Run("returns the claims of the account", func(t *testing.T) {
+		claims, err := httpauth.NewAPIKeyValidator(verifier).EnsureValidToken(ctx, apiKey)
+		require.NoError(t, err)
+
+		ctx := httpauth.SetClaimsToContext(ctx, claims.(*validator.ValidatedClaims))
+		customClaims, err := httpauth.GetClaimsFromContext(ctx)
+		require.NoError(t, err)
+		assert.True(t, customClaims.IsAPIKey())
+		assert.False(t, customClaims.IsM2M())
+		assert.True(t, customClaims.HasScope("read:accounts"))
+		assert.Equal(t, apiKeyCommonID, customClaims.CommonID)
+	})
+
+	t.Run("rejects an unknown key", func(t *testing.T) {
+		_, err := httpauth.NewAPIKeyValidator(verifier).EnsureValidToken(ctx, "cc_unknown")
+		assert.ErrorIs(t, err, httpauth.ErrInvalidAPIKey)
+	})
+}
+
+func TestAuthInterceptorAPIKeys(t *testing.T) {
+	ctx := context.Background()
+
+	issuer, err := authtest.NewIssuer("https://issuer.test/", audience)
+	require.NoError(t, err)
+	defer issuer.Close()
+
+	jwtValidator, err := httpauth.NewJWTValidator(httpauth.WithTrustedIssuer(httpauth.TrustedIssuer{
+		IssuerURL: issuer.URL,
+		Audiences: []string{audience},
+		JWKSURL:   issuer.ServeJWKS(),
+	}))
+	require.NoError(t, err)
+
+	verifier := httpauth.APIKeyVerifierFunc(func(_ context.Context, key string) (httpauth.APIKeyPrincipal, error) {
+		if key != apiKey {
+			return httpauth.APIKeyPrincipal{}, errors.New("unknown key")
+		}
+
+		return httpauth.APIKeyPrincipal{KeyID: "key", CommonID: apiKeyCommonID, Scopes: []string{"read:accounts"}}, nil
+	})
+
+	newClient := func(t *testing.T, opts ...httpauth.AuthInterceptorOption) accountsapiv1connect.AccountServiceClient {
+		opts = append(opts, httpauth.WithPolicy(httpauth.DefaultPolicy()))
+		authInterceptor := httpauth.NewAuthInterceptor(boot.NewSlogger(), jwtValidator, opts...)
+
+		mux := http.NewServeMux()
+		mux.Handle(accountsapiv1connect.NewAccountServiceHandler(claimsAccountHandler{}, connect.WithInterceptors(authInterceptor.Incoming())))
+
+		server := httptest.NewServer(mux)
+		t.Cleanup(server.Close)
+
+		return accountsapiv1connect.NewAccountServiceClient(server.Client(), server.URL)
+	}
+
+	withAPIKey := func(key string, req connect.AnyRequest) {
+		req.Header().Set(httpauth.AuthorizationHeaderKey, httpauth.APIKeyScheme+" "+key)
+	}
+
+	client := newClient(t, httpauth.WithAPIKeyValidator(httpauth.NewAPIKeyValidator(verifier)))
+
+	t.Run("accepts a key of the account", func(t *testing.T) {
+		commonID := apiKeyCommonID
+		req := connect.NewRequest(&accountsapiv1.GetAccountRequest{CommonId: &commonID})
+		withAPIKey(apiKey, req)
+
+		_, err := client.GetAccount(ctx, req)
+		assert.NoError(t, err)
+	})
+
+	t.Run("denies the accounts of others", func(t *testing.T) {
+		commonID := "5e3c1b6a-1f3d-4c43-8f0e-9e6f7a1d2b3c"
+		req := connect.NewRequest(&accountsapiv1.GetAccountRequest{CommonId: &commonID})
+		withAPIKey(apiKey, req)
+
+		_, err := client.GetAccount(ctx, req)
+		assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+	})
+
+	t.Run("denies scopes the key was not granted", func(t *testing.T) {
+		req := connect.NewRequest(&accountsapiv1.DeleteAccountRequest{CommonId: apiKeyCommonID})
+		withAPIKey(apiKey, req)
+
+		_, err := client.DeleteAccount(ctx, req)
+		assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+	})
+
+	t.Run("denies machine procedures", func(t *testing.T) {
+		req := connect.NewRequest(&accountsapiv1.CreateAccountRequest{})
+		withAPIKey(apiKey, req)
+
+		_, err := client.CreateAccount(ctx, req)
+		assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+	})
+
+	t.Run("rejects an unknown key", func(t *testing.T) {
+		req := connect.NewRequest(&accountsapiv1.GetAccountRequest{})
+		withAPIKey("cc_unknown", req)
+
+		_, err := client.GetAccount(ctx, req)
+		assert.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+	})
+
+	t.Run("still accepts bearer tokens", func(t *testing.T) {
+		commonID := apiKeyCommonID
+		req := connect.NewRequest(&accountsapiv1.GetAccountRequest{CommonId: &commonID})
+		token, err := issuer.Sign(authtest.Claims{Subject: "auth0|user", Scope: "read:accounts", CommonID: commonID})
+		require.NoError(t, err)
+		req.Header().Set(httpauth.AuthorizationHeaderKey, "Bearer "+token)
+
+		_, err = client.GetAccount(ctx, req)
+		assert.NoError(t, err)
+	})
+
+	t.Run("rejects keys without an api key validator", func(t *testing.T) {
+		req := connect.NewRequest(&accountsapiv1.GetAccountRequest{})
+		withAPIKey(apiKey, req)
+
+		_, err := newClient(t).GetAccount(ctx, req)
+		assert.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+	})
+}
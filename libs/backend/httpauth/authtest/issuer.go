@@ -0,0 +1,108 @@
+// Package authtest provides an in-process token issuer that signs access
+// tokens and serves its key set so the httpauth validators can be tested
+// without Auth0.
+package authtest
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"os"
+	"time"
+
+	"libs/backend/httpauth/devissuer"
+
+	"gopkg.in/go-jose/go-jose.v2"
+)
+
+// Issuer signs access tokens with generated keys
+type Issuer struct {
+	URL      string
+	Audience string
+
+	issuer *devissuer.Issuer
+	server *httptest.Server
+}
+
+// Claims are the claims of a signed token, the zero values take the defaults of the issuer
+type Claims struct {
+	Subject     string
+	Audience    string
+	Scope       string
+	GrantType   string
+	CommonID    string
+	Roles       []string
+	Permissions []string
+	ExpiresIn   time.Duration
+	Issuer      string
+
+	// ID is the jti claim and IssuedAt the iat claim
+	ID       string
+	IssuedAt time.Time
+
+	// Algorithm is RS256 by default or ES256
+	Algorithm jose.SignatureAlgorithm
+}
+
+// NewIssuer generates the signing keys of an issuer with the url and audience
+func NewIssuer(issuerURL, audience string) (*Issuer, error) {
+	issuer, err := devissuer.NewIssuer(issuerURL, devissuer.WithAudiences(audience))
+	if err != nil {
+		return nil, err
+	}
+
+	return &Issuer{URL: issuer.URL(), Audience: audience, issuer: issuer}, nil
+}
+
+// JWKS returns the public key set of the issuer
+func (i *Issuer) JWKS() jose.JSONWebKeySet {
+	return i.issuer.JWKS()
+}
+
+// WriteJWKSFile writes the public key set to the path
+func (i *Issuer) WriteJWKSFile(path string) error {
+	data, err := json.Marshal(i.JWKS())
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, data, 0o600)
+}
+
+// ServeJWKS starts a server of the issuer and returns the url of its key set
+func (i *Issuer) ServeJWKS() string {
+	if i.server == nil {
+		i.server = httptest.NewServer(i.issuer.Handler())
+	}
+
+	return i.server.URL + devissuer.JWKSPath
+}
+
+// Close stops the server of the issuer
+func (i *Issuer) Close() {
+	if i.server != nil {
+		i.server.Close()
+	}
+}
+
+// Sign returns a signed access token with the claims
+func (i *Issuer) Sign(claims Claims) (string, error) {
+	token, err := i.issuer.Mint(devissuer.TokenRequest{
+		Subject:     claims.Subject,
+		Audience:    claims.Audience,
+		Scope:       claims.Scope,
+		Permissions: claims.Permissions,
+		Roles:       claims.Roles,
+		CommonID:    claims.CommonID,
+		GrantType:   claims.GrantType,
+		ExpiresIn:   claims.ExpiresIn,
+		Algorithm:   claims.Algorithm,
+		Issuer:      claims.Issuer,
+		ID:          claims.ID,
+		IssuedAt:    claims.IssuedAt,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return token.AccessToken, nil
+}
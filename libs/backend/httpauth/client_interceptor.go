@@ -0,0 +1,129 @@
+package httpauth
+
+import (
+	"context"
+
+	"connectrpc.com/connect"
+)
+
+// TokenSource provides the authorization header value of a machine token,
+// e.g. the M2M client credentials token of the service
+type TokenSource interface {
+	AuthorizationHeader(ctx context.Context) (string, error)
+}
+
+// TokenSourceFunc adapts a function to a TokenSource
+type TokenSourceFunc func(ctx context.Context) (string, error)
+
+// AuthorizationHeader calls the function
+func (f TokenSourceFunc) AuthorizationHeader(ctx context.Context) (string, error) {
+	return f(ctx)
+}
+
+// ClientAuthInterceptorOption configures the ClientAuthInterceptor
+type ClientAuthInterceptorOption func(*ClientAuthInterceptor)
+
+// WithTokenSource attaches a machine token to the requests without a caller token
+func WithTokenSource(tokenSource TokenSource) ClientAuthInterceptorOption {
+	return func(c *ClientAuthInterceptor) {
+		c.tokenSource = tokenSource
+	}
+}
+
+// ClientAuthInterceptor sets the authorization header of outgoing requests,
+// forwarding the token of the caller from the context or attaching a machine token
+type ClientAuthInterceptor struct {
+	tokenSource TokenSource
+}
+
+// Assertion of the proper interfaces
+var _ connect.Interceptor = ClientAuthInterceptor{}
+
+// NewClientAuthInterceptor constructs the interceptor of connectRPC clients
+func NewClientAuthInterceptor(opts ...ClientAuthInterceptorOption) ClientAuthInterceptor {
+	c := ClientAuthInterceptor{}
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	return c
+}
+
+// WrapUnary sets the authorization header of unary requests sent by the client
+func (c ClientAuthInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		if !req.Spec().IsClient || req.Header().Get(AuthorizationHeaderKey) != "" {
+			return next(ctx, req)
+		}
+
+		authHeader, err := c.authorizationHeader(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if authHeader != "" {
+			req.Header().Set(AuthorizationHeaderKey, authHeader)
+		}
+
+		return next(ctx, req)
+	}
+}
+
+// WrapStreamingClient sets the authorization header of streams opened by the client
+func (c ClientAuthInterceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return func(ctx context.Context, spec connect.Spec) connect.StreamingClientConn {
+		conn := next(ctx, spec)
+		if conn.RequestHeader().Get(AuthorizationHeaderKey) != "" {
+			return conn
+		}
+
+		// The headers are sent with the first message, errors surface there
+		authHeader, err := c.authorizationHeader(ctx)
+		if err != nil {
+			return &failedStreamingClientConn{StreamingClientConn: conn, err: err}
+		}
+		if authHeader != "" {
+			conn.RequestHeader().Set(AuthorizationHeaderKey, authHeader)
+		}
+
+		return conn
+	}
+}
+
+// WrapStreamingHandler leaves the streams handled by servers untouched
+func (c ClientAuthInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return next
+}
+
+// authorizationHeader returns the token of the caller, or a machine token
+func (c ClientAuthInterceptor) authorizationHeader(ctx context.Context) (string, error) {
+	if authHeader := GetAuthTokenFromContext(ctx); authHeader != "" {
+		return authHeader, nil
+	}
+
+	if c.tokenSource == nil {
+		return "", nil
+	}
+
+	authHeader, err := c.tokenSource.AuthorizationHeader(ctx)
+	if err != nil {
+		return "", connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	return authHeader, nil
+}
+
+// failedStreamingClientConn fails the stream the token could not be attached to
+type failedStreamingClientConn struct {
+	connect.StreamingClientConn
+	err error
+}
+
+// Send fails with the token error
+func (c *failedStreamingClientConn) Send(any) error {
+	return c.err
+}
+
+// Receive fails with the token error
+func (c *failedStreamingClientConn) Receive(any) error {
+	return c.err
+}
@@ -0,0 +1,102 @@
+package httpauth_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"libs/backend/boot"
+	"libs/backend/httpauth"
+	"libs/backend/httpauth/authtest"
+	accountsapiv1 "libs/backend/proto-gen/go/accounts/accountsapi/v1"
+	"libs/backend/proto-gen/go/accounts/accountsapi/v1/accountsapiv1connect"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientAuthInterceptor(t *testing.T) {
+	ctx := context.Background()
+
+	issuer, err := authtest.NewIssuer("https://issuer.test/", audience)
+	require.NoError(t, err)
+	defer issuer.Close()
+
+	jwtValidator, err := httpauth.NewJWTValidator(httpauth.WithTrustedIssuer(httpauth.TrustedIssuer{
+		IssuerURL: issuer.URL,
+		Audiences: []string{audience},
+		JWKSURL:   issuer.ServeJWKS(),
+	}))
+	require.NoError(t, err)
+
+	authInterceptor := httpauth.NewAuthInterceptor(boot.NewSlogger(), jwtValidator)
+	server := httptest.NewServer(http.NewServeMux())
+	defer server.Close()
+
+	mux := server.Config.Handler.(*http.ServeMux)
+	mux.Handle(accountsapiv1connect.NewAccountServiceHandler(
+		accountsapiv1connect.UnimplementedAccountServiceHandler{},
+		connect.WithInterceptors(authInterceptor.Incoming()),
+	))
+
+	newClient := func(opts ...httpauth.ClientAuthInterceptorOption) accountsapiv1connect.AccountServiceClient {
+		return accountsapiv1connect.NewAccountServiceClient(
+			server.Client(),
+			server.URL,
+			connect.WithInterceptors(httpauth.NewClientAuthInterceptor(opts...)),
+		)
+	}
+
+	machineToken, err := issuer.Sign(authtest.Claims{
+		Subject:   "client@clients",
+		Scope:     "create:accounts delete:accounts",
+		GrantType: httpauth.ClientCredentialsGrantType,
+	})
+	require.NoError(t, err)
+
+	tokenSource := httpauth.TokenSourceFunc(func(context.Context) (string, error) {
+		return "Bearer " + machineToken, nil
+	})
+
+	t.Run("sends no token without a caller or token source", func(t *testing.T) {
+		_, err := newClient().DeleteAccount(ctx, connect.NewRequest(&accountsapiv1.DeleteAccountRequest{}))
+		assert.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+	})
+
+	t.Run("forwards the token of the caller", func(t *testing.T) {
+		const commonID = "0b0d2f5e-7f55-4a4c-9f55-1bde1f4d1c11"
+
+		userToken, err := issuer.Sign(authtest.Claims{Subject: "auth0|user", Scope: "delete:accounts", CommonID: commonID})
+		require.NoError(t, err)
+
+		// A user token is denied on the machine only procedure, so the caller token was forwarded
+		callerCtx := httpauth.SetAuthTokenToContext(ctx, "Bearer "+userToken)
+		_, err = newClient(httpauth.WithTokenSource(tokenSource)).CreateAccount(callerCtx, connect.NewRequest(&accountsapiv1.CreateAccountRequest{}))
+		assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+	})
+
+	t.Run("attaches a machine token", func(t *testing.T) {
+		_, err := newClient(httpauth.WithTokenSource(tokenSource)).CreateAccount(ctx, connect.NewRequest(&accountsapiv1.CreateAccountRequest{}))
+		assert.Equal(t, connect.CodeUnimplemented, connect.CodeOf(err))
+	})
+
+	t.Run("keeps an explicit header", func(t *testing.T) {
+		req := connect.NewRequest(&accountsapiv1.CreateAccountRequest{})
+		req.Header().Set(httpauth.AuthorizationHeaderKey, "Bearer invalid")
+
+		_, err := newClient(httpauth.WithTokenSource(tokenSource)).CreateAccount(ctx, req)
+		assert.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+	})
+
+	t.Run("fails when the token source fails", func(t *testing.T) {
+		failing := httpauth.TokenSourceFunc(func(context.Context) (string, error) {
+			return "", errors.New("token endpoint unavailable")
+		})
+
+		_, err := newClient(httpauth.WithTokenSource(failing)).CreateAccount(ctx, connect.NewRequest(&accountsapiv1.CreateAccountRequest{}))
+		assert.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+	})
+}
@@ -3,4 +3,25 @@ package httpauth
 const (
 	// Used for headers to be sent and recieved via HTTP and ConnectRPC
 	AuthorizationHeaderKey = "Authorization"
+
+	// Authorization schemes of the access tokens and the API keys
+	BearerScheme = "Bearer"
+	APIKeyScheme = "ApiKey"
+
+	// Grant type claim of the tokens issued to machines
+	ClientCredentialsGrantType = "client-credentials"
+
+	// Grant type of the claims of API keys, which are never in a token
+	APIKeyGrantType = "api-key"
+
+	// Scope granting access to the resources of every account
+	AdminScope = "admin:accounts"
+
+	// Namespaced claim carrying the common id of the user's account,
+	// must match the json tag of CustomClaims.CommonID
+	CommonIDClaim = "https://career-cue.com/common_id"
+
+	// Namespaced claim carrying the roles of the user,
+	// must match the json tag of CustomClaims.Roles
+	RolesClaim = "https://career-cue.com/roles"
 )
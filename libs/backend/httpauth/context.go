@@ -2,6 +2,7 @@ package httpauth
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/auth0/go-jwt-middleware/v2/validator"
 )
@@ -12,29 +13,53 @@ type accessTokenClaimsKey struct{}
 // ClaimsKey will ensure the claims get stored and retrieved under the same key
 var ClaimsKey = accessTokenClaimsKey{}
 
+// subjectKey is used for obtaining the token subject from the context
+type subjectKey struct{}
+
+// tokenErrorKey is used for obtaining the token validation failure from the context
+type tokenErrorKey struct{}
+
+// anyAccountKey marks a context whose procedure the policy allows on any account
+type anyAccountKey struct{}
+
 // authMiddlewareContextKey ensures key is appened to and from context
 type authMiddlewareContextKey struct{}
 
 // AuthMiddlewareContextKey will be used to obtain and set context auth
 var AuthMiddlewareContextKey authMiddlewareContextKey = authMiddlewareContextKey{}
 
-// SetClaimsToContext will assign the custom claims to the conext
+// SetClaimsToContext will assign the custom claims and the subject to the conext
 func SetClaimsToContext(ctx context.Context, claims *validator.ValidatedClaims) context.Context {
+	ctx = context.WithValue(ctx, subjectKey{}, claims.RegisteredClaims.Subject)
 	return context.WithValue(ctx, ClaimsKey, claims.CustomClaims.(*CustomClaims))
 }
 
+// GetSubjectFromContext will pull the sub claim of the caller out of the context
+func GetSubjectFromContext(ctx context.Context) string {
+	subject, _ := ctx.Value(subjectKey{}).(string)
+	return subject
+}
+
 // GetClaimsFromContext will pull the custom claims out of the context
 func GetClaimsFromContext(ctx context.Context) (*CustomClaims, error) {
 	claims := ctx.Value(ClaimsKey)
 	customClaimsValidated, ok := claims.(*CustomClaims)
 
 	if !ok {
+		if tokenErr := GetTokenErrorFromContext(ctx); tokenErr != nil {
+			return nil, fmt.Errorf("%w: %w", ErrCustomClaimsNotValid, tokenErr)
+		}
 		return nil, ErrCustomClaimsNotValid
 	}
 
 	return customClaimsValidated, nil
 }
 
+// SetAuthTokenToContext will assign the authorization header value of the caller to the context
+func SetAuthTokenToContext(ctx context.Context, authHeader string) context.Context {
+	return context.WithValue(ctx, AuthMiddlewareContextKey, authHeader)
+}
+
 // GetAuthTokenFromContext will pull context value from middleware
 func GetAuthTokenFromContext(ctx context.Context) string {
 	val, ok := ctx.Value(AuthMiddlewareContextKey).(string)
@@ -44,3 +69,29 @@ func GetAuthTokenFromContext(ctx context.Context) string {
 
 	return val
 }
+
+// setAnyAccountGrantToContext marks that the policy allows the caller to
+// perform the procedure on the resources of any account
+func setAnyAccountGrantToContext(ctx context.Context) context.Context {
+	return context.WithValue(ctx, anyAccountKey{}, true)
+}
+
+// isAnyAccountGranted checks whether the policy allows the caller to perform
+// the procedure on the resources of any account
+func isAnyAccountGranted(ctx context.Context) bool {
+	granted, _ := ctx.Value(anyAccountKey{}).(bool)
+	return granted
+}
+
+// setTokenErrorToContext will assign the reason the bearer token of the caller
+// was rejected to the context
+func setTokenErrorToContext(ctx context.Context, err error) context.Context {
+	return context.WithValue(ctx, tokenErrorKey{}, fmt.Errorf("%w: %w", ErrTokenInvalid, err))
+}
+
+// GetTokenErrorFromContext will pull the reason the bearer token of the caller
+// was rejected out of the context, it wraps ErrTokenInvalid
+func GetTokenErrorFromContext(ctx context.Context) error {
+	err, _ := ctx.Value(tokenErrorKey{}).(error)
+	return err
+}
@@ -0,0 +1,188 @@
+package devissuer
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"gopkg.in/go-jose/go-jose.v2"
+)
+
+// Paths served by the issuer
+const (
+	JWKSPath      = "/.well-known/jwks.json"
+	DiscoveryPath = "/.well-known/openid-configuration"
+	TokenPath     = "/oauth/token"
+	DevTokenPath  = "/dev/token"
+)
+
+// clientCredentialsGrant is the OAuth2 grant type of machine tokens
+const clientCredentialsGrant = "client_credentials"
+
+// tokenRequestBody is the OAuth2 token request, sent as JSON or form values
+type tokenRequestBody struct {
+	GrantType    string `json:"grant_type"`
+	ClientID     string `json:"client_id"`
+	ClientSecret string `json:"client_secret"`
+	Audience     string `json:"audience"`
+}
+
+// devTokenRequestBody mints a user token without credentials
+type devTokenRequestBody struct {
+	Subject     string   `json:"sub"`
+	Audience    string   `json:"audience"`
+	Scope       string   `json:"scope"`
+	Permissions []string `json:"permissions"`
+	Roles       []string `json:"roles"`
+	CommonID    string   `json:"common_id"`
+	ExpiresIn   int64    `json:"expires_in"`
+	Algorithm   string   `json:"alg"`
+}
+
+// tokenResponseBody is the OAuth2 token response
+type tokenResponseBody struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int64  `json:"expires_in"`
+	Scope       string `json:"scope,omitempty"`
+}
+
+// errorResponseBody is the OAuth2 error response
+type errorResponseBody struct {
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// discoveryDocument is the subset of the OpenID configuration the validators read
+type discoveryDocument struct {
+	Issuer        string `json:"issuer"`
+	JWKSURI       string `json:"jwks_uri"`
+	TokenEndpoint string `json:"token_endpoint"`
+}
+
+// Handler serves the key set, the discovery document and the token endpoints
+func (i *Issuer) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET "+JWKSPath, i.handleJWKS)
+	mux.HandleFunc("GET "+DiscoveryPath, i.handleDiscovery)
+	mux.HandleFunc("POST "+TokenPath, i.handleToken)
+	mux.HandleFunc("POST "+DevTokenPath, i.handleDevToken)
+
+	return mux
+}
+
+// handleJWKS serves the public key set
+func (i *Issuer) handleJWKS(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, i.JWKS())
+}
+
+// handleDiscovery serves the endpoints of the issuer
+func (i *Issuer) handleDiscovery(w http.ResponseWriter, _ *http.Request) {
+	base := strings.TrimSuffix(i.url, "/")
+
+	writeJSON(w, http.StatusOK, discoveryDocument{
+		Issuer:        i.url,
+		JWKSURI:       base + JWKSPath,
+		TokenEndpoint: base + TokenPath,
+	})
+}
+
+// handleToken mints machine tokens with the client credentials grant
+func (i *Issuer) handleToken(w http.ResponseWriter, r *http.Request) {
+	var body tokenRequestBody
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") {
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid_request", err.Error())
+			return
+		}
+	} else {
+		if err := r.ParseForm(); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid_request", err.Error())
+			return
+		}
+		body = tokenRequestBody{
+			GrantType:    r.PostForm.Get("grant_type"),
+			ClientID:     r.PostForm.Get("client_id"),
+			ClientSecret: r.PostForm.Get("client_secret"),
+			Audience:     r.PostForm.Get("audience"),
+		}
+	}
+
+	// Clients may authenticate with basic auth instead of the body
+	if clientID, clientSecret, ok := r.BasicAuth(); ok {
+		body.ClientID, body.ClientSecret = clientID, clientSecret
+	}
+
+	if body.GrantType != clientCredentialsGrant {
+		writeError(w, http.StatusBadRequest, "unsupported_grant_type", "only client_credentials is supported")
+		return
+	}
+
+	token, err := i.MintClientCredentials(body.ClientID, body.ClientSecret, body.Audience)
+	i.writeToken(w, token, err)
+}
+
+// handleDevToken mints user tokens with the requested claims
+func (i *Issuer) handleDevToken(w http.ResponseWriter, r *http.Request) {
+	var body devTokenRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", err.Error())
+		return
+	}
+
+	if body.Subject == "" {
+		writeError(w, http.StatusBadRequest, "invalid_request", "sub is required")
+		return
+	}
+
+	if err := i.checkAudience(body.Audience); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", err.Error())
+		return
+	}
+
+	token, err := i.Mint(TokenRequest{
+		Subject:     body.Subject,
+		Audience:    body.Audience,
+		Scope:       body.Scope,
+		Permissions: body.Permissions,
+		Roles:       body.Roles,
+		CommonID:    body.CommonID,
+		ExpiresIn:   time.Duration(body.ExpiresIn) * time.Second,
+		Algorithm:   jose.SignatureAlgorithm(body.Algorithm),
+	})
+	i.writeToken(w, token, err)
+}
+
+// writeToken writes the token or the OAuth2 error of the minting failure
+func (i *Issuer) writeToken(w http.ResponseWriter, token Token, err error) {
+	switch {
+	case errors.Is(err, ErrUnknownClient):
+		writeError(w, http.StatusUnauthorized, "access_denied", err.Error())
+	case errors.Is(err, ErrUnknownAudience), errors.Is(err, ErrUnsupportedAlgorithm):
+		writeError(w, http.StatusBadRequest, "invalid_request", err.Error())
+	case err != nil:
+		writeError(w, http.StatusInternalServerError, "server_error", err.Error())
+	default:
+		writeJSON(w, http.StatusOK, tokenResponseBody{
+			AccessToken: token.AccessToken,
+			TokenType:   "Bearer",
+			ExpiresIn:   int64(token.ExpiresIn / time.Second),
+			Scope:       token.Scope,
+		})
+	}
+}
+
+// writeError writes an OAuth2 error response
+func writeError(w http.ResponseWriter, status int, code, description string) {
+	writeJSON(w, status, errorResponseBody{Error: code, ErrorDescription: description})
+}
+
+// writeJSON writes the value as a JSON response
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
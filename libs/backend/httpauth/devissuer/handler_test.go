@@ -0,0 +1,148 @@
+package devissuer_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"libs/backend/httpauth"
+	"libs/backend/httpauth/devissuer"
+
+	"github.com/auth0/go-jwt-middleware/v2/validator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const audience = "https://api.career-cue.test"
+
+// tokenResponse is the OAuth2 token response of the issuer
+type tokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int64  `json:"expires_in"`
+	Error       string `json:"error"`
+}
+
+func TestIssuerHandler(t *testing.T) {
+	ctx := context.Background()
+
+	// The issuer url is the url of the server, known once it listens
+	var handler http.Handler
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handler.ServeHTTP(w, r)
+	}))
+	defer server.Close()
+
+	issuer, err := devissuer.NewIssuer(
+		server.URL,
+		devissuer.WithAudiences(audience),
+		devissuer.WithClient(devissuer.Client{ID: "accounts-worker", Secret: "secret", Scopes: []string{"create:accounts", "delete:accounts"}}),
+	)
+	require.NoError(t, err)
+	handler = issuer.Handler()
+
+	// The validator discovers the key set through the openid configuration of the issuer
+	jwtValidator, err := httpauth.NewJWTValidator(httpauth.WithTrustedIssuer(httpauth.TrustedIssuer{
+		IssuerURL:  httpauth.Auth0IssuerURL(server.URL),
+		Audiences:  []string{audience},
+		Algorithms: []validator.SignatureAlgorithm{validator.RS256, validator.ES256},
+	}))
+	require.NoError(t, err)
+
+	validate := func(t *testing.T, accessToken string) *httpauth.CustomClaims {
+		claims, err := jwtValidator.EnsureValidToken(ctx, accessToken)
+		require.NoError(t, err)
+
+		return claims.(*validator.ValidatedClaims).CustomClaims.(*httpauth.CustomClaims)
+	}
+
+	post := func(t *testing.T, path, contentType string, body []byte) (int, tokenResponse) {
+		res, err := server.Client().Post(server.URL+path, contentType, bytes.NewReader(body))
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		var token tokenResponse
+		require.NoError(t, json.NewDecoder(res.Body).Decode(&token))
+		return res.StatusCode, token
+	}
+
+	t.Run("mints machine tokens from json", func(t *testing.T) {
+		body, _ := json.Marshal(map[string]string{
+			"grant_type":    "client_credentials",
+			"client_id":     "accounts-worker",
+			"client_secret": "secret",
+			"audience":      audience,
+		})
+
+		status, token := post(t, devissuer.TokenPath, "application/json", body)
+		require.Equal(t, http.StatusOK, status)
+		assert.Equal(t, "Bearer", token.TokenType)
+		assert.Equal(t, int64(devissuer.DefaultTokenTTL.Seconds()), token.ExpiresIn)
+
+		claims := validate(t, token.AccessToken)
+		assert.True(t, claims.IsM2M())
+		assert.True(t, claims.HasScope("delete:accounts"))
+	})
+
+	t.Run("mints machine tokens from form values", func(t *testing.T) {
+		form := url.Values{
+			"grant_type":    {"client_credentials"},
+			"client_id":     {"accounts-worker"},
+			"client_secret": {"secret"},
+		}
+
+		status, token := post(t, devissuer.TokenPath, "application/x-www-form-urlencoded", []byte(form.Encode()))
+		require.Equal(t, http.StatusOK, status)
+		assert.True(t, validate(t, token.AccessToken).IsM2M())
+	})
+
+	t.Run("rejects a wrong secret", func(t *testing.T) {
+		body, _ := json.Marshal(map[string]string{
+			"grant_type":    "client_credentials",
+			"client_id":     "accounts-worker",
+			"client_secret": "wrong",
+		})
+
+		status, token := post(t, devissuer.TokenPath, "application/json", body)
+		assert.Equal(t, http.StatusUnauthorized, status)
+		assert.Equal(t, "access_denied", token.Error)
+	})
+
+	t.Run("rejects another grant", func(t *testing.T) {
+		status, token := post(t, devissuer.TokenPath, "application/json", []byte(`{"grant_type":"password"}`))
+		assert.Equal(t, http.StatusBadRequest, status)
+		assert.Equal(t, "unsupported_grant_type", token.Error)
+	})
+
+	t.Run("mints user tokens without credentials", func(t *testing.T) {
+		body, _ := json.Marshal(map[string]any{
+			"sub":         "auth0|dev",
+			"scope":       "read:accounts",
+			"permissions": []string{"read:registration"},
+			"roles":       []string{httpauth.RoleSupport},
+			"common_id":   "0b0d2f5e-7f55-4a4c-9f55-1bde1f4d1c11",
+			"alg":         "ES256",
+		})
+
+		status, token := post(t, devissuer.DevTokenPath, "application/json", body)
+		require.Equal(t, http.StatusOK, status)
+
+		claims := validate(t, token.AccessToken)
+		assert.False(t, claims.IsM2M())
+		assert.True(t, claims.HasScope("read:accounts"))
+		assert.True(t, claims.HasPermission("read:registration"))
+		assert.True(t, claims.HasRole(httpauth.RoleSupport))
+		assert.Equal(t, "0b0d2f5e-7f55-4a4c-9f55-1bde1f4d1c11", claims.CommonID)
+	})
+
+	t.Run("rejects an unknown audience", func(t *testing.T) {
+		status, token := post(t, devissuer.DevTokenPath, "application/json", []byte(`{"sub":"auth0|dev","audience":"https://other.test"}`))
+		assert.Equal(t, http.StatusBadRequest, status)
+		assert.True(t, strings.Contains(token.Error, "invalid_request"))
+	})
+}
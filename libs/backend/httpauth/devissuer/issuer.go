@@ -0,0 +1,309 @@
+// Package devissuer mints access tokens compatible with the httpauth
+// validators and the m2m client, so the platform runs locally without Auth0.
+// It must never be deployed as the issuer trusted by production services.
+package devissuer
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"libs/backend/httpauth"
+
+	"gopkg.in/go-jose/go-jose.v2"
+	"gopkg.in/go-jose/go-jose.v2/jwt"
+)
+
+// Defaults of the issuer
+const (
+	DefaultTokenTTL  = time.Hour
+	DefaultAlgorithm = jose.RS256
+)
+
+// Key ids of the signing keys in the key set
+const (
+	rsaKeyID = "dev-rs256"
+	ecKeyID  = "dev-es256"
+)
+
+// Errors of the token requests
+var (
+	ErrUnknownClient        = errors.New("unknown client or wrong secret")
+	ErrUnknownAudience      = errors.New("audience not served by the issuer")
+	ErrUnsupportedAlgorithm = errors.New("signing algorithm not supported")
+)
+
+// Client is a machine allowed to use the client credentials grant
+type Client struct {
+	ID     string
+	Secret string
+	Scopes []string
+}
+
+// TokenRequest are the claims of a minted token, the zero values take the
+// defaults of the issuer
+type TokenRequest struct {
+	Subject     string
+	Audience    string
+	Scope       string
+	Permissions []string
+	Roles       []string
+	CommonID    string
+	GrantType   string
+	ExpiresIn   time.Duration
+
+	// ID is the jti claim, a random id by default
+	ID string
+
+	// IssuedAt overrides the iat claim, which is the current time by default
+	IssuedAt time.Time
+
+	// Algorithm is RS256 or ES256
+	Algorithm jose.SignatureAlgorithm
+
+	// Issuer overrides the iss claim, e.g. to test untrusted issuers
+	Issuer string
+}
+
+// Token is a minted access token
+type Token struct {
+	// ID is the jti claim of the token, the id revocations refer to
+	ID          string
+	AccessToken string
+	ExpiresIn   time.Duration
+	Scope       string
+}
+
+// Option configures the Issuer
+type Option func(*Issuer)
+
+// WithAudiences sets the audiences tokens are minted for, the first is the default
+func WithAudiences(audiences ...string) Option {
+	return func(i *Issuer) {
+		i.audiences = audiences
+	}
+}
+
+// WithClient registers a machine for the client credentials grant
+func WithClient(client Client) Option {
+	return func(i *Issuer) {
+		i.clients[client.ID] = client
+	}
+}
+
+// WithAlgorithm sets the default signing algorithm
+func WithAlgorithm(algorithm jose.SignatureAlgorithm) Option {
+	return func(i *Issuer) {
+		i.algorithm = algorithm
+	}
+}
+
+// WithTokenTTL sets the default lifetime of the tokens
+func WithTokenTTL(ttl time.Duration) Option {
+	return func(i *Issuer) {
+		i.tokenTTL = ttl
+	}
+}
+
+// Issuer signs access tokens with generated RSA and EC keys
+type Issuer struct {
+	url       string
+	audiences []string
+	clients   map[string]Client
+	algorithm jose.SignatureAlgorithm
+	tokenTTL  time.Duration
+
+	rsaKey *rsa.PrivateKey
+	ecKey  *ecdsa.PrivateKey
+}
+
+// NewIssuer generates the signing keys of the issuer with the url, which
+// becomes the iss claim and must end with a slash like Auth0 issuers
+func NewIssuer(issuerURL string, opts ...Option) (*Issuer, error) {
+	if !strings.HasSuffix(issuerURL, "/") {
+		issuerURL += "/"
+	}
+
+	i := &Issuer{
+		url:       issuerURL,
+		clients:   make(map[string]Client),
+		algorithm: DefaultAlgorithm,
+		tokenTTL:  DefaultTokenTTL,
+	}
+	for _, opt := range opts {
+		opt(i)
+	}
+
+	if len(i.audiences) == 0 {
+		return nil, errors.New("issuer needs an audience")
+	}
+	if i.algorithm != jose.RS256 && i.algorithm != jose.ES256 {
+		return nil, ErrUnsupportedAlgorithm
+	}
+
+	var err error
+	if i.rsaKey, err = rsa.GenerateKey(rand.Reader, 2048); err != nil {
+		return nil, fmt.Errorf("cannot generate the rsa key: %w", err)
+	}
+	if i.ecKey, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader); err != nil {
+		return nil, fmt.Errorf("cannot generate the ec key: %w", err)
+	}
+
+	return i, nil
+}
+
+// URL returns the issuer url
+func (i *Issuer) URL() string {
+	return i.url
+}
+
+// Audience returns the default audience
+func (i *Issuer) Audience() string {
+	return i.audiences[0]
+}
+
+// JWKS returns the public key set of the issuer
+func (i *Issuer) JWKS() jose.JSONWebKeySet {
+	return jose.JSONWebKeySet{
+		Keys: []jose.JSONWebKey{
+			{Key: &i.rsaKey.PublicKey, KeyID: rsaKeyID, Algorithm: string(jose.RS256), Use: "sig"},
+			{Key: &i.ecKey.PublicKey, KeyID: ecKeyID, Algorithm: string(jose.ES256), Use: "sig"},
+		},
+	}
+}
+
+// Mint signs a token with the claims of the request, any audience is accepted
+// so tests can mint tokens the validators reject
+func (i *Issuer) Mint(req TokenRequest) (Token, error) {
+	audience := req.Audience
+	if audience == "" {
+		audience = i.Audience()
+	}
+
+	signer, err := i.signer(req.Algorithm)
+	if err != nil {
+		return Token{}, err
+	}
+
+	expiresIn := req.ExpiresIn
+	if expiresIn == 0 {
+		expiresIn = i.tokenTTL
+	}
+
+	issuer := req.Issuer
+	if issuer == "" {
+		issuer = i.url
+	}
+
+	id := req.ID
+	if id == "" {
+		id, err = newTokenID()
+		if err != nil {
+			return Token{}, err
+		}
+	}
+
+	now := req.IssuedAt
+	if now.IsZero() {
+		now = time.Now()
+	}
+	registered := jwt.Claims{
+		ID:       id,
+		Issuer:   issuer,
+		Subject:  req.Subject,
+		Audience: jwt.Audience{audience},
+		IssuedAt: jwt.NewNumericDate(now),
+		Expiry:   jwt.NewNumericDate(now.Add(expiresIn)),
+	}
+
+	custom := map[string]interface{}{}
+	if req.Scope != "" {
+		custom["scope"] = req.Scope
+	}
+	if req.GrantType != "" {
+		custom["gty"] = req.GrantType
+	}
+	if req.CommonID != "" {
+		custom[httpauth.CommonIDClaim] = req.CommonID
+	}
+	if len(req.Roles) > 0 {
+		custom[httpauth.RolesClaim] = req.Roles
+	}
+	if len(req.Permissions) > 0 {
+		custom["permissions"] = req.Permissions
+	}
+
+	accessToken, err := jwt.Signed(signer).Claims(registered).Claims(custom).CompactSerialize()
+	if err != nil {
+		return Token{}, fmt.Errorf("cannot sign the token: %w", err)
+	}
+
+	return Token{ID: id, AccessToken: accessToken, ExpiresIn: expiresIn, Scope: req.Scope}, nil
+}
+
+// newTokenID returns a random jti
+func newTokenID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("cannot generate the token id: %w", err)
+	}
+
+	return hex.EncodeToString(b), nil
+}
+
+// MintClientCredentials signs a machine token of the client like the client
+// credentials grant of Auth0
+func (i *Issuer) MintClientCredentials(clientID, clientSecret, audience string) (Token, error) {
+	client, ok := i.clients[clientID]
+	if !ok || client.Secret != clientSecret {
+		return Token{}, ErrUnknownClient
+	}
+	if err := i.checkAudience(audience); err != nil {
+		return Token{}, err
+	}
+
+	return i.Mint(TokenRequest{
+		Subject:   client.ID + "@clients",
+		Audience:  audience,
+		Scope:     strings.Join(client.Scopes, " "),
+		GrantType: httpauth.ClientCredentialsGrantType,
+	})
+}
+
+// checkAudience ensures the audience is served by the issuer, an empty audience is the default
+func (i *Issuer) checkAudience(audience string) error {
+	if audience != "" && !slices.Contains(i.audiences, audience) {
+		return ErrUnknownAudience
+	}
+
+	return nil
+}
+
+// signer returns the signer of the algorithm, or of the default algorithm
+func (i *Issuer) signer(algorithm jose.SignatureAlgorithm) (jose.Signer, error) {
+	if algorithm == "" {
+		algorithm = i.algorithm
+	}
+
+	var key jose.JSONWebKey
+	switch algorithm {
+	case jose.RS256:
+		key = jose.JSONWebKey{Key: i.rsaKey, KeyID: rsaKeyID}
+	case jose.ES256:
+		key = jose.JSONWebKey{Key: i.ecKey, KeyID: ecKeyID}
+	default:
+		return nil, ErrUnsupportedAlgorithm
+	}
+
+	return jose.NewSigner(
+		jose.SigningKey{Algorithm: algorithm, Key: key},
+		(&jose.SignerOptions{}).WithType("JWT"),
+	)
+}
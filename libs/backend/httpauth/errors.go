@@ -11,3 +11,22 @@ var (
 var (
 	ErrCustomClaimsScopeEmpty = errors.New("empty custom claims scope")
 )
+
+// Token Validation Errors
+var (
+	ErrNoTrustedIssuers     = errors.New("no trusted issuers")
+	ErrUntrustedIssuer      = errors.New("token issuer not trusted")
+	ErrUnsupportedAlgorithm = errors.New("token signing algorithm not supported")
+	ErrAPIKeysNotAccepted   = errors.New("api keys not accepted")
+	ErrInvalidAPIKey        = errors.New("api key invalid")
+	ErrTokenRevoked         = errors.New("token revoked")
+	ErrTokenInvalid         = errors.New("token invalid")
+)
+
+// Authorization Errors
+var (
+	ErrMissingScope = errors.New("required scope not granted")
+	ErrM2MOnly      = errors.New("procedure restricted to machine to machine tokens")
+	ErrNotOwner     = errors.New("resource not owned by the caller")
+	ErrPolicyDenied = errors.New("action denied by policy")
+)
@@ -5,11 +5,15 @@ go 1.23
 require (
 	connectrpc.com/connect v1.17.0
 	github.com/auth0/go-jwt-middleware/v2 v2.2.2
+	github.com/stretchr/testify v1.10.0
+	google.golang.org/protobuf v1.36.1
+	gopkg.in/go-jose/go-jose.v2 v2.6.3
 )
 
 require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
 	golang.org/x/crypto v0.17.0 // indirect
 	golang.org/x/sync v0.8.0 // indirect
-	google.golang.org/protobuf v1.34.2 // indirect
-	gopkg.in/go-jose/go-jose.v2 v2.6.3 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
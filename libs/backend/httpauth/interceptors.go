@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"libs/backend/boot"
+	"log/slog"
+	"net/http"
 	"strings"
 
 	"connectrpc.com/connect"
@@ -17,57 +19,153 @@ const (
 type AuthInerceptor struct {
 	logger               boot.Logger
 	accessTokenValidator Validator
+	apiKeyValidator      Validator
+	rules                *procedureRules
+	policy               *Policy
+}
+
+// AuthInterceptorOption configures the AuthInerceptor
+type AuthInterceptorOption func(*AuthInerceptor)
+
+// WithPolicy authorizes the roles of the callers of procedures that declare
+// a policy target against the policy
+func WithPolicy(policy *Policy) AuthInterceptorOption {
+	return func(a *AuthInerceptor) {
+		a.policy = policy
+	}
+}
+
+// WithAPIKeyValidator accepts the API keys of the ApiKey authorization scheme
+// alongside the bearer tokens, without it API keys are rejected
+func WithAPIKeyValidator(apiKeyValidator Validator) AuthInterceptorOption {
+	return func(a *AuthInerceptor) {
+		a.apiKeyValidator = apiKeyValidator
+	}
 }
 
 // NewAuthInterceptor will intercept connectRPC requests and handle authentication
-func NewAuthInterceptor(logger boot.Logger) AuthInerceptor {
-	return AuthInerceptor{logger: logger, accessTokenValidator: NewAccessTokenValidator(logger)}
+// with the validator, which is built once and shared by every request
+func NewAuthInterceptor(logger boot.Logger, accessTokenValidator Validator, opts ...AuthInterceptorOption) AuthInerceptor {
+	a := AuthInerceptor{logger: logger, accessTokenValidator: accessTokenValidator, rules: &procedureRules{}}
+	for _, opt := range opts {
+		opt(&a)
+	}
+
+	return a
 }
 
-// Incoming will handle auth for incoming server requests
-func (a AuthInerceptor) Incoming() connect.UnaryInterceptorFunc {
-	interceptor := func(next connect.UnaryFunc) connect.UnaryFunc {
-		return connect.UnaryFunc(func(
-			ctx context.Context,
-			req connect.AnyRequest,
-		) (connect.AnyResponse, error) {
-			authTokenHeaderValue := req.Header().Get(AuthorizationHeaderKey)
-
-			// Check token in handlers.
-			if authTokenHeaderValue == "" {
-				return nil, connect.NewError(
-					connect.CodeUnauthenticated,
-					errors.New("no token provided"),
-				)
-			}
-
-			// Validate token length
-			authTokenValues := strings.Split(authTokenHeaderValue, " ")
-
-			if len(authTokenValues) != tokenValueLength {
-				return nil, connect.NewError(
-					connect.CodeUnauthenticated,
-					errors.New("token invalid"),
-				)
-			}
-
-			// Validate incoming token
-			accessToken := authTokenValues[1]
-			claims, err := a.accessTokenValidator.EnsureValidToken(ctx, accessToken)
-
-			// Validate claims
-			if err != nil {
-				return nil, connect.NewError(
-					connect.CodeUnauthenticated,
-					errors.New("token claims invalid"),
-				)
-			}
-
-			// Set the validated custom claims to the context
-			ctx = SetClaimsToContext(ctx, claims.(*validator.ValidatedClaims))
+// Assertion of the proper interfaces
+var _ connect.Interceptor = AuthInerceptor{}
+
+// Incoming will handle auth for incoming server requests of every stream type,
+// enforcing the access and required scopes the procedure declares in its proto options
+func (a AuthInerceptor) Incoming() connect.Interceptor {
+	return a
+}
 
+// WrapUnary authenticates unary requests handled by the server
+func (a AuthInerceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		if req.Spec().IsClient {
 			return next(ctx, req)
-		})
+		}
+
+		ctx, err := a.authenticate(ctx, req.Spec(), req.Header())
+		if err != nil {
+			return nil, err
+		}
+
+		return next(ctx, req)
+	}
+}
+
+// WrapStreamingClient leaves the streams of clients untouched
+func (a AuthInerceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+// WrapStreamingHandler authenticates client, server and bidi streams handled
+// by the server before any message is received
+func (a AuthInerceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		ctx, err := a.authenticate(ctx, conn.Spec(), conn.RequestHeader())
+		if err != nil {
+			return err
+		}
+
+		return next(ctx, conn)
 	}
-	return connect.UnaryInterceptorFunc(interceptor)
+}
+
+// authenticate validates the token of the request headers and authorizes the
+// claims against the procedure, returning the context with the claims and token
+func (a AuthInerceptor) authenticate(ctx context.Context, spec connect.Spec, header http.Header) (context.Context, error) {
+	rule := a.rules.get(spec.Procedure, spec.Schema)
+
+	// Public procedures are called without a token
+	if rule.IsPublic() {
+		return ctx, nil
+	}
+
+	authTokenHeaderValue := header.Get(AuthorizationHeaderKey)
+
+	// Check token in handlers.
+	if authTokenHeaderValue == "" {
+		return nil, connect.NewError(
+			connect.CodeUnauthenticated,
+			errors.New("no token provided"),
+		)
+	}
+
+	// Validate token length
+	authTokenValues := strings.Split(authTokenHeaderValue, " ")
+
+	if len(authTokenValues) != tokenValueLength {
+		return nil, connect.NewError(
+			connect.CodeUnauthenticated,
+			errors.New("token invalid"),
+		)
+	}
+
+	// API keys are validated by their own validator, any other scheme is a bearer token
+	tokenValidator := a.accessTokenValidator
+	if strings.EqualFold(authTokenValues[0], APIKeyScheme) {
+		if a.apiKeyValidator == nil {
+			return nil, connect.NewError(connect.CodeUnauthenticated, ErrAPIKeysNotAccepted)
+		}
+		tokenValidator = a.apiKeyValidator
+	}
+
+	// Validate incoming token
+	accessToken := authTokenValues[1]
+	claims, err := tokenValidator.EnsureValidToken(ctx, accessToken)
+
+	// Validate claims
+	if err != nil {
+		a.logger.Debug("Rejected access token", slog.Any("error", err))
+		return nil, connect.NewError(
+			connect.CodeUnauthenticated,
+			errors.New("token claims invalid"),
+		)
+	}
+
+	// Set the validated custom claims and the token, forwarded by client interceptors, to the context
+	ctx = SetClaimsToContext(ctx, claims.(*validator.ValidatedClaims))
+	ctx = SetAuthTokenToContext(ctx, authTokenHeaderValue)
+
+	// Authorize the claims against the procedure
+	customClaims, err := GetClaimsFromContext(ctx)
+	if err == nil {
+		err = rule.Authorize(customClaims, a.policy)
+	}
+	if err != nil {
+		return nil, connect.NewError(connect.CodePermissionDenied, err)
+	}
+
+	// Let the ownership checks of the handler know the policy covers any account
+	if rule.AllowsAnyAccount(customClaims, a.policy) {
+		ctx = setAnyAccountGrantToContext(ctx)
+	}
+
+	return ctx, nil
 }
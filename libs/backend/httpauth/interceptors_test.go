@@ -0,0 +1,244 @@
+package httpauth_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"libs/backend/boot"
+	"libs/backend/httpauth"
+	"libs/backend/httpauth/authtest"
+	accountsapiv1 "libs/backend/proto-gen/go/accounts/accountsapi/v1"
+	"libs/backend/proto-gen/go/accounts/accountsapi/v1/accountsapiv1connect"
+	inboundwebhooksapiv1 "libs/backend/proto-gen/go/webhooks/inboundwebhooksapi/v1"
+	"libs/backend/proto-gen/go/webhooks/inboundwebhooksapi/v1/inboundwebhooksapiv1connect"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthInterceptorIncoming(t *testing.T) {
+	ctx := context.Background()
+
+	issuer, err := authtest.NewIssuer("https://issuer.test/", audience)
+	require.NoError(t, err)
+	defer issuer.Close()
+
+	jwtValidator, err := httpauth.NewJWTValidator(httpauth.WithTrustedIssuer(httpauth.TrustedIssuer{
+		IssuerURL: issuer.URL,
+		Audiences: []string{audience},
+		JWKSURL:   issuer.ServeJWKS(),
+	}))
+	require.NoError(t, err)
+
+	authInterceptor := httpauth.NewAuthInterceptor(boot.NewSlogger(), jwtValidator, httpauth.WithPolicy(httpauth.DefaultPolicy()))
+	options := connect.WithInterceptors(authInterceptor.Incoming())
+
+	// The unimplemented handlers answer every request that passes the interceptor
+	mux := http.NewServeMux()
+	mux.Handle(accountsapiv1connect.NewAccountServiceHandler(accountsapiv1connect.UnimplementedAccountServiceHandler{}, options))
+	mux.Handle(inboundwebhooksapiv1connect.NewInboundWebhooksAuthServiceHandler(inboundwebhooksapiv1connect.UnimplementedInboundWebhooksAuthServiceHandler{}, options))
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	accountsClient := accountsapiv1connect.NewAccountServiceClient(server.Client(), server.URL)
+	webhooksClient := inboundwebhooksapiv1connect.NewInboundWebhooksAuthServiceClient(server.Client(), server.URL)
+
+	withToken := func(t *testing.T, claims authtest.Claims, req connect.AnyRequest) {
+		token, err := issuer.Sign(claims)
+		require.NoError(t, err)
+		req.Header().Set(httpauth.AuthorizationHeaderKey, "Bearer "+token)
+	}
+
+	t.Run("requires a token", func(t *testing.T) {
+		_, err := accountsClient.GetAccount(ctx, connect.NewRequest(&accountsapiv1.GetAccountRequest{}))
+		assert.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+	})
+
+	t.Run("allows public procedures without a token", func(t *testing.T) {
+		_, err := webhooksClient.UserRegistered(ctx, connect.NewRequest(&inboundwebhooksapiv1.UserRegisteredRequest{}))
+		assert.Equal(t, connect.CodeUnimplemented, connect.CodeOf(err))
+	})
+
+	t.Run("denies a token without the required scope", func(t *testing.T) {
+		req := connect.NewRequest(&accountsapiv1.DeleteAccountRequest{})
+		withToken(t, authtest.Claims{Subject: "auth0|user", Scope: "read:accounts"}, req)
+
+		_, err := accountsClient.DeleteAccount(ctx, req)
+		assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+	})
+
+	t.Run("allows a token with the required scope", func(t *testing.T) {
+		req := connect.NewRequest(&accountsapiv1.DeleteAccountRequest{})
+		withToken(t, authtest.Claims{Subject: "auth0|user", Scope: "read:accounts delete:accounts"}, req)
+
+		_, err := accountsClient.DeleteAccount(ctx, req)
+		assert.Equal(t, connect.CodeUnimplemented, connect.CodeOf(err))
+	})
+
+	t.Run("denies a user token on machine to machine procedures", func(t *testing.T) {
+		req := connect.NewRequest(&accountsapiv1.CreateAccountRequest{})
+		withToken(t, authtest.Claims{Subject: "auth0|user", Scope: "create:accounts"}, req)
+
+		_, err := accountsClient.CreateAccount(ctx, req)
+		assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+	})
+
+	t.Run("allows a machine token on machine to machine procedures", func(t *testing.T) {
+		req := connect.NewRequest(&accountsapiv1.CreateAccountRequest{})
+		withToken(t, authtest.Claims{
+			Subject:   "client@clients",
+			Scope:     "create:accounts",
+			GrantType: httpauth.ClientCredentialsGrantType,
+		}, req)
+
+		_, err := accountsClient.CreateAccount(ctx, req)
+		assert.Equal(t, connect.CodeUnimplemented, connect.CodeOf(err))
+	})
+
+	t.Run("denies a role the policy does not allow", func(t *testing.T) {
+		req := connect.NewRequest(&accountsapiv1.DeleteAccountRequest{})
+		withToken(t, authtest.Claims{
+			Subject: "auth0|support",
+			Scope:   "delete:accounts",
+			Roles:   []string{httpauth.RoleSupport},
+		}, req)
+
+		_, err := accountsClient.DeleteAccount(ctx, req)
+		assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+	})
+}
+
+// ownedAccountHandler answers account reads of the owner only
+type ownedAccountHandler struct {
+	accountsapiv1connect.UnimplementedAccountServiceHandler
+}
+
+func (ownedAccountHandler) GetAccount(ctx context.Context, req *connect.Request[accountsapiv1.GetAccountRequest]) (*connect.Response[accountsapiv1.GetAccountResponse], error) {
+	if err := httpauth.AuthorizeOwnerFromContext(ctx, req.Msg.GetCommonId()); err != nil {
+		return nil, connect.NewError(connect.CodePermissionDenied, err)
+	}
+
+	return connect.NewResponse(&accountsapiv1.GetAccountResponse{}), nil
+}
+
+func TestAuthInterceptorAnyAccountPolicy(t *testing.T) {
+	ctx := context.Background()
+	commonID := "0b0d2f5e-7f55-4a4c-9f55-1bde1f4d1c11"
+
+	issuer, err := authtest.NewIssuer("https://issuer.test/", audience)
+	require.NoError(t, err)
+	defer issuer.Close()
+
+	jwtValidator, err := httpauth.NewJWTValidator(httpauth.WithTrustedIssuer(httpauth.TrustedIssuer{
+		IssuerURL: issuer.URL,
+		Audiences: []string{audience},
+		JWKSURL:   issuer.ServeJWKS(),
+	}))
+	require.NoError(t, err)
+
+	authInterceptor := httpauth.NewAuthInterceptor(boot.NewSlogger(), jwtValidator, httpauth.WithPolicy(httpauth.DefaultPolicy()))
+
+	mux := http.NewServeMux()
+	mux.Handle(accountsapiv1connect.NewAccountServiceHandler(ownedAccountHandler{}, connect.WithInterceptors(authInterceptor.Incoming())))
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := accountsapiv1connect.NewAccountServiceClient(server.Client(), server.URL)
+
+	getAccount := func(t *testing.T, claims authtest.Claims) error {
+		token, err := issuer.Sign(claims)
+		require.NoError(t, err)
+
+		req := connect.NewRequest(&accountsapiv1.GetAccountRequest{CommonId: &commonID})
+		req.Header().Set(httpauth.AuthorizationHeaderKey, "Bearer "+token)
+
+		_, err = client.GetAccount(ctx, req)
+		return err
+	}
+
+	t.Run("allows the owner", func(t *testing.T) {
+		assert.NoError(t, getAccount(t, authtest.Claims{Subject: "auth0|owner", Scope: "read:accounts", CommonID: commonID}))
+	})
+
+	t.Run("denies other users", func(t *testing.T) {
+		err := getAccount(t, authtest.Claims{Subject: "auth0|other", Scope: "read:accounts", CommonID: "other"})
+		assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+	})
+
+	t.Run("allows support to read any account", func(t *testing.T) {
+		assert.NoError(t, getAccount(t, authtest.Claims{Subject: "auth0|support", Scope: "read:accounts", CommonID: "other", Roles: []string{httpauth.RoleSupport}}))
+	})
+}
+
+// streamingHandlerConn is the server side of a stream of the procedure
+type streamingHandlerConn struct {
+	connect.StreamingHandlerConn
+	spec   connect.Spec
+	header http.Header
+}
+
+func (c *streamingHandlerConn) Spec() connect.Spec         { return c.spec }
+func (c *streamingHandlerConn) RequestHeader() http.Header { return c.header }
+
+func TestAuthInterceptorStreamingHandler(t *testing.T) {
+	ctx := context.Background()
+
+	issuer, err := authtest.NewIssuer("https://issuer.test/", audience)
+	require.NoError(t, err)
+	defer issuer.Close()
+
+	jwtValidator, err := httpauth.NewJWTValidator(httpauth.WithTrustedIssuer(httpauth.TrustedIssuer{
+		IssuerURL: issuer.URL,
+		Audiences: []string{audience},
+		JWKSURL:   issuer.ServeJWKS(),
+	}))
+	require.NoError(t, err)
+
+	authInterceptor := httpauth.NewAuthInterceptor(boot.NewSlogger(), jwtValidator)
+
+	// Any procedure descriptor serves as the schema of the stream
+	method := accountsapiv1.File_accounts_accountsapi_v1_api_proto.Services().ByName("AccountService").Methods().ByName("DeleteAccount")
+	newConn := func(authHeader string) *streamingHandlerConn {
+		header := http.Header{}
+		if authHeader != "" {
+			header.Set(httpauth.AuthorizationHeaderKey, authHeader)
+		}
+
+		return &streamingHandlerConn{
+			spec:   connect.Spec{Procedure: accountsapiv1connect.AccountServiceDeleteAccountProcedure, Schema: method, StreamType: connect.StreamTypeBidi},
+			header: header,
+		}
+	}
+
+	var handledClaims *httpauth.CustomClaims
+	handler := authInterceptor.Incoming().WrapStreamingHandler(func(ctx context.Context, _ connect.StreamingHandlerConn) error {
+		handledClaims, err = httpauth.GetClaimsFromContext(ctx)
+		return err
+	})
+
+	t.Run("rejects a stream without a token", func(t *testing.T) {
+		err := handler(ctx, newConn(""))
+		assert.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+	})
+
+	t.Run("rejects a stream without the required scope", func(t *testing.T) {
+		token, err := issuer.Sign(authtest.Claims{Subject: "auth0|user"})
+		require.NoError(t, err)
+
+		err = handler(ctx, newConn("Bearer "+token))
+		assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+	})
+
+	t.Run("hands the claims to the stream", func(t *testing.T) {
+		token, err := issuer.Sign(authtest.Claims{Subject: "auth0|user", Scope: "delete:accounts"})
+		require.NoError(t, err)
+
+		require.NoError(t, handler(ctx, newConn("Bearer "+token)))
+		assert.True(t, handledClaims.HasScope("delete:accounts"))
+	})
+}
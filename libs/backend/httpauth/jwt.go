@@ -2,14 +2,9 @@ package httpauth
 
 import (
 	"context"
-	"libs/backend/boot"
-	"log/slog"
-	"net/url"
-	"os"
+	"slices"
 	"strings"
-	"time"
 
-	"github.com/auth0/go-jwt-middleware/v2/jwks"
 	"github.com/auth0/go-jwt-middleware/v2/validator"
 )
 
@@ -17,6 +12,18 @@ import (
 type CustomClaims struct {
 	validator.CustomClaims
 	Scope string `json:"scope"`
+
+	// GrantType is the grant the token was issued by
+	GrantType string `json:"gty"`
+
+	// CommonID is the account of the user, added to the token by an Auth0 Action
+	CommonID string `json:"https://career-cue.com/common_id"`
+
+	// Permissions are the Auth0 RBAC permissions of the user
+	Permissions []string `json:"permissions"`
+
+	// Roles are the Auth0 roles of the user, added to the token by an Auth0 Action
+	Roles []string `json:"https://career-cue.com/roles"`
 }
 
 // Validate does nothing for this example, but we need
@@ -37,41 +44,31 @@ func (c CustomClaims) HasScope(expectedScope string) bool {
 	return false
 }
 
-// AccessTokenValidator will implement the auth.Validator interface
-// for access token validation
-type AccessTokenValidator struct {
-	logger boot.Logger
+// IsM2M checks whether the token was issued to a machine by the client credentials grant
+func (c CustomClaims) IsM2M() bool {
+	return c.GrantType == ClientCredentialsGrantType
 }
 
-// NewAccessTokenValidator handles validator construction
-func NewAccessTokenValidator(logger boot.Logger) AccessTokenValidator {
-	return AccessTokenValidator{logger}
+// IsAPIKey checks whether the claims are of an API key instead of a token
+func (c CustomClaims) IsAPIKey() bool {
+	return c.GrantType == APIKeyGrantType
 }
 
-// EnsureValidToken is a middleware that will check the validity of our JWT.
-func (v AccessTokenValidator) EnsureValidToken(ctx context.Context, accessToken string) (interface{}, error) {
-	issuerURL, err := url.Parse("https://" + os.Getenv("AUTH0_DOMAIN") + "/")
-	if err != nil {
-		v.logger.Error("Failed to parse the issuer url", slog.Any("error", err))
-	}
+// HasPermission checks whether the user was granted the permission
+func (c CustomClaims) HasPermission(permission string) bool {
+	return slices.Contains(c.Permissions, permission)
+}
+
+// HasRole checks whether the user was assigned the role
+func (c CustomClaims) HasRole(role string) bool {
+	return slices.Contains(c.Roles, role)
+}
 
-	provider := jwks.NewCachingProvider(issuerURL, 5*time.Minute)
-
-	jwtValidator, err := validator.New(
-		provider.KeyFunc,
-		validator.RS256,
-		issuerURL.String(),
-		[]string{os.Getenv("AUTH0_AUDIENCE")},
-		validator.WithCustomClaims(
-			func() validator.CustomClaims {
-				return &CustomClaims{}
-			},
-		),
-		validator.WithAllowedClockSkew(time.Minute),
-	)
-	if err != nil {
-		v.logger.Error("Failed to set up the jwt validator")
+// RolesOrDefault returns the roles of the user, a user without roles is a RoleUser
+func (c CustomClaims) RolesOrDefault() []string {
+	if len(c.Roles) == 0 {
+		return []string{RoleUser}
 	}
 
-	return jwtValidator.ValidateToken(ctx, accessToken)
+	return c.Roles
 }
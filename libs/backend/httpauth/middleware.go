@@ -2,7 +2,11 @@ package httpauth
 
 import (
 	"context"
+	"errors"
 	"net/http"
+	"strings"
+
+	"github.com/auth0/go-jwt-middleware/v2/validator"
 )
 
 // AuthMiddleware will pass forward the auth header to downstream services
@@ -22,3 +26,36 @@ func AuthMiddleware(next http.Handler) http.HandlerFunc {
 		next.ServeHTTP(w, r)
 	}
 }
+
+// ClaimsMiddleware validates the bearer token of the request and sets its claims
+// to the context.Context, requests without a token continue without claims so
+// the handlers decide what an anonymous caller may do. Requests with an invalid
+// token continue without claims as well, with the validation failure set to the
+// context so the handlers answer them in their own protocol
+func ClaimsMiddleware(accessTokenValidator Validator, next http.Handler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		authHeader := r.Header.Get(AuthorizationHeaderKey)
+		if authHeader == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		authTokenValues := strings.Split(authHeader, " ")
+		if len(authTokenValues) != tokenValueLength {
+			r = r.WithContext(setTokenErrorToContext(r.Context(), errors.New("authorization header malformed")))
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		claims, err := accessTokenValidator.EnsureValidToken(r.Context(), authTokenValues[1])
+		if err != nil {
+			r = r.WithContext(setTokenErrorToContext(r.Context(), err))
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		r = r.WithContext(SetClaimsToContext(r.Context(), claims.(*validator.ValidatedClaims)))
+
+		next.ServeHTTP(w, r)
+	}
+}
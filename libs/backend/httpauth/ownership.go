@@ -0,0 +1,37 @@
+package httpauth
+
+import "context"
+
+// AuthorizeOwner ensures the caller owns the account of the common id, unless
+// the token was issued to a machine or grants the admin scope or role
+func AuthorizeOwner(claims *CustomClaims, commonID string) error {
+	if claims == nil {
+		return ErrCustomClaimsNotValid
+	}
+
+	if claims.IsM2M() || claims.HasScope(AdminScope) || claims.HasRole(RoleAdmin) {
+		return nil
+	}
+
+	if claims.CommonID == "" || claims.CommonID != commonID {
+		return ErrNotOwner
+	}
+
+	return nil
+}
+
+// AuthorizeOwnerFromContext authorizes the claims set to the context against
+// the account of the common id. Callers the policy of the procedure allows to
+// act on any account, e.g. support staff, are not required to own it.
+func AuthorizeOwnerFromContext(ctx context.Context, commonID string) error {
+	claims, err := GetClaimsFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	if isAnyAccountGranted(ctx) {
+		return nil
+	}
+
+	return AuthorizeOwner(claims, commonID)
+}
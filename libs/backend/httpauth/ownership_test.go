@@ -0,0 +1,104 @@
+package httpauth_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"libs/backend/httpauth"
+	"libs/backend/httpauth/authtest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthorizeOwner(t *testing.T) {
+	const commonID = "0b0d2f5e-7f55-4a4c-9f55-1bde1f4d1c11"
+
+	tests := []struct {
+		name   string
+		claims *httpauth.CustomClaims
+		err    error
+	}{
+		{name: "owner", claims: &httpauth.CustomClaims{CommonID: commonID}},
+		{name: "other user", claims: &httpauth.CustomClaims{CommonID: "other"}, err: httpauth.ErrNotOwner},
+		{name: "no common id", claims: &httpauth.CustomClaims{}, err: httpauth.ErrNotOwner},
+		{name: "admin", claims: &httpauth.CustomClaims{Scope: httpauth.AdminScope}},
+		{name: "machine", claims: &httpauth.CustomClaims{GrantType: httpauth.ClientCredentialsGrantType}},
+		{name: "no claims", err: httpauth.ErrCustomClaimsNotValid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ErrorIs(t, httpauth.AuthorizeOwner(tt.claims, commonID), tt.err)
+		})
+	}
+}
+
+func TestClaimsMiddleware(t *testing.T) {
+	const commonID = "0b0d2f5e-7f55-4a4c-9f55-1bde1f4d1c11"
+
+	issuer, err := authtest.NewIssuer("https://issuer.test/", audience)
+	require.NoError(t, err)
+	defer issuer.Close()
+
+	jwtValidator, err := httpauth.NewJWTValidator(httpauth.WithTrustedIssuer(httpauth.TrustedIssuer{
+		IssuerURL: issuer.URL,
+		Audiences: []string{audience},
+		JWKSURL:   issuer.ServeJWKS(),
+	}))
+	require.NoError(t, err)
+
+	// The handler answers whether the caller owns the account, callers with a
+	// rejected token are told apart from the callers without one
+	handler := httpauth.ClaimsMiddleware(jwtValidator, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		err := httpauth.AuthorizeOwnerFromContext(r.Context(), commonID)
+		switch {
+		case errors.Is(err, httpauth.ErrTokenInvalid):
+			w.WriteHeader(http.StatusUnauthorized)
+		case err != nil:
+			w.WriteHeader(http.StatusForbidden)
+		}
+	}))
+
+	serve := func(authHeader string) int {
+		req := httptest.NewRequestWithContext(context.Background(), http.MethodPost, "/graphql", nil)
+		if authHeader != "" {
+			req.Header.Set(httpauth.AuthorizationHeaderKey, authHeader)
+		}
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	owner, err := issuer.Sign(authtest.Claims{Subject: "auth0|owner", CommonID: commonID})
+	require.NoError(t, err)
+
+	other, err := issuer.Sign(authtest.Claims{Subject: "auth0|other", CommonID: "other"})
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, serve("Bearer "+owner))
+	assert.Equal(t, http.StatusForbidden, serve("Bearer "+other))
+	assert.Equal(t, http.StatusForbidden, serve(""))
+	assert.Equal(t, http.StatusUnauthorized, serve("Bearer invalid"))
+	assert.Equal(t, http.StatusUnauthorized, serve(owner))
+
+	t.Run("sets the validation failure to the context", func(t *testing.T) {
+		var claimsErr, tokenErr error
+		handler := httpauth.ClaimsMiddleware(jwtValidator, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, claimsErr = httpauth.GetClaimsFromContext(r.Context())
+			tokenErr = httpauth.GetTokenErrorFromContext(r.Context())
+		}))
+
+		req := httptest.NewRequestWithContext(context.Background(), http.MethodPost, "/graphql", nil)
+		req.Header.Set(httpauth.AuthorizationHeaderKey, "Bearer invalid")
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+
+		assert.ErrorIs(t, tokenErr, httpauth.ErrTokenInvalid)
+		assert.ErrorIs(t, claimsErr, httpauth.ErrCustomClaimsNotValid)
+		assert.ErrorIs(t, claimsErr, httpauth.ErrTokenInvalid)
+	})
+}
@@ -0,0 +1,252 @@
+package httpauth
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+)
+
+// Roles of the users, assigned in Auth0
+const (
+	RoleUser    = "user"
+	RoleCoach   = "coach"
+	RoleSupport = "support"
+	RoleAdmin   = "admin"
+)
+
+// Wildcard matches any role, action or resource type in a policy rule
+const Wildcard = "*"
+
+// Effect is the outcome of a matching policy rule
+type Effect string
+
+// Effects of the policy rules, a deny overrides any allow
+const (
+	EffectAllow Effect = "allow"
+	EffectDeny  Effect = "deny"
+)
+
+// Scope is whose resources a policy rule covers
+type Scope string
+
+// Scopes of the policy rules, rules cover the own account of the caller
+// unless they are scoped to any account
+const (
+	ScopeOwn Scope = "own"
+	ScopeAny Scope = "any"
+)
+
+// PolicyRule allows or denies the roles to perform the actions on the resource types
+type PolicyRule struct {
+	Effect    Effect   `json:"effect"`
+	Roles     []string `json:"roles"`
+	Actions   []string `json:"actions"`
+	Resources []string `json:"resources"`
+
+	// Scope lets an allow cover the resources of other accounts, a deny
+	// applies to every account regardless of its scope
+	Scope Scope `json:"scope,omitempty"`
+}
+
+// matches checks whether the rule applies to the role, action and resource type
+func (r PolicyRule) matches(role, action, resource string) bool {
+	return matchesAny(r.Roles, role) && matchesAny(r.Actions, action) && matchesAny(r.Resources, resource)
+}
+
+// matchesAny checks whether the values contain the value or the wildcard
+func matchesAny(values []string, value string) bool {
+	return slices.Contains(values, Wildcard) || slices.Contains(values, value)
+}
+
+// Policy decides which roles may perform an action on a resource type,
+// anything not allowed by a rule is denied
+type Policy struct {
+	Rules []PolicyRule `json:"rules"`
+}
+
+// NewPolicy constructs a policy of the rules
+func NewPolicy(rules ...PolicyRule) (*Policy, error) {
+	policy := &Policy{Rules: rules}
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+
+	return policy, nil
+}
+
+// LoadPolicyFile reads a JSON policy from a local file
+func LoadPolicyFile(path string) (*Policy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read the policy file: %w", err)
+	}
+
+	var policy Policy
+	if err := json.Unmarshal(data, &policy); err != nil {
+		return nil, fmt.Errorf("cannot parse the policy file: %w", err)
+	}
+
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &policy, nil
+}
+
+// LoadPolicyOrDefault reads the policy file, or returns the DefaultPolicy without a path
+func LoadPolicyOrDefault(path string) (*Policy, error) {
+	if path == "" {
+		return DefaultPolicy(), nil
+	}
+
+	return LoadPolicyFile(path)
+}
+
+// DefaultPolicy is the policy of the platform roles
+func DefaultPolicy() *Policy {
+	return &Policy{
+		Rules: []PolicyRule{
+			{
+				Effect:    EffectAllow,
+				Roles:     []string{RoleUser, RoleCoach},
+				Actions:   []string{"read", "update", "delete", "restore"},
+				Resources: []string{"account"},
+			},
+			{
+				Effect:    EffectAllow,
+				Roles:     []string{RoleUser, RoleCoach},
+				Actions:   []string{"read", "manage"},
+				Resources: []string{"webhook", "api_key"},
+			},
+			{
+				Effect:    EffectAllow,
+				Roles:     []string{RoleSupport},
+				Actions:   []string{"read", "restore"},
+				Resources: []string{"account", "registration", "webhook", "api_key"},
+				Scope:     ScopeAny,
+			},
+			{
+				Effect:    EffectDeny,
+				Roles:     []string{RoleSupport},
+				Actions:   []string{"delete"},
+				Resources: []string{Wildcard},
+			},
+			{
+				Effect:    EffectAllow,
+				Roles:     []string{RoleAdmin},
+				Actions:   []string{Wildcard},
+				Resources: []string{Wildcard},
+				Scope:     ScopeAny,
+			},
+		},
+	}
+}
+
+// Validate ensures every rule has an effect, roles, actions and resource types
+func (p *Policy) Validate() error {
+	for i, rule := range p.Rules {
+		if rule.Effect != EffectAllow && rule.Effect != EffectDeny {
+			return fmt.Errorf("policy rule %d has unknown effect %q", i, rule.Effect)
+		}
+		if len(rule.Roles) == 0 || len(rule.Actions) == 0 || len(rule.Resources) == 0 {
+			return fmt.Errorf("policy rule %d needs roles, actions and resources", i)
+		}
+		if rule.Scope != "" && rule.Scope != ScopeOwn && rule.Scope != ScopeAny {
+			return fmt.Errorf("policy rule %d has unknown scope %q", i, rule.Scope)
+		}
+	}
+
+	return nil
+}
+
+// IsAllowed checks whether any of the roles may perform the action on the
+// resource type, a matching deny of any role wins over the allows
+func (p *Policy) IsAllowed(roles []string, action, resource string) bool {
+	return p.isAllowed(roles, action, resource, false)
+}
+
+// IsAllowedForAnyAccount checks whether any of the roles may perform the
+// action on the resource type of other accounts than its own
+func (p *Policy) IsAllowedForAnyAccount(roles []string, action, resource string) bool {
+	return p.isAllowed(roles, action, resource, true)
+}
+
+// isAllowed evaluates the rules, only counting the allows scoped to any
+// account when the resource is not the caller's own
+func (p *Policy) isAllowed(roles []string, action, resource string, anyAccount bool) bool {
+	allowed := false
+	for _, rule := range p.Rules {
+		for _, role := range roles {
+			if !rule.matches(role, action, resource) {
+				continue
+			}
+
+			if rule.Effect == EffectDeny {
+				return false
+			}
+			if !anyAccount || rule.Scope == ScopeAny {
+				allowed = true
+			}
+		}
+	}
+
+	return allowed
+}
+
+// Authorize ensures the caller may perform the action on the resource type,
+// either by an Auth0 RBAC permission of the form "action:resource" or by the
+// rules of its roles. Machines are authorized by their scopes instead.
+func (p *Policy) Authorize(claims *CustomClaims, action, resource string) error {
+	if claims == nil {
+		return ErrCustomClaimsNotValid
+	}
+
+	if claims.IsM2M() {
+		return nil
+	}
+
+	roles := claims.RolesOrDefault()
+	if !p.IsAllowed(roles, action, resource) && !p.permitted(claims, roles, action, resource) {
+		return ErrPolicyDenied
+	}
+
+	return nil
+}
+
+// permitted checks the permissions of the caller, unless a rule denies its roles
+func (p *Policy) permitted(claims *CustomClaims, roles []string, action, resource string) bool {
+	if !claims.HasPermission(action + ":" + resource) {
+		return false
+	}
+
+	for _, rule := range p.Rules {
+		if rule.Effect != EffectDeny {
+			continue
+		}
+		for _, role := range roles {
+			if rule.matches(role, action, resource) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// AuthorizeOwner ensures the caller owns the account of the common id, or
+// that the rules of its roles allow the action on the resource type of any
+// account, e.g. support staff reading the accounts of users
+func (p *Policy) AuthorizeOwner(claims *CustomClaims, action, resource, commonID string) error {
+	err := AuthorizeOwner(claims, commonID)
+	if !errors.Is(err, ErrNotOwner) {
+		return err
+	}
+
+	if p.IsAllowedForAnyAccount(claims.RolesOrDefault(), action, resource) {
+		return nil
+	}
+
+	return err
+}
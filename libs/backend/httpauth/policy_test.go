@@ -0,0 +1,130 @@
+package httpauth_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"libs/backend/httpauth"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPolicyIsAllowed(t *testing.T) {
+	policy := httpauth.DefaultPolicy()
+
+	tests := []struct {
+		name     string
+		roles    []string
+		action   string
+		resource string
+		allowed  bool
+	}{
+		{name: "user reads account", roles: []string{httpauth.RoleUser}, action: "read", resource: "account", allowed: true},
+		{name: "user reads registration", roles: []string{httpauth.RoleUser}, action: "read", resource: "registration"},
+		{name: "support reads registration", roles: []string{httpauth.RoleSupport}, action: "read", resource: "registration", allowed: true},
+		{name: "support deletes account", roles: []string{httpauth.RoleSupport}, action: "delete", resource: "account"},
+		{name: "deny of support wins over user", roles: []string{httpauth.RoleUser, httpauth.RoleSupport}, action: "delete", resource: "account"},
+		{name: "admin deletes anything", roles: []string{httpauth.RoleAdmin}, action: "delete", resource: "registration", allowed: true},
+		{name: "unknown role", roles: []string{"guest"}, action: "read", resource: "account"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.allowed, policy.IsAllowed(tt.roles, tt.action, tt.resource))
+		})
+	}
+}
+
+func TestPolicyAuthorize(t *testing.T) {
+	policy := httpauth.DefaultPolicy()
+
+	t.Run("defaults to the user role", func(t *testing.T) {
+		assert.NoError(t, policy.Authorize(&httpauth.CustomClaims{}, "read", "account"))
+		assert.ErrorIs(t, policy.Authorize(&httpauth.CustomClaims{}, "read", "registration"), httpauth.ErrPolicyDenied)
+	})
+
+	t.Run("allows by permission", func(t *testing.T) {
+		claims := &httpauth.CustomClaims{Permissions: []string{"read:registration"}}
+		assert.NoError(t, policy.Authorize(claims, "read", "registration"))
+	})
+
+	t.Run("denies by role over permission", func(t *testing.T) {
+		claims := &httpauth.CustomClaims{Roles: []string{httpauth.RoleSupport}, Permissions: []string{"delete:account"}}
+		assert.ErrorIs(t, policy.Authorize(claims, "delete", "account"), httpauth.ErrPolicyDenied)
+	})
+
+	t.Run("skips machines", func(t *testing.T) {
+		claims := &httpauth.CustomClaims{GrantType: httpauth.ClientCredentialsGrantType}
+		assert.NoError(t, policy.Authorize(claims, "delete", "registration"))
+	})
+}
+
+func TestPolicyAuthorizeOwner(t *testing.T) {
+	const commonID = "0b0d2f5e-7f55-4a4c-9f55-1bde1f4d1c11"
+	policy := httpauth.DefaultPolicy()
+
+	tests := []struct {
+		name   string
+		claims *httpauth.CustomClaims
+		action string
+		err    error
+	}{
+		{name: "owner", claims: &httpauth.CustomClaims{CommonID: commonID}, action: "delete"},
+		{name: "other user", claims: &httpauth.CustomClaims{CommonID: "other"}, action: "read", err: httpauth.ErrNotOwner},
+		{name: "support reads other accounts", claims: &httpauth.CustomClaims{CommonID: "other", Roles: []string{httpauth.RoleSupport}}, action: "read"},
+		{name: "support restores other accounts", claims: &httpauth.CustomClaims{CommonID: "other", Roles: []string{httpauth.RoleSupport}}, action: "restore"},
+		{name: "support updates other accounts", claims: &httpauth.CustomClaims{CommonID: "other", Roles: []string{httpauth.RoleSupport}}, action: "update", err: httpauth.ErrNotOwner},
+		{name: "support deletes other accounts", claims: &httpauth.CustomClaims{CommonID: "other", Roles: []string{httpauth.RoleSupport}}, action: "delete", err: httpauth.ErrNotOwner},
+		{name: "no claims", action: "read", err: httpauth.ErrCustomClaimsNotValid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ErrorIs(t, policy.AuthorizeOwner(tt.claims, tt.action, "account", commonID), tt.err)
+		})
+	}
+}
+
+func TestLoadPolicyFile(t *testing.T) {
+	dir := t.TempDir()
+
+	t.Run("loads the rules", func(t *testing.T) {
+		path := filepath.Join(dir, "policy.json")
+		require.NoError(t, os.WriteFile(path, []byte(`{
+			"rules": [
+				{"effect": "allow", "roles": ["coach"], "actions": ["read"], "resources": ["*"]}
+			]
+		}`), 0o600))
+
+		policy, err := httpauth.LoadPolicyFile(path)
+		require.NoError(t, err)
+		assert.True(t, policy.IsAllowed([]string{httpauth.RoleCoach}, "read", "registration"))
+		assert.False(t, policy.IsAllowed([]string{httpauth.RoleCoach}, "delete", "registration"))
+	})
+
+	t.Run("rejects an unknown scope", func(t *testing.T) {
+		path := filepath.Join(dir, "scope.json")
+		require.NoError(t, os.WriteFile(path, []byte(`{
+			"rules": [
+				{"effect": "allow", "roles": ["coach"], "actions": ["read"], "resources": ["*"], "scope": "team"}
+			]
+		}`), 0o600))
+
+		_, err := httpauth.LoadPolicyFile(path)
+		assert.Error(t, err)
+	})
+
+	t.Run("rejects an unknown effect", func(t *testing.T) {
+		path := filepath.Join(dir, "invalid.json")
+		require.NoError(t, os.WriteFile(path, []byte(`{
+			"rules": [
+				{"effect": "maybe", "roles": ["coach"], "actions": ["read"], "resources": ["*"]}
+			]
+		}`), 0o600))
+
+		_, err := httpauth.LoadPolicyFile(path)
+		assert.Error(t, err)
+	})
+}
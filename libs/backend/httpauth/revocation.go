@@ -0,0 +1,178 @@
+package httpauth
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/auth0/go-jwt-middleware/v2/validator"
+)
+
+// DefaultMaxTokenLifetime is the default lifetime of the access tokens of
+// Auth0 APIs, revocations older than the lifetime reject no valid token
+const DefaultMaxTokenLifetime = 24 * time.Hour
+
+// Assertion of the proper interfaces
+var _ RevocationStore = (*RevocationCache)(nil)
+
+// RevocationStore answers whether access tokens were revoked
+type RevocationStore interface {
+	// IsTokenRevoked checks whether the token of the jti was revoked
+	IsTokenRevoked(ctx context.Context, jti string) (bool, error)
+
+	// SessionsRevokedBefore returns the time the tokens of the account or of
+	// the sub claim must be issued after, the zero time when the sessions were
+	// never revoked
+	SessionsRevokedBefore(ctx context.Context, subject string) (time.Time, error)
+}
+
+// WithRevocationStore rejects the revoked tokens after their signature and claims are validated
+func WithRevocationStore(store RevocationStore) JWTValidatorOption {
+	return func(o *jwtValidatorOptions) {
+		o.revocations = store
+	}
+}
+
+// sessionRevocation rejects the tokens of an account or a sub issued before the time
+type sessionRevocation struct {
+	issuedBefore time.Time
+	expiresAt    time.Time
+}
+
+// RevocationCacheOption configures the RevocationCache
+type RevocationCacheOption func(*RevocationCache)
+
+// WithRevocationClock sets the clock the expiry of the revocations is checked with
+func WithRevocationClock(now func() time.Time) RevocationCacheOption {
+	return func(c *RevocationCache) {
+		c.now = now
+	}
+}
+
+// RevocationCache keeps the revocations in memory, it is filled from the
+// sessions revoked events so validation never waits on the network.
+// Revocations are forgotten once every token they reject has expired.
+type RevocationCache struct {
+	mu       sync.RWMutex
+	tokens   map[string]time.Time
+	sessions map[string]sessionRevocation // by common id or sub
+	now      func() time.Time
+}
+
+// NewRevocationCache constructs an empty revocation cache
+func NewRevocationCache(opts ...RevocationCacheOption) *RevocationCache {
+	c := &RevocationCache{
+		tokens:   make(map[string]time.Time),
+		sessions: make(map[string]sessionRevocation),
+		now:      time.Now,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// RevokeToken rejects the token of the jti until it expires
+func (c *RevocationCache) RevokeToken(jti string, expiresAt time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.prune()
+	if expiresAt.After(c.tokens[jti]) {
+		c.tokens[jti] = expiresAt
+	}
+}
+
+// RevokeSessions rejects the tokens of the account or the sub issued before
+// the time, keeping the latest revocation of the subject
+func (c *RevocationCache) RevokeSessions(subject string, issuedBefore time.Time, expiresAt time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.prune()
+	revocation := c.sessions[subject]
+	if issuedBefore.After(revocation.issuedBefore) {
+		revocation.issuedBefore = issuedBefore
+	}
+	if expiresAt.After(revocation.expiresAt) {
+		revocation.expiresAt = expiresAt
+	}
+	c.sessions[subject] = revocation
+}
+
+// IsTokenRevoked checks whether the token of the jti was revoked
+func (c *RevocationCache) IsTokenRevoked(_ context.Context, jti string) (bool, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	expiresAt, ok := c.tokens[jti]
+	return ok && c.now().Before(expiresAt), nil
+}
+
+// SessionsRevokedBefore returns the time the tokens of the account or the sub must be issued after
+func (c *RevocationCache) SessionsRevokedBefore(_ context.Context, subject string) (time.Time, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	revocation, ok := c.sessions[subject]
+	if !ok || !c.now().Before(revocation.expiresAt) {
+		return time.Time{}, nil
+	}
+
+	return revocation.issuedBefore, nil
+}
+
+// prune forgets the revocations of expired tokens, the lock must be held
+func (c *RevocationCache) prune() {
+	now := c.now()
+
+	for jti, expiresAt := range c.tokens {
+		if !now.Before(expiresAt) {
+			delete(c.tokens, jti)
+		}
+	}
+	for subject, revocation := range c.sessions {
+		if !now.Before(revocation.expiresAt) {
+			delete(c.sessions, subject)
+		}
+	}
+}
+
+// ensureNotRevoked rejects the validated claims of a revoked token. The
+// session revocations are looked up by the common id claim and by the sub
+// claim, so the tokens without a common id claim, like the tokens of M2M
+// clients and users not linked to an account yet, are revoked by their sub.
+// Tokens without an iat claim count as issued before any revocation.
+func ensureNotRevoked(ctx context.Context, store RevocationStore, claims *validator.ValidatedClaims) error {
+	if jti := claims.RegisteredClaims.ID; jti != "" {
+		revoked, err := store.IsTokenRevoked(ctx, jti)
+		if err != nil {
+			return err
+		}
+		if revoked {
+			return ErrTokenRevoked
+		}
+	}
+
+	subjects := make([]string, 0, 2)
+	if customClaims, ok := claims.CustomClaims.(*CustomClaims); ok && customClaims.CommonID != "" {
+		subjects = append(subjects, customClaims.CommonID)
+	}
+	if sub := claims.RegisteredClaims.Subject; sub != "" {
+		subjects = append(subjects, sub)
+	}
+
+	issuedAt := time.Unix(claims.RegisteredClaims.IssuedAt, 0)
+	for _, subject := range subjects {
+		revokedBefore, err := store.SessionsRevokedBefore(ctx, subject)
+		if err != nil {
+			return err
+		}
+		if !revokedBefore.IsZero() && issuedAt.Before(revokedBefore) {
+			return ErrTokenRevoked
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,124 @@
+package httpauth_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"libs/backend/httpauth"
+	"libs/backend/httpauth/authtest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const revokedCommonID = "7c9e6679-7425-40de-944b-e07fc1f90ae7"
+
+func TestRevocationCache(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	cache := httpauth.NewRevocationCache(httpauth.WithRevocationClock(func() time.Time { return now }))
+
+	t.Run("revokes tokens until they expire", func(t *testing.T) {
+		cache.RevokeToken("token-1", now.Add(time.Hour))
+
+		revoked, err := cache.IsTokenRevoked(ctx, "token-1")
+		require.NoError(t, err)
+		assert.True(t, revoked)
+
+		revoked, err = cache.IsTokenRevoked(ctx, "token-2")
+		require.NoError(t, err)
+		assert.False(t, revoked)
+
+		now = now.Add(2 * time.Hour)
+		revoked, err = cache.IsTokenRevoked(ctx, "token-1")
+		require.NoError(t, err)
+		assert.False(t, revoked)
+	})
+
+	t.Run("keeps the latest session revocation", func(t *testing.T) {
+		first := now.Add(-time.Minute)
+		cache.RevokeSessions(revokedCommonID, now, now.Add(time.Hour))
+		cache.RevokeSessions(revokedCommonID, first, now.Add(time.Hour))
+
+		revokedBefore, err := cache.SessionsRevokedBefore(ctx, revokedCommonID)
+		require.NoError(t, err)
+		assert.Equal(t, now, revokedBefore)
+
+		now = now.Add(2 * time.Hour)
+		revokedBefore, err = cache.SessionsRevokedBefore(ctx, revokedCommonID)
+		require.NoError(t, err)
+		assert.True(t, revokedBefore.IsZero())
+	})
+}
+
+func TestJWTValidatorRevocations(t *testing.T) {
+	ctx := context.Background()
+
+	issuer, err := authtest.NewIssuer("https://issuer.test/", audience)
+	require.NoError(t, err)
+	defer issuer.Close()
+
+	cache := httpauth.NewRevocationCache()
+	jwtValidator, err := httpauth.NewJWTValidator(
+		httpauth.WithTrustedIssuer(httpauth.TrustedIssuer{
+			IssuerURL: issuer.URL,
+			Audiences: []string{audience},
+			JWKSURL:   issuer.ServeJWKS(),
+		}),
+		httpauth.WithRevocationStore(cache),
+	)
+	require.NoError(t, err)
+
+	sign := func(claims authtest.Claims) string {
+		claims.Subject = "auth0|user"
+		claims.CommonID = revokedCommonID
+		token, err := issuer.Sign(claims)
+		require.NoError(t, err)
+		return token
+	}
+
+	t.Run("rejects revoked tokens by jti", func(t *testing.T) {
+		revoked := sign(authtest.Claims{ID: "revoked-token"})
+		other := sign(authtest.Claims{ID: "other-token"})
+		cache.RevokeToken("revoked-token", time.Now().Add(time.Hour))
+
+		_, err := jwtValidator.EnsureValidToken(ctx, revoked)
+		assert.ErrorIs(t, err, httpauth.ErrTokenRevoked)
+
+		_, err = jwtValidator.EnsureValidToken(ctx, other)
+		assert.NoError(t, err)
+	})
+
+	t.Run("rejects the tokens of the account issued before the revocation", func(t *testing.T) {
+		revokedAt := time.Now()
+		before := sign(authtest.Claims{IssuedAt: revokedAt.Add(-time.Minute)})
+		after := sign(authtest.Claims{IssuedAt: revokedAt.Add(time.Second)})
+		cache.RevokeSessions(revokedCommonID, revokedAt, revokedAt.Add(time.Hour))
+
+		_, err := jwtValidator.EnsureValidToken(ctx, before)
+		assert.ErrorIs(t, err, httpauth.ErrTokenRevoked)
+
+		_, err = jwtValidator.EnsureValidToken(ctx, after)
+		assert.NoError(t, err)
+	})
+
+	t.Run("rejects the tokens without a common id issued before the revocation of their sub", func(t *testing.T) {
+		signClient := func(issuedAt time.Time) string {
+			token, err := issuer.Sign(authtest.Claims{Subject: "client-1@clients", IssuedAt: issuedAt})
+			require.NoError(t, err)
+			return token
+		}
+
+		revokedAt := time.Now()
+		before := signClient(revokedAt.Add(-time.Minute))
+		after := signClient(revokedAt.Add(time.Second))
+		cache.RevokeSessions("client-1@clients", revokedAt, revokedAt.Add(time.Hour))
+
+		_, err := jwtValidator.EnsureValidToken(ctx, before)
+		assert.ErrorIs(t, err, httpauth.ErrTokenRevoked)
+
+		_, err = jwtValidator.EnsureValidToken(ctx, after)
+		assert.NoError(t, err)
+	})
+}
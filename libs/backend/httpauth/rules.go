@@ -0,0 +1,105 @@
+package httpauth
+
+import (
+	"sync"
+
+	authv1 "libs/backend/proto-gen/go/auth/v1"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// ProcedureRule is the access and scopes a procedure declares through the
+// auth.v1 method options
+type ProcedureRule struct {
+	Access         authv1.Access
+	RequiredScopes []string
+
+	// Policy is the action and resource type authorized by the role policy, if any
+	Policy *authv1.PolicyTarget
+}
+
+// IsPublic reports whether the procedure can be called without an access token
+func (r ProcedureRule) IsPublic() bool {
+	return r.Access == authv1.Access_ACCESS_PUBLIC
+}
+
+// Authorize ensures the claims satisfy the access and scopes of the procedure,
+// and the policy when the procedure declares a policy target
+func (r ProcedureRule) Authorize(claims *CustomClaims, policy *Policy) error {
+	if r.IsPublic() {
+		return nil
+	}
+
+	if claims == nil {
+		return ErrCustomClaimsNotValid
+	}
+
+	if r.Access == authv1.Access_ACCESS_M2M && !claims.IsM2M() {
+		return ErrM2MOnly
+	}
+
+	for _, scope := range r.RequiredScopes {
+		if !claims.HasScope(scope) {
+			return ErrMissingScope
+		}
+	}
+
+	if policy != nil && r.Policy != nil {
+		return policy.Authorize(claims, r.Policy.GetAction(), r.Policy.GetResource())
+	}
+
+	return nil
+}
+
+// AllowsAnyAccount checks whether the policy allows the caller to perform the
+// procedure on the resources of other accounts than its own
+func (r ProcedureRule) AllowsAnyAccount(claims *CustomClaims, policy *Policy) bool {
+	if claims == nil || policy == nil || r.Policy == nil {
+		return false
+	}
+
+	return policy.IsAllowedForAnyAccount(claims.RolesOrDefault(), r.Policy.GetAction(), r.Policy.GetResource())
+}
+
+// ProcedureRuleFromSchema reads the rule from the method descriptor Connect
+// exposes as the schema of a procedure, a procedure without one requires
+// a valid access token only
+func ProcedureRuleFromSchema(schema any) ProcedureRule {
+	method, ok := schema.(protoreflect.MethodDescriptor)
+	if !ok {
+		return ProcedureRule{}
+	}
+
+	options := method.Options()
+	if options == nil {
+		return ProcedureRule{}
+	}
+
+	rule := ProcedureRule{
+		Access:         proto.GetExtension(options, authv1.E_Access).(authv1.Access),
+		RequiredScopes: proto.GetExtension(options, authv1.E_RequiredScopes).([]string),
+	}
+	if proto.HasExtension(options, authv1.E_Policy) {
+		rule.Policy = proto.GetExtension(options, authv1.E_Policy).(*authv1.PolicyTarget)
+	}
+
+	return rule
+}
+
+// procedureRules caches the rules by procedure so the options are read once
+type procedureRules struct {
+	rules sync.Map
+}
+
+// get returns the cached rule of the procedure
+func (p *procedureRules) get(procedure string, schema any) ProcedureRule {
+	if rule, ok := p.rules.Load(procedure); ok {
+		return rule.(ProcedureRule)
+	}
+
+	rule := ProcedureRuleFromSchema(schema)
+	p.rules.Store(procedure, rule)
+
+	return rule
+}
@@ -0,0 +1,254 @@
+package httpauth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/auth0/go-jwt-middleware/v2/jwks"
+	"github.com/auth0/go-jwt-middleware/v2/validator"
+	"gopkg.in/go-jose/go-jose.v2"
+	"gopkg.in/go-jose/go-jose.v2/jwt"
+)
+
+// Defaults of the trusted issuers
+const (
+	DefaultJWKSCacheTTL     = 5 * time.Minute
+	DefaultAllowedClockSkew = time.Minute
+)
+
+// Assertion of the proper interfaces
+var _ Validator = (*JWTValidator)(nil)
+
+// TrustedIssuer describes an issuer whose access tokens are accepted
+type TrustedIssuer struct {
+	// IssuerURL must equal the iss claim of the tokens
+	IssuerURL string
+	Audiences []string
+
+	// Algorithms defaults to RS256
+	Algorithms []validator.SignatureAlgorithm
+
+	// JWKSURL overrides the key set discovered from the well known endpoints
+	// of the issuer, e.g. to point at an in-process test server
+	JWKSURL string
+
+	// JWKSFile loads the key set once from a local file instead of over HTTP
+	JWKSFile string
+
+	// CacheTTL defaults to DefaultJWKSCacheTTL
+	CacheTTL time.Duration
+}
+
+// Auth0IssuerURL returns the issuer url of the Auth0 tenant domain, a domain
+// with a scheme such as a local development issuer is kept as is
+func Auth0IssuerURL(domain string) string {
+	if !strings.Contains(domain, "://") {
+		domain = "https://" + domain
+	}
+
+	return strings.TrimSuffix(domain, "/") + "/"
+}
+
+// JWTValidatorOption configures the JWTValidator
+type JWTValidatorOption func(*jwtValidatorOptions)
+
+// jwtValidatorOptions are the options the validators are built from
+type jwtValidatorOptions struct {
+	issuers          []TrustedIssuer
+	allowedClockSkew time.Duration
+	client           *http.Client
+	revocations      RevocationStore
+}
+
+// WithTrustedIssuer accepts the tokens of the issuer
+func WithTrustedIssuer(issuer TrustedIssuer) JWTValidatorOption {
+	return func(o *jwtValidatorOptions) {
+		o.issuers = append(o.issuers, issuer)
+	}
+}
+
+// WithAllowedClockSkew sets the leeway of the time based claims
+func WithAllowedClockSkew(skew time.Duration) JWTValidatorOption {
+	return func(o *jwtValidatorOptions) {
+		o.allowedClockSkew = skew
+	}
+}
+
+// WithHTTPClient sets the client the key sets are fetched with
+func WithHTTPClient(client *http.Client) JWTValidatorOption {
+	return func(o *jwtValidatorOptions) {
+		o.client = client
+	}
+}
+
+// JWTValidator validates access tokens against the trusted issuers,
+// every validator is built once on construction
+type JWTValidator struct {
+	// validators by issuer url and signature algorithm
+	validators map[string]map[validator.SignatureAlgorithm]*validator.Validator
+
+	// revocations is optional, revoked tokens are rejected when set
+	revocations RevocationStore
+}
+
+// NewJWTValidator builds the validators of the trusted issuers
+func NewJWTValidator(opts ...JWTValidatorOption) (*JWTValidator, error) {
+	options := jwtValidatorOptions{
+		allowedClockSkew: DefaultAllowedClockSkew,
+		client:           &http.Client{Timeout: 15 * time.Second},
+	}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	if len(options.issuers) == 0 {
+		return nil, ErrNoTrustedIssuers
+	}
+
+	v := &JWTValidator{
+		validators:  make(map[string]map[validator.SignatureAlgorithm]*validator.Validator, len(options.issuers)),
+		revocations: options.revocations,
+	}
+
+	for _, issuer := range options.issuers {
+		if _, ok := v.validators[issuer.IssuerURL]; ok {
+			return nil, fmt.Errorf("issuer %s is trusted more than once", issuer.IssuerURL)
+		}
+
+		keyFunc, err := newKeyFunc(issuer, options.client)
+		if err != nil {
+			return nil, err
+		}
+
+		algorithms := issuer.Algorithms
+		if len(algorithms) == 0 {
+			algorithms = []validator.SignatureAlgorithm{validator.RS256}
+		}
+
+		byAlgorithm := make(map[validator.SignatureAlgorithm]*validator.Validator, len(algorithms))
+		for _, algorithm := range algorithms {
+			jwtValidator, err := validator.New(
+				keyFunc,
+				algorithm,
+				issuer.IssuerURL,
+				issuer.Audiences,
+				validator.WithCustomClaims(
+					func() validator.CustomClaims {
+						return &CustomClaims{}
+					},
+				),
+				validator.WithAllowedClockSkew(options.allowedClockSkew),
+			)
+			if err != nil {
+				return nil, fmt.Errorf("cannot set up the validator of issuer %s: %w", issuer.IssuerURL, err)
+			}
+
+			byAlgorithm[algorithm] = jwtValidator
+		}
+
+		v.validators[issuer.IssuerURL] = byAlgorithm
+	}
+
+	return v, nil
+}
+
+// EnsureValidToken validates the token with the validator of its issuer and
+// algorithm, returning the *validator.ValidatedClaims
+func (v *JWTValidator) EnsureValidToken(ctx context.Context, accessToken string) (interface{}, error) {
+	token, err := jwt.ParseSigned(accessToken)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse the token: %w", err)
+	}
+
+	// The unverified claims only select the validator, which verifies them
+	var unverified jwt.Claims
+	if err := token.UnsafeClaimsWithoutVerification(&unverified); err != nil {
+		return nil, fmt.Errorf("could not read the token claims: %w", err)
+	}
+
+	byAlgorithm, ok := v.validators[unverified.Issuer]
+	if !ok {
+		return nil, ErrUntrustedIssuer
+	}
+
+	jwtValidator, ok := byAlgorithm[validator.SignatureAlgorithm(token.Headers[0].Algorithm)]
+	if !ok {
+		return nil, ErrUnsupportedAlgorithm
+	}
+
+	validated, err := jwtValidator.ValidateToken(ctx, accessToken)
+	if err != nil || v.revocations == nil {
+		return validated, err
+	}
+
+	claims, ok := validated.(*validator.ValidatedClaims)
+	if !ok {
+		return nil, ErrCustomClaimsNotValid
+	}
+	if err := ensureNotRevoked(ctx, v.revocations, claims); err != nil {
+		return nil, err
+	}
+
+	return claims, nil
+}
+
+// newKeyFunc returns the key set source of the issuer
+func newKeyFunc(issuer TrustedIssuer, client *http.Client) (func(context.Context) (interface{}, error), error) {
+	if issuer.JWKSFile != "" {
+		keySet, err := ReadJWKSFile(issuer.JWKSFile)
+		if err != nil {
+			return nil, err
+		}
+
+		return func(context.Context) (interface{}, error) {
+			return keySet, nil
+		}, nil
+	}
+
+	issuerURL, err := url.Parse(issuer.IssuerURL)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse the issuer url %s: %w", issuer.IssuerURL, err)
+	}
+
+	providerOptions := []jwks.ProviderOption{jwks.WithCustomClient(client)}
+	if issuer.JWKSURL != "" {
+		jwksURL, err := url.Parse(issuer.JWKSURL)
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse the jwks url %s: %w", issuer.JWKSURL, err)
+		}
+
+		providerOptions = append(providerOptions, jwks.WithCustomJWKSURI(jwksURL))
+	}
+
+	cacheTTL := issuer.CacheTTL
+	if cacheTTL == 0 {
+		cacheTTL = DefaultJWKSCacheTTL
+	}
+
+	return jwks.NewCachingProvider(issuerURL, cacheTTL, providerOptions...).KeyFunc, nil
+}
+
+// ReadJWKSFile reads a JSON Web Key Set from a local file
+func ReadJWKSFile(path string) (*jose.JSONWebKeySet, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read the jwks file: %w", err)
+	}
+
+	var keySet jose.JSONWebKeySet
+	if err := json.Unmarshal(data, &keySet); err != nil {
+		return nil, fmt.Errorf("cannot parse the jwks file: %w", err)
+	}
+
+	if len(keySet.Keys) == 0 {
+		return nil, fmt.Errorf("jwks file %s has no keys", path)
+	}
+
+	return &keySet, nil
+}
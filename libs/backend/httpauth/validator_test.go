@@ -0,0 +1,125 @@
+package httpauth_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"libs/backend/httpauth"
+	"libs/backend/httpauth/authtest"
+
+	"github.com/auth0/go-jwt-middleware/v2/validator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const audience = "https://api.career-cue.test"
+
+func TestNewJWTValidator(t *testing.T) {
+	t.Run("requires a trusted issuer", func(t *testing.T) {
+		_, err := httpauth.NewJWTValidator()
+		assert.ErrorIs(t, err, httpauth.ErrNoTrustedIssuers)
+	})
+
+	t.Run("requires an audience", func(t *testing.T) {
+		_, err := httpauth.NewJWTValidator(httpauth.WithTrustedIssuer(httpauth.TrustedIssuer{
+			IssuerURL: "https://issuer.test/",
+			JWKSURL:   "http://127.0.0.1/jwks.json",
+		}))
+		assert.Error(t, err)
+	})
+
+	t.Run("fails on a missing jwks file", func(t *testing.T) {
+		_, err := httpauth.NewJWTValidator(httpauth.WithTrustedIssuer(httpauth.TrustedIssuer{
+			IssuerURL: "https://issuer.test/",
+			Audiences: []string{audience},
+			JWKSFile:  filepath.Join(t.TempDir(), "missing.json"),
+		}))
+		assert.Error(t, err)
+	})
+}
+
+func TestJWTValidatorEnsureValidToken(t *testing.T) {
+	ctx := context.Background()
+
+	primary, err := authtest.NewIssuer("https://primary.test/", audience)
+	require.NoError(t, err)
+	defer primary.Close()
+
+	secondary, err := authtest.NewIssuer("https://secondary.test/", audience)
+	require.NoError(t, err)
+
+	jwksFile := filepath.Join(t.TempDir(), "jwks.json")
+	require.NoError(t, secondary.WriteJWKSFile(jwksFile))
+
+	untrusted, err := authtest.NewIssuer("https://untrusted.test/", audience)
+	require.NoError(t, err)
+
+	jwtValidator, err := httpauth.NewJWTValidator(
+		httpauth.WithTrustedIssuer(httpauth.TrustedIssuer{
+			IssuerURL: primary.URL,
+			Audiences: []string{audience},
+			JWKSURL:   primary.ServeJWKS(),
+		}),
+		httpauth.WithTrustedIssuer(httpauth.TrustedIssuer{
+			IssuerURL: secondary.URL,
+			Audiences: []string{audience},
+			JWKSFile:  jwksFile,
+		}),
+		httpauth.WithAllowedClockSkew(time.Second),
+	)
+	require.NoError(t, err)
+
+	t.Run("accepts the tokens of every trusted issuer", func(t *testing.T) {
+		for _, issuer := range []*authtest.Issuer{primary, secondary} {
+			token, err := issuer.Sign(authtest.Claims{Subject: "auth0|user", Scope: "read:accounts write:accounts"})
+			require.NoError(t, err)
+
+			claims, err := jwtValidator.EnsureValidToken(ctx, token)
+			require.NoError(t, err)
+
+			validated := claims.(*validator.ValidatedClaims)
+			assert.Equal(t, issuer.URL, validated.RegisteredClaims.Issuer)
+			assert.Equal(t, "auth0|user", validated.RegisteredClaims.Subject)
+			assert.True(t, validated.CustomClaims.(*httpauth.CustomClaims).HasScope("write:accounts"))
+		}
+	})
+
+	t.Run("rejects an untrusted issuer", func(t *testing.T) {
+		token, err := untrusted.Sign(authtest.Claims{Subject: "auth0|user"})
+		require.NoError(t, err)
+
+		_, err = jwtValidator.EnsureValidToken(ctx, token)
+		assert.ErrorIs(t, err, httpauth.ErrUntrustedIssuer)
+	})
+
+	t.Run("rejects a token signed by another key", func(t *testing.T) {
+		token, err := untrusted.Sign(authtest.Claims{Subject: "auth0|user", Issuer: primary.URL})
+		require.NoError(t, err)
+
+		_, err = jwtValidator.EnsureValidToken(ctx, token)
+		assert.Error(t, err)
+	})
+
+	t.Run("rejects another audience", func(t *testing.T) {
+		token, err := primary.Sign(authtest.Claims{Subject: "auth0|user", Audience: "https://other.test"})
+		require.NoError(t, err)
+
+		_, err = jwtValidator.EnsureValidToken(ctx, token)
+		assert.Error(t, err)
+	})
+
+	t.Run("rejects an expired token", func(t *testing.T) {
+		token, err := primary.Sign(authtest.Claims{Subject: "auth0|user", ExpiresIn: -time.Minute})
+		require.NoError(t, err)
+
+		_, err = jwtValidator.EnsureValidToken(ctx, token)
+		assert.Error(t, err)
+	})
+
+	t.Run("rejects a malformed token", func(t *testing.T) {
+		_, err := jwtValidator.EnsureValidToken(ctx, "not-a-token")
+		assert.Error(t, err)
+	})
+}
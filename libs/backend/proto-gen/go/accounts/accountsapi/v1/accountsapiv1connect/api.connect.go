@@ -39,17 +39,25 @@ const (
 	// AccountServiceGetAccountProcedure is the fully-qualified name of the AccountService's GetAccount
 	// RPC.
 	AccountServiceGetAccountProcedure = "/accounts.accountsapi.v1.AccountService/GetAccount"
+	// AccountServiceUpdateAccountProcedure is the fully-qualified name of the AccountService's
+	// UpdateAccount RPC.
+	AccountServiceUpdateAccountProcedure = "/accounts.accountsapi.v1.AccountService/UpdateAccount"
 	// AccountServiceDeleteAccountProcedure is the fully-qualified name of the AccountService's
 	// DeleteAccount RPC.
 	AccountServiceDeleteAccountProcedure = "/accounts.accountsapi.v1.AccountService/DeleteAccount"
+	// AccountServiceRestoreAccountProcedure is the fully-qualified name of the AccountService's
+	// RestoreAccount RPC.
+	AccountServiceRestoreAccountProcedure = "/accounts.accountsapi.v1.AccountService/RestoreAccount"
 )
 
 // These variables are the protoreflect.Descriptor objects for the RPCs defined in this package.
 var (
-	accountServiceServiceDescriptor             = v1.File_accounts_accountsapi_v1_api_proto.Services().ByName("AccountService")
-	accountServiceCreateAccountMethodDescriptor = accountServiceServiceDescriptor.Methods().ByName("CreateAccount")
-	accountServiceGetAccountMethodDescriptor    = accountServiceServiceDescriptor.Methods().ByName("GetAccount")
-	accountServiceDeleteAccountMethodDescriptor = accountServiceServiceDescriptor.Methods().ByName("DeleteAccount")
+	accountServiceServiceDescriptor              = v1.File_accounts_accountsapi_v1_api_proto.Services().ByName("AccountService")
+	accountServiceCreateAccountMethodDescriptor  = accountServiceServiceDescriptor.Methods().ByName("CreateAccount")
+	accountServiceGetAccountMethodDescriptor     = accountServiceServiceDescriptor.Methods().ByName("GetAccount")
+	accountServiceUpdateAccountMethodDescriptor  = accountServiceServiceDescriptor.Methods().ByName("UpdateAccount")
+	accountServiceDeleteAccountMethodDescriptor  = accountServiceServiceDescriptor.Methods().ByName("DeleteAccount")
+	accountServiceRestoreAccountMethodDescriptor = accountServiceServiceDescriptor.Methods().ByName("RestoreAccount")
 )
 
 // AccountServiceClient is a client for the accounts.accountsapi.v1.AccountService service.
@@ -58,8 +66,12 @@ type AccountServiceClient interface {
 	CreateAccount(context.Context, *connect.Request[v1.CreateAccountRequest]) (*connect.Response[v1.CreateAcountResponse], error)
 	// GetAccount retrieves an account by its common id
 	GetAccount(context.Context, *connect.Request[v1.GetAccountRequest]) (*connect.Response[v1.GetAccountResponse], error)
+	// UpdateAccount changes the username and/or email address of an account
+	UpdateAccount(context.Context, *connect.Request[v1.UpdateAccountRequest]) (*connect.Response[v1.UpdateAccountResponse], error)
 	// Delete Account will soft/hard delete an account
 	DeleteAccount(context.Context, *connect.Request[v1.DeleteAccountRequest]) (*connect.Response[v1.DeleteAccountResponse], error)
+	// RestoreAccount restores a soft deleted account
+	RestoreAccount(context.Context, *connect.Request[v1.RestoreAccountRequest]) (*connect.Response[v1.RestoreAccountResponse], error)
 }
 
 // NewAccountServiceClient constructs a client for the accounts.accountsapi.v1.AccountService
@@ -84,20 +96,34 @@ func NewAccountServiceClient(httpClient connect.HTTPClient, baseURL string, opts
 			connect.WithSchema(accountServiceGetAccountMethodDescriptor),
 			connect.WithClientOptions(opts...),
 		),
+		updateAccount: connect.NewClient[v1.UpdateAccountRequest, v1.UpdateAccountResponse](
+			httpClient,
+			baseURL+AccountServiceUpdateAccountProcedure,
+			connect.WithSchema(accountServiceUpdateAccountMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
 		deleteAccount: connect.NewClient[v1.DeleteAccountRequest, v1.DeleteAccountResponse](
 			httpClient,
 			baseURL+AccountServiceDeleteAccountProcedure,
 			connect.WithSchema(accountServiceDeleteAccountMethodDescriptor),
 			connect.WithClientOptions(opts...),
 		),
+		restoreAccount: connect.NewClient[v1.RestoreAccountRequest, v1.RestoreAccountResponse](
+			httpClient,
+			baseURL+AccountServiceRestoreAccountProcedure,
+			connect.WithSchema(accountServiceRestoreAccountMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
 // accountServiceClient implements AccountServiceClient.
 type accountServiceClient struct {
-	createAccount *connect.Client[v1.CreateAccountRequest, v1.CreateAcountResponse]
-	getAccount    *connect.Client[v1.GetAccountRequest, v1.GetAccountResponse]
-	deleteAccount *connect.Client[v1.DeleteAccountRequest, v1.DeleteAccountResponse]
+	createAccount  *connect.Client[v1.CreateAccountRequest, v1.CreateAcountResponse]
+	getAccount     *connect.Client[v1.GetAccountRequest, v1.GetAccountResponse]
+	updateAccount  *connect.Client[v1.UpdateAccountRequest, v1.UpdateAccountResponse]
+	deleteAccount  *connect.Client[v1.DeleteAccountRequest, v1.DeleteAccountResponse]
+	restoreAccount *connect.Client[v1.RestoreAccountRequest, v1.RestoreAccountResponse]
 }
 
 // CreateAccount calls accounts.accountsapi.v1.AccountService.CreateAccount.
@@ -110,19 +136,33 @@ func (c *accountServiceClient) GetAccount(ctx context.Context, req *connect.Requ
 	return c.getAccount.CallUnary(ctx, req)
 }
 
+// UpdateAccount calls accounts.accountsapi.v1.AccountService.UpdateAccount.
+func (c *accountServiceClient) UpdateAccount(ctx context.Context, req *connect.Request[v1.UpdateAccountRequest]) (*connect.Response[v1.UpdateAccountResponse], error) {
+	return c.updateAccount.CallUnary(ctx, req)
+}
+
 // DeleteAccount calls accounts.accountsapi.v1.AccountService.DeleteAccount.
 func (c *accountServiceClient) DeleteAccount(ctx context.Context, req *connect.Request[v1.DeleteAccountRequest]) (*connect.Response[v1.DeleteAccountResponse], error) {
 	return c.deleteAccount.CallUnary(ctx, req)
 }
 
+// RestoreAccount calls accounts.accountsapi.v1.AccountService.RestoreAccount.
+func (c *accountServiceClient) RestoreAccount(ctx context.Context, req *connect.Request[v1.RestoreAccountRequest]) (*connect.Response[v1.RestoreAccountResponse], error) {
+	return c.restoreAccount.CallUnary(ctx, req)
+}
+
 // AccountServiceHandler is an implementation of the accounts.accountsapi.v1.AccountService service.
 type AccountServiceHandler interface {
 	// CreateAccount creates a new account
 	CreateAccount(context.Context, *connect.Request[v1.CreateAccountRequest]) (*connect.Response[v1.CreateAcountResponse], error)
 	// GetAccount retrieves an account by its common id
 	GetAccount(context.Context, *connect.Request[v1.GetAccountRequest]) (*connect.Response[v1.GetAccountResponse], error)
+	// UpdateAccount changes the username and/or email address of an account
+	UpdateAccount(context.Context, *connect.Request[v1.UpdateAccountRequest]) (*connect.Response[v1.UpdateAccountResponse], error)
 	// Delete Account will soft/hard delete an account
 	DeleteAccount(context.Context, *connect.Request[v1.DeleteAccountRequest]) (*connect.Response[v1.DeleteAccountResponse], error)
+	// RestoreAccount restores a soft deleted account
+	RestoreAccount(context.Context, *connect.Request[v1.RestoreAccountRequest]) (*connect.Response[v1.RestoreAccountResponse], error)
 }
 
 // NewAccountServiceHandler builds an HTTP handler from the service implementation. It returns the
@@ -143,20 +183,36 @@ func NewAccountServiceHandler(svc AccountServiceHandler, opts ...connect.Handler
 		connect.WithSchema(accountServiceGetAccountMethodDescriptor),
 		connect.WithHandlerOptions(opts...),
 	)
+	accountServiceUpdateAccountHandler := connect.NewUnaryHandler(
+		AccountServiceUpdateAccountProcedure,
+		svc.UpdateAccount,
+		connect.WithSchema(accountServiceUpdateAccountMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
 	accountServiceDeleteAccountHandler := connect.NewUnaryHandler(
 		AccountServiceDeleteAccountProcedure,
 		svc.DeleteAccount,
 		connect.WithSchema(accountServiceDeleteAccountMethodDescriptor),
 		connect.WithHandlerOptions(opts...),
 	)
+	accountServiceRestoreAccountHandler := connect.NewUnaryHandler(
+		AccountServiceRestoreAccountProcedure,
+		svc.RestoreAccount,
+		connect.WithSchema(accountServiceRestoreAccountMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/accounts.accountsapi.v1.AccountService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case AccountServiceCreateAccountProcedure:
 			accountServiceCreateAccountHandler.ServeHTTP(w, r)
 		case AccountServiceGetAccountProcedure:
 			accountServiceGetAccountHandler.ServeHTTP(w, r)
+		case AccountServiceUpdateAccountProcedure:
+			accountServiceUpdateAccountHandler.ServeHTTP(w, r)
 		case AccountServiceDeleteAccountProcedure:
 			accountServiceDeleteAccountHandler.ServeHTTP(w, r)
+		case AccountServiceRestoreAccountProcedure:
+			accountServiceRestoreAccountHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -174,6 +230,14 @@ func (UnimplementedAccountServiceHandler) GetAccount(context.Context, *connect.R
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("accounts.accountsapi.v1.AccountService.GetAccount is not implemented"))
 }
 
+func (UnimplementedAccountServiceHandler) UpdateAccount(context.Context, *connect.Request[v1.UpdateAccountRequest]) (*connect.Response[v1.UpdateAccountResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("accounts.accountsapi.v1.AccountService.UpdateAccount is not implemented"))
+}
+
 func (UnimplementedAccountServiceHandler) DeleteAccount(context.Context, *connect.Request[v1.DeleteAccountRequest]) (*connect.Response[v1.DeleteAccountResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("accounts.accountsapi.v1.AccountService.DeleteAccount is not implemented"))
 }
+
+func (UnimplementedAccountServiceHandler) RestoreAccount(context.Context, *connect.Request[v1.RestoreAccountRequest]) (*connect.Response[v1.RestoreAccountResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("accounts.accountsapi.v1.AccountService.RestoreAccount is not implemented"))
+}
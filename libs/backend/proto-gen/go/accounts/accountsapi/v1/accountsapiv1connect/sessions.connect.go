@@ -0,0 +1,181 @@
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: accounts/accountsapi/v1/sessions.proto
+
+package accountsapiv1connect
+
+import (
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	v1 "libs/backend/proto-gen/go/accounts/accountsapi/v1"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// SessionServiceName is the fully-qualified name of the SessionService service.
+	SessionServiceName = "accounts.accountsapi.v1.SessionService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// SessionServiceRevokeSessionsProcedure is the fully-qualified name of the SessionService's
+	// RevokeSessions RPC.
+	SessionServiceRevokeSessionsProcedure = "/accounts.accountsapi.v1.SessionService/RevokeSessions"
+	// SessionServiceRevokeTokenProcedure is the fully-qualified name of the SessionService's
+	// RevokeToken RPC.
+	SessionServiceRevokeTokenProcedure = "/accounts.accountsapi.v1.SessionService/RevokeToken"
+	// SessionServiceListRevocationsProcedure is the fully-qualified name of the SessionService's
+	// ListRevocations RPC.
+	SessionServiceListRevocationsProcedure = "/accounts.accountsapi.v1.SessionService/ListRevocations"
+)
+
+// These variables are the protoreflect.Descriptor objects for the RPCs defined in this package.
+var (
+	sessionServiceServiceDescriptor               = v1.File_accounts_accountsapi_v1_sessions_proto.Services().ByName("SessionService")
+	sessionServiceRevokeSessionsMethodDescriptor  = sessionServiceServiceDescriptor.Methods().ByName("RevokeSessions")
+	sessionServiceRevokeTokenMethodDescriptor     = sessionServiceServiceDescriptor.Methods().ByName("RevokeToken")
+	sessionServiceListRevocationsMethodDescriptor = sessionServiceServiceDescriptor.Methods().ByName("ListRevocations")
+)
+
+// SessionServiceClient is a client for the accounts.accountsapi.v1.SessionService service.
+type SessionServiceClient interface {
+	// RevokeSessions logs an account out everywhere by rejecting every access
+	// token of the account issued until now
+	RevokeSessions(context.Context, *connect.Request[v1.RevokeSessionsRequest]) (*connect.Response[v1.RevokeSessionsResponse], error)
+	// RevokeToken rejects a single access token by its jti
+	RevokeToken(context.Context, *connect.Request[v1.RevokeTokenRequest]) (*connect.Response[v1.RevokeTokenResponse], error)
+	// ListRevocations lists the session revocations of an account
+	ListRevocations(context.Context, *connect.Request[v1.ListRevocationsRequest]) (*connect.Response[v1.ListRevocationsResponse], error)
+}
+
+// NewSessionServiceClient constructs a client for the accounts.accountsapi.v1.SessionService
+// service. By default, it uses the Connect protocol with the binary Protobuf Codec, asks for
+// gzipped responses, and sends uncompressed requests. To use the gRPC or gRPC-Web protocols, supply
+// the connect.WithGRPC() or connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewSessionServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) SessionServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	return &sessionServiceClient{
+		revokeSessions: connect.NewClient[v1.RevokeSessionsRequest, v1.RevokeSessionsResponse](
+			httpClient,
+			baseURL+SessionServiceRevokeSessionsProcedure,
+			connect.WithSchema(sessionServiceRevokeSessionsMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		revokeToken: connect.NewClient[v1.RevokeTokenRequest, v1.RevokeTokenResponse](
+			httpClient,
+			baseURL+SessionServiceRevokeTokenProcedure,
+			connect.WithSchema(sessionServiceRevokeTokenMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		listRevocations: connect.NewClient[v1.ListRevocationsRequest, v1.ListRevocationsResponse](
+			httpClient,
+			baseURL+SessionServiceListRevocationsProcedure,
+			connect.WithSchema(sessionServiceListRevocationsMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// sessionServiceClient implements SessionServiceClient.
+type sessionServiceClient struct {
+	revokeSessions  *connect.Client[v1.RevokeSessionsRequest, v1.RevokeSessionsResponse]
+	revokeToken     *connect.Client[v1.RevokeTokenRequest, v1.RevokeTokenResponse]
+	listRevocations *connect.Client[v1.ListRevocationsRequest, v1.ListRevocationsResponse]
+}
+
+// RevokeSessions calls accounts.accountsapi.v1.SessionService.RevokeSessions.
+func (c *sessionServiceClient) RevokeSessions(ctx context.Context, req *connect.Request[v1.RevokeSessionsRequest]) (*connect.Response[v1.RevokeSessionsResponse], error) {
+	return c.revokeSessions.CallUnary(ctx, req)
+}
+
+// RevokeToken calls accounts.accountsapi.v1.SessionService.RevokeToken.
+func (c *sessionServiceClient) RevokeToken(ctx context.Context, req *connect.Request[v1.RevokeTokenRequest]) (*connect.Response[v1.RevokeTokenResponse], error) {
+	return c.revokeToken.CallUnary(ctx, req)
+}
+
+// ListRevocations calls accounts.accountsapi.v1.SessionService.ListRevocations.
+func (c *sessionServiceClient) ListRevocations(ctx context.Context, req *connect.Request[v1.ListRevocationsRequest]) (*connect.Response[v1.ListRevocationsResponse], error) {
+	return c.listRevocations.CallUnary(ctx, req)
+}
+
+// SessionServiceHandler is an implementation of the accounts.accountsapi.v1.SessionService service.
+type SessionServiceHandler interface {
+	// RevokeSessions logs an account out everywhere by rejecting every access
+	// token of the account issued until now
+	RevokeSessions(context.Context, *connect.Request[v1.RevokeSessionsRequest]) (*connect.Response[v1.RevokeSessionsResponse], error)
+	// RevokeToken rejects a single access token by its jti
+	RevokeToken(context.Context, *connect.Request[v1.RevokeTokenRequest]) (*connect.Response[v1.RevokeTokenResponse], error)
+	// ListRevocations lists the session revocations of an account
+	ListRevocations(context.Context, *connect.Request[v1.ListRevocationsRequest]) (*connect.Response[v1.ListRevocationsResponse], error)
+}
+
+// NewSessionServiceHandler builds an HTTP handler from the service implementation. It returns the
+// path on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewSessionServiceHandler(svc SessionServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	sessionServiceRevokeSessionsHandler := connect.NewUnaryHandler(
+		SessionServiceRevokeSessionsProcedure,
+		svc.RevokeSessions,
+		connect.WithSchema(sessionServiceRevokeSessionsMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	sessionServiceRevokeTokenHandler := connect.NewUnaryHandler(
+		SessionServiceRevokeTokenProcedure,
+		svc.RevokeToken,
+		connect.WithSchema(sessionServiceRevokeTokenMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	sessionServiceListRevocationsHandler := connect.NewUnaryHandler(
+		SessionServiceListRevocationsProcedure,
+		svc.ListRevocations,
+		connect.WithSchema(sessionServiceListRevocationsMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/accounts.accountsapi.v1.SessionService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case SessionServiceRevokeSessionsProcedure:
+			sessionServiceRevokeSessionsHandler.ServeHTTP(w, r)
+		case SessionServiceRevokeTokenProcedure:
+			sessionServiceRevokeTokenHandler.ServeHTTP(w, r)
+		case SessionServiceListRevocationsProcedure:
+			sessionServiceListRevocationsHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedSessionServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedSessionServiceHandler struct{}
+
+func (UnimplementedSessionServiceHandler) RevokeSessions(context.Context, *connect.Request[v1.RevokeSessionsRequest]) (*connect.Response[v1.RevokeSessionsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("accounts.accountsapi.v1.SessionService.RevokeSessions is not implemented"))
+}
+
+func (UnimplementedSessionServiceHandler) RevokeToken(context.Context, *connect.Request[v1.RevokeTokenRequest]) (*connect.Response[v1.RevokeTokenResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("accounts.accountsapi.v1.SessionService.RevokeToken is not implemented"))
+}
+
+func (UnimplementedSessionServiceHandler) ListRevocations(context.Context, *connect.Request[v1.ListRevocationsRequest]) (*connect.Response[v1.ListRevocationsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("accounts.accountsapi.v1.SessionService.ListRevocations is not implemented"))
+}
@@ -0,0 +1,151 @@
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: accounts/accountsworker/v1/api.proto
+
+package accountsworkerv1connect
+
+import (
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	v1 "libs/backend/proto-gen/go/accounts/accountsworker/v1"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// RegistrationSagaServiceName is the fully-qualified name of the RegistrationSagaService service.
+	RegistrationSagaServiceName = "accounts.accountsworker.v1.RegistrationSagaService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// RegistrationSagaServiceGetRegistrationSagaProcedure is the fully-qualified name of the
+	// RegistrationSagaService's GetRegistrationSaga RPC.
+	RegistrationSagaServiceGetRegistrationSagaProcedure = "/accounts.accountsworker.v1.RegistrationSagaService/GetRegistrationSaga"
+	// RegistrationSagaServiceListRegistrationSagasProcedure is the fully-qualified name of the
+	// RegistrationSagaService's ListRegistrationSagas RPC.
+	RegistrationSagaServiceListRegistrationSagasProcedure = "/accounts.accountsworker.v1.RegistrationSagaService/ListRegistrationSagas"
+)
+
+// These variables are the protoreflect.Descriptor objects for the RPCs defined in this package.
+var (
+	registrationSagaServiceServiceDescriptor                     = v1.File_accounts_accountsworker_v1_api_proto.Services().ByName("RegistrationSagaService")
+	registrationSagaServiceGetRegistrationSagaMethodDescriptor   = registrationSagaServiceServiceDescriptor.Methods().ByName("GetRegistrationSaga")
+	registrationSagaServiceListRegistrationSagasMethodDescriptor = registrationSagaServiceServiceDescriptor.Methods().ByName("ListRegistrationSagas")
+)
+
+// RegistrationSagaServiceClient is a client for the
+// accounts.accountsworker.v1.RegistrationSagaService service.
+type RegistrationSagaServiceClient interface {
+	// GetRegistrationSaga retrieves the registration saga of a user by common id
+	GetRegistrationSaga(context.Context, *connect.Request[v1.GetRegistrationSagaRequest]) (*connect.Response[v1.GetRegistrationSagaResponse], error)
+	// ListRegistrationSagas lists the most recently updated registration sagas
+	ListRegistrationSagas(context.Context, *connect.Request[v1.ListRegistrationSagasRequest]) (*connect.Response[v1.ListRegistrationSagasResponse], error)
+}
+
+// NewRegistrationSagaServiceClient constructs a client for the
+// accounts.accountsworker.v1.RegistrationSagaService service. By default, it uses the Connect
+// protocol with the binary Protobuf Codec, asks for gzipped responses, and sends uncompressed
+// requests. To use the gRPC or gRPC-Web protocols, supply the connect.WithGRPC() or
+// connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewRegistrationSagaServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) RegistrationSagaServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	return &registrationSagaServiceClient{
+		getRegistrationSaga: connect.NewClient[v1.GetRegistrationSagaRequest, v1.GetRegistrationSagaResponse](
+			httpClient,
+			baseURL+RegistrationSagaServiceGetRegistrationSagaProcedure,
+			connect.WithSchema(registrationSagaServiceGetRegistrationSagaMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		listRegistrationSagas: connect.NewClient[v1.ListRegistrationSagasRequest, v1.ListRegistrationSagasResponse](
+			httpClient,
+			baseURL+RegistrationSagaServiceListRegistrationSagasProcedure,
+			connect.WithSchema(registrationSagaServiceListRegistrationSagasMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// registrationSagaServiceClient implements RegistrationSagaServiceClient.
+type registrationSagaServiceClient struct {
+	getRegistrationSaga   *connect.Client[v1.GetRegistrationSagaRequest, v1.GetRegistrationSagaResponse]
+	listRegistrationSagas *connect.Client[v1.ListRegistrationSagasRequest, v1.ListRegistrationSagasResponse]
+}
+
+// GetRegistrationSaga calls accounts.accountsworker.v1.RegistrationSagaService.GetRegistrationSaga.
+func (c *registrationSagaServiceClient) GetRegistrationSaga(ctx context.Context, req *connect.Request[v1.GetRegistrationSagaRequest]) (*connect.Response[v1.GetRegistrationSagaResponse], error) {
+	return c.getRegistrationSaga.CallUnary(ctx, req)
+}
+
+// ListRegistrationSagas calls
+// accounts.accountsworker.v1.RegistrationSagaService.ListRegistrationSagas.
+func (c *registrationSagaServiceClient) ListRegistrationSagas(ctx context.Context, req *connect.Request[v1.ListRegistrationSagasRequest]) (*connect.Response[v1.ListRegistrationSagasResponse], error) {
+	return c.listRegistrationSagas.CallUnary(ctx, req)
+}
+
+// RegistrationSagaServiceHandler is an implementation of the
+// accounts.accountsworker.v1.RegistrationSagaService service.
+type RegistrationSagaServiceHandler interface {
+	// GetRegistrationSaga retrieves the registration saga of a user by common id
+	GetRegistrationSaga(context.Context, *connect.Request[v1.GetRegistrationSagaRequest]) (*connect.Response[v1.GetRegistrationSagaResponse], error)
+	// ListRegistrationSagas lists the most recently updated registration sagas
+	ListRegistrationSagas(context.Context, *connect.Request[v1.ListRegistrationSagasRequest]) (*connect.Response[v1.ListRegistrationSagasResponse], error)
+}
+
+// NewRegistrationSagaServiceHandler builds an HTTP handler from the service implementation. It
+// returns the path on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewRegistrationSagaServiceHandler(svc RegistrationSagaServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	registrationSagaServiceGetRegistrationSagaHandler := connect.NewUnaryHandler(
+		RegistrationSagaServiceGetRegistrationSagaProcedure,
+		svc.GetRegistrationSaga,
+		connect.WithSchema(registrationSagaServiceGetRegistrationSagaMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	registrationSagaServiceListRegistrationSagasHandler := connect.NewUnaryHandler(
+		RegistrationSagaServiceListRegistrationSagasProcedure,
+		svc.ListRegistrationSagas,
+		connect.WithSchema(registrationSagaServiceListRegistrationSagasMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/accounts.accountsworker.v1.RegistrationSagaService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case RegistrationSagaServiceGetRegistrationSagaProcedure:
+			registrationSagaServiceGetRegistrationSagaHandler.ServeHTTP(w, r)
+		case RegistrationSagaServiceListRegistrationSagasProcedure:
+			registrationSagaServiceListRegistrationSagasHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedRegistrationSagaServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedRegistrationSagaServiceHandler struct{}
+
+func (UnimplementedRegistrationSagaServiceHandler) GetRegistrationSaga(context.Context, *connect.Request[v1.GetRegistrationSagaRequest]) (*connect.Response[v1.GetRegistrationSagaResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("accounts.accountsworker.v1.RegistrationSagaService.GetRegistrationSaga is not implemented"))
+}
+
+func (UnimplementedRegistrationSagaServiceHandler) ListRegistrationSagas(context.Context, *connect.Request[v1.ListRegistrationSagasRequest]) (*connect.Response[v1.ListRegistrationSagasResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("accounts.accountsworker.v1.RegistrationSagaService.ListRegistrationSagas is not implemented"))
+}
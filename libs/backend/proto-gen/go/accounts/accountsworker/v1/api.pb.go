@@ -0,0 +1,788 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.2
+// 	protoc        (unknown)
+// source: accounts/accountsworker/v1/api.proto
+
+package accountsworkerv1
+
+import (
+	_ "buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	_ "libs/backend/proto-gen/go/auth/v1"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// RegistrationSagaState is the overall state of a registration saga
+type RegistrationSagaState int32
+
+const (
+	RegistrationSagaState_REGISTRATION_SAGA_STATE_UNSPECIFIED  RegistrationSagaState = 0
+	RegistrationSagaState_REGISTRATION_SAGA_STATE_RUNNING      RegistrationSagaState = 1
+	RegistrationSagaState_REGISTRATION_SAGA_STATE_COMPLETED    RegistrationSagaState = 2
+	RegistrationSagaState_REGISTRATION_SAGA_STATE_COMPENSATING RegistrationSagaState = 3
+	RegistrationSagaState_REGISTRATION_SAGA_STATE_COMPENSATED  RegistrationSagaState = 4
+	RegistrationSagaState_REGISTRATION_SAGA_STATE_FAILED       RegistrationSagaState = 5
+)
+
+// Enum value maps for RegistrationSagaState.
+var (
+	RegistrationSagaState_name = map[int32]string{
+		0: "REGISTRATION_SAGA_STATE_UNSPECIFIED",
+		1: "REGISTRATION_SAGA_STATE_RUNNING",
+		2: "REGISTRATION_SAGA_STATE_COMPLETED",
+		3: "REGISTRATION_SAGA_STATE_COMPENSATING",
+		4: "REGISTRATION_SAGA_STATE_COMPENSATED",
+		5: "REGISTRATION_SAGA_STATE_FAILED",
+	}
+	RegistrationSagaState_value = map[string]int32{
+		"REGISTRATION_SAGA_STATE_UNSPECIFIED":  0,
+		"REGISTRATION_SAGA_STATE_RUNNING":      1,
+		"REGISTRATION_SAGA_STATE_COMPLETED":    2,
+		"REGISTRATION_SAGA_STATE_COMPENSATING": 3,
+		"REGISTRATION_SAGA_STATE_COMPENSATED":  4,
+		"REGISTRATION_SAGA_STATE_FAILED":       5,
+	}
+)
+
+func (x RegistrationSagaState) Enum() *RegistrationSagaState {
+	p := new(RegistrationSagaState)
+	*p = x
+	return p
+}
+
+func (x RegistrationSagaState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RegistrationSagaState) Descriptor() protoreflect.EnumDescriptor {
+	return file_accounts_accountsworker_v1_api_proto_enumTypes[0].Descriptor()
+}
+
+func (RegistrationSagaState) Type() protoreflect.EnumType {
+	return &file_accounts_accountsworker_v1_api_proto_enumTypes[0]
+}
+
+func (x RegistrationSagaState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RegistrationSagaState.Descriptor instead.
+func (RegistrationSagaState) EnumDescriptor() ([]byte, []int) {
+	return file_accounts_accountsworker_v1_api_proto_rawDescGZIP(), []int{0}
+}
+
+// RegistrationSagaStepStatus is the state of a single saga step
+type RegistrationSagaStepStatus int32
+
+const (
+	RegistrationSagaStepStatus_REGISTRATION_SAGA_STEP_STATUS_UNSPECIFIED RegistrationSagaStepStatus = 0
+	RegistrationSagaStepStatus_REGISTRATION_SAGA_STEP_STATUS_PENDING     RegistrationSagaStepStatus = 1
+	RegistrationSagaStepStatus_REGISTRATION_SAGA_STEP_STATUS_RUNNING     RegistrationSagaStepStatus = 2
+	RegistrationSagaStepStatus_REGISTRATION_SAGA_STEP_STATUS_SUCCEEDED   RegistrationSagaStepStatus = 3
+	RegistrationSagaStepStatus_REGISTRATION_SAGA_STEP_STATUS_FAILED      RegistrationSagaStepStatus = 4
+	RegistrationSagaStepStatus_REGISTRATION_SAGA_STEP_STATUS_COMPENSATED RegistrationSagaStepStatus = 5
+)
+
+// Enum value maps for RegistrationSagaStepStatus.
+var (
+	RegistrationSagaStepStatus_name = map[int32]string{
+		0: "REGISTRATION_SAGA_STEP_STATUS_UNSPECIFIED",
+		1: "REGISTRATION_SAGA_STEP_STATUS_PENDING",
+		2: "REGISTRATION_SAGA_STEP_STATUS_RUNNING",
+		3: "REGISTRATION_SAGA_STEP_STATUS_SUCCEEDED",
+		4: "REGISTRATION_SAGA_STEP_STATUS_FAILED",
+		5: "REGISTRATION_SAGA_STEP_STATUS_COMPENSATED",
+	}
+	RegistrationSagaStepStatus_value = map[string]int32{
+		"REGISTRATION_SAGA_STEP_STATUS_UNSPECIFIED": 0,
+		"REGISTRATION_SAGA_STEP_STATUS_PENDING":     1,
+		"REGISTRATION_SAGA_STEP_STATUS_RUNNING":     2,
+		"REGISTRATION_SAGA_STEP_STATUS_SUCCEEDED":   3,
+		"REGISTRATION_SAGA_STEP_STATUS_FAILED":      4,
+		"REGISTRATION_SAGA_STEP_STATUS_COMPENSATED": 5,
+	}
+)
+
+func (x RegistrationSagaStepStatus) Enum() *RegistrationSagaStepStatus {
+	p := new(RegistrationSagaStepStatus)
+	*p = x
+	return p
+}
+
+func (x RegistrationSagaStepStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RegistrationSagaStepStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_accounts_accountsworker_v1_api_proto_enumTypes[1].Descriptor()
+}
+
+func (RegistrationSagaStepStatus) Type() protoreflect.EnumType {
+	return &file_accounts_accountsworker_v1_api_proto_enumTypes[1]
+}
+
+func (x RegistrationSagaStepStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RegistrationSagaStepStatus.Descriptor instead.
+func (RegistrationSagaStepStatus) EnumDescriptor() ([]byte, []int) {
+	return file_accounts_accountsworker_v1_api_proto_rawDescGZIP(), []int{1}
+}
+
+// RegistrationSagaStep defines a single step of the registration saga
+type RegistrationSagaStep struct {
+	state         protoimpl.MessageState     `protogen:"open.v1"`
+	Name          string                     `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Status        RegistrationSagaStepStatus `protobuf:"varint,2,opt,name=status,proto3,enum=accounts.accountsworker.v1.RegistrationSagaStepStatus" json:"status,omitempty"`
+	Attempts      int32                      `protobuf:"varint,3,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	LastError     string                     `protobuf:"bytes,4,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	StartedAt     *timestamppb.Timestamp     `protobuf:"bytes,5,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	CompletedAt   *timestamppb.Timestamp     `protobuf:"bytes,6,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegistrationSagaStep) Reset() {
+	*x = RegistrationSagaStep{}
+	mi := &file_accounts_accountsworker_v1_api_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegistrationSagaStep) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegistrationSagaStep) ProtoMessage() {}
+
+func (x *RegistrationSagaStep) ProtoReflect() protoreflect.Message {
+	mi := &file_accounts_accountsworker_v1_api_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegistrationSagaStep.ProtoReflect.Descriptor instead.
+func (*RegistrationSagaStep) Descriptor() ([]byte, []int) {
+	return file_accounts_accountsworker_v1_api_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *RegistrationSagaStep) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RegistrationSagaStep) GetStatus() RegistrationSagaStepStatus {
+	if x != nil {
+		return x.Status
+	}
+	return RegistrationSagaStepStatus_REGISTRATION_SAGA_STEP_STATUS_UNSPECIFIED
+}
+
+func (x *RegistrationSagaStep) GetAttempts() int32 {
+	if x != nil {
+		return x.Attempts
+	}
+	return 0
+}
+
+func (x *RegistrationSagaStep) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+func (x *RegistrationSagaStep) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+func (x *RegistrationSagaStep) GetCompletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CompletedAt
+	}
+	return nil
+}
+
+// RegistrationSaga defines the tracked state of a user registration
+type RegistrationSaga struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	CommonId      string                  `protobuf:"bytes,1,opt,name=common_id,json=commonId,proto3" json:"common_id,omitempty"`
+	Username      string                  `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	EmailAddress  string                  `protobuf:"bytes,3,opt,name=email_address,json=emailAddress,proto3" json:"email_address,omitempty"`
+	State         RegistrationSagaState   `protobuf:"varint,4,opt,name=state,proto3,enum=accounts.accountsworker.v1.RegistrationSagaState" json:"state,omitempty"`
+	CurrentStep   string                  `protobuf:"bytes,5,opt,name=current_step,json=currentStep,proto3" json:"current_step,omitempty"`
+	Steps         []*RegistrationSagaStep `protobuf:"bytes,6,rep,name=steps,proto3" json:"steps,omitempty"`
+	LastError     string                  `protobuf:"bytes,7,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	DeadlineAt    *timestamppb.Timestamp  `protobuf:"bytes,8,opt,name=deadline_at,json=deadlineAt,proto3" json:"deadline_at,omitempty"`
+	CreatedAt     *timestamppb.Timestamp  `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp  `protobuf:"bytes,10,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	CompletedAt   *timestamppb.Timestamp  `protobuf:"bytes,11,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegistrationSaga) Reset() {
+	*x = RegistrationSaga{}
+	mi := &file_accounts_accountsworker_v1_api_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegistrationSaga) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegistrationSaga) ProtoMessage() {}
+
+func (x *RegistrationSaga) ProtoReflect() protoreflect.Message {
+	mi := &file_accounts_accountsworker_v1_api_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegistrationSaga.ProtoReflect.Descriptor instead.
+func (*RegistrationSaga) Descriptor() ([]byte, []int) {
+	return file_accounts_accountsworker_v1_api_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *RegistrationSaga) GetCommonId() string {
+	if x != nil {
+		return x.CommonId
+	}
+	return ""
+}
+
+func (x *RegistrationSaga) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *RegistrationSaga) GetEmailAddress() string {
+	if x != nil {
+		return x.EmailAddress
+	}
+	return ""
+}
+
+func (x *RegistrationSaga) GetState() RegistrationSagaState {
+	if x != nil {
+		return x.State
+	}
+	return RegistrationSagaState_REGISTRATION_SAGA_STATE_UNSPECIFIED
+}
+
+func (x *RegistrationSaga) GetCurrentStep() string {
+	if x != nil {
+		return x.CurrentStep
+	}
+	return ""
+}
+
+func (x *RegistrationSaga) GetSteps() []*RegistrationSagaStep {
+	if x != nil {
+		return x.Steps
+	}
+	return nil
+}
+
+func (x *RegistrationSaga) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+func (x *RegistrationSaga) GetDeadlineAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeadlineAt
+	}
+	return nil
+}
+
+func (x *RegistrationSaga) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *RegistrationSaga) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *RegistrationSaga) GetCompletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CompletedAt
+	}
+	return nil
+}
+
+// GetRegistrationSagaRequest defines the incoming data for the get registration saga request
+type GetRegistrationSagaRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CommonId      string                 `protobuf:"bytes,1,opt,name=common_id,json=commonId,proto3" json:"common_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRegistrationSagaRequest) Reset() {
+	*x = GetRegistrationSagaRequest{}
+	mi := &file_accounts_accountsworker_v1_api_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRegistrationSagaRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRegistrationSagaRequest) ProtoMessage() {}
+
+func (x *GetRegistrationSagaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_accounts_accountsworker_v1_api_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRegistrationSagaRequest.ProtoReflect.Descriptor instead.
+func (*GetRegistrationSagaRequest) Descriptor() ([]byte, []int) {
+	return file_accounts_accountsworker_v1_api_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetRegistrationSagaRequest) GetCommonId() string {
+	if x != nil {
+		return x.CommonId
+	}
+	return ""
+}
+
+// GetRegistrationSagaResponse defines the response for the get registration saga request
+type GetRegistrationSagaResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Saga          *RegistrationSaga      `protobuf:"bytes,1,opt,name=saga,proto3" json:"saga,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRegistrationSagaResponse) Reset() {
+	*x = GetRegistrationSagaResponse{}
+	mi := &file_accounts_accountsworker_v1_api_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRegistrationSagaResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRegistrationSagaResponse) ProtoMessage() {}
+
+func (x *GetRegistrationSagaResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_accounts_accountsworker_v1_api_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRegistrationSagaResponse.ProtoReflect.Descriptor instead.
+func (*GetRegistrationSagaResponse) Descriptor() ([]byte, []int) {
+	return file_accounts_accountsworker_v1_api_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetRegistrationSagaResponse) GetSaga() *RegistrationSaga {
+	if x != nil {
+		return x.Saga
+	}
+	return nil
+}
+
+// ListRegistrationSagasRequest filters the registration sagas by state
+type ListRegistrationSagasRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	State         RegistrationSagaState  `protobuf:"varint,1,opt,name=state,proto3,enum=accounts.accountsworker.v1.RegistrationSagaState" json:"state,omitempty"`
+	PageSize      int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRegistrationSagasRequest) Reset() {
+	*x = ListRegistrationSagasRequest{}
+	mi := &file_accounts_accountsworker_v1_api_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRegistrationSagasRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRegistrationSagasRequest) ProtoMessage() {}
+
+func (x *ListRegistrationSagasRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_accounts_accountsworker_v1_api_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRegistrationSagasRequest.ProtoReflect.Descriptor instead.
+func (*ListRegistrationSagasRequest) Descriptor() ([]byte, []int) {
+	return file_accounts_accountsworker_v1_api_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListRegistrationSagasRequest) GetState() RegistrationSagaState {
+	if x != nil {
+		return x.State
+	}
+	return RegistrationSagaState_REGISTRATION_SAGA_STATE_UNSPECIFIED
+}
+
+func (x *ListRegistrationSagasRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+// ListRegistrationSagasResponse defines the response for the list registration sagas request
+type ListRegistrationSagasResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sagas         []*RegistrationSaga    `protobuf:"bytes,1,rep,name=sagas,proto3" json:"sagas,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRegistrationSagasResponse) Reset() {
+	*x = ListRegistrationSagasResponse{}
+	mi := &file_accounts_accountsworker_v1_api_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRegistrationSagasResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRegistrationSagasResponse) ProtoMessage() {}
+
+func (x *ListRegistrationSagasResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_accounts_accountsworker_v1_api_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRegistrationSagasResponse.ProtoReflect.Descriptor instead.
+func (*ListRegistrationSagasResponse) Descriptor() ([]byte, []int) {
+	return file_accounts_accountsworker_v1_api_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListRegistrationSagasResponse) GetSagas() []*RegistrationSaga {
+	if x != nil {
+		return x.Sagas
+	}
+	return nil
+}
+
+var File_accounts_accountsworker_v1_api_proto protoreflect.FileDescriptor
+
+var file_accounts_accountsworker_v1_api_proto_rawDesc = []byte{
+	0x0a, 0x24, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x2f, 0x61, 0x63, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x73, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2f, 0x76, 0x31, 0x2f, 0x61, 0x70, 0x69,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x1a, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x73,
+	0x2e, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e,
+	0x76, 0x31, 0x1a, 0x1b, 0x62, 0x75, 0x66, 0x2f, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65,
+	0x2f, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a,
+	0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x1a, 0x15, 0x61, 0x75, 0x74, 0x68, 0x2f, 0x76, 0x31, 0x2f, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xaf, 0x02, 0x0a, 0x14, 0x52, 0x65, 0x67, 0x69,
+	0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x61, 0x67, 0x61, 0x53, 0x74, 0x65, 0x70,
+	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x4e, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x36, 0x2e, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x2e,
+	0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e, 0x76,
+	0x31, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x61,
+	0x67, 0x61, 0x53, 0x74, 0x65, 0x70, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x61, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x73,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x61, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x73,
+	0x12, 0x1d, 0x0a, 0x0a, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6c, 0x61, 0x73, 0x74, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12,
+	0x39, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52,
+	0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x3d, 0x0a, 0x0c, 0x63, 0x6f,
+	0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0b, 0x63, 0x6f,
+	0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x41, 0x74, 0x22, 0xb5, 0x04, 0x0a, 0x10, 0x52, 0x65,
+	0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x61, 0x67, 0x61, 0x12, 0x1b,
+	0x0a, 0x09, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x75,
+	0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75,
+	0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x65, 0x6d, 0x61, 0x69, 0x6c,
+	0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c,
+	0x65, 0x6d, 0x61, 0x69, 0x6c, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x47, 0x0a, 0x05,
+	0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x31, 0x2e, 0x61, 0x63,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x2e, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x77,
+	0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x61, 0x67, 0x61, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x05,
+	0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74,
+	0x5f, 0x73, 0x74, 0x65, 0x70, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x75, 0x72,
+	0x72, 0x65, 0x6e, 0x74, 0x53, 0x74, 0x65, 0x70, 0x12, 0x46, 0x0a, 0x05, 0x73, 0x74, 0x65, 0x70,
+	0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x30, 0x2e, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x73, 0x2e, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x77, 0x6f, 0x72, 0x6b, 0x65,
+	0x72, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x53, 0x61, 0x67, 0x61, 0x53, 0x74, 0x65, 0x70, 0x52, 0x05, 0x73, 0x74, 0x65, 0x70, 0x73,
+	0x12, 0x1d, 0x0a, 0x0a, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6c, 0x61, 0x73, 0x74, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12,
+	0x3b, 0x0a, 0x0b, 0x64, 0x65, 0x61, 0x64, 0x6c, 0x69, 0x6e, 0x65, 0x5f, 0x61, 0x74, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x52, 0x0a, 0x64, 0x65, 0x61, 0x64, 0x6c, 0x69, 0x6e, 0x65, 0x41, 0x74, 0x12, 0x39, 0x0a, 0x0a,
+	0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x63, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x39, 0x0a, 0x0a, 0x75, 0x70, 0x64, 0x61, 0x74,
+	0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64,
+	0x41, 0x74, 0x12, 0x3d, 0x0a, 0x0c, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x5f,
+	0x61, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x52, 0x0b, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x41,
+	0x74, 0x22, 0x43, 0x0a, 0x1a, 0x47, 0x65, 0x74, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x53, 0x61, 0x67, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x25, 0x0a, 0x09, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x42, 0x08, 0xba, 0x48, 0x05, 0x72, 0x03, 0xb0, 0x01, 0x01, 0x52, 0x08, 0x63, 0x6f,
+	0x6d, 0x6d, 0x6f, 0x6e, 0x49, 0x64, 0x22, 0x5f, 0x0a, 0x1b, 0x47, 0x65, 0x74, 0x52, 0x65, 0x67,
+	0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x61, 0x67, 0x61, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x40, 0x0a, 0x04, 0x73, 0x61, 0x67, 0x61, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x2e, 0x61,
+	0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31,
+	0x2e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x61, 0x67,
+	0x61, 0x52, 0x04, 0x73, 0x61, 0x67, 0x61, 0x22, 0x99, 0x01, 0x0a, 0x1c, 0x4c, 0x69, 0x73, 0x74,
+	0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x61, 0x67, 0x61,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x51, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x31, 0x2e, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x73, 0x2e, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x77, 0x6f, 0x72, 0x6b, 0x65,
+	0x72, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x53, 0x61, 0x67, 0x61, 0x53, 0x74, 0x61, 0x74, 0x65, 0x42, 0x08, 0xba, 0x48, 0x05, 0x82,
+	0x01, 0x02, 0x10, 0x01, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x26, 0x0a, 0x09, 0x70,
+	0x61, 0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x42, 0x09,
+	0xba, 0x48, 0x06, 0x1a, 0x04, 0x18, 0x64, 0x28, 0x00, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53,
+	0x69, 0x7a, 0x65, 0x22, 0x63, 0x0a, 0x1d, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x67, 0x69, 0x73,
+	0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x61, 0x67, 0x61, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x42, 0x0a, 0x05, 0x73, 0x61, 0x67, 0x61, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x2e, 0x61,
+	0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31,
+	0x2e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x61, 0x67,
+	0x61, 0x52, 0x05, 0x73, 0x61, 0x67, 0x61, 0x73, 0x2a, 0x83, 0x02, 0x0a, 0x15, 0x52, 0x65, 0x67,
+	0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x61, 0x67, 0x61, 0x53, 0x74, 0x61,
+	0x74, 0x65, 0x12, 0x27, 0x0a, 0x23, 0x52, 0x45, 0x47, 0x49, 0x53, 0x54, 0x52, 0x41, 0x54, 0x49,
+	0x4f, 0x4e, 0x5f, 0x53, 0x41, 0x47, 0x41, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x55, 0x4e,
+	0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x23, 0x0a, 0x1f, 0x52,
+	0x45, 0x47, 0x49, 0x53, 0x54, 0x52, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x53, 0x41, 0x47, 0x41,
+	0x5f, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x52, 0x55, 0x4e, 0x4e, 0x49, 0x4e, 0x47, 0x10, 0x01,
+	0x12, 0x25, 0x0a, 0x21, 0x52, 0x45, 0x47, 0x49, 0x53, 0x54, 0x52, 0x41, 0x54, 0x49, 0x4f, 0x4e,
+	0x5f, 0x53, 0x41, 0x47, 0x41, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x43, 0x4f, 0x4d, 0x50,
+	0x4c, 0x45, 0x54, 0x45, 0x44, 0x10, 0x02, 0x12, 0x28, 0x0a, 0x24, 0x52, 0x45, 0x47, 0x49, 0x53,
+	0x54, 0x52, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x53, 0x41, 0x47, 0x41, 0x5f, 0x53, 0x54, 0x41,
+	0x54, 0x45, 0x5f, 0x43, 0x4f, 0x4d, 0x50, 0x45, 0x4e, 0x53, 0x41, 0x54, 0x49, 0x4e, 0x47, 0x10,
+	0x03, 0x12, 0x27, 0x0a, 0x23, 0x52, 0x45, 0x47, 0x49, 0x53, 0x54, 0x52, 0x41, 0x54, 0x49, 0x4f,
+	0x4e, 0x5f, 0x53, 0x41, 0x47, 0x41, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x43, 0x4f, 0x4d,
+	0x50, 0x45, 0x4e, 0x53, 0x41, 0x54, 0x45, 0x44, 0x10, 0x04, 0x12, 0x22, 0x0a, 0x1e, 0x52, 0x45,
+	0x47, 0x49, 0x53, 0x54, 0x52, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x53, 0x41, 0x47, 0x41, 0x5f,
+	0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x46, 0x41, 0x49, 0x4c, 0x45, 0x44, 0x10, 0x05, 0x2a, 0xa7,
+	0x02, 0x0a, 0x1a, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53,
+	0x61, 0x67, 0x61, 0x53, 0x74, 0x65, 0x70, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x2d, 0x0a,
+	0x29, 0x52, 0x45, 0x47, 0x49, 0x53, 0x54, 0x52, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x53, 0x41,
+	0x47, 0x41, 0x5f, 0x53, 0x54, 0x45, 0x50, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x55,
+	0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x29, 0x0a, 0x25,
+	0x52, 0x45, 0x47, 0x49, 0x53, 0x54, 0x52, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x53, 0x41, 0x47,
+	0x41, 0x5f, 0x53, 0x54, 0x45, 0x50, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x50, 0x45,
+	0x4e, 0x44, 0x49, 0x4e, 0x47, 0x10, 0x01, 0x12, 0x29, 0x0a, 0x25, 0x52, 0x45, 0x47, 0x49, 0x53,
+	0x54, 0x52, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x53, 0x41, 0x47, 0x41, 0x5f, 0x53, 0x54, 0x45,
+	0x50, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x52, 0x55, 0x4e, 0x4e, 0x49, 0x4e, 0x47,
+	0x10, 0x02, 0x12, 0x2b, 0x0a, 0x27, 0x52, 0x45, 0x47, 0x49, 0x53, 0x54, 0x52, 0x41, 0x54, 0x49,
+	0x4f, 0x4e, 0x5f, 0x53, 0x41, 0x47, 0x41, 0x5f, 0x53, 0x54, 0x45, 0x50, 0x5f, 0x53, 0x54, 0x41,
+	0x54, 0x55, 0x53, 0x5f, 0x53, 0x55, 0x43, 0x43, 0x45, 0x45, 0x44, 0x45, 0x44, 0x10, 0x03, 0x12,
+	0x28, 0x0a, 0x24, 0x52, 0x45, 0x47, 0x49, 0x53, 0x54, 0x52, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f,
+	0x53, 0x41, 0x47, 0x41, 0x5f, 0x53, 0x54, 0x45, 0x50, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53,
+	0x5f, 0x46, 0x41, 0x49, 0x4c, 0x45, 0x44, 0x10, 0x04, 0x12, 0x2d, 0x0a, 0x29, 0x52, 0x45, 0x47,
+	0x49, 0x53, 0x54, 0x52, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x53, 0x41, 0x47, 0x41, 0x5f, 0x53,
+	0x54, 0x45, 0x50, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x43, 0x4f, 0x4d, 0x50, 0x45,
+	0x4e, 0x53, 0x41, 0x54, 0x45, 0x44, 0x10, 0x05, 0x32, 0x91, 0x03, 0x0a, 0x17, 0x52, 0x65, 0x67,
+	0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x61, 0x67, 0x61, 0x53, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x12, 0xb6, 0x01, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x52, 0x65, 0x67, 0x69,
+	0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x61, 0x67, 0x61, 0x12, 0x36, 0x2e, 0x61,
+	0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x2e, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x73,
+	0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x67,
+	0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x61, 0x67, 0x61, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x37, 0x2e, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x2e,
+	0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e, 0x76,
+	0x31, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x53, 0x61, 0x67, 0x61, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x2e, 0xa2,
+	0xbb, 0x18, 0x12, 0x72, 0x65, 0x61, 0x64, 0x3a, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0xb2, 0xbb, 0x18, 0x14, 0x0a, 0x04, 0x72, 0x65, 0x61, 0x64, 0x12,
+	0x0c, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0xbc, 0x01,
+	0x0a, 0x15, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x53, 0x61, 0x67, 0x61, 0x73, 0x12, 0x38, 0x2e, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x73, 0x2e, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x77, 0x6f, 0x72, 0x6b, 0x65,
+	0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x61, 0x67, 0x61, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x39, 0x2e, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x2e, 0x61, 0x63, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x73, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4c,
+	0x69, 0x73, 0x74, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53,
+	0x61, 0x67, 0x61, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x2e, 0xa2, 0xbb,
+	0x18, 0x12, 0x72, 0x65, 0x61, 0x64, 0x3a, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0xb2, 0xbb, 0x18, 0x14, 0x0a, 0x04, 0x72, 0x65, 0x61, 0x64, 0x12, 0x0c,
+	0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0xfb, 0x01, 0x0a,
+	0x1e, 0x63, 0x6f, 0x6d, 0x2e, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x2e, 0x61, 0x63,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x42,
+	0x08, 0x41, 0x70, 0x69, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x50, 0x01, 0x5a, 0x45, 0x6c, 0x69, 0x62,
+	0x73, 0x2f, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2d,
+	0x67, 0x65, 0x6e, 0x2f, 0x67, 0x6f, 0x2f, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x2f,
+	0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2f, 0x76,
+	0x31, 0x3b, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72,
+	0x76, 0x31, 0xa2, 0x02, 0x03, 0x41, 0x41, 0x58, 0xaa, 0x02, 0x1a, 0x41, 0x63, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x73, 0x2e, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x77, 0x6f, 0x72, 0x6b,
+	0x65, 0x72, 0x2e, 0x56, 0x31, 0xca, 0x02, 0x1a, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x73,
+	0x5c, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x5c,
+	0x56, 0x31, 0xe2, 0x02, 0x26, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x5c, 0x41, 0x63,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x5c, 0x56, 0x31, 0x5c,
+	0x47, 0x50, 0x42, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0xea, 0x02, 0x1c, 0x41, 0x63,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x3a, 0x3a, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x73,
+	0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x3a, 0x3a, 0x56, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x33,
+}
+
+var (
+	file_accounts_accountsworker_v1_api_proto_rawDescOnce sync.Once
+	file_accounts_accountsworker_v1_api_proto_rawDescData = file_accounts_accountsworker_v1_api_proto_rawDesc
+)
+
+func file_accounts_accountsworker_v1_api_proto_rawDescGZIP() []byte {
+	file_accounts_accountsworker_v1_api_proto_rawDescOnce.Do(func() {
+		file_accounts_accountsworker_v1_api_proto_rawDescData = protoimpl.X.CompressGZIP(file_accounts_accountsworker_v1_api_proto_rawDescData)
+	})
+	return file_accounts_accountsworker_v1_api_proto_rawDescData
+}
+
+var file_accounts_accountsworker_v1_api_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_accounts_accountsworker_v1_api_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_accounts_accountsworker_v1_api_proto_goTypes = []any{
+	(RegistrationSagaState)(0),            // 0: accounts.accountsworker.v1.RegistrationSagaState
+	(RegistrationSagaStepStatus)(0),       // 1: accounts.accountsworker.v1.RegistrationSagaStepStatus
+	(*RegistrationSagaStep)(nil),          // 2: accounts.accountsworker.v1.RegistrationSagaStep
+	(*RegistrationSaga)(nil),              // 3: accounts.accountsworker.v1.RegistrationSaga
+	(*GetRegistrationSagaRequest)(nil),    // 4: accounts.accountsworker.v1.GetRegistrationSagaRequest
+	(*GetRegistrationSagaResponse)(nil),   // 5: accounts.accountsworker.v1.GetRegistrationSagaResponse
+	(*ListRegistrationSagasRequest)(nil),  // 6: accounts.accountsworker.v1.ListRegistrationSagasRequest
+	(*ListRegistrationSagasResponse)(nil), // 7: accounts.accountsworker.v1.ListRegistrationSagasResponse
+	(*timestamppb.Timestamp)(nil),         // 8: google.protobuf.Timestamp
+}
+var file_accounts_accountsworker_v1_api_proto_depIdxs = []int32{
+	1,  // 0: accounts.accountsworker.v1.RegistrationSagaStep.status:type_name -> accounts.accountsworker.v1.RegistrationSagaStepStatus
+	8,  // 1: accounts.accountsworker.v1.RegistrationSagaStep.started_at:type_name -> google.protobuf.Timestamp
+	8,  // 2: accounts.accountsworker.v1.RegistrationSagaStep.completed_at:type_name -> google.protobuf.Timestamp
+	0,  // 3: accounts.accountsworker.v1.RegistrationSaga.state:type_name -> accounts.accountsworker.v1.RegistrationSagaState
+	2,  // 4: accounts.accountsworker.v1.RegistrationSaga.steps:type_name -> accounts.accountsworker.v1.RegistrationSagaStep
+	8,  // 5: accounts.accountsworker.v1.RegistrationSaga.deadline_at:type_name -> google.protobuf.Timestamp
+	8,  // 6: accounts.accountsworker.v1.RegistrationSaga.created_at:type_name -> google.protobuf.Timestamp
+	8,  // 7: accounts.accountsworker.v1.RegistrationSaga.updated_at:type_name -> google.protobuf.Timestamp
+	8,  // 8: accounts.accountsworker.v1.RegistrationSaga.completed_at:type_name -> google.protobuf.Timestamp
+	3,  // 9: accounts.accountsworker.v1.GetRegistrationSagaResponse.saga:type_name -> accounts.accountsworker.v1.RegistrationSaga
+	0,  // 10: accounts.accountsworker.v1.ListRegistrationSagasRequest.state:type_name -> accounts.accountsworker.v1.RegistrationSagaState
+	3,  // 11: accounts.accountsworker.v1.ListRegistrationSagasResponse.sagas:type_name -> accounts.accountsworker.v1.RegistrationSaga
+	4,  // 12: accounts.accountsworker.v1.RegistrationSagaService.GetRegistrationSaga:input_type -> accounts.accountsworker.v1.GetRegistrationSagaRequest
+	6,  // 13: accounts.accountsworker.v1.RegistrationSagaService.ListRegistrationSagas:input_type -> accounts.accountsworker.v1.ListRegistrationSagasRequest
+	5,  // 14: accounts.accountsworker.v1.RegistrationSagaService.GetRegistrationSaga:output_type -> accounts.accountsworker.v1.GetRegistrationSagaResponse
+	7,  // 15: accounts.accountsworker.v1.RegistrationSagaService.ListRegistrationSagas:output_type -> accounts.accountsworker.v1.ListRegistrationSagasResponse
+	14, // [14:16] is the sub-list for method output_type
+	12, // [12:14] is the sub-list for method input_type
+	12, // [12:12] is the sub-list for extension type_name
+	12, // [12:12] is the sub-list for extension extendee
+	0,  // [0:12] is the sub-list for field type_name
+}
+
+func init() { file_accounts_accountsworker_v1_api_proto_init() }
+func file_accounts_accountsworker_v1_api_proto_init() {
+	if File_accounts_accountsworker_v1_api_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_accounts_accountsworker_v1_api_proto_rawDesc,
+			NumEnums:      2,
+			NumMessages:   6,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_accounts_accountsworker_v1_api_proto_goTypes,
+		DependencyIndexes: file_accounts_accountsworker_v1_api_proto_depIdxs,
+		EnumInfos:         file_accounts_accountsworker_v1_api_proto_enumTypes,
+		MessageInfos:      file_accounts_accountsworker_v1_api_proto_msgTypes,
+	}.Build()
+	File_accounts_accountsworker_v1_api_proto = out.File
+	file_accounts_accountsworker_v1_api_proto_rawDesc = nil
+	file_accounts_accountsworker_v1_api_proto_goTypes = nil
+	file_accounts_accountsworker_v1_api_proto_depIdxs = nil
+}
@@ -0,0 +1,376 @@
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: webhooks/outboundwebhooksapi/v1/api.proto
+
+package outboundwebhooksapiv1connect
+
+import (
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	v11 "libs/backend/proto-gen/go/common/v1"
+	v1 "libs/backend/proto-gen/go/webhooks/outboundwebhooksapi/v1"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// OutboundWebhooksServiceName is the fully-qualified name of the OutboundWebhooksService service.
+	OutboundWebhooksServiceName = "webhooks.outboundwebhooksapi.v1.OutboundWebhooksService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// OutboundWebhooksServiceCreateEndpointProcedure is the fully-qualified name of the
+	// OutboundWebhooksService's CreateEndpoint RPC.
+	OutboundWebhooksServiceCreateEndpointProcedure = "/webhooks.outboundwebhooksapi.v1.OutboundWebhooksService/CreateEndpoint"
+	// OutboundWebhooksServiceGetEndpointProcedure is the fully-qualified name of the
+	// OutboundWebhooksService's GetEndpoint RPC.
+	OutboundWebhooksServiceGetEndpointProcedure = "/webhooks.outboundwebhooksapi.v1.OutboundWebhooksService/GetEndpoint"
+	// OutboundWebhooksServiceListEndpointsProcedure is the fully-qualified name of the
+	// OutboundWebhooksService's ListEndpoints RPC.
+	OutboundWebhooksServiceListEndpointsProcedure = "/webhooks.outboundwebhooksapi.v1.OutboundWebhooksService/ListEndpoints"
+	// OutboundWebhooksServiceUpdateEndpointProcedure is the fully-qualified name of the
+	// OutboundWebhooksService's UpdateEndpoint RPC.
+	OutboundWebhooksServiceUpdateEndpointProcedure = "/webhooks.outboundwebhooksapi.v1.OutboundWebhooksService/UpdateEndpoint"
+	// OutboundWebhooksServiceEnableEndpointProcedure is the fully-qualified name of the
+	// OutboundWebhooksService's EnableEndpoint RPC.
+	OutboundWebhooksServiceEnableEndpointProcedure = "/webhooks.outboundwebhooksapi.v1.OutboundWebhooksService/EnableEndpoint"
+	// OutboundWebhooksServiceDisableEndpointProcedure is the fully-qualified name of the
+	// OutboundWebhooksService's DisableEndpoint RPC.
+	OutboundWebhooksServiceDisableEndpointProcedure = "/webhooks.outboundwebhooksapi.v1.OutboundWebhooksService/DisableEndpoint"
+	// OutboundWebhooksServiceDeleteEndpointProcedure is the fully-qualified name of the
+	// OutboundWebhooksService's DeleteEndpoint RPC.
+	OutboundWebhooksServiceDeleteEndpointProcedure = "/webhooks.outboundwebhooksapi.v1.OutboundWebhooksService/DeleteEndpoint"
+	// OutboundWebhooksServiceListDeliveriesProcedure is the fully-qualified name of the
+	// OutboundWebhooksService's ListDeliveries RPC.
+	OutboundWebhooksServiceListDeliveriesProcedure = "/webhooks.outboundwebhooksapi.v1.OutboundWebhooksService/ListDeliveries"
+	// OutboundWebhooksServiceRedeliverDeliveryProcedure is the fully-qualified name of the
+	// OutboundWebhooksService's RedeliverDelivery RPC.
+	OutboundWebhooksServiceRedeliverDeliveryProcedure = "/webhooks.outboundwebhooksapi.v1.OutboundWebhooksService/RedeliverDelivery"
+)
+
+// These variables are the protoreflect.Descriptor objects for the RPCs defined in this package.
+var (
+	outboundWebhooksServiceServiceDescriptor                 = v1.File_webhooks_outboundwebhooksapi_v1_api_proto.Services().ByName("OutboundWebhooksService")
+	outboundWebhooksServiceCreateEndpointMethodDescriptor    = outboundWebhooksServiceServiceDescriptor.Methods().ByName("CreateEndpoint")
+	outboundWebhooksServiceGetEndpointMethodDescriptor       = outboundWebhooksServiceServiceDescriptor.Methods().ByName("GetEndpoint")
+	outboundWebhooksServiceListEndpointsMethodDescriptor     = outboundWebhooksServiceServiceDescriptor.Methods().ByName("ListEndpoints")
+	outboundWebhooksServiceUpdateEndpointMethodDescriptor    = outboundWebhooksServiceServiceDescriptor.Methods().ByName("UpdateEndpoint")
+	outboundWebhooksServiceEnableEndpointMethodDescriptor    = outboundWebhooksServiceServiceDescriptor.Methods().ByName("EnableEndpoint")
+	outboundWebhooksServiceDisableEndpointMethodDescriptor   = outboundWebhooksServiceServiceDescriptor.Methods().ByName("DisableEndpoint")
+	outboundWebhooksServiceDeleteEndpointMethodDescriptor    = outboundWebhooksServiceServiceDescriptor.Methods().ByName("DeleteEndpoint")
+	outboundWebhooksServiceListDeliveriesMethodDescriptor    = outboundWebhooksServiceServiceDescriptor.Methods().ByName("ListDeliveries")
+	outboundWebhooksServiceRedeliverDeliveryMethodDescriptor = outboundWebhooksServiceServiceDescriptor.Methods().ByName("RedeliverDelivery")
+)
+
+// OutboundWebhooksServiceClient is a client for the
+// webhooks.outboundwebhooksapi.v1.OutboundWebhooksService service.
+type OutboundWebhooksServiceClient interface {
+	// CreateEndpoint registers an endpoint and returns its signing secret once
+	CreateEndpoint(context.Context, *connect.Request[v1.CreateEndpointRequest]) (*connect.Response[v1.CreateEndpointResponse], error)
+	// GetEndpoint retrieves an endpoint by its id
+	GetEndpoint(context.Context, *connect.Request[v1.GetEndpointRequest]) (*connect.Response[v1.GetEndpointResponse], error)
+	// ListEndpoints lists the endpoints of a user
+	ListEndpoints(context.Context, *connect.Request[v1.ListEndpointsRequest]) (*connect.Response[v1.ListEndpointsResponse], error)
+	// UpdateEndpoint changes the provided fields of an endpoint
+	UpdateEndpoint(context.Context, *connect.Request[v1.UpdateEndpointRequest]) (*connect.Response[v1.UpdateEndpointResponse], error)
+	// EnableEndpoint enables an endpoint and resets its failure count
+	EnableEndpoint(context.Context, *connect.Request[v1.EnableEndpointRequest]) (*connect.Response[v1.EnableEndpointResponse], error)
+	// DisableEndpoint stops deliveries to an endpoint until it is enabled
+	DisableEndpoint(context.Context, *connect.Request[v1.DisableEndpointRequest]) (*connect.Response[v1.DisableEndpointResponse], error)
+	// DeleteEndpoint deletes an endpoint and its delivery log
+	DeleteEndpoint(context.Context, *connect.Request[v1.DeleteEndpointRequest]) (*connect.Response[v11.Empty], error)
+	// ListDeliveries lists the most recent deliveries of an endpoint
+	ListDeliveries(context.Context, *connect.Request[v1.ListDeliveriesRequest]) (*connect.Response[v1.ListDeliveriesResponse], error)
+	// RedeliverDelivery delivers the event of a delivery again as a new delivery
+	RedeliverDelivery(context.Context, *connect.Request[v1.RedeliverDeliveryRequest]) (*connect.Response[v1.RedeliverDeliveryResponse], error)
+}
+
+// NewOutboundWebhooksServiceClient constructs a client for the
+// webhooks.outboundwebhooksapi.v1.OutboundWebhooksService service. By default, it uses the Connect
+// protocol with the binary Protobuf Codec, asks for gzipped responses, and sends uncompressed
+// requests. To use the gRPC or gRPC-Web protocols, supply the connect.WithGRPC() or
+// connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewOutboundWebhooksServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) OutboundWebhooksServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	return &outboundWebhooksServiceClient{
+		createEndpoint: connect.NewClient[v1.CreateEndpointRequest, v1.CreateEndpointResponse](
+			httpClient,
+			baseURL+OutboundWebhooksServiceCreateEndpointProcedure,
+			connect.WithSchema(outboundWebhooksServiceCreateEndpointMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		getEndpoint: connect.NewClient[v1.GetEndpointRequest, v1.GetEndpointResponse](
+			httpClient,
+			baseURL+OutboundWebhooksServiceGetEndpointProcedure,
+			connect.WithSchema(outboundWebhooksServiceGetEndpointMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		listEndpoints: connect.NewClient[v1.ListEndpointsRequest, v1.ListEndpointsResponse](
+			httpClient,
+			baseURL+OutboundWebhooksServiceListEndpointsProcedure,
+			connect.WithSchema(outboundWebhooksServiceListEndpointsMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		updateEndpoint: connect.NewClient[v1.UpdateEndpointRequest, v1.UpdateEndpointResponse](
+			httpClient,
+			baseURL+OutboundWebhooksServiceUpdateEndpointProcedure,
+			connect.WithSchema(outboundWebhooksServiceUpdateEndpointMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		enableEndpoint: connect.NewClient[v1.EnableEndpointRequest, v1.EnableEndpointResponse](
+			httpClient,
+			baseURL+OutboundWebhooksServiceEnableEndpointProcedure,
+			connect.WithSchema(outboundWebhooksServiceEnableEndpointMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		disableEndpoint: connect.NewClient[v1.DisableEndpointRequest, v1.DisableEndpointResponse](
+			httpClient,
+			baseURL+OutboundWebhooksServiceDisableEndpointProcedure,
+			connect.WithSchema(outboundWebhooksServiceDisableEndpointMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		deleteEndpoint: connect.NewClient[v1.DeleteEndpointRequest, v11.Empty](
+			httpClient,
+			baseURL+OutboundWebhooksServiceDeleteEndpointProcedure,
+			connect.WithSchema(outboundWebhooksServiceDeleteEndpointMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		listDeliveries: connect.NewClient[v1.ListDeliveriesRequest, v1.ListDeliveriesResponse](
+			httpClient,
+			baseURL+OutboundWebhooksServiceListDeliveriesProcedure,
+			connect.WithSchema(outboundWebhooksServiceListDeliveriesMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		redeliverDelivery: connect.NewClient[v1.RedeliverDeliveryRequest, v1.RedeliverDeliveryResponse](
+			httpClient,
+			baseURL+OutboundWebhooksServiceRedeliverDeliveryProcedure,
+			connect.WithSchema(outboundWebhooksServiceRedeliverDeliveryMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// outboundWebhooksServiceClient implements OutboundWebhooksServiceClient.
+type outboundWebhooksServiceClient struct {
+	createEndpoint    *connect.Client[v1.CreateEndpointRequest, v1.CreateEndpointResponse]
+	getEndpoint       *connect.Client[v1.GetEndpointRequest, v1.GetEndpointResponse]
+	listEndpoints     *connect.Client[v1.ListEndpointsRequest, v1.ListEndpointsResponse]
+	updateEndpoint    *connect.Client[v1.UpdateEndpointRequest, v1.UpdateEndpointResponse]
+	enableEndpoint    *connect.Client[v1.EnableEndpointRequest, v1.EnableEndpointResponse]
+	disableEndpoint   *connect.Client[v1.DisableEndpointRequest, v1.DisableEndpointResponse]
+	deleteEndpoint    *connect.Client[v1.DeleteEndpointRequest, v11.Empty]
+	listDeliveries    *connect.Client[v1.ListDeliveriesRequest, v1.ListDeliveriesResponse]
+	redeliverDelivery *connect.Client[v1.RedeliverDeliveryRequest, v1.RedeliverDeliveryResponse]
+}
+
+// CreateEndpoint calls webhooks.outboundwebhooksapi.v1.OutboundWebhooksService.CreateEndpoint.
+func (c *outboundWebhooksServiceClient) CreateEndpoint(ctx context.Context, req *connect.Request[v1.CreateEndpointRequest]) (*connect.Response[v1.CreateEndpointResponse], error) {
+	return c.createEndpoint.CallUnary(ctx, req)
+}
+
+// GetEndpoint calls webhooks.outboundwebhooksapi.v1.OutboundWebhooksService.GetEndpoint.
+func (c *outboundWebhooksServiceClient) GetEndpoint(ctx context.Context, req *connect.Request[v1.GetEndpointRequest]) (*connect.Response[v1.GetEndpointResponse], error) {
+	return c.getEndpoint.CallUnary(ctx, req)
+}
+
+// ListEndpoints calls webhooks.outboundwebhooksapi.v1.OutboundWebhooksService.ListEndpoints.
+func (c *outboundWebhooksServiceClient) ListEndpoints(ctx context.Context, req *connect.Request[v1.ListEndpointsRequest]) (*connect.Response[v1.ListEndpointsResponse], error) {
+	return c.listEndpoints.CallUnary(ctx, req)
+}
+
+// UpdateEndpoint calls webhooks.outboundwebhooksapi.v1.OutboundWebhooksService.UpdateEndpoint.
+func (c *outboundWebhooksServiceClient) UpdateEndpoint(ctx context.Context, req *connect.Request[v1.UpdateEndpointRequest]) (*connect.Response[v1.UpdateEndpointResponse], error) {
+	return c.updateEndpoint.CallUnary(ctx, req)
+}
+
+// EnableEndpoint calls webhooks.outboundwebhooksapi.v1.OutboundWebhooksService.EnableEndpoint.
+func (c *outboundWebhooksServiceClient) EnableEndpoint(ctx context.Context, req *connect.Request[v1.EnableEndpointRequest]) (*connect.Response[v1.EnableEndpointResponse], error) {
+	return c.enableEndpoint.CallUnary(ctx, req)
+}
+
+// DisableEndpoint calls webhooks.outboundwebhooksapi.v1.OutboundWebhooksService.DisableEndpoint.
+func (c *outboundWebhooksServiceClient) DisableEndpoint(ctx context.Context, req *connect.Request[v1.DisableEndpointRequest]) (*connect.Response[v1.DisableEndpointResponse], error) {
+	return c.disableEndpoint.CallUnary(ctx, req)
+}
+
+// DeleteEndpoint calls webhooks.outboundwebhooksapi.v1.OutboundWebhooksService.DeleteEndpoint.
+func (c *outboundWebhooksServiceClient) DeleteEndpoint(ctx context.Context, req *connect.Request[v1.DeleteEndpointRequest]) (*connect.Response[v11.Empty], error) {
+	return c.deleteEndpoint.CallUnary(ctx, req)
+}
+
+// ListDeliveries calls webhooks.outboundwebhooksapi.v1.OutboundWebhooksService.ListDeliveries.
+func (c *outboundWebhooksServiceClient) ListDeliveries(ctx context.Context, req *connect.Request[v1.ListDeliveriesRequest]) (*connect.Response[v1.ListDeliveriesResponse], error) {
+	return c.listDeliveries.CallUnary(ctx, req)
+}
+
+// RedeliverDelivery calls
+// webhooks.outboundwebhooksapi.v1.OutboundWebhooksService.RedeliverDelivery.
+func (c *outboundWebhooksServiceClient) RedeliverDelivery(ctx context.Context, req *connect.Request[v1.RedeliverDeliveryRequest]) (*connect.Response[v1.RedeliverDeliveryResponse], error) {
+	return c.redeliverDelivery.CallUnary(ctx, req)
+}
+
+// OutboundWebhooksServiceHandler is an implementation of the
+// webhooks.outboundwebhooksapi.v1.OutboundWebhooksService service.
+type OutboundWebhooksServiceHandler interface {
+	// CreateEndpoint registers an endpoint and returns its signing secret once
+	CreateEndpoint(context.Context, *connect.Request[v1.CreateEndpointRequest]) (*connect.Response[v1.CreateEndpointResponse], error)
+	// GetEndpoint retrieves an endpoint by its id
+	GetEndpoint(context.Context, *connect.Request[v1.GetEndpointRequest]) (*connect.Response[v1.GetEndpointResponse], error)
+	// ListEndpoints lists the endpoints of a user
+	ListEndpoints(context.Context, *connect.Request[v1.ListEndpointsRequest]) (*connect.Response[v1.ListEndpointsResponse], error)
+	// UpdateEndpoint changes the provided fields of an endpoint
+	UpdateEndpoint(context.Context, *connect.Request[v1.UpdateEndpointRequest]) (*connect.Response[v1.UpdateEndpointResponse], error)
+	// EnableEndpoint enables an endpoint and resets its failure count
+	EnableEndpoint(context.Context, *connect.Request[v1.EnableEndpointRequest]) (*connect.Response[v1.EnableEndpointResponse], error)
+	// DisableEndpoint stops deliveries to an endpoint until it is enabled
+	DisableEndpoint(context.Context, *connect.Request[v1.DisableEndpointRequest]) (*connect.Response[v1.DisableEndpointResponse], error)
+	// DeleteEndpoint deletes an endpoint and its delivery log
+	DeleteEndpoint(context.Context, *connect.Request[v1.DeleteEndpointRequest]) (*connect.Response[v11.Empty], error)
+	// ListDeliveries lists the most recent deliveries of an endpoint
+	ListDeliveries(context.Context, *connect.Request[v1.ListDeliveriesRequest]) (*connect.Response[v1.ListDeliveriesResponse], error)
+	// RedeliverDelivery delivers the event of a delivery again as a new delivery
+	RedeliverDelivery(context.Context, *connect.Request[v1.RedeliverDeliveryRequest]) (*connect.Response[v1.RedeliverDeliveryResponse], error)
+}
+
+// NewOutboundWebhooksServiceHandler builds an HTTP handler from the service implementation. It
+// returns the path on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewOutboundWebhooksServiceHandler(svc OutboundWebhooksServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	outboundWebhooksServiceCreateEndpointHandler := connect.NewUnaryHandler(
+		OutboundWebhooksServiceCreateEndpointProcedure,
+		svc.CreateEndpoint,
+		connect.WithSchema(outboundWebhooksServiceCreateEndpointMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	outboundWebhooksServiceGetEndpointHandler := connect.NewUnaryHandler(
+		OutboundWebhooksServiceGetEndpointProcedure,
+		svc.GetEndpoint,
+		connect.WithSchema(outboundWebhooksServiceGetEndpointMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	outboundWebhooksServiceListEndpointsHandler := connect.NewUnaryHandler(
+		OutboundWebhooksServiceListEndpointsProcedure,
+		svc.ListEndpoints,
+		connect.WithSchema(outboundWebhooksServiceListEndpointsMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	outboundWebhooksServiceUpdateEndpointHandler := connect.NewUnaryHandler(
+		OutboundWebhooksServiceUpdateEndpointProcedure,
+		svc.UpdateEndpoint,
+		connect.WithSchema(outboundWebhooksServiceUpdateEndpointMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	outboundWebhooksServiceEnableEndpointHandler := connect.NewUnaryHandler(
+		OutboundWebhooksServiceEnableEndpointProcedure,
+		svc.EnableEndpoint,
+		connect.WithSchema(outboundWebhooksServiceEnableEndpointMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	outboundWebhooksServiceDisableEndpointHandler := connect.NewUnaryHandler(
+		OutboundWebhooksServiceDisableEndpointProcedure,
+		svc.DisableEndpoint,
+		connect.WithSchema(outboundWebhooksServiceDisableEndpointMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	outboundWebhooksServiceDeleteEndpointHandler := connect.NewUnaryHandler(
+		OutboundWebhooksServiceDeleteEndpointProcedure,
+		svc.DeleteEndpoint,
+		connect.WithSchema(outboundWebhooksServiceDeleteEndpointMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	outboundWebhooksServiceListDeliveriesHandler := connect.NewUnaryHandler(
+		OutboundWebhooksServiceListDeliveriesProcedure,
+		svc.ListDeliveries,
+		connect.WithSchema(outboundWebhooksServiceListDeliveriesMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	outboundWebhooksServiceRedeliverDeliveryHandler := connect.NewUnaryHandler(
+		OutboundWebhooksServiceRedeliverDeliveryProcedure,
+		svc.RedeliverDelivery,
+		connect.WithSchema(outboundWebhooksServiceRedeliverDeliveryMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/webhooks.outboundwebhooksapi.v1.OutboundWebhooksService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case OutboundWebhooksServiceCreateEndpointProcedure:
+			outboundWebhooksServiceCreateEndpointHandler.ServeHTTP(w, r)
+		case OutboundWebhooksServiceGetEndpointProcedure:
+			outboundWebhooksServiceGetEndpointHandler.ServeHTTP(w, r)
+		case OutboundWebhooksServiceListEndpointsProcedure:
+			outboundWebhooksServiceListEndpointsHandler.ServeHTTP(w, r)
+		case OutboundWebhooksServiceUpdateEndpointProcedure:
+			outboundWebhooksServiceUpdateEndpointHandler.ServeHTTP(w, r)
+		case OutboundWebhooksServiceEnableEndpointProcedure:
+			outboundWebhooksServiceEnableEndpointHandler.ServeHTTP(w, r)
+		case OutboundWebhooksServiceDisableEndpointProcedure:
+			outboundWebhooksServiceDisableEndpointHandler.ServeHTTP(w, r)
+		case OutboundWebhooksServiceDeleteEndpointProcedure:
+			outboundWebhooksServiceDeleteEndpointHandler.ServeHTTP(w, r)
+		case OutboundWebhooksServiceListDeliveriesProcedure:
+			outboundWebhooksServiceListDeliveriesHandler.ServeHTTP(w, r)
+		case OutboundWebhooksServiceRedeliverDeliveryProcedure:
+			outboundWebhooksServiceRedeliverDeliveryHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedOutboundWebhooksServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedOutboundWebhooksServiceHandler struct{}
+
+func (UnimplementedOutboundWebhooksServiceHandler) CreateEndpoint(context.Context, *connect.Request[v1.CreateEndpointRequest]) (*connect.Response[v1.CreateEndpointResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("webhooks.outboundwebhooksapi.v1.OutboundWebhooksService.CreateEndpoint is not implemented"))
+}
+
+func (UnimplementedOutboundWebhooksServiceHandler) GetEndpoint(context.Context, *connect.Request[v1.GetEndpointRequest]) (*connect.Response[v1.GetEndpointResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("webhooks.outboundwebhooksapi.v1.OutboundWebhooksService.GetEndpoint is not implemented"))
+}
+
+func (UnimplementedOutboundWebhooksServiceHandler) ListEndpoints(context.Context, *connect.Request[v1.ListEndpointsRequest]) (*connect.Response[v1.ListEndpointsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("webhooks.outboundwebhooksapi.v1.OutboundWebhooksService.ListEndpoints is not implemented"))
+}
+
+func (UnimplementedOutboundWebhooksServiceHandler) UpdateEndpoint(context.Context, *connect.Request[v1.UpdateEndpointRequest]) (*connect.Response[v1.UpdateEndpointResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("webhooks.outboundwebhooksapi.v1.OutboundWebhooksService.UpdateEndpoint is not implemented"))
+}
+
+func (UnimplementedOutboundWebhooksServiceHandler) EnableEndpoint(context.Context, *connect.Request[v1.EnableEndpointRequest]) (*connect.Response[v1.EnableEndpointResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("webhooks.outboundwebhooksapi.v1.OutboundWebhooksService.EnableEndpoint is not implemented"))
+}
+
+func (UnimplementedOutboundWebhooksServiceHandler) DisableEndpoint(context.Context, *connect.Request[v1.DisableEndpointRequest]) (*connect.Response[v1.DisableEndpointResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("webhooks.outboundwebhooksapi.v1.OutboundWebhooksService.DisableEndpoint is not implemented"))
+}
+
+func (UnimplementedOutboundWebhooksServiceHandler) DeleteEndpoint(context.Context, *connect.Request[v1.DeleteEndpointRequest]) (*connect.Response[v11.Empty], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("webhooks.outboundwebhooksapi.v1.OutboundWebhooksService.DeleteEndpoint is not implemented"))
+}
+
+func (UnimplementedOutboundWebhooksServiceHandler) ListDeliveries(context.Context, *connect.Request[v1.ListDeliveriesRequest]) (*connect.Response[v1.ListDeliveriesResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("webhooks.outboundwebhooksapi.v1.OutboundWebhooksService.ListDeliveries is not implemented"))
+}
+
+func (UnimplementedOutboundWebhooksServiceHandler) RedeliverDelivery(context.Context, *connect.Request[v1.RedeliverDeliveryRequest]) (*connect.Response[v1.RedeliverDeliveryResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("webhooks.outboundwebhooksapi.v1.OutboundWebhooksService.RedeliverDelivery is not implemented"))
+}